@@ -22,7 +22,16 @@ import (
 
 // GitOpsDeploymentSpec defines the desired state of GitOpsDeployment
 type GitOpsDeploymentSpec struct {
-	Source ApplicationSource `json:"source"`
+	// Source is a reference to the location of the application's manifests or chart.
+	// Either 'source' or 'sources' must be specified, but not both.
+	// +optional
+	Source ApplicationSource `json:"source,omitempty"`
+
+	// Sources is a list of references to the locations of the application's manifests or charts, for applications
+	// which are composed of multiple sources (for example, a Helm chart in one repository, and its values in another).
+	// Either 'source' or 'sources' must be specified, but not both.
+	// +optional
+	Sources []ApplicationSource `json:"sources,omitempty"`
 
 	// Destination is a reference to a target namespace/cluster to deploy to.
 	// This field may be empty: if it is empty, it is assumed that the destination
@@ -32,6 +41,12 @@ type GitOpsDeploymentSpec struct {
 	// SyncPolicy controls when and how a sync will be performed.
 	SyncPolicy *SyncPolicy `json:"syncPolicy,omitempty"`
 
+	// IgnoreDifferences is a list of resources and their fields which should be ignored when comparing the live state
+	// of the cluster with the desired state in the GitOps repository. For example, the replica count of a Deployment
+	// managed by a HorizontalPodAutoscaler.
+	// +optional
+	IgnoreDifferences []ResourceIgnoreDifferences `json:"ignoreDifferences,omitempty"`
+
 	// Two possible values:
 	// - Automated: whenever a new commit occurs in the GitOps repository, or the Argo CD Application is out of sync, Argo CD should be told to (re)synchronize.
 	// - Manual: Argo CD should never be told to resynchronize. Instead, synchronize operations will be triggered via GitOpsDeploymentSyncRun operations only.
@@ -40,18 +55,158 @@ type GitOpsDeploymentSpec struct {
 	// Note: This is somewhat of a placeholder for more advanced logic that can be implemented in the future.
 	// For an example of this type of logic, see the 'syncPolicy' field of Argo CD Application.
 	Type string `json:"type"`
+
+	// Suspend, if true, suspends the GitOpsDeployment: changes to the GitOps repository will no longer be deployed,
+	// automated syncs are disabled, and new GitOpsDeploymentSyncRuns are rejected. The resources that have already
+	// been deployed are left as-is (they are not pruned). Set to false (the default) to resume the GitOpsDeployment.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// DeletionPolicy controls what happens to the resources that were deployed by the GitOpsDeployment, when the
+	// GitOpsDeployment is deleted:
+	// - Cascade-Background (the default): the resources are deleted from the target cluster, in the background.
+	// - Cascade-Foreground: the resources are deleted from the target cluster, before the Argo CD Application is deleted.
+	// - Orphan: the resources are left as-is on the target cluster (for example, so that another GitOpsDeployment can take ownership of them).
+	// +kubebuilder:validation:Enum=Cascade-Foreground;Cascade-Background;Orphan
+	// +optional
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
+// DeletionPolicy controls what happens to the resources that were deployed by a GitOpsDeployment, when it is deleted.
+type DeletionPolicy string
+
+const (
+	DeletionPolicy_CascadeForeground DeletionPolicy = "Cascade-Foreground"
+	DeletionPolicy_CascadeBackground DeletionPolicy = "Cascade-Background"
+	DeletionPolicy_Orphan            DeletionPolicy = "Orphan"
+)
+
+// AnnotationKeyRefresh is the annotation that may be set on a GitOpsDeployment to request that the GitOps engine
+// refreshes the GitOpsDeployment (re-reads the manifests from the source repository), rather than waiting for the next poll.
+// - The annotation is removed once the refresh has completed.
+const AnnotationKeyRefresh = "managed-gitops.redhat.com/refresh"
+
+// RefreshType is the value of the AnnotationKeyRefresh annotation
+type RefreshType string
+
+const (
+	// RefreshType_Normal compares the live state against the latest manifests of the source repository
+	RefreshType_Normal RefreshType = "normal"
+	// RefreshType_Hard additionally invalidates the manifest cache of the GitOps engine, before regenerating the manifests
+	RefreshType_Hard RefreshType = "hard"
+)
+
 // ApplicationSource contains all required information about the source of an application
 type ApplicationSource struct {
 	// RepoURL is the URL to the repository (Git or Helm) that contains the application manifests
 	RepoURL string `json:"repoURL"`
 	// Path is a directory path within the Git repository, and is only valid for applications sourced from Git.
-	Path string `json:"path"`
+	// Path is required, unless the source is a 'ref' source within 'sources'.
+	// +optional
+	Path string `json:"path,omitempty"`
 	// TargetRevision defines the revision of the source to sync the application to.
 	// In case of Git, this can be commit, tag, or branch. If omitted, will equal to HEAD.
 	// In case of Helm, this is a semver tag for the Chart's version.
 	TargetRevision string `json:"targetRevision,omitempty"`
+
+	// Helm holds helm specific options, and is only valid for applications sourced from a Helm chart.
+	// +optional
+	Helm *ApplicationSourceHelm `json:"helm,omitempty"`
+
+	// Kustomize holds kustomize specific options, and is only valid for applications sourced from a Kustomize overlay.
+	// +optional
+	Kustomize *ApplicationSourceKustomize `json:"kustomize,omitempty"`
+
+	// Ref is a reference to this source, which may be used by other sources within 'sources'. For example, a Helm
+	// source may reference the value files of a source with 'ref: values' using '$values/path/to/values.yaml'.
+	// Ref is only valid for sources within 'sources'.
+	// +optional
+	Ref string `json:"ref,omitempty"`
+}
+
+// ApplicationSourceHelm holds helm specific options
+type ApplicationSourceHelm struct {
+	// ValueFiles is a list of Helm value files to use when generating a template.
+	// The paths are relative to the Helm chart directory (spec.source.path).
+	ValueFiles []string `json:"valueFiles,omitempty"`
+	// Parameters is a list of Helm parameters which are passed to the helm template command upon manifest generation
+	Parameters []HelmParameter `json:"parameters,omitempty"`
+	// ReleaseName is the Helm release name to use. If omitted it will use the application name
+	ReleaseName string `json:"releaseName,omitempty"`
+	// Values specifies Helm values to be passed to helm template, typically defined as a block
+	Values string `json:"values,omitempty"`
+	// SkipCrds skips custom resource definition installation step (Helm's --skip-crds)
+	SkipCrds bool `json:"skipCrds,omitempty"`
+}
+
+// HelmParameter is a parameter that's passed to helm template during manifest generation
+type HelmParameter struct {
+	// Name is the name of the Helm parameter
+	Name string `json:"name"`
+	// Value is the value for the Helm parameter
+	Value string `json:"value,omitempty"`
+	// ForceString determines whether to tell Helm to interpret booleans and numbers as strings
+	ForceString bool `json:"forceString,omitempty"`
+}
+
+// ApplicationSourceKustomize holds kustomize specific options
+type ApplicationSourceKustomize struct {
+	// NamePrefix is a prefix appended to resources for Kustomize apps
+	NamePrefix string `json:"namePrefix,omitempty"`
+	// NameSuffix is a suffix appended to resources for Kustomize apps
+	NameSuffix string `json:"nameSuffix,omitempty"`
+	// Images is a list of Kustomize image override specifications, for example:
+	// - 'quay.io/my-org/my-image:v1.2.3' (override the tag of an image with the same name)
+	// - 'my-image=quay.io/my-org/my-other-image:v1.2.3' (replace an image with another image)
+	Images []string `json:"images,omitempty"`
+	// CommonLabels is a list of additional labels to add to rendered manifests
+	CommonLabels map[string]string `json:"commonLabels,omitempty"`
+	// CommonAnnotations is a list of additional annotations to add to rendered manifests
+	CommonAnnotations map[string]string `json:"commonAnnotations,omitempty"`
+	// Patches is a list of inline Kustomize patches, applied to the rendered manifests. Requires Argo CD v2.9+.
+	Patches []KustomizePatch `json:"patches,omitempty"`
+}
+
+// KustomizePatch is an inline Kustomize patch: either a strategic merge patch, or a JSON 6902 patch
+type KustomizePatch struct {
+	// Patch is the content of the patch, in YAML or JSON
+	Patch string `json:"patch"`
+	// Target selects the resources to patch. It is required for a JSON 6902 patch; a strategic merge patch without a
+	// target is applied to the resource with the same kind, name and namespace as the patch.
+	// +optional
+	Target *KustomizeSelector `json:"target,omitempty"`
+}
+
+// KustomizeSelector selects the resources that a Kustomize patch is applied to
+type KustomizeSelector struct {
+	Group     string `json:"group,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Kind      string `json:"kind,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	// AnnotationSelector selects the resources by annotation, with the same syntax as a label selector
+	AnnotationSelector string `json:"annotationSelector,omitempty"`
+	// LabelSelector selects the resources by label
+	LabelSelector string `json:"labelSelector,omitempty"`
+}
+
+// ResourceIgnoreDifferences contains resource filter and list of json paths which should be ignored during comparison with live state.
+type ResourceIgnoreDifferences struct {
+	// Group is the API group of the resources to ignore differences in. Empty for resources in the core API group.
+	Group string `json:"group,omitempty"`
+	// Kind is the kind of the resources to ignore differences in.
+	Kind string `json:"kind"`
+	// Name, if specified, limits the ignored differences to resources with this name.
+	Name string `json:"name,omitempty"`
+	// Namespace, if specified, limits the ignored differences to resources in this namespace.
+	Namespace string `json:"namespace,omitempty"`
+	// JSONPointers is a list of JSON pointers (RFC 6901) to the fields to ignore, e.g. '/spec/replicas'
+	JSONPointers []string `json:"jsonPointers,omitempty"`
+	// JQPathExpressions is a list of JQ path expressions to the fields to ignore, e.g. '.spec.template.spec.initContainers[] | select(.name == "injected")'
+	JQPathExpressions []string `json:"jqPathExpressions,omitempty"`
+	// ManagedFieldsManagers is a list of trusted managers. Fields mutated by those managers will take precedence over the
+	// desired state defined in the GitOps repository, and won't be displayed in diffs.
+	ManagedFieldsManagers []string `json:"managedFieldsManagers,omitempty"`
 }
 
 // ApplicationDestination holds information about the application's destination
@@ -74,13 +229,101 @@ type SyncOption string
 const (
 	SyncOptions_CreateNamespace_true  SyncOption = "CreateNamespace=true"
 	SyncOptions_CreateNamespace_false SyncOption = "CreateNamespace=false"
+
+	SyncOptions_ServerSideApply_true  SyncOption = "ServerSideApply=true"
+	SyncOptions_ServerSideApply_false SyncOption = "ServerSideApply=false"
+
+	SyncOptions_PruneLast_true                SyncOption = "PruneLast=true"
+	SyncOptions_ApplyOutOfSyncOnly_true       SyncOption = "ApplyOutOfSyncOnly=true"
+	SyncOptions_Replace_true                  SyncOption = "Replace=true"
+	SyncOptions_RespectIgnoreDifferences_true SyncOption = "RespectIgnoreDifferences=true"
+
+	SyncOptions_Validate_true  SyncOption = "Validate=true"
+	SyncOptions_Validate_false SyncOption = "Validate=false"
 )
 
+// IsSupportedSyncOption returns true if the sync option is one of the supported values for SyncOptions, false otherwise.
+func IsSupportedSyncOption(syncOption SyncOption) bool {
+	switch syncOption {
+	case SyncOptions_CreateNamespace_true, SyncOptions_CreateNamespace_false,
+		SyncOptions_ServerSideApply_true, SyncOptions_ServerSideApply_false,
+		SyncOptions_PruneLast_true,
+		SyncOptions_ApplyOutOfSyncOnly_true,
+		SyncOptions_Replace_true,
+		SyncOptions_RespectIgnoreDifferences_true,
+		SyncOptions_Validate_true, SyncOptions_Validate_false:
+		return true
+	}
+	return false
+}
+
 type SyncPolicy struct {
 	// Options allow you to specify whole app sync-options.
 	// This option may be empty, if and when it is empty it is considered that there are no SyncOptions present.
 	SyncOptions SyncOptions `json:"syncOptions,omitempty"`
+
+	// Automated controls the behaviour of automated syncs. It is only used when .spec.type is 'automated'.
+	// If not specified, it defaults to prune, selfHeal, and allowEmpty all being enabled.
+	// +optional
+	Automated *SyncPolicyAutomated `json:"automated,omitempty"`
+
+	// Retry controls the strategy to apply if an automated sync fails. It is only used when .spec.type is 'automated'.
+	// If not specified, it defaults to unlimited retries, with a backoff of 5 seconds (doubling with each retry, up to 3 minutes).
+	// +optional
+	Retry *RetryStrategy `json:"retry,omitempty"`
+
+	// PrunePropagationPolicy controls how resources are deleted when they are pruned by a sync: 'foreground',
+	// 'background' (the default) or 'orphan'. See the 'propagationPolicy' of the Kubernetes DeleteOptions.
+	// +kubebuilder:validation:Enum=foreground;background;orphan
+	// +optional
+	PrunePropagationPolicy PrunePropagationPolicy `json:"prunePropagationPolicy,omitempty"`
+}
+
+// PrunePropagationPolicy controls how resources are deleted when they are pruned by a sync.
+type PrunePropagationPolicy string
+
+const (
+	PrunePropagationPolicy_Foreground PrunePropagationPolicy = "foreground"
+	PrunePropagationPolicy_Background PrunePropagationPolicy = "background"
+	PrunePropagationPolicy_Orphan     PrunePropagationPolicy = "orphan"
+)
+
+// SyncPolicyAutomated controls the behavior of an automated sync
+type SyncPolicyAutomated struct {
+	// Prune specifies whether to delete resources from the cluster that are not found in the sources anymore as part of automated sync (default: true)
+	// +optional
+	Prune *bool `json:"prune,omitempty"`
+	// SelfHeal specifies whether to revert resources back to their desired state upon modification in the cluster (default: true)
+	// +optional
+	SelfHeal *bool `json:"selfHeal,omitempty"`
+	// AllowEmpty allows apps have zero live resources (default: true)
+	// +optional
+	AllowEmpty *bool `json:"allowEmpty,omitempty"`
 }
+
+// DefaultSyncPolicyAutomated returns the automated sync policy that is used for GitOpsDeployments that do not specify one.
+func DefaultSyncPolicyAutomated() *SyncPolicyAutomated {
+	enabled := true
+	return &SyncPolicyAutomated{
+		Prune:      &enabled,
+		SelfHeal:   &enabled,
+		AllowEmpty: &enabled,
+	}
+}
+
+// DefaultRetryStrategy returns the automated sync retry strategy that is used for GitOpsDeployments that do not specify one.
+func DefaultRetryStrategy() *RetryStrategy {
+	factor := int64(2)
+	return &RetryStrategy{
+		Limit: -1,
+		Backoff: &Backoff{
+			Duration:    "5s",
+			Factor:      &factor,
+			MaxDuration: "3m",
+		},
+	}
+}
+
 type SyncOptions []SyncOption
 
 const (
@@ -88,6 +331,20 @@ const (
 	GitOpsDeploymentSpecType_Manual    = "manual"
 )
 
+// HasMultipleSources returns true if the GitOpsDeployment uses the 'sources' field, rather than the 'source' field.
+func (spec GitOpsDeploymentSpec) HasMultipleSources() bool {
+	return len(spec.Sources) > 0
+}
+
+// GetSources returns the sources of the GitOpsDeployment: either the contents of the 'sources' field, or
+// a single element list containing the 'source' field.
+func (spec GitOpsDeploymentSpec) GetSources() []ApplicationSource {
+	if spec.HasMultipleSources() {
+		return spec.Sources
+	}
+	return []ApplicationSource{spec.Source}
+}
+
 func SyncOptionToStringSlice(syncOptions SyncOptions) []string {
 	if syncOptions == nil {
 		return nil
@@ -116,7 +373,9 @@ type ResourceStatus struct {
 
 // ReconciledState contains the last version of the GitOpsDeployment resource that the ArgoCD Controller reconciled
 type ReconciledState struct {
-	Source      GitOpsDeploymentSource      `json:"source"`
+	Source GitOpsDeploymentSource `json:"source"`
+	// Sources is only set for GitOpsDeployments that use '.spec.sources'
+	Sources     []GitOpsDeploymentSource    `json:"sources,omitempty"`
 	Destination GitOpsDeploymentDestination `json:"destination"`
 }
 
@@ -136,8 +395,21 @@ type GitOpsDeploymentDestination struct {
 
 // GitOpsDeploymentStatus defines the observed state of GitOpsDeployment
 type GitOpsDeploymentStatus struct {
-	Conditions []GitOpsDeploymentCondition `json:"conditions,omitempty"`
-	Sync       SyncStatus                  `json:"sync,omitempty"`
+	// Conditions contains the Ready, Synced, Healthy and Progressing conditions of the GitOpsDeployment, along with
+	// conditions reported by Argo CD, and the deprecated ErrorOccurred/SyncError/Suspended conditions.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
+
+	// ObservedGeneration is the most recent .metadata.generation of the GitOpsDeployment that was processed by the
+	// GitOps Service. If it is less than .metadata.generation, the status does not yet reflect the latest spec.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	Sync SyncStatus `json:"sync,omitempty"`
 	// Health contains information about the application's current health status
 	Health HealthStatus `json:"health,omitempty"`
 
@@ -149,6 +421,38 @@ type GitOpsDeploymentStatus struct {
 
 	// OperationState contains information about any ongoing operations, such as a sync
 	OperationState *OperationState `json:"operationState,omitempty"`
+
+	// History contains information about the most recent syncs of the GitOpsDeployment, ordered from oldest to newest.
+	// At most DeploymentHistoryLimit entries are kept.
+	History []DeploymentHistoryEntry `json:"history,omitempty"`
+
+	// LastRefreshedAt is the time at which the most recent refresh, requested via the AnnotationKeyRefresh annotation, completed.
+	LastRefreshedAt *metav1.Time `json:"lastRefreshedAt,omitempty"`
+}
+
+// DeploymentHistoryLimit is the maximum number of entries kept in the .status.history field of a GitOpsDeployment
+const DeploymentHistoryLimit = 10
+
+// DeploymentHistoryEntry contains information about a previous sync of a GitOpsDeployment
+type DeploymentHistoryEntry struct {
+	// ID is the identifier of the corresponding entry in the Argo CD Application's revision history, and may be
+	// used to roll back to this deployment. Argo CD only records successful syncs in its history, so ID is not set
+	// for syncs that did not succeed.
+	ID *int64 `json:"id,omitempty"`
+	// Revision holds the revision the sync was performed against
+	Revision string `json:"revision"`
+	// Source is the source that was used for the sync
+	Source GitOpsDeploymentSource `json:"source,omitempty"`
+	// DeployStartedAt holds the time the sync started
+	DeployStartedAt *metav1.Time `json:"deployStartedAt,omitempty"`
+	// DeployedAt holds the time the sync completed
+	DeployedAt metav1.Time `json:"deployedAt"`
+	// InitiatedBy contains information about who initiated the sync
+	InitiatedBy OperationInitiator `json:"initiatedBy,omitempty"`
+	// SyncResult is the final phase of the sync, e.g. Succeeded, Failed or Error
+	SyncResult OperationPhase `json:"syncResult"`
+	// Message holds any pertinent messages from the sync (typically errors)
+	Message string `json:"message,omitempty"`
 }
 
 // OperationState contains information about state of a running operation
@@ -181,6 +485,11 @@ type ApplicationOperation struct {
 	Retry RetryStrategy `json:"retry,omitempty" protobuf:"bytes,4,opt,name=retry"`
 }
 
+// DryRun returns true if the operation was a sync that was only simulated, and thus made no changes to the cluster.
+func (o *ApplicationOperation) DryRun() bool {
+	return o.Sync != nil && o.Sync.DryRun
+}
+
 type Info struct {
 	Name  string `json:"name" protobuf:"bytes,1,name=name"`
 	Value string `json:"value" protobuf:"bytes,2,name=value"`
@@ -405,6 +714,9 @@ const (
 )
 
 // GitOpsDeploymentCondition contains details about an GitOpsDeployment condition, which is usually an error or warning
+//
+// Deprecated: the conditions of a GitOpsDeployment are now reported as metav1.Conditions. This type will be removed in
+// a future release.
 type GitOpsDeploymentCondition struct {
 	// Type is a GitOpsDeployment condition type
 	Type GitOpsDeploymentConditionType `json:"type"`
@@ -433,8 +745,29 @@ type GitOpsDeploymentCondition struct {
 type GitOpsDeploymentConditionType string
 
 const (
-	GitOpsDeploymentConditionSyncError     GitOpsDeploymentConditionType = "SyncError"
+	// GitOpsDeploymentConditionReady is True when the latest spec of the GitOpsDeployment has been processed without
+	// error, and the deployed resources are both in sync with the GitOps repository and healthy.
+	GitOpsDeploymentConditionReady GitOpsDeploymentConditionType = "Ready"
+	// GitOpsDeploymentConditionSynced is True when the deployed resources are in sync with the GitOps repository.
+	GitOpsDeploymentConditionSynced GitOpsDeploymentConditionType = "Synced"
+	// GitOpsDeploymentConditionHealthy is True when the deployed resources are healthy.
+	GitOpsDeploymentConditionHealthy GitOpsDeploymentConditionType = "Healthy"
+	// GitOpsDeploymentConditionProgressing is True while the GitOpsDeployment is being reconciled or synced, or while
+	// the deployed resources are progressing towards a healthy state.
+	GitOpsDeploymentConditionProgressing GitOpsDeploymentConditionType = "Progressing"
+	// GitOpsDeploymentConditionManagedEnvironmentReachable is False when the GitOpsDeploymentManagedEnvironment that the
+	// GitOpsDeployment deploys to is not reachable, according to the most recent probe of its connection.
+	GitOpsDeploymentConditionManagedEnvironmentReachable GitOpsDeploymentConditionType = "ManagedEnvironmentReachable"
+
+	// Deprecated: use the Synced and Ready conditions instead. This condition will be removed in a future release.
+	GitOpsDeploymentConditionSyncError GitOpsDeploymentConditionType = "SyncError"
+	// Deprecated: use the Ready condition instead. This condition will be removed in a future release.
 	GitOpsDeploymentConditionErrorOccurred GitOpsDeploymentConditionType = "ErrorOccurred"
+	GitOpsDeploymentConditionSuspended     GitOpsDeploymentConditionType = "Suspended"
+	// GitOpsDeploymentConditionRolledBack is True after the GitOpsDeployment was rolled back by a GitOpsDeploymentSyncRun:
+	// automated sync remains disabled (so that the rolled back revision stays deployed) until the spec of the
+	// GitOpsDeployment is changed. The ObservedGeneration of the condition is the generation that was rolled back.
+	GitOpsDeploymentConditionRolledBack GitOpsDeploymentConditionType = "RolledBack"
 )
 
 // GitOpsConditionStatus is a type which represents possible comparison results
@@ -491,11 +824,34 @@ type GitOpsDeploymentReasonType string
 const (
 	GitopsDeploymentReasonSyncError     GitOpsDeploymentReasonType = "SyncError"
 	GitopsDeploymentReasonErrorOccurred GitOpsDeploymentReasonType = "ErrorOccurred"
+	GitopsDeploymentReasonSuspended     GitOpsDeploymentReasonType = "Suspended"
+	GitopsDeploymentReasonRolledBack    GitOpsDeploymentReasonType = "RolledBack"
+
+	GitopsDeploymentReasonReady         GitOpsDeploymentReasonType = "Ready"
+	GitopsDeploymentReasonReconciling   GitOpsDeploymentReasonType = "Reconciling"
+	GitopsDeploymentReasonNotSynced     GitOpsDeploymentReasonType = "NotSynced"
+	GitopsDeploymentReasonNotHealthy    GitOpsDeploymentReasonType = "NotHealthy"
+	GitopsDeploymentReasonSyncRunning   GitOpsDeploymentReasonType = "SyncRunning"
+	GitopsDeploymentReasonProgressing   GitOpsDeploymentReasonType = "ResourcesProgressing"
+	GitopsDeploymentReasonIdle          GitOpsDeploymentReasonType = "Idle"
+	GitopsDeploymentReasonStatusUnknown GitOpsDeploymentReasonType = "Unknown"
+
+	GitopsDeploymentReasonManagedEnvironmentReachable   GitOpsDeploymentReasonType = "Reachable"
+	GitopsDeploymentReasonManagedEnvironmentUnreachable GitOpsDeploymentReasonType = "Unreachable"
 )
 
 const (
 	GitOpsDeploymentUserError_InvalidPathSlash = "spec.source.path cannot be '/'"
 	GitOpsDeploymentUserError_PathIsRequired   = "spec.source.path is a required field and it cannot be empty"
+
+	GitOpsDeploymentUserError_Suspended = "the GitOpsDeployment is suspended: changes to the GitOps repository will not be deployed until .spec.suspend is set to false"
+
+	GitOpsDeploymentUserError_RolledBack = "the GitOpsDeployment was rolled back to revision '%s': automated sync is disabled until the spec of the GitOpsDeployment is changed"
+
+	GitOpsDeploymentUserError_SourcesInvalidPathSlash = "spec.sources[].path cannot be '/'"
+	GitOpsDeploymentUserError_SourcesPathIsRequired   = "spec.sources[].path is a required field for sources that do not specify 'ref', and it cannot be empty"
+
+	GitOpsDeploymentUserError_InvalidRefreshType = "invalid value '%s' for the '" + AnnotationKeyRefresh + "' annotation: the value must be 'normal' or 'hard'"
 )
 
 // +kubebuilder:object:root=true
@@ -18,9 +18,17 @@ package v1alpha1
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
 	logutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util/log"
+	"gopkg.in/yaml.v2"
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -30,8 +38,35 @@ const (
 	error_nonempty_namespace_empty_environment = "the environment field should not be empty when the namespace is non-empty"
 	error_invalid_sync_option                  = "the specified sync option in .spec.syncPolicy.syncOptions is either mispelled or is not supported by GitOpsDeployment"
 	error_invalid_spec_type                    = "spec type must be manual or automated"
+	error_conflicting_sync_options             = "the .spec.syncPolicy.syncOptions field contains conflicting sync options"
+	error_empty_ignore_differences_kind        = "every entry in .spec.ignoreDifferences must have a non-empty kind"
+	error_empty_ignore_differences_fields      = "every entry in .spec.ignoreDifferences must specify at least one of jsonPointers, jqPathExpressions, or managedFieldsManagers"
+	error_invalid_ignore_differences_pointer   = "every JSON pointer in .spec.ignoreDifferences[].jsonPointers must begin with '/'"
+	error_invalid_helm_values                  = "the .spec.source.helm.values field must contain a valid YAML map of Helm values"
+	error_empty_helm_value_file                = "the .spec.source.helm.valueFiles field must not contain empty values"
+	error_empty_helm_parameter_name            = "every parameter in .spec.source.helm.parameters must have a non-empty name"
+	error_invalid_helm_release_name            = "the .spec.source.helm.releaseName field must be no more than 53 characters"
+	error_invalid_kustomize_image              = "the .spec.source.kustomize.images field contains an invalid image override"
+	error_invalid_kustomize_common_label       = "the .spec.source.kustomize.commonLabels field contains an invalid label"
+	error_invalid_kustomize_common_annotation  = "the .spec.source.kustomize.commonAnnotations field contains an invalid annotation"
+	error_invalid_kustomize_patch              = "every patch in .spec.source.kustomize.patches must contain a valid YAML or JSON patch"
+	error_source_and_sources_both_set          = "the .spec.source and .spec.sources fields are mutually exclusive, only one may be specified"
+	error_empty_sources_repo_url               = "every source in .spec.sources must have a non-empty repoURL"
+	error_duplicate_sources_ref                = "every ref in .spec.sources must be unique"
+	error_ref_not_valid_in_source              = "the .spec.source.ref field is only valid for sources in .spec.sources"
+	error_invalid_retry_limit                  = "the .spec.syncPolicy.retry.limit field must be -1 (unlimited), 0 (no retries), or a positive number"
+	error_invalid_retry_backoff_duration       = "the .spec.syncPolicy.retry.backoff duration fields must be a number of seconds, or a valid duration (e.g. '5s', '2m', '1h')"
+	error_invalid_retry_backoff_factor         = "the .spec.syncPolicy.retry.backoff.factor field must be a positive number"
+	error_invalid_refresh_annotation           = "the " + AnnotationKeyRefresh + " annotation must be 'normal' or 'hard'"
 )
 
+// helmReleaseNameMaxLength is the maximum length of a Helm release name, as enforced by Helm itself.
+const helmReleaseNameMaxLength = 53
+
+// kustomizeImageRegex matches a Kustomize image override, in the same format as 'kustomize edit set image':
+// '[<name>=]<new name>[:<new tag>][@<digest>]'
+var kustomizeImageRegex = regexp.MustCompile(`^([a-zA-Z0-9][a-zA-Z0-9._/:-]*=)?[a-zA-Z0-9][a-zA-Z0-9._/:-]*(@[a-zA-Z0-9]+:[a-fA-F0-9]+)?$`)
+
 // log is for logging in this package.
 var gitopsdeploymentlog = logf.Log.WithName(logutil.LogLogger_managed_gitops)
 
@@ -49,6 +84,33 @@ var _ webhook.Defaulter = &GitOpsDeployment{}
 func (r *GitOpsDeployment) Default() {
 	gitopsdeploymentlog.Info("default", "name", r.Name)
 
+	// The automated sync policy and retry strategy are only used by automated GitOpsDeployments
+	if !strings.EqualFold(r.Spec.Type, GitOpsDeploymentSpecType_Automated) {
+		return
+	}
+
+	if r.Spec.SyncPolicy == nil {
+		r.Spec.SyncPolicy = &SyncPolicy{}
+	}
+
+	defaultAutomated := DefaultSyncPolicyAutomated()
+	if r.Spec.SyncPolicy.Automated == nil {
+		r.Spec.SyncPolicy.Automated = defaultAutomated
+	} else {
+		if r.Spec.SyncPolicy.Automated.Prune == nil {
+			r.Spec.SyncPolicy.Automated.Prune = defaultAutomated.Prune
+		}
+		if r.Spec.SyncPolicy.Automated.SelfHeal == nil {
+			r.Spec.SyncPolicy.Automated.SelfHeal = defaultAutomated.SelfHeal
+		}
+		if r.Spec.SyncPolicy.Automated.AllowEmpty == nil {
+			r.Spec.SyncPolicy.Automated.AllowEmpty = defaultAutomated.AllowEmpty
+		}
+	}
+
+	if r.Spec.SyncPolicy.Retry == nil {
+		r.Spec.SyncPolicy.Retry = DefaultRetryStrategy()
+	}
 }
 
 //+kubebuilder:webhook:path=/validate-managed-gitops-redhat-com-v1alpha1-gitopsdeployment,mutating=false,failurePolicy=fail,sideEffects=None,groups=managed-gitops.redhat.com,resources=gitopsdeployments,verbs=create;update,versions=v1alpha1,name=vgitopsdeployment.kb.io,admissionReviewVersions=v1
@@ -93,13 +155,14 @@ func (r *GitOpsDeployment) ValidateGitOpsDeployment() error {
 
 	// Check whether sync options are valid
 	if r.Spec.SyncPolicy != nil {
-		for _, syncOptionString := range r.Spec.SyncPolicy.SyncOptions {
-
-			if !(syncOptionString == SyncOptions_CreateNamespace_true ||
-				syncOptionString == SyncOptions_CreateNamespace_false) {
-				return fmt.Errorf(error_invalid_sync_option)
-			}
+		if err := validateSyncOptions(r.Spec.SyncPolicy.SyncOptions); err != nil {
+			return err
+		}
+	}
 
+	if r.Spec.SyncPolicy != nil && r.Spec.SyncPolicy.Retry != nil {
+		if err := validateRetryStrategy(*r.Spec.SyncPolicy.Retry); err != nil {
+			return err
 		}
 	}
 
@@ -107,5 +170,203 @@ func (r *GitOpsDeployment) ValidateGitOpsDeployment() error {
 		return fmt.Errorf(error_nonempty_namespace_empty_environment)
 	}
 
+	if refreshType, exists := r.Annotations[AnnotationKeyRefresh]; exists &&
+		!(refreshType == string(RefreshType_Normal) || refreshType == string(RefreshType_Hard)) {
+		return fmt.Errorf(error_invalid_refresh_annotation)
+	}
+
+	for _, ignoreDifferences := range r.Spec.IgnoreDifferences {
+		if err := validateResourceIgnoreDifferences(ignoreDifferences); err != nil {
+			return err
+		}
+	}
+
+	if r.Spec.HasMultipleSources() {
+		if err := validateApplicationSources(r.Spec); err != nil {
+			return err
+		}
+	} else if r.Spec.Source.Ref != "" {
+		return fmt.Errorf(error_ref_not_valid_in_source)
+	}
+
+	for _, source := range r.Spec.GetSources() {
+
+		if source.Helm != nil {
+			if err := validateApplicationSourceHelm(*source.Helm); err != nil {
+				return err
+			}
+		}
+
+		if source.Kustomize != nil {
+			if err := validateApplicationSourceKustomize(*source.Kustomize); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// validateSyncOptions ensures that every sync option is supported, and that no two sync options conflict with each other.
+func validateSyncOptions(syncOptions SyncOptions) error {
+
+	// The value of each sync option, indexed by name: e.g. 'CreateNamespace=true' is stored as 'CreateNamespace' -> 'true'
+	syncOptionValues := map[string]string{}
+
+	for _, syncOption := range syncOptions {
+
+		if !IsSupportedSyncOption(syncOption) {
+			return fmt.Errorf(error_invalid_sync_option)
+		}
+
+		name, value, _ := strings.Cut(string(syncOption), "=")
+
+		// A sync option may not be both enabled and disabled, e.g. 'CreateNamespace=true' and 'CreateNamespace=false'
+		if existingValue, exists := syncOptionValues[name]; exists && existingValue != value {
+			return fmt.Errorf(error_conflicting_sync_options)
+		}
+		syncOptionValues[name] = value
+	}
+
+	// Argo CD does not support replacing resources when using server-side apply
+	if syncOptionValues["Replace"] == "true" && syncOptionValues["ServerSideApply"] == "true" {
+		return fmt.Errorf(error_conflicting_sync_options)
+	}
+
+	return nil
+}
+
+// validateResourceIgnoreDifferences ensures that an entry of .spec.ignoreDifferences can be passed to Argo CD.
+func validateResourceIgnoreDifferences(ignoreDifferences ResourceIgnoreDifferences) error {
+
+	if strings.TrimSpace(ignoreDifferences.Kind) == "" {
+		return fmt.Errorf(error_empty_ignore_differences_kind)
+	}
+
+	if len(ignoreDifferences.JSONPointers) == 0 && len(ignoreDifferences.JQPathExpressions) == 0 &&
+		len(ignoreDifferences.ManagedFieldsManagers) == 0 {
+		return fmt.Errorf(error_empty_ignore_differences_fields)
+	}
+
+	for _, jsonPointer := range ignoreDifferences.JSONPointers {
+		if !strings.HasPrefix(jsonPointer, "/") {
+			return fmt.Errorf(error_invalid_ignore_differences_pointer)
+		}
+	}
+
+	return nil
+}
+
+// validateRetryStrategy ensures that the retry strategy of a GitOpsDeployment sync policy can be passed to Argo CD.
+func validateRetryStrategy(retry RetryStrategy) error {
+
+	if retry.Limit < -1 {
+		return fmt.Errorf(error_invalid_retry_limit)
+	}
+
+	if retry.Backoff == nil {
+		return nil
+	}
+
+	for _, duration := range []string{retry.Backoff.Duration, retry.Backoff.MaxDuration} {
+		if duration == "" {
+			continue
+		}
+		// As with Argo CD, a duration without a unit is interpreted as a number of seconds
+		if _, err := strconv.Atoi(duration); err == nil {
+			continue
+		}
+		if _, err := time.ParseDuration(duration); err != nil {
+			return fmt.Errorf(error_invalid_retry_backoff_duration)
+		}
+	}
+
+	if retry.Backoff.Factor != nil && *retry.Backoff.Factor < 1 {
+		return fmt.Errorf(error_invalid_retry_backoff_factor)
+	}
+
+	return nil
+}
+
+// validateApplicationSources ensures that the .spec.sources field of a GitOpsDeployment is consistent with the rest of the spec.
+func validateApplicationSources(spec GitOpsDeploymentSpec) error {
+
+	if spec.Source.RepoURL != "" || spec.Source.Path != "" || spec.Source.TargetRevision != "" {
+		return fmt.Errorf(error_source_and_sources_both_set)
+	}
+
+	refs := map[string]bool{}
+	for _, source := range spec.Sources {
+		if strings.TrimSpace(source.RepoURL) == "" {
+			return fmt.Errorf(error_empty_sources_repo_url)
+		}
+
+		if source.Ref != "" {
+			if refs[source.Ref] {
+				return fmt.Errorf(error_duplicate_sources_ref)
+			}
+			refs[source.Ref] = true
+		}
+	}
+
+	return nil
+}
+
+// validateApplicationSourceKustomize ensures that the Kustomize options of a GitOpsDeployment source can be passed to Argo CD.
+func validateApplicationSourceKustomize(kustomize ApplicationSourceKustomize) error {
+
+	for _, image := range kustomize.Images {
+		if !kustomizeImageRegex.MatchString(image) {
+			return fmt.Errorf(error_invalid_kustomize_image)
+		}
+	}
+
+	for key, value := range kustomize.CommonLabels {
+		if len(validation.IsQualifiedName(key)) > 0 || len(validation.IsValidLabelValue(value)) > 0 {
+			return fmt.Errorf(error_invalid_kustomize_common_label)
+		}
+	}
+
+	if len(apivalidation.ValidateAnnotations(kustomize.CommonAnnotations, field.NewPath("commonAnnotations"))) > 0 {
+		return fmt.Errorf(error_invalid_kustomize_common_annotation)
+	}
+
+	for _, patch := range kustomize.Patches {
+		// A JSON 6902 patch is a list of operations, while a strategic merge patch is a map: both are valid YAML.
+		var patchContent interface{}
+		if strings.TrimSpace(patch.Patch) == "" || yaml.Unmarshal([]byte(patch.Patch), &patchContent) != nil {
+			return fmt.Errorf(error_invalid_kustomize_patch)
+		}
+	}
+
+	return nil
+}
+
+// validateApplicationSourceHelm ensures that the Helm options of a GitOpsDeployment source can be passed to Argo CD.
+func validateApplicationSourceHelm(helm ApplicationSourceHelm) error {
+
+	for _, valueFile := range helm.ValueFiles {
+		if valueFile == "" {
+			return fmt.Errorf(error_empty_helm_value_file)
+		}
+	}
+
+	for _, param := range helm.Parameters {
+		if param.Name == "" {
+			return fmt.Errorf(error_empty_helm_parameter_name)
+		}
+	}
+
+	if len(helm.ReleaseName) > helmReleaseNameMaxLength {
+		return fmt.Errorf(error_invalid_helm_release_name)
+	}
+
+	if helm.Values != "" {
+		values := map[string]interface{}{}
+		if err := yaml.Unmarshal([]byte(helm.Values), &values); err != nil {
+			return fmt.Errorf(error_invalid_helm_values)
+		}
+	}
+
 	return nil
 }
@@ -116,6 +116,55 @@ var _ = Describe("GitOpsDeployment validation webhook", func() {
 		})
 	})
 
+	Context("Create GitOpsDeployment CR with conflicting .spec.syncPolicy.syncOptions", func() {
+		It("Should fail with error saying the sync options conflict, if an option is both enabled and disabled", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Spec.SyncPolicy = &SyncPolicy{
+				SyncOptions: SyncOptions{
+					SyncOptions_ServerSideApply_true,
+					SyncOptions_ServerSideApply_false,
+				},
+			}
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_conflicting_sync_options))
+		})
+
+		It("Should fail with error saying the sync options conflict, if both Replace and ServerSideApply are enabled", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Spec.SyncPolicy = &SyncPolicy{
+				SyncOptions: SyncOptions{
+					SyncOptions_Replace_true,
+					SyncOptions_ServerSideApply_true,
+				},
+			}
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_conflicting_sync_options))
+		})
+
+		It("Should succeed when the sync options do not conflict", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Spec.SyncPolicy = &SyncPolicy{
+				SyncOptions: SyncOptions{
+					SyncOptions_ServerSideApply_true,
+					SyncOptions_PruneLast_true,
+					SyncOptions_ApplyOutOfSyncOnly_true,
+					SyncOptions_RespectIgnoreDifferences_true,
+					SyncOptions_Validate_false,
+				},
+			}
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Succeed())
+
+			err = k8sClient.Delete(context.Background(), gitopsDepl)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
 	Context("Create GitOpsDeployment CR with empty Environment field and non-empty namespace", func() {
 		It("Should fail with error saying the environment field should not be empty when the namespace is non-empty", func() {
 			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
@@ -149,4 +198,265 @@ var _ = Describe("GitOpsDeployment validation webhook", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 	})
+
+	Context("Create GitOpsDeployment CR with invalid .spec.source.helm field", func() {
+		It("Should fail with error saying the helm values must be a valid YAML map", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Spec.Source.Helm = &ApplicationSourceHelm{
+				Values: "not-a-map",
+			}
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_invalid_helm_values))
+		})
+
+		It("Should fail with error saying every helm parameter must have a name", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Spec.Source.Helm = &ApplicationSourceHelm{
+				Parameters: []HelmParameter{{Name: "", Value: "value"}},
+			}
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_empty_helm_parameter_name))
+		})
+
+		It("Should succeed when the helm field is valid", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Spec.Source.Helm = &ApplicationSourceHelm{
+				ValueFiles:  []string{"values-prod.yaml"},
+				Values:      "replicaCount: 2\nimage:\n  tag: v1\n",
+				ReleaseName: "my-release",
+				Parameters:  []HelmParameter{{Name: "service.type", Value: "NodePort"}},
+			}
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Succeed())
+
+			err = k8sClient.Delete(context.Background(), gitopsDepl)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("Create GitOpsDeployment CR with invalid .spec.ignoreDifferences field", func() {
+		It("Should fail with error saying the kind must not be empty", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Spec.IgnoreDifferences = []ResourceIgnoreDifferences{
+				{Group: "apps", JSONPointers: []string{"/spec/replicas"}},
+			}
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_empty_ignore_differences_kind))
+		})
+
+		It("Should fail with error saying at least one field must be ignored", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Spec.IgnoreDifferences = []ResourceIgnoreDifferences{
+				{Group: "apps", Kind: "Deployment"},
+			}
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_empty_ignore_differences_fields))
+		})
+
+		It("Should fail with error saying JSON pointers must begin with '/'", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Spec.IgnoreDifferences = []ResourceIgnoreDifferences{
+				{Group: "apps", Kind: "Deployment", JSONPointers: []string{"spec/replicas"}},
+			}
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_invalid_ignore_differences_pointer))
+		})
+	})
+
+	Context("Create GitOpsDeployment CR with invalid .spec.syncPolicy.retry field", func() {
+		It("Should fail with error saying the retry limit is invalid", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Spec.SyncPolicy = &SyncPolicy{
+				Retry: &RetryStrategy{Limit: -2},
+			}
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_invalid_retry_limit))
+		})
+
+		It("Should fail with error saying the retry backoff duration is invalid", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Spec.SyncPolicy = &SyncPolicy{
+				Retry: &RetryStrategy{Limit: 3, Backoff: &Backoff{Duration: "five seconds"}},
+			}
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_invalid_retry_backoff_duration))
+		})
+	})
+
+	Context("Create GitOpsDeployment CR with the refresh annotation", func() {
+		It("Should fail with error saying the refresh annotation is invalid", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Annotations = map[string]string{AnnotationKeyRefresh: "soft"}
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_invalid_refresh_annotation))
+		})
+
+		It("Should succeed when the refresh annotation is valid", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Annotations = map[string]string{AnnotationKeyRefresh: string(RefreshType_Hard)}
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Succeed())
+
+			err = k8sClient.Delete(context.Background(), gitopsDepl)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("Default the .spec.syncPolicy field of an automated GitOpsDeployment", func() {
+		It("Should default the automated and retry fields, without overriding values specified by the user", func() {
+			disabled := false
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Spec.SyncPolicy = &SyncPolicy{
+				Automated: &SyncPolicyAutomated{Prune: &disabled},
+			}
+
+			gitopsDepl.Default()
+
+			Expect(*gitopsDepl.Spec.SyncPolicy.Automated.Prune).To(BeFalse())
+			Expect(*gitopsDepl.Spec.SyncPolicy.Automated.SelfHeal).To(BeTrue())
+			Expect(*gitopsDepl.Spec.SyncPolicy.Automated.AllowEmpty).To(BeTrue())
+			Expect(gitopsDepl.Spec.SyncPolicy.Retry).To(Equal(DefaultRetryStrategy()))
+		})
+
+		It("Should not default the sync policy of a manual GitOpsDeployment", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Manual
+
+			gitopsDepl.Default()
+
+			Expect(gitopsDepl.Spec.SyncPolicy).To(BeNil())
+		})
+	})
+
+	Context("Create GitOpsDeployment CR with invalid .spec.sources field", func() {
+		It("Should fail with error saying the source and sources fields are mutually exclusive", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Spec.Source = ApplicationSource{RepoURL: "https://github.com/my-org/my-app", Path: "app"}
+			gitopsDepl.Spec.Sources = []ApplicationSource{
+				{RepoURL: "https://github.com/my-org/my-chart", Path: "chart"},
+			}
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_source_and_sources_both_set))
+		})
+
+		It("Should fail with error saying every ref must be unique", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Spec.Sources = []ApplicationSource{
+				{RepoURL: "https://github.com/my-org/my-values", Ref: "values"},
+				{RepoURL: "https://github.com/my-org/my-other-values", Ref: "values"},
+			}
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_duplicate_sources_ref))
+		})
+
+		It("Should fail with error saying ref is only valid in sources", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Spec.Source.Ref = "values"
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_ref_not_valid_in_source))
+		})
+
+		It("Should succeed when the sources field is valid", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Spec.Sources = []ApplicationSource{
+				{
+					RepoURL: "https://github.com/my-org/my-chart",
+					Path:    "chart",
+					Helm:    &ApplicationSourceHelm{ValueFiles: []string{"$values/envs/prod/values.yaml"}},
+				},
+				{RepoURL: "https://github.com/my-org/my-values", Ref: "values"},
+			}
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Succeed())
+
+			err = k8sClient.Delete(context.Background(), gitopsDepl)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("Create GitOpsDeployment CR with invalid .spec.source.kustomize field", func() {
+		It("Should fail with error saying the kustomize image override is invalid", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+
+			for _, image := range []string{"", "quay.io/org/app:v2;rm", "quay.io/org/app:\"v2\""} {
+				gitopsDepl.Spec.Source.Kustomize = &ApplicationSourceKustomize{
+					Images: []string{image},
+				}
+
+				err := k8sClient.Create(ctx, gitopsDepl)
+				Expect(err).Should(Not(Succeed()))
+				Expect(err.Error()).Should(ContainSubstring(error_invalid_kustomize_image))
+			}
+		})
+
+		It("Should fail with error saying the kustomize common label is invalid", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Spec.Source.Kustomize = &ApplicationSourceKustomize{
+				CommonLabels: map[string]string{"invalid key!": "value"},
+			}
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_invalid_kustomize_common_label))
+		})
+
+		It("Should fail with error saying the kustomize patch is invalid", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+
+			for _, patch := range []string{"", "- op: replace\n  path: [/spec/replicas"} {
+				gitopsDepl.Spec.Source.Kustomize = &ApplicationSourceKustomize{
+					Patches: []KustomizePatch{{Patch: patch}},
+				}
+
+				err := k8sClient.Create(ctx, gitopsDepl)
+				Expect(err).Should(Not(Succeed()))
+				Expect(err.Error()).Should(ContainSubstring(error_invalid_kustomize_patch))
+			}
+		})
+
+		It("Should succeed when the kustomize field is valid", func() {
+			gitopsDepl.Spec.Type = GitOpsDeploymentSpecType_Automated
+			gitopsDepl.Spec.Source.Kustomize = &ApplicationSourceKustomize{
+				NamePrefix: "prod-",
+				Images: []string{"quay.io/org/app:v2", "app=quay.io/org/other-app:v2",
+					"quay.io/org/app@sha256:3b0b4c7ab5b1e2a8c8d8e5f9c1f0a3d2e4b6c8a0f1e3d5c7b9a2c4e6f8a0b1c3"},
+				CommonLabels:      map[string]string{"app.kubernetes.io/part-of": "my-app"},
+				CommonAnnotations: map[string]string{"example.com/owner": "team a", "example.com/link": "https://example.com/?a=1&b=2;c=50%"},
+				Patches: []KustomizePatch{{
+					Patch:  "- op: replace\n  path: /spec/replicas\n  value: 3",
+					Target: &KustomizeSelector{Kind: "Deployment", Name: "my-app"},
+				}},
+			}
+
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).Should(Succeed())
+
+			err = k8sClient.Delete(context.Background(), gitopsDepl)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
 })
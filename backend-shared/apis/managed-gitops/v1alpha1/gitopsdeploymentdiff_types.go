@@ -0,0 +1,100 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GitOpsDeploymentDiffSpec defines the desired state of GitOpsDeploymentDiff
+type GitOpsDeploymentDiffSpec struct {
+	// Reference to the target GitOpsDeployment, in the same namespace, to compute the diff of
+	GitopsDeploymentName string `json:"gitopsDeploymentName"`
+}
+
+// GitOpsDeploymentDiffStatus defines the observed state of GitOpsDeploymentDiff
+type GitOpsDeploymentDiffStatus struct {
+	// ObservedGeneration is the .metadata.generation of the GitOpsDeploymentDiff that the diff was computed for.
+	// The diff is computed again whenever the spec of the GitOpsDeploymentDiff is modified.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Phase is the current phase of the diff: Pending, Completed or Failed
+	Phase GitOpsDeploymentDiffPhase `json:"phase,omitempty"`
+
+	// Message contains details about the result of the diff, typically errors
+	Message string `json:"message,omitempty"`
+
+	// ComparedAt is the time at which the diff was computed
+	ComparedAt *metav1.Time `json:"comparedAt,omitempty"`
+
+	// Resources contains the diff of each resource of the GitOpsDeployment that is OutOfSync
+	Resources []ResourceDiff `json:"resources,omitempty"`
+}
+
+type GitOpsDeploymentDiffPhase string
+
+const (
+	// GitOpsDeploymentDiffPhase_Pending indicates that the diff has been requested, but has not yet been computed
+	GitOpsDeploymentDiffPhase_Pending GitOpsDeploymentDiffPhase = "Pending"
+	// GitOpsDeploymentDiffPhase_Completed indicates that the diff was successfully computed
+	GitOpsDeploymentDiffPhase_Completed GitOpsDeploymentDiffPhase = "Completed"
+	// GitOpsDeploymentDiffPhase_Failed indicates that the diff could not be computed
+	GitOpsDeploymentDiffPhase_Failed GitOpsDeploymentDiffPhase = "Failed"
+)
+
+// ResourceDiff is the difference between the live state of a resource on the target cluster, and the target state
+// of the resource (as defined in the GitOps repository).
+type ResourceDiff struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	// Diff is a unified diff of the normalized live state (---), and the target state (+++), of the resource, as YAML.
+	// - The values of Secrets are redacted.
+	Diff string `json:"diff"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Compared At",type=date,JSONPath=`.status.comparedAt`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// GitOpsDeploymentDiff is the Schema for the gitopsdeploymentdiffs API.
+// A GitOpsDeploymentDiff requests the GitOps Service to compute the difference between the live state, and the
+// target state, of each OutOfSync resource of a GitOpsDeployment.
+type GitOpsDeploymentDiff struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GitOpsDeploymentDiffSpec   `json:"spec,omitempty"`
+	Status GitOpsDeploymentDiffStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// GitOpsDeploymentDiffList contains a list of GitOpsDeploymentDiff
+type GitOpsDeploymentDiffList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GitOpsDeploymentDiff `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GitOpsDeploymentDiff{}, &GitOpsDeploymentDiffList{})
+}
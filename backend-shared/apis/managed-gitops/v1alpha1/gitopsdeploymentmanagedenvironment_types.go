@@ -22,6 +22,10 @@ import (
 
 const (
 	ManagedEnvironmentStatusConnectionInitializationSucceeded = "ConnectionInitializationSucceeded"
+
+	// ManagedEnvironmentStatusReachable is True if the GitOps Service was able to connect to the cluster, using the
+	// stored cluster credentials, the last time the connection was probed. The connection is probed periodically.
+	ManagedEnvironmentStatusReachable = "Reachable"
 )
 
 // The GitOpsDeploymentManagedEnvironment CR describes a remote cluster which the GitOps Service will deploy to, via Argo CD.
@@ -33,7 +37,9 @@ type GitOpsDeploymentManagedEnvironmentSpec struct {
 	// APIURL is the URL of the cluster to connect to
 	APIURL string `json:"apiURL"`
 
-	// ClusterCredentialsSecret is a reference to a Secret that contains cluster connection details. The cluster details should be in the form of a kubeconfig file.
+	// ClusterCredentialsSecret is a reference to a Secret that contains cluster connection details. The cluster details should either be
+	// in the form of a kubeconfig file (in the 'kubeconfig' key), or a bearer token (in the 'token' key) along with the CA certificates
+	// of the cluster (in the 'ca.crt' key) and an optional client certificate and key (in the 'tls.crt' and 'tls.key' keys).
 	ClusterCredentialsSecret string `json:"credentialsSecret"`
 
 	// AllowInsecureSkipTLSVerify controls whether Argo CD will accept a Kubernetes API URL with untrusted-TLS certificate.
@@ -41,6 +47,21 @@ type GitOpsDeploymentManagedEnvironmentSpec struct {
 	// Defaults to false.
 	AllowInsecureSkipTLSVerify bool `json:"allowInsecureSkipTLSVerify"`
 
+	// CABundle is a PEM-encoded bundle of CA certificates, which is used to verify the TLS certificate of the cluster.
+	// Optional: This should be used (instead of AllowInsecureSkipTLSVerify) for clusters whose certificate is signed by a private CA.
+	// May not be specified together with CASecretRef, or with AllowInsecureSkipTLSVerify.
+	CABundle string `json:"caBundle,omitempty"`
+
+	// CASecretRef is a reference to a Secret, in the same Namespace, that contains a PEM-encoded bundle of CA certificates,
+	// which is used to verify the TLS certificate of the cluster.
+	// Optional: This is an alternative to CABundle, for example for CA certificates that are managed by another tool.
+	// May not be specified together with CABundle, or with AllowInsecureSkipTLSVerify.
+	CASecretRef *ManagedEnvironmentCASecretRef `json:"caSecretRef,omitempty"`
+
+	// TLSServerName is the name that is used to verify the TLS certificate of the cluster, when it differs from the host name of the API URL.
+	// Optional: for example, if the cluster is reached via a proxy or a load balancer.
+	TLSServerName string `json:"tlsServerName,omitempty"`
+
 	// CreateNewServiceAccount controls whether Argo CD will use the ServiceAccount provided by the user in the Secret, or if a new ServiceAccount
 	// should be created.
 	//
@@ -74,6 +95,20 @@ type GitOpsDeploymentManagedEnvironmentSpec struct {
 	ClusterResources bool `json:"clusterResources,omitempty"`
 }
 
+// ManagedEnvironmentCASecretRef references the key of a Secret that contains a PEM-encoded bundle of CA certificates
+type ManagedEnvironmentCASecretRef struct {
+
+	// Name is the name of the Secret, which must be in the same Namespace as the GitOpsDeploymentManagedEnvironment
+	Name string `json:"name"`
+
+	// Key is the key of the Secret that contains the CA certificates.
+	// Optional, defaults to 'ca.crt'.
+	Key string `json:"key,omitempty"`
+}
+
+// DefaultCASecretKey is the key of the Secret referenced by CASecretRef that is used, if no key is specified
+const DefaultCASecretKey = "ca.crt"
+
 type AllowInsecureSkipTLSVerify bool
 
 // Insecure TLS Status types
@@ -87,6 +122,12 @@ const (
 // GitOpsDeploymentManagedEnvironmentStatus defines the observed state of GitOpsDeploymentManagedEnvironment
 type GitOpsDeploymentManagedEnvironmentStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// LastProbeTime is the time at which the connection to the cluster was most recently probed
+	LastProbeTime *metav1.Time `json:"lastProbeTime,omitempty"`
+
+	// LastProbeLatency is how long the most recent probe of the connection to the cluster took
+	LastProbeLatency *metav1.Duration `json:"lastProbeLatency,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -119,6 +160,14 @@ const (
 	ConditionReasonInvalidNamespaceList               ManagedEnvironmentConditionReason = "InvalidNamespaceList"
 	ConditionReasonUnableToRetrieveRestConfig         ManagedEnvironmentConditionReason = "UnableToRetrieveRestConfig"
 	ConditionReasonUnknownError                       ManagedEnvironmentConditionReason = "UnknownError"
+	ConditionReasonInvalidCABundle                    ManagedEnvironmentConditionReason = "InvalidCABundle"
+	ConditionReasonMissingTokenField                  ManagedEnvironmentConditionReason = "MissingTokenField"
+	ConditionReasonMissingCACertificateField          ManagedEnvironmentConditionReason = "MissingCACertificateField"
+	ConditionReasonMissingClientCertificateField      ManagedEnvironmentConditionReason = "MissingClientCertificateField"
+	ConditionReasonMissingClientKeyField              ManagedEnvironmentConditionReason = "MissingClientKeyField"
+	ConditionReasonInvalidClientCertificate           ManagedEnvironmentConditionReason = "InvalidClientCertificate"
+	ConditionReasonReachable                          ManagedEnvironmentConditionReason = "Reachable"
+	ConditionReasonUnreachable                        ManagedEnvironmentConditionReason = "Unreachable"
 )
 
 //+kubebuilder:object:root=true
@@ -27,7 +27,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
-const error_invalid_cluster_api_url = "cluster api url must start with https://"
+const (
+	error_invalid_cluster_api_url     = "cluster api url must start with https://"
+	error_ca_bundle_and_ca_secret_ref = "only one of caBundle and caSecretRef may be specified"
+	error_ca_bundle_and_insecure      = "allowInsecureSkipTLSVerify may not be specified together with caBundle or caSecretRef"
+	error_missing_ca_secret_ref_name  = "caSecretRef must specify the name of a Secret"
+)
 
 // log is for logging in this package.
 var gitopsdeploymentmanagedenvironmentlog = logf.Log.WithName(logutil.LogLogger_managed_gitops)
@@ -93,5 +98,17 @@ func (r *GitOpsDeploymentManagedEnvironment) ValidateGitOpsDeploymentManagedEnv(
 		}
 	}
 
+	if r.Spec.CABundle != "" && r.Spec.CASecretRef != nil {
+		return fmt.Errorf(error_ca_bundle_and_ca_secret_ref)
+	}
+
+	if r.Spec.AllowInsecureSkipTLSVerify && (r.Spec.CABundle != "" || r.Spec.CASecretRef != nil) {
+		return fmt.Errorf(error_ca_bundle_and_insecure)
+	}
+
+	if r.Spec.CASecretRef != nil && r.Spec.CASecretRef.Name == "" {
+		return fmt.Errorf(error_missing_ca_secret_ref_name)
+	}
+
 	return nil
 }
@@ -78,4 +78,28 @@ var _ = Describe("GitOpsDeploymentManagedEnvironment validation webhook", func()
 		})
 	})
 
+	Context("Create GitOpsDeploymentManagedEnvironment CR with invalid CA fields", func() {
+		It("Should fail with an error if both caBundle and caSecretRef are specified", func() {
+
+			managedEnv.Spec.APIURL = "https://api.fake-unit-test-data.origin-ci-int-gce.dev.rhcloud.com:6443"
+			managedEnv.Spec.CABundle = "fake-ca-bundle"
+			managedEnv.Spec.CASecretRef = &ManagedEnvironmentCASecretRef{Name: "fake-ca-secret"}
+
+			err := k8sClient.Create(ctx, managedEnv)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring(error_ca_bundle_and_ca_secret_ref))
+		})
+
+		It("Should fail with an error if caSecretRef is specified with allowInsecureSkipTLSVerify", func() {
+
+			managedEnv.Spec.APIURL = "https://api.fake-unit-test-data.origin-ci-int-gce.dev.rhcloud.com:6443"
+			managedEnv.Spec.AllowInsecureSkipTLSVerify = true
+			managedEnv.Spec.CASecretRef = &ManagedEnvironmentCASecretRef{Name: "fake-ca-secret"}
+
+			err := k8sClient.Create(ctx, managedEnv)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring(error_ca_bundle_and_insecure))
+		})
+	})
+
 })
@@ -0,0 +1,168 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GitOpsDeploymentSetSpec defines the desired state of GitOpsDeploymentSet
+type GitOpsDeploymentSetSpec struct {
+
+	// Generators generate the sets of parameters that are used to render the template. One GitOpsDeployment is
+	// generated for each set of parameters, from each generator.
+	// +kubebuilder:validation:MinItems=1
+	Generators []GitOpsDeploymentSetGenerator `json:"generators"`
+
+	// Template is the template of the GitOpsDeployments that are generated. Within the string fields of the template,
+	// '{{parameter}}' is replaced with the value of that parameter.
+	Template GitOpsDeploymentSetTemplate `json:"template"`
+}
+
+// GitOpsDeploymentSetGenerator generates sets of parameters. Exactly one field should be specified.
+type GitOpsDeploymentSetGenerator struct {
+
+	// List generates one set of parameters for each element of the list
+	List *ListGenerator `json:"list,omitempty"`
+
+	// Git generates one set of parameters for each matching directory of a Git repository
+	Git *GitGenerator `json:"git,omitempty"`
+
+	// ManagedEnvironment generates one set of parameters for each GitOpsDeploymentManagedEnvironment, in the same
+	// namespace, that matches the selector.
+	ManagedEnvironment *ManagedEnvironmentGenerator `json:"managedEnvironment,omitempty"`
+}
+
+// ListGenerator generates a static list of parameter sets
+type ListGenerator struct {
+	// Elements is the list of parameter sets: the keys of each element are the parameter names.
+	Elements []map[string]string `json:"elements"`
+}
+
+// GitGenerator generates parameter sets from the directories of a Git repository.
+//
+// The parameters are:
+// - 'path': the path of the directory, relative to the root of the repository (for example, 'environments/dev')
+// - 'path.basename': the name of the directory (for example, 'dev')
+// - 'path.basenameNormalized': the name of the directory, with any characters that are not valid in a resource name replaced with '-'
+type GitGenerator struct {
+	// RepoURL is the URL of the Git repository. If a GitOpsDeploymentRepositoryCredential exists in the namespace for
+	// this repository, its credentials are used.
+	RepoURL string `json:"repoURL"`
+
+	// Revision is the branch, tag or commit SHA of the repository to read the directories from. Defaults to HEAD.
+	Revision string `json:"revision,omitempty"`
+
+	// Directories selects the directories of the repository to generate parameter sets for.
+	// +kubebuilder:validation:MinItems=1
+	Directories []GitDirectoryGeneratorItem `json:"directories"`
+
+	// RequeueAfterSeconds is how often the repository is checked for changes. Defaults to 180 seconds.
+	RequeueAfterSeconds *int64 `json:"requeueAfterSeconds,omitempty"`
+}
+
+// GitDirectoryGeneratorItem selects the directories of a Git repository whose path matches a pattern
+type GitDirectoryGeneratorItem struct {
+	// Path is the pattern (as with Go's path.Match, for example 'environments/*') matched against directory paths
+	Path string `json:"path"`
+
+	// Exclude, if true, excludes the matching directories, even if they match another pattern.
+	Exclude bool `json:"exclude,omitempty"`
+}
+
+// ManagedEnvironmentGenerator generates parameter sets from GitOpsDeploymentManagedEnvironments.
+//
+// The parameters are:
+// - 'name': the name of the GitOpsDeploymentManagedEnvironment
+// - 'apiURL': the API URL of the cluster
+// - 'metadata.labels.<key>': the value of each label of the GitOpsDeploymentManagedEnvironment
+type ManagedEnvironmentGenerator struct {
+	// Selector selects the GitOpsDeploymentManagedEnvironments by label. An empty selector selects all of them.
+	Selector metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// GitOpsDeploymentSetTemplate is the template of the GitOpsDeployments generated by a GitOpsDeploymentSet
+type GitOpsDeploymentSetTemplate struct {
+	GitOpsDeploymentSetTemplateMeta `json:"metadata"`
+
+	Spec GitOpsDeploymentSpec `json:"spec"`
+}
+
+// GitOpsDeploymentSetTemplateMeta is the metadata of the GitOpsDeployments generated by a GitOpsDeploymentSet
+type GitOpsDeploymentSetTemplateMeta struct {
+	// Name is the name of the generated GitOpsDeployment: it should contain parameters, so that the name is unique for
+	// each parameter set.
+	Name string `json:"name"`
+
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// GitOpsDeploymentSetStatus defines the observed state of GitOpsDeploymentSet
+type GitOpsDeploymentSetStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Resources contains the status of each GitOpsDeployment generated by the GitOpsDeploymentSet
+	Resources []GitOpsDeploymentSetResourceStatus `json:"resources,omitempty"`
+}
+
+// GitOpsDeploymentSetResourceStatus identifies a GitOpsDeployment generated by a GitOpsDeploymentSet: the sync and
+// health status of each GitOpsDeployment are reported in its own status field.
+type GitOpsDeploymentSetResourceStatus struct {
+	// Name is the name of the GitOpsDeployment
+	Name string `json:"name"`
+}
+
+const (
+	// GitOpsDeploymentSetConditionErrorOccurred is True if the GitOpsDeployments could not be generated (or created,
+	// updated, or deleted), and False otherwise.
+	GitOpsDeploymentSetConditionErrorOccurred = "ErrorOccurred"
+)
+
+const (
+	GitOpsDeploymentSetReasonGeneratorError    = "GeneratorError"
+	GitOpsDeploymentSetReasonTemplateError     = "TemplateError"
+	GitOpsDeploymentSetReasonUpdateError       = "UpdateError"
+	GitOpsDeploymentSetReasonResourcesUpToDate = "ResourcesUpToDate"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// GitOpsDeploymentSet is the Schema for the gitopsdeploymentsets API.
+// A GitOpsDeploymentSet generates GitOpsDeployments from a template, for each set of parameters produced by its
+// generators (similar to an Argo CD ApplicationSet).
+type GitOpsDeploymentSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GitOpsDeploymentSetSpec   `json:"spec,omitempty"`
+	Status GitOpsDeploymentSetStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// GitOpsDeploymentSetList contains a list of GitOpsDeploymentSet
+type GitOpsDeploymentSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GitOpsDeploymentSet `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GitOpsDeploymentSet{}, &GitOpsDeploymentSetList{})
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	logutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util/log"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+const error_invalid_git_generator_repo_url = "the repoURL of a git generator must begin with https://, ssh:// or git@"
+
+// log is for logging in this package.
+var gitopsdeploymentsetlog = logf.Log.WithName(logutil.LogLogger_managed_gitops)
+
+func (r *GitOpsDeploymentSet) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-managed-gitops-redhat-com-v1alpha1-gitopsdeploymentset,mutating=true,failurePolicy=fail,sideEffects=None,groups=managed-gitops.redhat.com,resources=gitopsdeploymentsets,verbs=create;update,versions=v1alpha1,name=mgitopsdeploymentset.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &GitOpsDeploymentSet{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type
+func (r *GitOpsDeploymentSet) Default() {
+	gitopsdeploymentsetlog.Info("default", "name", r.Name)
+
+}
+
+//+kubebuilder:webhook:path=/validate-managed-gitops-redhat-com-v1alpha1-gitopsdeploymentset,mutating=false,failurePolicy=fail,sideEffects=None,groups=managed-gitops.redhat.com,resources=gitopsdeploymentsets,verbs=create;update,versions=v1alpha1,name=vgitopsdeploymentset.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &GitOpsDeploymentSet{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *GitOpsDeploymentSet) ValidateCreate() error {
+	gitopsdeploymentsetlog.Info("validate create", "name", r.Name)
+
+	if err := r.ValidateGitOpsDeploymentSet(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *GitOpsDeploymentSet) ValidateUpdate(old runtime.Object) error {
+	gitopsdeploymentsetlog.Info("validate update", "name", r.Name)
+
+	if err := r.ValidateGitOpsDeploymentSet(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *GitOpsDeploymentSet) ValidateDelete() error {
+	gitopsdeploymentsetlog.Info("validate delete", "name", r.Name)
+
+	return nil
+}
+
+// ValidateGitOpsDeploymentSet verifies that the Git generators only reference remote repositories: the repositories
+// are cloned by the backend, so other URLs (for example, 'file://' or a local path) must not be accepted.
+func (r *GitOpsDeploymentSet) ValidateGitOpsDeploymentSet() error {
+
+	for _, generator := range r.Spec.Generators {
+		if generator.Git == nil {
+			continue
+		}
+
+		if !(strings.HasPrefix(generator.Git.RepoURL, "https://") || strings.HasPrefix(generator.Git.RepoURL, "ssh://") ||
+			strings.HasPrefix(generator.Git.RepoURL, "git@")) {
+			return fmt.Errorf(error_invalid_git_generator_repo_url)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package v1alpha1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	//+kubebuilder:scaffold:imports
+)
+
+var _ = Describe("GitOpsDeploymentSet validation webhook", func() {
+	var namespace *corev1.Namespace
+	var gitopsDeplSet *GitOpsDeploymentSet
+	var ctx context.Context
+
+	BeforeEach(func() {
+
+		ctx = context.Background()
+
+		namespace = &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-4",
+				UID:  uuid.NewUUID(),
+			},
+			Spec: corev1.NamespaceSpec{},
+		}
+
+		gitopsDeplSet = &GitOpsDeploymentSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-gitops-depl-set",
+				Namespace: namespace.Name,
+				UID:       uuid.NewUUID(),
+			},
+			Spec: GitOpsDeploymentSetSpec{
+				Generators: []GitOpsDeploymentSetGenerator{{
+					Git: &GitGenerator{
+						RepoURL:     "https://github.com/test/test",
+						Directories: []GitDirectoryGeneratorItem{{Path: "environments/*"}},
+					},
+				}},
+				Template: GitOpsDeploymentSetTemplate{
+					GitOpsDeploymentSetTemplateMeta: GitOpsDeploymentSetTemplateMeta{
+						Name: "{{path.basenameNormalized}}",
+					},
+					Spec: GitOpsDeploymentSpec{
+						Source: ApplicationSource{
+							RepoURL: "https://github.com/test/test",
+							Path:    "{{path}}",
+						},
+						Type: GitOpsDeploymentSpecType_Automated,
+					},
+				},
+			},
+		}
+
+	})
+
+	Context("Create GitOpsDeploymentSet CR with a Git generator", func() {
+		It("Should fail with error saying the repoURL must begin with https://, ssh:// or git@", func() {
+
+			err := k8sClient.Create(ctx, namespace)
+			Expect(err).ToNot(HaveOccurred())
+
+			gitopsDeplSet.Spec.Generators[0].Git.RepoURL = "file:///tmp/repo"
+			err = k8sClient.Create(ctx, gitopsDeplSet)
+
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_invalid_git_generator_repo_url))
+
+			gitopsDeplSet.Spec.Generators[0].Git.RepoURL = "/tmp/repo"
+			err = k8sClient.Create(ctx, gitopsDeplSet)
+
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_invalid_git_generator_repo_url))
+		})
+
+		It("Should succeed for https://, ssh:// and git@ repoURLs", func() {
+
+			for i, repoURL := range []string{"https://github.com/test/test", "ssh://git@github.com/test/test", "git@github.com:test/test.git"} {
+				set := gitopsDeplSet.DeepCopy()
+				set.Name = gitopsDeplSet.Name + "-" + string(rune('a'+i))
+				set.Spec.Generators[0].Git.RepoURL = repoURL
+
+				err := k8sClient.Create(ctx, set)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = k8sClient.Delete(context.Background(), set)
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	})
+
+	Context("Update GitOpsDeploymentSet CR with an invalid Git generator repoURL", func() {
+		It("Should fail with error saying the repoURL must begin with https://, ssh:// or git@", func() {
+
+			err := k8sClient.Create(ctx, gitopsDeplSet)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDeplSet), gitopsDeplSet)
+			Expect(err).ToNot(HaveOccurred())
+
+			gitopsDeplSet.Spec.Generators[0].Git.RepoURL = "file:///tmp/repo"
+			err = k8sClient.Update(ctx, gitopsDeplSet)
+
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_invalid_git_generator_repo_url))
+
+			err = k8sClient.Delete(context.Background(), gitopsDeplSet)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
+})
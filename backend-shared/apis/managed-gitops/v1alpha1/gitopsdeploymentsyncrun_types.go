@@ -27,15 +27,124 @@ type GitOpsDeploymentSyncRunSpec struct {
 
 	// Optional: If specified, tells the GitOps Service to deploy a particular git commit SHA
 	RevisionID string `json:"revisionID,omitempty"`
+
+	// Optional: If specified, tells the GitOps Service to roll back the GitOpsDeployment to the deployment with
+	// this ID in the .status.history field of the GitOpsDeployment.
+	// May not be combined with revisionID or rollbackToRevision.
+	RollbackToHistoryID *int64 `json:"rollbackToHistoryID,omitempty"`
+
+	// Optional: If specified, tells the GitOps Service to roll back the GitOpsDeployment to the most recent successful
+	// deployment of this revision, in the .status.history field of the GitOpsDeployment.
+	// May not be combined with revisionID or rollbackToHistoryID.
+	RollbackToRevision string `json:"rollbackToRevision,omitempty"`
+
+	// Optional: If true, resources that are no longer defined in the GitOps repository are deleted from the target
+	// cluster during the sync.
+	Prune bool `json:"prune,omitempty"`
+
+	// Optional: If true, the sync is only simulated (as with `kubectl apply --dry-run`): no changes are made to the target cluster.
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// Optional: If true, resources that cannot be updated are deleted and recreated (as with `kubectl apply --force`).
+	Force bool `json:"force,omitempty"`
+
+	// Optional: The sync strategy to use: 'hook' (the default) applies resources using hook annotations (if any),
+	// while 'apply' ignores hooks and only performs a `kubectl apply`.
+	// +kubebuilder:validation:Enum=hook;apply
+	Strategy SyncRunStrategyType `json:"strategy,omitempty"`
+
+	// Optional: If specified, only these resources of the GitOpsDeployment are synced, rather than all of them.
+	Resources []SyncOperationResource `json:"resources,omitempty"`
+
+	// Optional: If true, the sync (or rollback) is terminated, if it is still in progress. The GitOpsDeploymentSyncRun
+	// is retained, and its status reports that the sync was terminated.
+	// - Once set, this field may not be unset.
+	Terminate bool `json:"terminate,omitempty"`
+}
+
+type SyncRunStrategyType string
+
+const (
+	SyncRunStrategyType_Hook  SyncRunStrategyType = "hook"
+	SyncRunStrategyType_Apply SyncRunStrategyType = "apply"
+)
+
+// IsRollback returns true if the GitOpsDeploymentSyncRun requests a rollback to a previous deployment, rather than a sync.
+func (spec GitOpsDeploymentSyncRunSpec) IsRollback() bool {
+	return spec.RollbackToHistoryID != nil || spec.RollbackToRevision != ""
+}
+
+// HasSyncOptions returns true if the GitOpsDeploymentSyncRun specifies any options that customize the sync.
+func (spec GitOpsDeploymentSyncRunSpec) HasSyncOptions() bool {
+	return spec.Prune || spec.DryRun || spec.Force || spec.Strategy != "" || len(spec.Resources) > 0
 }
 
 // GitOpsDeploymentSyncRunStatus defines the observed state of GitOpsDeploymentSyncRun
 type GitOpsDeploymentSyncRunStatus struct {
 	Conditions []GitOpsDeploymentSyncRunCondition `json:"conditions,omitempty"`
+
+	// Phase is the current phase of the sync: Pending, Running, Succeeded, Failed or Terminated
+	Phase SyncRunPhase `json:"phase,omitempty"`
+
+	// StartedAt is the time at which the sync started
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// FinishedAt is the time at which the sync completed
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty"`
+
+	// Revision is the revision (e.g. git commit SHA) that was synced
+	Revision string `json:"revision,omitempty"`
+
+	// Message contains details about the result of the sync, typically errors
+	Message string `json:"message,omitempty"`
+
+	// Resources contains the result of the sync for each individual resource
+	Resources []ResourceResult `json:"resources,omitempty"`
+
+	// Rollback contains the progress and result of the rollback, if the GitOpsDeploymentSyncRun requested one
+	Rollback *SyncRunRollbackStatus `json:"rollback,omitempty"`
+}
+
+type SyncRunPhase string
+
+const (
+	// SyncRunPhase_Pending indicates that the sync has been requested, but has not yet started
+	SyncRunPhase_Pending SyncRunPhase = "Pending"
+	// SyncRunPhase_Running indicates that the sync is in progress
+	SyncRunPhase_Running SyncRunPhase = "Running"
+	// SyncRunPhase_Succeeded indicates that the sync completed successfully
+	SyncRunPhase_Succeeded SyncRunPhase = "Succeeded"
+	// SyncRunPhase_Failed indicates that the sync completed unsuccessfully
+	SyncRunPhase_Failed SyncRunPhase = "Failed"
+	// SyncRunPhase_Terminated indicates that the sync was terminated before it completed, as requested by .spec.terminate
+	SyncRunPhase_Terminated SyncRunPhase = "Terminated"
+)
+
+// IsCompleted returns true if the sync has completed, either successfully, unsuccessfully, or by being terminated.
+func (phase SyncRunPhase) IsCompleted() bool {
+	return phase == SyncRunPhase_Succeeded || phase == SyncRunPhase_Failed || phase == SyncRunPhase_Terminated
+}
+
+// SyncRunRollbackStatus contains the progress and result of a rollback requested by a GitOpsDeploymentSyncRun
+type SyncRunRollbackStatus struct {
+	// HistoryID is the ID of the entry in the .status.history field of the GitOpsDeployment that is rolled back to
+	HistoryID int64 `json:"historyID"`
+
+	// Revision is the revision that is rolled back to
+	Revision string `json:"revision,omitempty"`
+
+	// Phase is the current phase of the rollback: Running, Succeeded or Failed
+	Phase OperationPhase `json:"phase"`
+
+	// Message contains details about the result of the rollback, typically errors
+	Message string `json:"message,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+//+kubebuilder:printcolumn:name="Revision",type=string,JSONPath=`.status.revision`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // GitOpsDeploymentSyncRun is the Schema for the gitopsdeploymentsyncruns API
 type GitOpsDeploymentSyncRun struct {
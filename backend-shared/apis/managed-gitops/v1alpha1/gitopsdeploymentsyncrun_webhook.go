@@ -29,6 +29,13 @@ import (
 const (
 	error_invalid_name = "name should not be zyxwvutsrqponmlkjihgfedcba-abcdefghijklmnoqrstuvwxyz"
 	invalid_name       = "zyxwvutsrqponmlkjihgfedcba-abcdefghijklmnoqrstuvwxyz"
+
+	error_conflicting_rollback_fields = "only one of revisionID, rollbackToHistoryID and rollbackToRevision may be specified"
+	error_invalid_rollback_history_id = "rollbackToHistoryID must not be negative"
+	error_sync_options_with_rollback  = "prune, dryRun, force, strategy and resources may not be specified for a rollback"
+	error_invalid_sync_resource       = "each entry of resources must specify a kind and a name"
+	error_terminate_on_create         = "terminate may not be set when creating a GitOpsDeploymentSyncRun"
+	error_terminate_unset             = "terminate may not be unset, once it has been set"
 )
 
 // log is for logging in this package.
@@ -62,6 +69,18 @@ func (r *GitOpsDeploymentSyncRun) ValidateCreate() error {
 		return fmt.Errorf(error_invalid_name)
 	}
 
+	if err := r.validateRollback(); err != nil {
+		return err
+	}
+
+	if err := r.validateSyncOptions(); err != nil {
+		return err
+	}
+
+	if r.Spec.Terminate {
+		return fmt.Errorf(error_terminate_on_create)
+	}
+
 	return nil
 }
 
@@ -69,6 +88,18 @@ func (r *GitOpsDeploymentSyncRun) ValidateCreate() error {
 func (r *GitOpsDeploymentSyncRun) ValidateUpdate(old runtime.Object) error {
 	gitopsdeploymentsyncrunlog.Info("validate update", "name", r.Name)
 
+	if err := r.validateRollback(); err != nil {
+		return err
+	}
+
+	if err := r.validateSyncOptions(); err != nil {
+		return err
+	}
+
+	if oldSyncRun, ok := old.(*GitOpsDeploymentSyncRun); ok && oldSyncRun.Spec.Terminate && !r.Spec.Terminate {
+		return fmt.Errorf(error_terminate_unset)
+	}
+
 	return nil
 }
 
@@ -78,3 +109,44 @@ func (r *GitOpsDeploymentSyncRun) ValidateDelete() error {
 
 	return nil
 }
+
+// validateRollback verifies that at most one of the sync/rollback targets is specified.
+func (r *GitOpsDeploymentSyncRun) validateRollback() error {
+
+	targets := 0
+	if r.Spec.RevisionID != "" {
+		targets++
+	}
+	if r.Spec.RollbackToHistoryID != nil {
+		targets++
+	}
+	if r.Spec.RollbackToRevision != "" {
+		targets++
+	}
+
+	if targets > 1 {
+		return fmt.Errorf(error_conflicting_rollback_fields)
+	}
+
+	if r.Spec.RollbackToHistoryID != nil && *r.Spec.RollbackToHistoryID < 0 {
+		return fmt.Errorf(error_invalid_rollback_history_id)
+	}
+
+	return nil
+}
+
+// validateSyncOptions verifies that the sync options are only specified for a sync, and that the selected resources are valid.
+func (r *GitOpsDeploymentSyncRun) validateSyncOptions() error {
+
+	if r.Spec.IsRollback() && r.Spec.HasSyncOptions() {
+		return fmt.Errorf(error_sync_options_with_rollback)
+	}
+
+	for _, resource := range r.Spec.Resources {
+		if resource.Kind == "" || resource.Name == "" {
+			return fmt.Errorf(error_invalid_sync_resource)
+		}
+	}
+
+	return nil
+}
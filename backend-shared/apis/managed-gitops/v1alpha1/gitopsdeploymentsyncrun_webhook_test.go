@@ -55,4 +55,117 @@ var _ = Describe("GitOpsDeploymentSyncRun validation webhook", func() {
 		})
 	})
 
+	Context("Create GitOpsDeploymentSyncRun CR with rollback fields", func() {
+		It("Should fail if a rollback is combined with a revisionID", func() {
+			gitopsDeplSyncRunCr.Name = "test-rollback-revision"
+			gitopsDeplSyncRunCr.Spec.RollbackToRevision = "abc123"
+
+			err := k8sClient.Create(ctx, gitopsDeplSyncRunCr)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_conflicting_rollback_fields))
+		})
+
+		It("Should fail if both rollbackToHistoryID and rollbackToRevision are specified", func() {
+			historyID := int64(2)
+			gitopsDeplSyncRunCr.Name = "test-rollback-both"
+			gitopsDeplSyncRunCr.Spec.RevisionID = ""
+			gitopsDeplSyncRunCr.Spec.RollbackToHistoryID = &historyID
+			gitopsDeplSyncRunCr.Spec.RollbackToRevision = "abc123"
+
+			err := k8sClient.Create(ctx, gitopsDeplSyncRunCr)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_conflicting_rollback_fields))
+		})
+
+		It("Should fail if rollbackToHistoryID is negative", func() {
+			historyID := int64(-1)
+			gitopsDeplSyncRunCr.Name = "test-rollback-negative"
+			gitopsDeplSyncRunCr.Spec.RevisionID = ""
+			gitopsDeplSyncRunCr.Spec.RollbackToHistoryID = &historyID
+
+			err := k8sClient.Create(ctx, gitopsDeplSyncRunCr)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_invalid_rollback_history_id))
+		})
+
+		It("Should succeed if only rollbackToHistoryID is specified", func() {
+			historyID := int64(0)
+			gitopsDeplSyncRunCr.Name = "test-rollback-valid"
+			gitopsDeplSyncRunCr.Spec.RevisionID = ""
+			gitopsDeplSyncRunCr.Spec.RollbackToHistoryID = &historyID
+
+			err := k8sClient.Create(ctx, gitopsDeplSyncRunCr)
+			Expect(err).To(Succeed())
+
+			err = k8sClient.Delete(ctx, gitopsDeplSyncRunCr)
+			Expect(err).To(Succeed())
+		})
+	})
+
+	Context("Create GitOpsDeploymentSyncRun CR with sync options", func() {
+		It("Should fail if sync options are combined with a rollback", func() {
+			gitopsDeplSyncRunCr.Name = "test-sync-options-rollback"
+			gitopsDeplSyncRunCr.Spec.RevisionID = ""
+			gitopsDeplSyncRunCr.Spec.RollbackToRevision = "abc123"
+			gitopsDeplSyncRunCr.Spec.Prune = true
+
+			err := k8sClient.Create(ctx, gitopsDeplSyncRunCr)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_sync_options_with_rollback))
+		})
+
+		It("Should fail if a selected resource doesn't specify a name", func() {
+			gitopsDeplSyncRunCr.Name = "test-sync-options-resource"
+			gitopsDeplSyncRunCr.Spec.Resources = []SyncOperationResource{{Group: "apps", Kind: "Deployment"}}
+
+			err := k8sClient.Create(ctx, gitopsDeplSyncRunCr)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_invalid_sync_resource))
+		})
+
+		It("Should succeed if valid sync options are specified", func() {
+			gitopsDeplSyncRunCr.Name = "test-sync-options-valid"
+			gitopsDeplSyncRunCr.Spec.Prune = true
+			gitopsDeplSyncRunCr.Spec.Force = true
+			gitopsDeplSyncRunCr.Spec.Strategy = SyncRunStrategyType_Apply
+			gitopsDeplSyncRunCr.Spec.Resources = []SyncOperationResource{{Group: "apps", Kind: "Deployment", Name: "my-deployment"}}
+
+			err := k8sClient.Create(ctx, gitopsDeplSyncRunCr)
+			Expect(err).To(Succeed())
+
+			err = k8sClient.Delete(ctx, gitopsDeplSyncRunCr)
+			Expect(err).To(Succeed())
+		})
+	})
+
+	Context("Terminate GitOpsDeploymentSyncRun CR", func() {
+		It("Should fail if terminate is set on create", func() {
+			gitopsDeplSyncRunCr.Name = "test-terminate-create"
+			gitopsDeplSyncRunCr.Spec.Terminate = true
+
+			err := k8sClient.Create(ctx, gitopsDeplSyncRunCr)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_terminate_on_create))
+		})
+
+		It("Should succeed if terminate is set on update, but fail if it is then unset", func() {
+			gitopsDeplSyncRunCr.Name = "test-terminate-update"
+
+			err := k8sClient.Create(ctx, gitopsDeplSyncRunCr)
+			Expect(err).To(Succeed())
+
+			gitopsDeplSyncRunCr.Spec.Terminate = true
+			err = k8sClient.Update(ctx, gitopsDeplSyncRunCr)
+			Expect(err).To(Succeed())
+
+			gitopsDeplSyncRunCr.Spec.Terminate = false
+			err = k8sClient.Update(ctx, gitopsDeplSyncRunCr)
+			Expect(err).Should(Not(Succeed()))
+			Expect(err.Error()).Should(ContainSubstring(error_terminate_unset))
+
+			err = k8sClient.Delete(ctx, gitopsDeplSyncRunCr)
+			Expect(err).To(Succeed())
+		})
+	})
+
 })
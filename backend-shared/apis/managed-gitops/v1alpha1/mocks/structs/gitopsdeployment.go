@@ -39,7 +39,7 @@ func NewGitOpsDeploymentBuilder() *testGitopsDeploymentBuilder {
 
 func (t *testGitopsDeploymentBuilder) Initialized() *testGitopsDeploymentBuilder {
 	t.p.Status = api.GitOpsDeploymentStatus{
-		Conditions: []api.GitOpsDeploymentCondition{},
+		Conditions: []metav1.Condition{},
 	}
 	return t
 }
@@ -115,6 +115,9 @@ var _ = BeforeSuite(func() {
 	err = (&GitOpsDeploymentManagedEnvironment{}).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = (&GitOpsDeploymentSet{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
 	//+kubebuilder:scaffold:webhook
 
 	go func() {
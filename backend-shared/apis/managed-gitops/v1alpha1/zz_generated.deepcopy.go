@@ -92,6 +92,16 @@ func (in *ApplicationOperation) DeepCopy() *ApplicationOperation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ApplicationSource) DeepCopyInto(out *ApplicationSource) {
 	*out = *in
+	if in.Helm != nil {
+		in, out := &in.Helm, &out.Helm
+		*out = new(ApplicationSourceHelm)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kustomize != nil {
+		in, out := &in.Kustomize, &out.Kustomize
+		*out = new(ApplicationSourceKustomize)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSource.
@@ -104,12 +114,80 @@ func (in *ApplicationSource) DeepCopy() *ApplicationSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationSourceHelm) DeepCopyInto(out *ApplicationSourceHelm) {
+	*out = *in
+	if in.ValueFiles != nil {
+		in, out := &in.ValueFiles, &out.ValueFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]HelmParameter, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSourceHelm.
+func (in *ApplicationSourceHelm) DeepCopy() *ApplicationSourceHelm {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationSourceHelm)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationSourceKustomize) DeepCopyInto(out *ApplicationSourceKustomize) {
+	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CommonLabels != nil {
+		in, out := &in.CommonLabels, &out.CommonLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.CommonAnnotations != nil {
+		in, out := &in.CommonAnnotations, &out.CommonAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]KustomizePatch, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationSourceKustomize.
+func (in *ApplicationSourceKustomize) DeepCopy() *ApplicationSourceKustomize {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationSourceKustomize)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in ApplicationSources) DeepCopyInto(out *ApplicationSources) {
 	{
 		in := &in
 		*out = make(ApplicationSources, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
@@ -143,6 +221,73 @@ func (in *Backoff) DeepCopy() *Backoff {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentHistoryEntry) DeepCopyInto(out *DeploymentHistoryEntry) {
+	*out = *in
+	if in.ID != nil {
+		in, out := &in.ID, &out.ID
+		*out = new(int64)
+		**out = **in
+	}
+	out.Source = in.Source
+	if in.DeployStartedAt != nil {
+		in, out := &in.DeployStartedAt, &out.DeployStartedAt
+		*out = (*in).DeepCopy()
+	}
+	in.DeployedAt.DeepCopyInto(&out.DeployedAt)
+	out.InitiatedBy = in.InitiatedBy
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentHistoryEntry.
+func (in *DeploymentHistoryEntry) DeepCopy() *DeploymentHistoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentHistoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitDirectoryGeneratorItem) DeepCopyInto(out *GitDirectoryGeneratorItem) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitDirectoryGeneratorItem.
+func (in *GitDirectoryGeneratorItem) DeepCopy() *GitDirectoryGeneratorItem {
+	if in == nil {
+		return nil
+	}
+	out := new(GitDirectoryGeneratorItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitGenerator) DeepCopyInto(out *GitGenerator) {
+	*out = *in
+	if in.Directories != nil {
+		in, out := &in.Directories, &out.Directories
+		*out = make([]GitDirectoryGeneratorItem, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequeueAfterSeconds != nil {
+		in, out := &in.RequeueAfterSeconds, &out.RequeueAfterSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitGenerator.
+func (in *GitGenerator) DeepCopy() *GitGenerator {
+	if in == nil {
+		return nil
+	}
+	out := new(GitGenerator)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitOpsDeployment) DeepCopyInto(out *GitOpsDeployment) {
 	*out = *in
@@ -205,6 +350,104 @@ func (in *GitOpsDeploymentDestination) DeepCopy() *GitOpsDeploymentDestination {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsDeploymentDiff) DeepCopyInto(out *GitOpsDeploymentDiff) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentDiff.
+func (in *GitOpsDeploymentDiff) DeepCopy() *GitOpsDeploymentDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsDeploymentDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GitOpsDeploymentDiff) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsDeploymentDiffList) DeepCopyInto(out *GitOpsDeploymentDiffList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GitOpsDeploymentDiff, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentDiffList.
+func (in *GitOpsDeploymentDiffList) DeepCopy() *GitOpsDeploymentDiffList {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsDeploymentDiffList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GitOpsDeploymentDiffList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsDeploymentDiffSpec) DeepCopyInto(out *GitOpsDeploymentDiffSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentDiffSpec.
+func (in *GitOpsDeploymentDiffSpec) DeepCopy() *GitOpsDeploymentDiffSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsDeploymentDiffSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsDeploymentDiffStatus) DeepCopyInto(out *GitOpsDeploymentDiffStatus) {
+	*out = *in
+	if in.ComparedAt != nil {
+		in, out := &in.ComparedAt, &out.ComparedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceDiff, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentDiffStatus.
+func (in *GitOpsDeploymentDiffStatus) DeepCopy() *GitOpsDeploymentDiffStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsDeploymentDiffStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitOpsDeploymentList) DeepCopyInto(out *GitOpsDeploymentList) {
 	*out = *in
@@ -299,6 +542,11 @@ func (in *GitOpsDeploymentManagedEnvironmentList) DeepCopyObject() runtime.Objec
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitOpsDeploymentManagedEnvironmentSpec) DeepCopyInto(out *GitOpsDeploymentManagedEnvironmentSpec) {
 	*out = *in
+	if in.CASecretRef != nil {
+		in, out := &in.CASecretRef, &out.CASecretRef
+		*out = new(ManagedEnvironmentCASecretRef)
+		**out = **in
+	}
 	if in.Namespaces != nil {
 		in, out := &in.Namespaces, &out.Namespaces
 		*out = make([]string, len(*in))
@@ -326,6 +574,15 @@ func (in *GitOpsDeploymentManagedEnvironmentStatus) DeepCopyInto(out *GitOpsDepl
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastProbeTime != nil {
+		in, out := &in.LastProbeTime, &out.LastProbeTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastProbeLatency != nil {
+		in, out := &in.LastProbeLatency, &out.LastProbeLatency
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentManagedEnvironmentStatus.
@@ -347,89 +604,289 @@ func (in *GitOpsDeploymentRepositoryCredential) DeepCopyInto(out *GitOpsDeployme
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentRepositoryCredential.
-func (in *GitOpsDeploymentRepositoryCredential) DeepCopy() *GitOpsDeploymentRepositoryCredential {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentRepositoryCredential.
+func (in *GitOpsDeploymentRepositoryCredential) DeepCopy() *GitOpsDeploymentRepositoryCredential {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsDeploymentRepositoryCredential)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GitOpsDeploymentRepositoryCredential) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsDeploymentRepositoryCredentialList) DeepCopyInto(out *GitOpsDeploymentRepositoryCredentialList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GitOpsDeploymentRepositoryCredential, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentRepositoryCredentialList.
+func (in *GitOpsDeploymentRepositoryCredentialList) DeepCopy() *GitOpsDeploymentRepositoryCredentialList {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsDeploymentRepositoryCredentialList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GitOpsDeploymentRepositoryCredentialList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsDeploymentRepositoryCredentialSpec) DeepCopyInto(out *GitOpsDeploymentRepositoryCredentialSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentRepositoryCredentialSpec.
+func (in *GitOpsDeploymentRepositoryCredentialSpec) DeepCopy() *GitOpsDeploymentRepositoryCredentialSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsDeploymentRepositoryCredentialSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsDeploymentRepositoryCredentialStatus) DeepCopyInto(out *GitOpsDeploymentRepositoryCredentialStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentRepositoryCredentialStatus.
+func (in *GitOpsDeploymentRepositoryCredentialStatus) DeepCopy() *GitOpsDeploymentRepositoryCredentialStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsDeploymentRepositoryCredentialStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsDeploymentSet) DeepCopyInto(out *GitOpsDeploymentSet) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentSet.
+func (in *GitOpsDeploymentSet) DeepCopy() *GitOpsDeploymentSet {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsDeploymentSet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GitOpsDeploymentSet) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsDeploymentSetGenerator) DeepCopyInto(out *GitOpsDeploymentSetGenerator) {
+	*out = *in
+	if in.List != nil {
+		in, out := &in.List, &out.List
+		*out = new(ListGenerator)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(GitGenerator)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ManagedEnvironment != nil {
+		in, out := &in.ManagedEnvironment, &out.ManagedEnvironment
+		*out = new(ManagedEnvironmentGenerator)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentSetGenerator.
+func (in *GitOpsDeploymentSetGenerator) DeepCopy() *GitOpsDeploymentSetGenerator {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsDeploymentSetGenerator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsDeploymentSetList) DeepCopyInto(out *GitOpsDeploymentSetList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GitOpsDeploymentSet, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentSetList.
+func (in *GitOpsDeploymentSetList) DeepCopy() *GitOpsDeploymentSetList {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsDeploymentSetList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GitOpsDeploymentSetList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsDeploymentSetResourceStatus) DeepCopyInto(out *GitOpsDeploymentSetResourceStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentSetResourceStatus.
+func (in *GitOpsDeploymentSetResourceStatus) DeepCopy() *GitOpsDeploymentSetResourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GitOpsDeploymentSetResourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitOpsDeploymentSetSpec) DeepCopyInto(out *GitOpsDeploymentSetSpec) {
+	*out = *in
+	if in.Generators != nil {
+		in, out := &in.Generators, &out.Generators
+		*out = make([]GitOpsDeploymentSetGenerator, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentSetSpec.
+func (in *GitOpsDeploymentSetSpec) DeepCopy() *GitOpsDeploymentSetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GitOpsDeploymentRepositoryCredential)
+	out := new(GitOpsDeploymentSetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GitOpsDeploymentRepositoryCredential) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitOpsDeploymentRepositoryCredentialList) DeepCopyInto(out *GitOpsDeploymentRepositoryCredentialList) {
+func (in *GitOpsDeploymentSetStatus) DeepCopyInto(out *GitOpsDeploymentSetStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]GitOpsDeploymentRepositoryCredential, len(*in))
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]GitOpsDeploymentSetResourceStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentRepositoryCredentialList.
-func (in *GitOpsDeploymentRepositoryCredentialList) DeepCopy() *GitOpsDeploymentRepositoryCredentialList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentSetStatus.
+func (in *GitOpsDeploymentSetStatus) DeepCopy() *GitOpsDeploymentSetStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GitOpsDeploymentRepositoryCredentialList)
+	out := new(GitOpsDeploymentSetStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GitOpsDeploymentRepositoryCredentialList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitOpsDeploymentRepositoryCredentialSpec) DeepCopyInto(out *GitOpsDeploymentRepositoryCredentialSpec) {
+func (in *GitOpsDeploymentSetTemplate) DeepCopyInto(out *GitOpsDeploymentSetTemplate) {
 	*out = *in
+	in.GitOpsDeploymentSetTemplateMeta.DeepCopyInto(&out.GitOpsDeploymentSetTemplateMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentRepositoryCredentialSpec.
-func (in *GitOpsDeploymentRepositoryCredentialSpec) DeepCopy() *GitOpsDeploymentRepositoryCredentialSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentSetTemplate.
+func (in *GitOpsDeploymentSetTemplate) DeepCopy() *GitOpsDeploymentSetTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(GitOpsDeploymentRepositoryCredentialSpec)
+	out := new(GitOpsDeploymentSetTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GitOpsDeploymentRepositoryCredentialStatus) DeepCopyInto(out *GitOpsDeploymentRepositoryCredentialStatus) {
+func (in *GitOpsDeploymentSetTemplateMeta) DeepCopyInto(out *GitOpsDeploymentSetTemplateMeta) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentRepositoryCredentialStatus.
-func (in *GitOpsDeploymentRepositoryCredentialStatus) DeepCopy() *GitOpsDeploymentRepositoryCredentialStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentSetTemplateMeta.
+func (in *GitOpsDeploymentSetTemplateMeta) DeepCopy() *GitOpsDeploymentSetTemplateMeta {
 	if in == nil {
 		return nil
 	}
-	out := new(GitOpsDeploymentRepositoryCredentialStatus)
+	out := new(GitOpsDeploymentSetTemplateMeta)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -452,13 +909,27 @@ func (in *GitOpsDeploymentSource) DeepCopy() *GitOpsDeploymentSource {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitOpsDeploymentSpec) DeepCopyInto(out *GitOpsDeploymentSpec) {
 	*out = *in
-	out.Source = in.Source
+	in.Source.DeepCopyInto(&out.Source)
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]ApplicationSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	out.Destination = in.Destination
 	if in.SyncPolicy != nil {
 		in, out := &in.SyncPolicy, &out.SyncPolicy
 		*out = new(SyncPolicy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.IgnoreDifferences != nil {
+		in, out := &in.IgnoreDifferences, &out.IgnoreDifferences
+		*out = make([]ResourceIgnoreDifferences, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentSpec.
@@ -476,7 +947,7 @@ func (in *GitOpsDeploymentStatus) DeepCopyInto(out *GitOpsDeploymentStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]GitOpsDeploymentCondition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -490,12 +961,23 @@ func (in *GitOpsDeploymentStatus) DeepCopyInto(out *GitOpsDeploymentStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.ReconciledState = in.ReconciledState
+	in.ReconciledState.DeepCopyInto(&out.ReconciledState)
 	if in.OperationState != nil {
 		in, out := &in.OperationState, &out.OperationState
 		*out = new(OperationState)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]DeploymentHistoryEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastRefreshedAt != nil {
+		in, out := &in.LastRefreshedAt, &out.LastRefreshedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentStatus.
@@ -513,7 +995,7 @@ func (in *GitOpsDeploymentSyncRun) DeepCopyInto(out *GitOpsDeploymentSyncRun) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -589,6 +1071,16 @@ func (in *GitOpsDeploymentSyncRunList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitOpsDeploymentSyncRunSpec) DeepCopyInto(out *GitOpsDeploymentSyncRunSpec) {
 	*out = *in
+	if in.RollbackToHistoryID != nil {
+		in, out := &in.RollbackToHistoryID, &out.RollbackToHistoryID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]SyncOperationResource, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentSyncRunSpec.
@@ -611,6 +1103,24 @@ func (in *GitOpsDeploymentSyncRunStatus) DeepCopyInto(out *GitOpsDeploymentSyncR
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceResult, len(*in))
+		copy(*out, *in)
+	}
+	if in.Rollback != nil {
+		in, out := &in.Rollback, &out.Rollback
+		*out = new(SyncRunRollbackStatus)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitOpsDeploymentSyncRunStatus.
@@ -638,6 +1148,21 @@ func (in *HealthStatus) DeepCopy() *HealthStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HelmParameter) DeepCopyInto(out *HelmParameter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HelmParameter.
+func (in *HelmParameter) DeepCopy() *HelmParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(HelmParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Info) DeepCopyInto(out *Info) {
 	*out = *in
@@ -653,6 +1178,100 @@ func (in *Info) DeepCopy() *Info {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KustomizePatch) DeepCopyInto(out *KustomizePatch) {
+	*out = *in
+	if in.Target != nil {
+		in, out := &in.Target, &out.Target
+		*out = new(KustomizeSelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KustomizePatch.
+func (in *KustomizePatch) DeepCopy() *KustomizePatch {
+	if in == nil {
+		return nil
+	}
+	out := new(KustomizePatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KustomizeSelector) DeepCopyInto(out *KustomizeSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KustomizeSelector.
+func (in *KustomizeSelector) DeepCopy() *KustomizeSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(KustomizeSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ListGenerator) DeepCopyInto(out *ListGenerator) {
+	*out = *in
+	if in.Elements != nil {
+		in, out := &in.Elements, &out.Elements
+		*out = make([]map[string]string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make(map[string]string, len(*in))
+				for key, val := range *in {
+					(*out)[key] = val
+				}
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ListGenerator.
+func (in *ListGenerator) DeepCopy() *ListGenerator {
+	if in == nil {
+		return nil
+	}
+	out := new(ListGenerator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedEnvironmentCASecretRef) DeepCopyInto(out *ManagedEnvironmentCASecretRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedEnvironmentCASecretRef.
+func (in *ManagedEnvironmentCASecretRef) DeepCopy() *ManagedEnvironmentCASecretRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedEnvironmentCASecretRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManagedEnvironmentGenerator) DeepCopyInto(out *ManagedEnvironmentGenerator) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedEnvironmentGenerator.
+func (in *ManagedEnvironmentGenerator) DeepCopy() *ManagedEnvironmentGenerator {
+	if in == nil {
+		return nil
+	}
+	out := new(ManagedEnvironmentGenerator)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedNamespaceMetadata) DeepCopyInto(out *ManagedNamespaceMetadata) {
 	*out = *in
@@ -816,6 +1435,11 @@ func (in *OperationStatus) DeepCopy() *OperationStatus {
 func (in *ReconciledState) DeepCopyInto(out *ReconciledState) {
 	*out = *in
 	out.Source = in.Source
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]GitOpsDeploymentSource, len(*in))
+		copy(*out, *in)
+	}
 	out.Destination = in.Destination
 }
 
@@ -829,6 +1453,51 @@ func (in *ReconciledState) DeepCopy() *ReconciledState {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceDiff) DeepCopyInto(out *ResourceDiff) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceDiff.
+func (in *ResourceDiff) DeepCopy() *ResourceDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceIgnoreDifferences) DeepCopyInto(out *ResourceIgnoreDifferences) {
+	*out = *in
+	if in.JSONPointers != nil {
+		in, out := &in.JSONPointers, &out.JSONPointers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.JQPathExpressions != nil {
+		in, out := &in.JQPathExpressions, &out.JQPathExpressions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedFieldsManagers != nil {
+		in, out := &in.ManagedFieldsManagers, &out.ManagedFieldsManagers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceIgnoreDifferences.
+func (in *ResourceIgnoreDifferences) DeepCopy() *ResourceIgnoreDifferences {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceIgnoreDifferences)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceResult) DeepCopyInto(out *ResourceResult) {
 	*out = *in
@@ -925,7 +1594,7 @@ func (in *SyncOperation) DeepCopyInto(out *SyncOperation) {
 	if in.Source != nil {
 		in, out := &in.Source, &out.Source
 		*out = new(ApplicationSource)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.Manifests != nil {
 		in, out := &in.Manifests, &out.Manifests
@@ -940,7 +1609,9 @@ func (in *SyncOperation) DeepCopyInto(out *SyncOperation) {
 	if in.Sources != nil {
 		in, out := &in.Sources, &out.Sources
 		*out = make(ApplicationSources, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Revisions != nil {
 		in, out := &in.Revisions, &out.Revisions
@@ -988,11 +1659,13 @@ func (in *SyncOperationResult) DeepCopyInto(out *SyncOperationResult) {
 			}
 		}
 	}
-	out.Source = in.Source
+	in.Source.DeepCopyInto(&out.Source)
 	if in.Sources != nil {
 		in, out := &in.Sources, &out.Sources
 		*out = make(ApplicationSources, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Revisions != nil {
 		in, out := &in.Revisions, &out.Revisions
@@ -1043,6 +1716,16 @@ func (in *SyncPolicy) DeepCopyInto(out *SyncPolicy) {
 		*out = make(SyncOptions, len(*in))
 		copy(*out, *in)
 	}
+	if in.Automated != nil {
+		in, out := &in.Automated, &out.Automated
+		*out = new(SyncPolicyAutomated)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(RetryStrategy)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncPolicy.
@@ -1055,6 +1738,51 @@ func (in *SyncPolicy) DeepCopy() *SyncPolicy {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncPolicyAutomated) DeepCopyInto(out *SyncPolicyAutomated) {
+	*out = *in
+	if in.Prune != nil {
+		in, out := &in.Prune, &out.Prune
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SelfHeal != nil {
+		in, out := &in.SelfHeal, &out.SelfHeal
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowEmpty != nil {
+		in, out := &in.AllowEmpty, &out.AllowEmpty
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncPolicyAutomated.
+func (in *SyncPolicyAutomated) DeepCopy() *SyncPolicyAutomated {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncPolicyAutomated)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SyncRunRollbackStatus) DeepCopyInto(out *SyncRunRollbackStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SyncRunRollbackStatus.
+func (in *SyncRunRollbackStatus) DeepCopy() *SyncRunRollbackStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(SyncRunRollbackStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SyncStatus) DeepCopyInto(out *SyncStatus) {
 	*out = *in
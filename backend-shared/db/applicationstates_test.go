@@ -40,6 +40,7 @@ var _ = Describe("ApplicationStates Tests", func() {
 				Resources:                       make([]byte, 10),
 				ReconciledState:                 "test-reconciledState",
 				Conditions:                      []byte("sample"),
+				History:                         []byte("test-history"),
 			}
 
 			err = dbq.CreateApplicationState(ctx, applicationState)
@@ -54,12 +55,14 @@ var _ = Describe("ApplicationStates Tests", func() {
 
 			applicationState.Health = "Healthy"
 			applicationState.Sync_Status = "Synced"
+			applicationState.History = []byte("test-history-updated")
 			err = dbq.UpdateApplicationState(ctx, applicationState)
 			Expect(err).ToNot(HaveOccurred())
 
 			err = dbq.GetApplicationStateById(ctx, fetchObj)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(fetchObj).Should(Equal(applicationState))
+			Expect(fetchObj.History).Should(Equal([]byte("test-history-updated")))
 
 			rowsAffected, err := dbq.DeleteApplicationStateById(ctx, fetchObj.Applicationstate_application_id)
 			Expect(err).ToNot(HaveOccurred())
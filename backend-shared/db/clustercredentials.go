@@ -295,9 +295,21 @@ func (obj *ClusterCredentials) GetAsLogKeyValues() []interface{} {
 		return []interface{}{}
 	}
 
-	// We avoid logging the bearer_token or kube_config, as these contain sensitive user data.
+	// We avoid logging the bearer_token, kube_config or client key/certificate, as these contain sensitive user data.
 	return []interface{}{"host", obj.Host, "kube-config-length", len(obj.Kube_config),
 		"kube-config-context", len(obj.Kube_config_context), "serviceaccount_ns", obj.Serviceaccount_ns,
 		"serviceaccount-bearer-token-length", len(obj.Serviceaccount_bearer_token), "cluster_resources", obj.ClusterResources,
-		"cluster_namespaces", obj.Namespaces}
+		"cluster_namespaces", obj.Namespaces, "tls_server_name", obj.Tls_server_name,
+		"client-certificate-data-length", len(obj.Client_certificate_data), "client-key-data-length", len(obj.Client_key_data)}
+}
+
+// String returns the same fields as GetAsLogKeyValues, so that the credentials are not included when a
+// ClusterCredentials is formatted (e.g. via '%v' or '%+v').
+func (obj ClusterCredentials) String() string {
+	return fmt.Sprintf("ClusterCredentials{id: %s, %v}", obj.Clustercredentials_cred_id, obj.GetAsLogKeyValues())
+}
+
+// GoString ensures the credentials are likewise not included when formatted via '%#v'.
+func (obj ClusterCredentials) GoString() string {
+	return obj.String()
 }
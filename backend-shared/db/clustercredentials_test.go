@@ -2,6 +2,7 @@ package db_test
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -25,6 +26,10 @@ var _ = Describe("ClusterCredentials Tests", func() {
 				Kube_config_context:         "test-kube_config_context",
 				Serviceaccount_bearer_token: "test-serviceaccount_bearer_token",
 				Serviceaccount_ns:           "test-serviceaccount_ns",
+				Ca_bundle:                   "test-ca_bundle",
+				Tls_server_name:             "test-tls_server_name",
+				Client_certificate_data:     "test-client_certificate_data",
+				Client_key_data:             "test-client_key_data",
 			}
 			err = dbq.CreateClusterCredentials(ctx, &clusterCreds)
 			Expect(err).ToNot(HaveOccurred())
@@ -35,6 +40,10 @@ var _ = Describe("ClusterCredentials Tests", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(fetchedCluster.Created_on.After(time.Now().Add(time.Minute*-5))).To(BeTrue(), "Created on should be within the last 5 minutes")
 			Expect(clusterCreds).To(Equal(fetchedCluster))
+			Expect(fetchedCluster.Ca_bundle).To(Equal("test-ca_bundle"))
+			Expect(fetchedCluster.Tls_server_name).To(Equal("test-tls_server_name"))
+			Expect(fetchedCluster.Client_certificate_data).To(Equal("test-client_certificate_data"))
+			Expect(fetchedCluster.Client_key_data).To(Equal("test-client_key_data"))
 
 			count, err := dbq.DeleteClusterCredentialsById(ctx, clusterCreds.Clustercredentials_cred_id)
 			Expect(err).ToNot(HaveOccurred())
@@ -43,4 +52,31 @@ var _ = Describe("ClusterCredentials Tests", func() {
 			Expect(true).To(Equal(db.IsResultNotFoundError(err)))
 		})
 	})
+
+	Context("It should not include the credentials when ClusterCredentials are logged or formatted", func() {
+		It("should exclude the bearer token, kube config and client key/certificate", func() {
+			clusterCreds := db.ClusterCredentials{
+				Clustercredentials_cred_id:  "test-cred-id",
+				Host:                        "test-host",
+				Kube_config:                 "test-kube_config",
+				Serviceaccount_bearer_token: "test-serviceaccount_bearer_token",
+				Client_certificate_data:     "test-client_certificate_data",
+				Client_key_data:             "test-client_key_data",
+			}
+
+			for _, formatted := range []string{
+				fmt.Sprintf("%v", clusterCreds.GetAsLogKeyValues()),
+				clusterCreds.String(),
+				fmt.Sprintf("%v", clusterCreds),
+				fmt.Sprintf("%+v", &clusterCreds),
+				fmt.Sprintf("%#v", clusterCreds),
+			} {
+				Expect(formatted).To(ContainSubstring("test-host"))
+				Expect(formatted).ToNot(ContainSubstring("test-kube_config"))
+				Expect(formatted).ToNot(ContainSubstring("test-serviceaccount_bearer_token"))
+				Expect(formatted).ToNot(ContainSubstring("test-client_certificate_data"))
+				Expect(formatted).ToNot(ContainSubstring("test-client_key_data"))
+			}
+		})
+	})
 })
@@ -14,6 +14,10 @@ const (
 	ClusterCredentialsServiceaccountBearerTokenLength                       = 2048
 	ClusterCredentialsServiceaccountNsLength                                = 128
 	ClusterCredentialsNamespacesLength                                      = 4096
+	ClusterCredentialsCaBundleLength                                        = 65000
+	ClusterCredentialsTlsServerNameLength                                   = 512
+	ClusterCredentialsClientCertificateDataLength                           = 65000
+	ClusterCredentialsClientKeyDataLength                                   = 65000
 	GitopsEngineClusterGitopsengineclusterIDLength                          = 48
 	GitopsEngineInstanceGitopsengineinstanceIDLength                        = 48
 	GitopsEngineInstanceNamespaceNameLength                                 = 48
@@ -68,6 +72,7 @@ const (
 	SyncOperationDeploymentNameLength                                       = 256
 	SyncOperationRevisionLength                                             = 256
 	SyncOperationDesiredStateLength                                         = 16
+	SyncOperationSyncStrategyLength                                         = 16
 	RepositoryCredentialsRepositorycredentialsIDLength                      = 48
 	RepositoryCredentialsRepoCredUserIDLength                               = 48
 	RepositoryCredentialsRepoCredURLLength                                  = 512
@@ -124,6 +129,10 @@ var DbFieldMap = map[string]int{
 	"ClusterCredentialsServiceaccountBearerTokenLength":                       ClusterCredentialsServiceaccountBearerTokenLength,
 	"ClusterCredentialsServiceaccountNsLength":                                ClusterCredentialsServiceaccountNsLength,
 	"ClusterCredentialsNamespacesLength":                                      ClusterCredentialsNamespacesLength,
+	"ClusterCredentialsCaBundleLength":                                        ClusterCredentialsCaBundleLength,
+	"ClusterCredentialsTlsServerNameLength":                                   ClusterCredentialsTlsServerNameLength,
+	"ClusterCredentialsClientCertificateDataLength":                           ClusterCredentialsClientCertificateDataLength,
+	"ClusterCredentialsClientKeyDataLength":                                   ClusterCredentialsClientKeyDataLength,
 	"GitopsEngineClusterGitopsengineclusterIDLength":                          GitopsEngineClusterGitopsengineclusterIDLength,
 	"GitopsEngineInstanceGitopsengineinstanceIDLength":                        GitopsEngineInstanceGitopsengineinstanceIDLength,
 	"GitopsEngineInstanceNamespaceNameLength":                                 GitopsEngineInstanceNamespaceNameLength,
@@ -193,6 +202,7 @@ var DbFieldMap = map[string]int{
 	"SyncOperationDeploymentNameFieldLength":                                  SyncOperationDeploymentNameLength,
 	"SyncOperationRevisionLength":                                             SyncOperationRevisionLength,
 	"SyncOperationDesiredStateLength":                                         SyncOperationDesiredStateLength,
+	"SyncOperationSyncStrategyLength":                                         SyncOperationSyncStrategyLength,
 	"RepositoryCredentialsRepositorycredentialsIDLength":                      RepositoryCredentialsRepositorycredentialsIDLength,
 	"RepositoryCredentialsRepoCredUserIDLength":                               RepositoryCredentialsRepoCredUserIDLength,
 	"RepositoryCredentialsRepoCredURLLength":                                  RepositoryCredentialsRepoCredURLLength,
@@ -54,6 +54,7 @@ var _ = Describe("Operations Test", func() {
 			Resource_type:           "GitopsEngineInstance",
 			State:                   db.OperationState_Waiting,
 			Operation_owner_user_id: testClusterUser.Clusteruser_id,
+			Operation_result:        []byte("test-operation-result"),
 		}
 
 		err := dbq.CreateOperation(ctx, &operation, operation.Operation_owner_user_id)
@@ -91,6 +92,7 @@ var _ = Describe("Operations Test", func() {
 			State:                   db.OperationState_Waiting,
 			Operation_owner_user_id: testClusterUser.Clusteruser_id,
 			SeqID:                   int64(seq),
+			Operation_result:        []byte("test-operation-result-update"),
 		}
 		operationupdate.Created_on = operation.Created_on
 		operationupdate.Last_state_update = operation.Last_state_update
@@ -99,6 +101,7 @@ var _ = Describe("Operations Test", func() {
 		err = dbq.GetOperationById(ctx, &operationupdate)
 		Expect(err).ToNot(HaveOccurred())
 		Expect(operationupdate).ShouldNot(Equal(operation))
+		Expect(operationupdate.Operation_result).Should(Equal([]byte("test-operation-result-update")))
 
 		rowsAffected, err := dbq.DeleteOperationById(ctx, operationget.Operation_id)
 		Expect(err).ToNot(HaveOccurred())
@@ -54,12 +54,19 @@ var _ = Describe("SyncOperation Tests", func() {
 
 			Expect(err).ToNot(HaveOccurred())
 
+			rollbackHistoryID := int64(3)
 			insertRow := db.SyncOperation{
 				SyncOperation_id:    "test-sync",
 				Application_id:      application.Application_id,
 				DeploymentNameField: "testDeployment",
 				Revision:            "testRev",
 				DesiredState:        "Terminated",
+				RollbackHistoryID:   &rollbackHistoryID,
+				Prune:               true,
+				DryRun:              true,
+				Force:               true,
+				SyncStrategy:        "apply",
+				Resources:           []byte("resources"),
 			}
 
 			err = dbq.CreateSyncOperation(ctx, &insertRow)
@@ -76,6 +83,8 @@ var _ = Describe("SyncOperation Tests", func() {
 
 			updatedSyncOperation := insertRow
 			updatedSyncOperation.DesiredState = "Running"
+			updatedRollbackHistoryID := int64(5)
+			updatedSyncOperation.RollbackHistoryID = &updatedRollbackHistoryID
 
 			err = dbq.UpdateSyncOperation(ctx, &updatedSyncOperation)
 			Expect(err).ToNot(HaveOccurred())
@@ -83,6 +92,20 @@ var _ = Describe("SyncOperation Tests", func() {
 			err = dbq.GetSyncOperationById(ctx, &fetchRow)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(fetchRow.DesiredState).Should(Equal(updatedSyncOperation.DesiredState))
+			Expect(fetchRow.RollbackHistoryID).ToNot(BeNil())
+			Expect(*fetchRow.RollbackHistoryID).Should(Equal(updatedRollbackHistoryID))
+
+			By("removing the rollback history ID, which should be stored as NULL")
+			updatedSyncOperation.RollbackHistoryID = nil
+			err = dbq.UpdateSyncOperation(ctx, &updatedSyncOperation)
+			Expect(err).ToNot(HaveOccurred())
+
+			fetchRow = db.SyncOperation{
+				SyncOperation_id: "test-sync",
+			}
+			err = dbq.GetSyncOperationById(ctx, &fetchRow)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fetchRow.RollbackHistoryID).Should(BeNil())
 
 			rowCount, err := dbq.DeleteSyncOperationById(ctx, insertRow.SyncOperation_id)
 			Expect(err).ToNot(HaveOccurred())
@@ -122,6 +122,19 @@ type ClusterCredentials struct {
 	// -- - This corresponds to the Argo CD cluster secret field of the same name.
 	ClusterResources bool `pg:"cluster_resources"`
 
+	// -- A PEM-encoded bundle of CA certificates, used to verify the TLS certificate of the cluster
+	// -- - This corresponds to the 'caData' field of the Argo CD cluster secret TLS client config.
+	Ca_bundle string `pg:"ca_bundle"`
+
+	// -- The name used to verify the TLS certificate of the cluster, if it differs from the host name of the API URL
+	// -- - This corresponds to the 'serverName' field of the Argo CD cluster secret TLS client config.
+	Tls_server_name string `pg:"tls_server_name"`
+
+	// -- State 2) PEM-encoded client certificate (and key), used in addition to the bearer token to authenticate to the cluster
+	// -- - These correspond to the 'certData'/'keyData' fields of the Argo CD cluster secret TLS client config.
+	Client_certificate_data string `pg:"client_certificate_data"`
+	Client_key_data         string `pg:"client_key_data"`
+
 	// -- Created_on field will tell us how old resources are
 	Created_on time.Time `pg:"created_on"`
 }
@@ -183,6 +196,15 @@ const (
 	OperationResourceType_Application           OperationResourceType = "Application"
 	OperationResourceType_RepositoryCredentials OperationResourceType = "RepositoryCredentials"
 	OperationResourceType_GitOpsEngineInstance  OperationResourceType = "GitOpsEngineInstance"
+
+	// OperationResourceType_ApplicationRefresh and OperationResourceType_ApplicationHardRefresh request a (hard) refresh of
+	// the Argo CD Application: the resource id of the Operation is the Application_id.
+	OperationResourceType_ApplicationRefresh     OperationResourceType = "ApplicationRefresh"
+	OperationResourceType_ApplicationHardRefresh OperationResourceType = "ApplicationHardRefresh"
+
+	// OperationResourceType_ApplicationDiff requests the diff of the OutOfSync resources of the Argo CD Application: the
+	// resource id of the Operation is the Application_id, and the diff is returned in the result field of the Operation.
+	OperationResourceType_ApplicationDiff OperationResourceType = "ApplicationDiff"
 )
 
 // Operation
@@ -220,6 +242,8 @@ type Operation struct {
 	// * Application (user creates a new Application via service/web UI)
 	// * RepositoryCredentials (user provides private repository credentials via web UI)
 	// * SyncOperation (specified when user wants to sync an Argo CD Application)
+	// * ApplicationRefresh / ApplicationHardRefresh (specified when user wants to (hard) refresh an Argo CD Application)
+	// * ApplicationDiff (specified when user wants the diff of the OutOfSync resources of an Argo CD Application)
 	Resource_type OperationResourceType `pg:"resource_type"`
 
 	// -- When the operation was created. Used for garbage collection, as operations should be short lived.
@@ -240,6 +264,9 @@ type Operation struct {
 
 	// -- Amount of time to wait in seconds after last_state_update for a completed/failed operation to be garbage collected.
 	GC_expiration_time int `pg:"gc_expiration_time"`
+
+	// -- The (compressed) result of the operation, for operations that return data to the backend (for example, ApplicationDiff)
+	Operation_result []byte `pg:"operation_result"`
 }
 
 // Application represents an Argo CD Application CR within an Argo CD namespace.
@@ -312,6 +339,8 @@ type ApplicationState struct {
 	ReconciledState string `pg:"reconciled_state"`
 
 	Conditions []byte `pg:"conditions"`
+
+	History []byte `pg:"history"`
 }
 
 // DeploymentToApplicationMapping represents relationship from GitOpsDeployment CR in the namespace, to an Application table row
@@ -439,6 +468,27 @@ type SyncOperation struct {
 
 	DesiredState string `pg:"desired_state"`
 
+	// RollbackHistoryID is the ID of the Argo CD Application revision history entry to roll back to: if nil, the
+	// SyncOperation is a sync (to 'Revision'), rather than a rollback.
+	RollbackHistoryID *int64 `pg:"rollback_history_id"`
+
+	// Prune, DryRun, Force and SyncStrategy correspond to the sync options of the GitOpsDeploymentSyncRun CR
+	Prune bool `pg:"prune"`
+
+	DryRun bool `pg:"dry_run"`
+
+	Force bool `pg:"force"`
+
+	// -- Possible values:
+	// -- * hook
+	// -- * apply
+	// -- * (empty, which is equivalent to 'hook')
+	SyncStrategy string `pg:"sync_strategy"`
+
+	// Resources is the (compressed) list of resources to sync, from the 'resources' field of the GitOpsDeploymentSyncRun
+	// CR: if empty, all the resources of the Application are synced.
+	Resources []byte `pg:"resources"`
+
 	Created_on time.Time `pg:"created_on"`
 }
 
@@ -13,6 +13,11 @@ const (
 	// ArgoCDDefaultDestinationInCluster is 'in-cluster' which is the spec destination value that Argo CD recognizes
 	// as indicating that Argo CD should deploy to the local cluster (the cluster that Argo CD is installed on).
 	ArgoCDDefaultDestinationInCluster = "in-cluster"
+
+	// ArgoCDApplicationDeletionPolicyAnnotation is set on an Argo CD Application to the deletion policy of the
+	// corresponding GitOpsDeployment (see GitOpsDeploymentSpec.DeletionPolicy). The cluster-agent uses this to
+	// determine whether to delete the resources of the Application, when the Application is deleted.
+	ArgoCDApplicationDeletionPolicyAnnotation = "managed-gitops.redhat.com/deletion-policy"
 )
 
 // GenerateArgoCDClusterSecretName generates the name of the Argo CD cluster secret (and the name of the server within Argo CD).
@@ -57,7 +62,11 @@ func ConvertArgoCDClusterSecretNameToManagedIdDatabaseRowId(argoCDClusterSecretN
 }
 
 type ClusterSecretTLSClientConfigJSON struct {
-	Insecure bool `json:"insecure"`
+	Insecure   bool   `json:"insecure"`
+	ServerName string `json:"serverName,omitempty"`
+	CAData     []byte `json:"caData,omitempty"`
+	CertData   []byte `json:"certData,omitempty"`
+	KeyData    []byte `json:"keyData,omitempty"`
 }
 type ClusterSecretConfigJSON struct {
 	BearerToken     string                           `json:"bearerToken"`
@@ -121,6 +121,46 @@ func getOrCreateServiceAccountBearerToken(ctx context.Context, k8sClient client.
 
 }
 
+// RevokeServiceAccountBearerToken revokes a bearer token of the ServiceAccount that was created by InstallServiceAccount,
+// by deleting the ServiceAccount token Secret(s) that contain the token. Returns the number of Secrets that were deleted.
+func RevokeServiceAccountBearerToken(ctx context.Context, k8sClient client.Client, uuid string, serviceAccountNS string,
+	token string, log logr.Logger) (int, error) {
+
+	if token == "" {
+		return 0, fmt.Errorf("the token to revoke must not be empty")
+	}
+
+	serviceAccountName := GenerateServiceAccountName(uuid)
+
+	var secrets corev1.SecretList
+	if err := k8sClient.List(ctx, &secrets, client.InNamespace(serviceAccountNS)); err != nil {
+		return 0, fmt.Errorf("unable to list secrets in namespace '%s': %w", serviceAccountNS, err)
+	}
+
+	deleted := 0
+	for idx := range secrets.Items {
+		secret := secrets.Items[idx]
+
+		if secret.Type != corev1.SecretTypeServiceAccountToken ||
+			secret.Annotations[corev1.ServiceAccountNameKey] != serviceAccountName ||
+			string(secret.Data["token"]) != token {
+			continue
+		}
+
+		if err := k8sClient.Delete(ctx, &secret); err != nil {
+			if apierr.IsNotFound(err) {
+				continue
+			}
+			return deleted, fmt.Errorf("unable to delete token secret '%s' of service account '%s': %w", secret.Name, serviceAccountName, err)
+		}
+		logutil.LogAPIResourceChangeEvent(secret.Namespace, secret.Name, &secret, logutil.ResourceDeleted, log)
+
+		deleted++
+	}
+
+	return deleted, nil
+}
+
 func getServiceAccountTokenSecret(ctx context.Context, k8sClient client.Client, serviceAccount *corev1.ServiceAccount) (*corev1.Secret, error) {
 	secrets := &corev1.SecretList{}
 	ns := serviceAccount.Namespace
@@ -8,10 +8,12 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -178,4 +180,63 @@ var _ = Describe("Service Account Tests", func() {
 			})
 		})
 	})
+
+	Context("RevokeServiceAccountBearerToken", func() {
+
+		ctx := context.Background()
+		log := log.FromContext(ctx)
+
+		const (
+			uuid             = "my-uuid"
+			serviceAccountNS = "kube-system"
+		)
+
+		tokenSecret := func(name string, serviceAccountName string, token string) *corev1.Secret {
+			return &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: serviceAccountNS,
+					Annotations: map[string]string{
+						corev1.ServiceAccountNameKey: serviceAccountName,
+					},
+				},
+				Type: corev1.SecretTypeServiceAccountToken,
+				Data: map[string][]byte{
+					"token": []byte(token),
+				},
+			}
+		}
+
+		It("should delete only the token secret of the service account that contains the token", func() {
+
+			serviceAccountName := GenerateServiceAccountName(uuid)
+
+			oldTokenSecret := tokenSecret("old-token-secret", serviceAccountName, "old-token")
+			newTokenSecret := tokenSecret("new-token-secret", serviceAccountName, "new-token")
+			otherServiceAccountSecret := tokenSecret("other-token-secret", "other-service-account", "old-token")
+
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).
+				WithObjects(oldTokenSecret, newTokenSecret, otherServiceAccountSecret).Build()
+
+			deleted, err := RevokeServiceAccountBearerToken(ctx, k8sClient, uuid, serviceAccountNS, "old-token", log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(Equal(1))
+
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(oldTokenSecret), oldTokenSecret)
+			Expect(apierr.IsNotFound(err)).To(BeTrue(), "the token secret containing the revoked token should be deleted")
+
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(newTokenSecret), newTokenSecret)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(otherServiceAccountSecret), otherServiceAccountSecret)
+			Expect(err).ToNot(HaveOccurred(), "token secrets of other service accounts should not be deleted")
+		})
+
+		It("should return an error if the token is empty", func() {
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+
+			_, err := RevokeServiceAccountBearerToken(ctx, k8sClient, uuid, serviceAccountNS, "", log)
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
@@ -9,11 +9,11 @@ import (
 //go:generate mockgen -destination=mocks/conditions.go -package=$GOPACKAGE -source conditions.go
 
 type Conditions interface {
-	SetCondition(conditions *[]v1alpha1.GitOpsDeploymentCondition, conditionType v1alpha1.GitOpsDeploymentConditionType,
+	SetCondition(conditions *[]metav1.Condition, conditionType v1alpha1.GitOpsDeploymentConditionType,
 		status v1alpha1.GitOpsConditionStatus, reason v1alpha1.GitOpsDeploymentReasonType, message string)
-	FindCondition(conditions *[]v1alpha1.GitOpsDeploymentCondition,
-		conditionType v1alpha1.GitOpsDeploymentConditionType) (*v1alpha1.GitOpsDeploymentCondition, bool)
-	HasCondition(conditions *[]v1alpha1.GitOpsDeploymentCondition,
+	FindCondition(conditions *[]metav1.Condition,
+		conditionType v1alpha1.GitOpsDeploymentConditionType) (*metav1.Condition, bool)
+	HasCondition(conditions *[]metav1.Condition,
 		conditionType v1alpha1.GitOpsDeploymentConditionType) bool
 }
 
@@ -23,42 +23,40 @@ type ConditionManager struct {
 // Implement functions for the interface
 
 // SetCondition updates GitOpsDeployment status conditions
-func (c *ConditionManager) SetCondition(conditions *[]v1alpha1.GitOpsDeploymentCondition, conditionType v1alpha1.GitOpsDeploymentConditionType, status v1alpha1.GitOpsConditionStatus, reason v1alpha1.GitOpsDeploymentReasonType, message string) {
-	now := metav1.Now()
+func (c *ConditionManager) SetCondition(conditions *[]metav1.Condition, conditionType v1alpha1.GitOpsDeploymentConditionType, status v1alpha1.GitOpsConditionStatus, reason v1alpha1.GitOpsDeploymentReasonType, message string) {
 	condition, _ := c.FindCondition(conditions, conditionType)
 	if message != condition.Message ||
-		status != condition.Status ||
-		reason != condition.Reason ||
-		conditionType != condition.Type {
+		string(status) != string(condition.Status) ||
+		string(reason) != condition.Reason ||
+		condition.LastTransitionTime.IsZero() {
 
-		condition.LastTransitionTime = &now
+		condition.LastTransitionTime = metav1.Now()
 	}
 
 	condition.Message = message
-	condition.LastProbeTime = now
-	condition.Reason = reason
-	condition.Status = status
+	condition.Reason = string(reason)
+	condition.Status = metav1.ConditionStatus(status)
 }
 
 // FindCondition finds the suitable Condition object by looking into the conditions list and returns true if already exists
 // but, if none exists, it appends one and returns false
-func (c *ConditionManager) FindCondition(conditions *[]v1alpha1.GitOpsDeploymentCondition, conditionType v1alpha1.GitOpsDeploymentConditionType) (*v1alpha1.GitOpsDeploymentCondition, bool) {
+func (c *ConditionManager) FindCondition(conditions *[]metav1.Condition, conditionType v1alpha1.GitOpsDeploymentConditionType) (*metav1.Condition, bool) {
 	for i, condition := range *conditions {
-		if condition.Type == conditionType {
+		if condition.Type == string(conditionType) {
 			return &(*conditions)[i], true
 		}
 	}
 
 	// No such condition exists, so append it
-	*conditions = append(*conditions, v1alpha1.GitOpsDeploymentCondition{Type: conditionType})
+	*conditions = append(*conditions, metav1.Condition{Type: string(conditionType)})
 
 	return &(*conditions)[len(*conditions)-1], false
 }
 
 // HasCondition checks for the existence of a given Condition type
-func (c *ConditionManager) HasCondition(conditions *[]v1alpha1.GitOpsDeploymentCondition, conditionType v1alpha1.GitOpsDeploymentConditionType) bool {
+func (c *ConditionManager) HasCondition(conditions *[]metav1.Condition, conditionType v1alpha1.GitOpsDeploymentConditionType) bool {
 	for _, condition := range *conditions {
-		if condition.Type == conditionType {
+		if condition.Type == string(conditionType) {
 			return true
 		}
 	}
@@ -1,6 +1,8 @@
 package condition
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	gitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
@@ -8,7 +10,7 @@ import (
 )
 
 var _ = Describe("ConditionManager", func() {
-	var sut []gitopsv1alpha1.GitOpsDeploymentCondition
+	var sut []metav1.Condition
 	conditionManager := NewConditionManager()
 	errorOccured := gitopsv1alpha1.GitOpsDeploymentConditionErrorOccurred
 	reason := gitopsv1alpha1.GitopsDeploymentReasonErrorOccurred
@@ -17,95 +19,90 @@ var _ = Describe("ConditionManager", func() {
 	Context("when SetCondition() called with status condition true", func() {
 		status := gitopsv1alpha1.GitOpsConditionStatusTrue // set condition status true
 		BeforeEach(func() {
-			sut = []gitopsv1alpha1.GitOpsDeploymentCondition{}
+			sut = []metav1.Condition{}
 		})
 		It("should update the condition list", func() {
 			conditionManager.SetCondition(&sut, errorOccured, status, reason, message)
 
 			Expect(sut).To(HaveLen(1))
 			obj := getFirst(sut)
-			Expect(obj.Status).To(Equal(status))
+			Expect(obj.Status).To(Equal(metav1.ConditionStatus(status)))
 			Expect(obj.Message).To(Equal(message))
-			Expect(obj.Reason).To(Equal(reason))
-			Expect(obj.Type).To(Equal(errorOccured))
+			Expect(obj.Reason).To(Equal(string(reason)))
+			Expect(obj.Type).To(Equal(string(errorOccured)))
+			Expect(obj.LastTransitionTime.IsZero()).To(BeFalse())
 		})
-		It("should update the fields with given parameters except for LastProbeTime if there's one existing condition", func() {
+		It("should not modify the condition if there's one existing condition with the same values", func() {
 			// Set Existing condition
 			conditionManager.SetCondition(&sut, errorOccured, status, reason, message)
 			// Get current values
 			obj := getFirst(sut)
-			probe := obj.LastTransitionTime
 			transition := obj.LastTransitionTime
 
 			conditionManager.SetCondition(&sut, errorOccured, status, reason, message)
 			obj = getFirst(sut)
 
 			Expect((sut)).To(HaveLen(1))
-			Expect(obj.LastProbeTime).NotTo(Equal(probe))
 			Expect(obj.LastTransitionTime).To(Equal(transition))
 			Expect(obj.Message).To(Equal(message))
-			Expect(obj.Reason).To(Equal(reason))
+			Expect(obj.Reason).To(Equal(string(reason)))
 		})
 	})
 
 	Context("when SetCondition() called with status condition false", func() {
 		status := gitopsv1alpha1.GitOpsConditionStatusFalse
-		now := metav1.Now()
+		now := metav1.NewTime(time.Now().Add(-time.Minute))
 		BeforeEach(func() {
-			sut = []gitopsv1alpha1.GitOpsDeploymentCondition{}
+			sut = []metav1.Condition{}
 		})
 		It("should mark the existing condition as resolved", func() {
 			// Set existing condition
-			sut = append(sut, gitopsv1alpha1.GitOpsDeploymentCondition{
+			sut = append(sut, metav1.Condition{
 				Message:            "Dummy Error Fake Message",
-				Status:             gitopsv1alpha1.GitOpsConditionStatusTrue,
-				LastTransitionTime: &now,
-				LastProbeTime:      now,
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: now,
 				Reason:             "Dummy",
-				Type:               errorOccured,
+				Type:               string(errorOccured),
 			})
 
 			conditionManager.SetCondition(&sut, errorOccured, status, gitopsv1alpha1.GitOpsDeploymentReasonType("DummyResolved"), "Dummy Error Fake Message")
 			obj := getFirst(sut)
 			Expect(obj.Message).To(Equal("Dummy Error Fake Message"))
-			Expect(obj.Reason).To(Equal(gitopsv1alpha1.GitOpsDeploymentReasonType("DummyResolved")))
-			Expect(obj.Status).To(Equal(status))
-			Expect(obj.LastProbeTime).NotTo(Equal(now))
+			Expect(obj.Reason).To(Equal("DummyResolved"))
+			Expect(obj.Status).To(Equal(metav1.ConditionStatus(status)))
 			Expect(obj.LastTransitionTime).NotTo(Equal(now))
 		})
 	})
 
 	Context("when SetCondition() called with status condition true and a new err message", func() {
 		status := gitopsv1alpha1.GitOpsConditionStatusTrue
-		now := metav1.Now()
+		now := metav1.NewTime(time.Now().Add(-time.Minute))
 		BeforeEach(func() {
-			sut = []gitopsv1alpha1.GitOpsDeploymentCondition{}
+			sut = []metav1.Condition{}
 		})
 		It("should set a new error condition", func() {
 			// Set existing condition
-			sut = append(sut, gitopsv1alpha1.GitOpsDeploymentCondition{
+			sut = append(sut, metav1.Condition{
 				Message:            "DummyError",
-				Status:             gitopsv1alpha1.GitOpsConditionStatusFalse,
-				LastTransitionTime: &now,
-				LastProbeTime:      now,
+				Status:             metav1.ConditionFalse,
+				LastTransitionTime: now,
 				Reason:             "DummyResolved",
-				Type:               errorOccured,
+				Type:               string(errorOccured),
 			})
 			old := getFirst(sut)
 			conditionManager.SetCondition(&sut, errorOccured, status, gitopsv1alpha1.GitOpsDeploymentReasonType("SecondFakeReconcileError"), "SecondFakeReconcileMessage")
 			obj := getFirst(sut)
 			Expect(obj.Message).To(Equal("SecondFakeReconcileMessage"))
-			Expect(obj.Reason).To(Equal(gitopsv1alpha1.GitOpsDeploymentReasonType("SecondFakeReconcileError")))
-			Expect(obj.Status).To(Equal(status))
+			Expect(obj.Reason).To(Equal("SecondFakeReconcileError"))
+			Expect(obj.Status).To(Equal(metav1.ConditionStatus(status)))
 			Expect(obj.LastTransitionTime).NotTo(Equal(old.LastTransitionTime))
-			Expect(obj.LastProbeTime).NotTo(Equal(old.LastProbeTime))
 		})
 	})
 
 	Context("when HasCondition() called with an already existing condition", func() {
 		status := gitopsv1alpha1.GitOpsConditionStatusTrue
 		BeforeEach(func() {
-			sut = []gitopsv1alpha1.GitOpsDeploymentCondition{}
+			sut = []metav1.Condition{}
 		})
 		It("should return true", func() {
 			conditionManager.SetCondition(&sut, errorOccured, status, reason, message)
@@ -116,7 +113,7 @@ var _ = Describe("ConditionManager", func() {
 
 	Context("when HasCondition() called without an existing condition", func() {
 		BeforeEach(func() {
-			sut = []gitopsv1alpha1.GitOpsDeploymentCondition{}
+			sut = []metav1.Condition{}
 		})
 		It("should return false", func() {
 			result := conditionManager.HasCondition(&sut, errorOccured)
@@ -125,6 +122,6 @@ var _ = Describe("ConditionManager", func() {
 	})
 })
 
-func getFirst(list []gitopsv1alpha1.GitOpsDeploymentCondition) gitopsv1alpha1.GitOpsDeploymentCondition {
+func getFirst(list []metav1.Condition) metav1.Condition {
 	return list[0]
 }
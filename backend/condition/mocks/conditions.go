@@ -9,6 +9,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	v1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // MockConditions is a mock of Conditions interface.
@@ -35,10 +36,10 @@ func (m *MockConditions) EXPECT() *MockConditionsMockRecorder {
 }
 
 // FindCondition mocks base method.
-func (m *MockConditions) FindCondition(conditions *[]v1alpha1.GitOpsDeploymentCondition, conditionType v1alpha1.GitOpsDeploymentConditionType) (*v1alpha1.GitOpsDeploymentCondition, bool) {
+func (m *MockConditions) FindCondition(conditions *[]v1.Condition, conditionType v1alpha1.GitOpsDeploymentConditionType) (*v1.Condition, bool) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FindCondition", conditions, conditionType)
-	ret0, _ := ret[0].(*v1alpha1.GitOpsDeploymentCondition)
+	ret0, _ := ret[0].(*v1.Condition)
 	ret1, _ := ret[1].(bool)
 	return ret0, ret1
 }
@@ -50,7 +51,7 @@ func (mr *MockConditionsMockRecorder) FindCondition(conditions, conditionType an
 }
 
 // HasCondition mocks base method.
-func (m *MockConditions) HasCondition(conditions *[]v1alpha1.GitOpsDeploymentCondition, conditionType v1alpha1.GitOpsDeploymentConditionType) bool {
+func (m *MockConditions) HasCondition(conditions *[]v1.Condition, conditionType v1alpha1.GitOpsDeploymentConditionType) bool {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "HasCondition", conditions, conditionType)
 	ret0, _ := ret[0].(bool)
@@ -64,7 +65,7 @@ func (mr *MockConditionsMockRecorder) HasCondition(conditions, conditionType any
 }
 
 // SetCondition mocks base method.
-func (m *MockConditions) SetCondition(conditions *[]v1alpha1.GitOpsDeploymentCondition, conditionType v1alpha1.GitOpsDeploymentConditionType, status v1alpha1.GitOpsConditionStatus, reason v1alpha1.GitOpsDeploymentReasonType, message string) {
+func (m *MockConditions) SetCondition(conditions *[]v1.Condition, conditionType v1alpha1.GitOpsDeploymentConditionType, status v1alpha1.GitOpsConditionStatus, reason v1alpha1.GitOpsDeploymentReasonType, message string) {
 	m.ctrl.T.Helper()
 	m.ctrl.Call(m, "SetCondition", conditions, conditionType, status, reason, message)
 }
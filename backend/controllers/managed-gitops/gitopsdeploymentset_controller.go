@@ -0,0 +1,409 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managedgitops
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
+	sharedutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util"
+	logutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util/log"
+)
+
+const (
+	// defaultGitGeneratorRequeueAfter is how often a GitOpsDeploymentSet with a Git generator is reconciled, to detect
+	// changes to the directories of the repository (if not specified by the generator).
+	defaultGitGeneratorRequeueAfter = 180 * time.Second
+)
+
+// GitOpsDeploymentSetReconciler reconciles a GitOpsDeploymentSet object
+type GitOpsDeploymentSetReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=managed-gitops.redhat.com,resources=gitopsdeploymentsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=managed-gitops.redhat.com,resources=gitopsdeploymentsets/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=managed-gitops.redhat.com,resources=gitopsdeploymentsets/finalizers,verbs=update
+
+// Reconcile generates the GitOpsDeployments of a GitOpsDeploymentSet, from its generators and template:
+// - GitOpsDeployments that are generated, but do not exist, are created.
+// - GitOpsDeployments that were previously generated are updated to match the template.
+// - GitOpsDeployments that were previously generated, but are no longer generated, are deleted.
+// Each generated GitOpsDeployment is owned by the GitOpsDeploymentSet, and so they are deleted (by garbage collection)
+// when the GitOpsDeploymentSet is deleted.
+func (r *GitOpsDeploymentSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+
+	log := log.FromContext(ctx).
+		WithName(logutil.LogLogger_managed_gitops).
+		WithValues("name", req.Name, "namespace", req.Namespace, "component", "gitopsDeploymentSetReconcile")
+
+	rClient := sharedutil.IfEnabledSimulateUnreliableClient(r.Client)
+
+	set := &managedgitopsv1alpha1.GitOpsDeploymentSet{}
+	if err := rClient.Get(ctx, req.NamespacedName, set); err != nil {
+		if apierr.IsNotFound(err) {
+			// GitOpsDeploymentSet doesn't exist: it was deleted.
+			// Owner refs will ensure the GitOpsDeployments are deleted, so no work to do.
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("unable to retrieve GitOpsDeploymentSet: %v", err)
+	}
+
+	if set.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	requeueAfter := getGitOpsDeploymentSetRequeueAfter(*set)
+
+	// 1) Generate the GitOpsDeployments that we expect to exist
+	params, err := generateParameters(ctx, *set, rClient)
+	if err != nil {
+		log.Error(err, "unable to generate the parameters of GitOpsDeploymentSet")
+		if err := r.updateGitOpsDeploymentSetStatus(ctx, set, nil, managedgitopsv1alpha1.GitOpsDeploymentSetReasonGeneratorError,
+			err.Error(), rClient, log); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, err
+	}
+
+	expectedDeployments, err := r.generateExpectedGitOpsDeployments(*set, params)
+	if err != nil {
+		log.Error(err, "unable to render the template of GitOpsDeploymentSet")
+		if err := r.updateGitOpsDeploymentSetStatus(ctx, set, nil, managedgitopsv1alpha1.GitOpsDeploymentSetReasonTemplateError,
+			err.Error(), rClient, log); err != nil {
+			return ctrl.Result{}, err
+		}
+		// The template will only render differently once the GitOpsDeploymentSet (or its generated parameters) change
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	// 2) Delete any existing GitOpsDeployments that are no longer generated, then create/update the expected ones
+	var allErrors error
+
+	if err := deleteUnmatchedGitOpsDeploymentSetDeployments(ctx, *set, expectedDeployments, rClient, log); err != nil {
+		allErrors = err
+	}
+
+	resources := []managedgitopsv1alpha1.GitOpsDeploymentSetResourceStatus{}
+
+	for _, expectedDeployment := range expectedDeployments {
+
+		if err := processExpectedGitOpsDeploymentSetDeployment(ctx, expectedDeployment, *set, rClient, log); err != nil {
+			log.Error(err, "unable to process expected GitOpsDeployment of GitOpsDeploymentSet", "gitopsDeploymentName", expectedDeployment.Name)
+			if allErrors == nil {
+				allErrors = err
+			} else {
+				allErrors = fmt.Errorf("%s.\n%w", allErrors.Error(), err)
+			}
+			continue
+		}
+
+		resources = append(resources, managedgitopsv1alpha1.GitOpsDeploymentSetResourceStatus{Name: expectedDeployment.Name})
+	}
+
+	if allErrors != nil {
+		if err := r.updateGitOpsDeploymentSetStatus(ctx, set, resources, managedgitopsv1alpha1.GitOpsDeploymentSetReasonUpdateError,
+			allErrors.Error(), rClient, log); err != nil {
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{}, fmt.Errorf("unable to process expected GitOpsDeployments: %w", allErrors)
+	}
+
+	if err := r.updateGitOpsDeploymentSetStatus(ctx, set, resources, managedgitopsv1alpha1.GitOpsDeploymentSetReasonResourcesUpToDate,
+		"", rClient, log); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// generateExpectedGitOpsDeployments renders the template of the GitOpsDeploymentSet for each parameter set, returning
+// the expected GitOpsDeployments ordered by name.
+func (r *GitOpsDeploymentSetReconciler) generateExpectedGitOpsDeployments(set managedgitopsv1alpha1.GitOpsDeploymentSet,
+	params []map[string]string) ([]managedgitopsv1alpha1.GitOpsDeployment, error) {
+
+	res := []managedgitopsv1alpha1.GitOpsDeployment{}
+	names := map[string]bool{}
+
+	for _, paramSet := range params {
+
+		template, err := renderTemplate(set.Spec.Template, paramSet)
+		if err != nil {
+			return nil, err
+		}
+
+		if template.Name == "" {
+			return nil, fmt.Errorf("the name of a generated GitOpsDeployment is empty")
+		}
+
+		if names[template.Name] {
+			return nil, fmt.Errorf("more than one GitOpsDeployment would be generated with the name '%s': the template name should "+
+				"contain parameters that are unique for each parameter set", template.Name)
+		}
+		names[template.Name] = true
+
+		gitopsDepl := managedgitopsv1alpha1.GitOpsDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        template.Name,
+				Namespace:   set.Namespace,
+				Labels:      template.Labels,
+				Annotations: template.Annotations,
+			},
+			Spec: template.Spec,
+		}
+
+		if err := controllerutil.SetControllerReference(&set, &gitopsDepl, r.Scheme); err != nil {
+			return nil, fmt.Errorf("unable to set owner reference of GitOpsDeployment '%s': %v", gitopsDepl.Name, err)
+		}
+
+		res = append(res, gitopsDepl)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res, nil
+}
+
+// deleteUnmatchedGitOpsDeploymentSetDeployments deletes the GitOpsDeployments that are owned by the GitOpsDeploymentSet,
+// but which are not in the list of expected GitOpsDeployments.
+func deleteUnmatchedGitOpsDeploymentSetDeployments(ctx context.Context, set managedgitopsv1alpha1.GitOpsDeploymentSet,
+	expectedDeployments []managedgitopsv1alpha1.GitOpsDeployment, k8sClient client.Client, log logr.Logger) error {
+
+	expectedNames := map[string]bool{}
+	for _, expectedDeployment := range expectedDeployments {
+		expectedNames[expectedDeployment.Name] = true
+	}
+
+	var gitopsDeplList managedgitopsv1alpha1.GitOpsDeploymentList
+	if err := k8sClient.List(ctx, &gitopsDeplList, &client.ListOptions{Namespace: set.Namespace}); err != nil {
+		return fmt.Errorf("unable to list GitOpsDeployments: %v", err)
+	}
+
+	for i := range gitopsDeplList.Items {
+		gitopsDepl := gitopsDeplList.Items[i]
+
+		// We should only delete a GitOpsDeployment that was generated by the GitOpsDeploymentSet
+		if !isOwnedByGitOpsDeploymentSet(gitopsDepl, set) || expectedNames[gitopsDepl.Name] {
+			continue
+		}
+
+		if err := k8sClient.Delete(ctx, &gitopsDepl); err != nil && !apierr.IsNotFound(err) {
+			return fmt.Errorf("unable to delete GitOpsDeployment '%s': %v", gitopsDepl.Name, err)
+		}
+		log.Info("Deleted GitOpsDeployment which is no longer generated by the GitOpsDeploymentSet", "gitopsDeploymentName", gitopsDepl.Name)
+		logutil.LogAPIResourceChangeEvent(gitopsDepl.Namespace, gitopsDepl.Name, gitopsDepl, logutil.ResourceDeleted, log)
+	}
+
+	return nil
+}
+
+// processExpectedGitOpsDeploymentSetDeployment creates the expected GitOpsDeployment if it doesn't exist, or updates
+// it if it differs from what is expected.
+func processExpectedGitOpsDeploymentSetDeployment(ctx context.Context, expectedDeployment managedgitopsv1alpha1.GitOpsDeployment,
+	set managedgitopsv1alpha1.GitOpsDeploymentSet, k8sClient client.Client, log logr.Logger) error {
+
+	actualDeployment := managedgitopsv1alpha1.GitOpsDeployment{}
+
+	if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(&expectedDeployment), &actualDeployment); err != nil {
+
+		// A) If the GitOpsDeployment doesn't exist, create it
+		if !apierr.IsNotFound(err) {
+			return fmt.Errorf("unable to retrieve GitOpsDeployment '%s': %w", expectedDeployment.Name, err)
+		}
+		if err := k8sClient.Create(ctx, &expectedDeployment); err != nil {
+			return fmt.Errorf("unable to create GitOpsDeployment '%s': %w", expectedDeployment.Name, err)
+		}
+		logutil.LogAPIResourceChangeEvent(expectedDeployment.Namespace, expectedDeployment.Name, expectedDeployment, logutil.ResourceCreated, log)
+
+		return nil
+	}
+
+	// Don't modify a GitOpsDeployment that was not generated by the GitOpsDeploymentSet
+	if !isOwnedByGitOpsDeploymentSet(actualDeployment, set) {
+		return fmt.Errorf("GitOpsDeployment '%s' already exists, and was not generated by this GitOpsDeploymentSet", actualDeployment.Name)
+	}
+
+	// B) The GitOpsDeployment already exists, so update it if it is not as expected. Only the labels/annotations of
+	// the template are updated: any other labels/annotations added to the GitOpsDeployment are preserved.
+	updatedLabels := mergeStringMaps(actualDeployment.Labels, expectedDeployment.Labels)
+	updatedAnnotations := mergeStringMaps(actualDeployment.Annotations, expectedDeployment.Annotations)
+
+	if reflect.DeepEqual(expectedDeployment.Spec, actualDeployment.Spec) &&
+		reflect.DeepEqual(updatedLabels, actualDeployment.Labels) &&
+		reflect.DeepEqual(updatedAnnotations, actualDeployment.Annotations) {
+		return nil
+	}
+
+	actualDeployment.Spec = expectedDeployment.Spec
+	actualDeployment.Labels = updatedLabels
+	actualDeployment.Annotations = updatedAnnotations
+
+	if err := k8sClient.Update(ctx, &actualDeployment); err != nil {
+		return fmt.Errorf("unable to update GitOpsDeployment '%s': %w", actualDeployment.Name, err)
+	}
+	logutil.LogAPIResourceChangeEvent(actualDeployment.Namespace, actualDeployment.Name, actualDeployment, logutil.ResourceModified, log)
+
+	return nil
+}
+
+// updateGitOpsDeploymentSetStatus sets the ErrorOccurred condition (True if message is non-empty) and the generated
+// resources (if non-nil) in the status of the GitOpsDeploymentSet, and updates it if it has changed.
+func (r *GitOpsDeploymentSetReconciler) updateGitOpsDeploymentSetStatus(ctx context.Context, set *managedgitopsv1alpha1.GitOpsDeploymentSet,
+	resources []managedgitopsv1alpha1.GitOpsDeploymentSetResourceStatus, reason string, message string,
+	k8sClient client.Client, log logr.Logger) error {
+
+	originalStatus := set.Status.DeepCopy()
+
+	condition := metav1.Condition{
+		Type:    managedgitopsv1alpha1.GitOpsDeploymentSetConditionErrorOccurred,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	}
+	if message != "" {
+		condition.Status = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(&set.Status.Conditions, condition)
+
+	if resources != nil {
+		set.Status.Resources = resources
+	}
+
+	if reflect.DeepEqual(*originalStatus, set.Status) {
+		return nil
+	}
+
+	if err := k8sClient.Status().Update(ctx, set); err != nil {
+		log.Error(err, "unable to update GitOpsDeploymentSet status")
+		return fmt.Errorf("unable to update GitOpsDeploymentSet status: %w", err)
+	}
+
+	return nil
+}
+
+// getGitOpsDeploymentSetRequeueAfter returns how often the GitOpsDeploymentSet should be reconciled, to detect changes
+// to Git repositories, or 0 if it has no Git generators.
+func getGitOpsDeploymentSetRequeueAfter(set managedgitopsv1alpha1.GitOpsDeploymentSet) time.Duration {
+
+	var res time.Duration
+
+	for _, generator := range set.Spec.Generators {
+		if generator.Git == nil {
+			continue
+		}
+
+		requeueAfter := defaultGitGeneratorRequeueAfter
+		if generator.Git.RequeueAfterSeconds != nil && *generator.Git.RequeueAfterSeconds > 0 {
+			requeueAfter = time.Duration(*generator.Git.RequeueAfterSeconds) * time.Second
+		}
+
+		if res == 0 || requeueAfter < res {
+			res = requeueAfter
+		}
+	}
+
+	return res
+}
+
+// isOwnedByGitOpsDeploymentSet returns true if the GitOpsDeployment is controlled by the GitOpsDeploymentSet
+func isOwnedByGitOpsDeploymentSet(gitopsDepl managedgitopsv1alpha1.GitOpsDeployment, set managedgitopsv1alpha1.GitOpsDeploymentSet) bool {
+	ownerRef := metav1.GetControllerOf(&gitopsDepl)
+	return ownerRef != nil && ownerRef.UID == set.UID
+}
+
+// mergeStringMaps returns the contents of actual, with the keys of expected set to their expected values
+func mergeStringMaps(actual map[string]string, expected map[string]string) map[string]string {
+
+	if len(expected) == 0 {
+		return actual
+	}
+
+	res := map[string]string{}
+	for key, value := range actual {
+		res[key] = value
+	}
+	for key, value := range expected {
+		res[key] = value
+	}
+	return res
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GitOpsDeploymentSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&managedgitopsv1alpha1.GitOpsDeploymentSet{},
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		// Only changes to the generated GitOpsDeployments themselves (not to their status) require them to be regenerated
+		Owns(&managedgitopsv1alpha1.GitOpsDeployment{},
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{},
+				predicate.AnnotationChangedPredicate{}))).
+		Watches(
+			&source.Kind{Type: &managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment{}},
+			handler.EnqueueRequestsFromMapFunc(r.findGitOpsDeploymentSetsForManagedEnvironment),
+			builder.WithPredicates(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{})),
+		).
+		Complete(r)
+}
+
+// findGitOpsDeploymentSetsForManagedEnvironment maps an incoming GitOpsDeploymentManagedEnvironment event to the
+// GitOpsDeploymentSets in the same namespace that have a ManagedEnvironment generator.
+func (r *GitOpsDeploymentSetReconciler) findGitOpsDeploymentSetsForManagedEnvironment(managedEnv client.Object) []reconcile.Request {
+	ctx := context.Background()
+	handlerLog := log.FromContext(ctx).
+		WithName(logutil.LogLogger_managed_gitops)
+
+	var setList managedgitopsv1alpha1.GitOpsDeploymentSetList
+	if err := r.List(ctx, &setList, &client.ListOptions{Namespace: managedEnv.GetNamespace()}); err != nil {
+		handlerLog.Error(err, "unable to list GitOpsDeploymentSets in the ManagedEnvironment mapping function")
+		return []reconcile.Request{}
+	}
+
+	res := []reconcile.Request{}
+	for i := range setList.Items {
+		set := setList.Items[i]
+		for _, generator := range set.Spec.Generators {
+			if generator.ManagedEnvironment != nil {
+				res = append(res, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&set)})
+				break
+			}
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,453 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managedgitops
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
+	"github.com/redhat-appstudio/managed-gitops/backend-shared/util/tests"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("GitOpsDeploymentSet Controller Test", func() {
+
+	Context("Reconcile GitOpsDeploymentSet", func() {
+
+		var ctx context.Context
+		var k8sClient client.Client
+		var scheme *runtime.Scheme
+		var namespace *corev1.Namespace
+		var reconciler GitOpsDeploymentSetReconciler
+		var set *managedgitopsv1alpha1.GitOpsDeploymentSet
+
+		reconcileSet := func() (ctrl.Result, error) {
+			return reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(set)})
+		}
+
+		listGitOpsDeployments := func() []managedgitopsv1alpha1.GitOpsDeployment {
+			var gitopsDeplList managedgitopsv1alpha1.GitOpsDeploymentList
+			err := k8sClient.List(ctx, &gitopsDeplList, &client.ListOptions{Namespace: namespace.Name})
+			Expect(err).ToNot(HaveOccurred())
+			sort.Slice(gitopsDeplList.Items, func(i, j int) bool {
+				return gitopsDeplList.Items[i].Name < gitopsDeplList.Items[j].Name
+			})
+			return gitopsDeplList.Items
+		}
+
+		getErrorOccurredCondition := func() *metav1.Condition {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(set), set)
+			Expect(err).ToNot(HaveOccurred())
+			return meta.FindStatusCondition(set.Status.Conditions, managedgitopsv1alpha1.GitOpsDeploymentSetConditionErrorOccurred)
+		}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			var argocdNamespace, kubesystemNamespace *corev1.Namespace
+			var err error
+			scheme, argocdNamespace, kubesystemNamespace, namespace, err = tests.GenericTestSetup()
+			Expect(err).ToNot(HaveOccurred())
+
+			k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespace, argocdNamespace, kubesystemNamespace).Build()
+
+			reconciler = GitOpsDeploymentSetReconciler{
+				Client: k8sClient,
+				Scheme: scheme,
+			}
+
+			set = &managedgitopsv1alpha1.GitOpsDeploymentSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-set",
+					Namespace: namespace.Name,
+					UID:       uuid.NewUUID(),
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentSetSpec{
+					Generators: []managedgitopsv1alpha1.GitOpsDeploymentSetGenerator{
+						{
+							List: &managedgitopsv1alpha1.ListGenerator{
+								Elements: []map[string]string{
+									{"env": "dev", "revision": "main"},
+									{"env": "prod", "revision": "v1.0.0"},
+								},
+							},
+						},
+					},
+					Template: managedgitopsv1alpha1.GitOpsDeploymentSetTemplate{
+						GitOpsDeploymentSetTemplateMeta: managedgitopsv1alpha1.GitOpsDeploymentSetTemplateMeta{
+							Name:   "my-app-{{env}}",
+							Labels: map[string]string{"env": "{{env}}"},
+						},
+						Spec: managedgitopsv1alpha1.GitOpsDeploymentSpec{
+							Source: managedgitopsv1alpha1.ApplicationSource{
+								RepoURL:        "https://github.com/test/test",
+								Path:           "environments/{{env}}",
+								TargetRevision: "{{revision}}",
+							},
+							Destination: managedgitopsv1alpha1.ApplicationDestination{
+								Environment: "{{env}}-cluster",
+							},
+							Type: managedgitopsv1alpha1.GitOpsDeploymentSpecType_Automated,
+						},
+					},
+				},
+			}
+		})
+
+		It("should create a GitOpsDeployment, owned by the GitOpsDeploymentSet, for each element of a list generator", func() {
+			err := k8sClient.Create(ctx, set)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = reconcileSet()
+			Expect(err).ToNot(HaveOccurred())
+
+			gitopsDepls := listGitOpsDeployments()
+			Expect(gitopsDepls).To(HaveLen(2))
+
+			for _, gitopsDepl := range gitopsDepls {
+				Expect(isOwnedByGitOpsDeploymentSet(gitopsDepl, *set)).To(BeTrue())
+			}
+
+			Expect(gitopsDepls[0].Name).To(Equal("my-app-dev"))
+			Expect(gitopsDepls[0].Labels).To(Equal(map[string]string{"env": "dev"}))
+			Expect(gitopsDepls[0].Spec.Source.Path).To(Equal("environments/dev"))
+			Expect(gitopsDepls[0].Spec.Source.TargetRevision).To(Equal("main"))
+			Expect(gitopsDepls[0].Spec.Destination.Environment).To(Equal("dev-cluster"))
+
+			Expect(gitopsDepls[1].Name).To(Equal("my-app-prod"))
+			Expect(gitopsDepls[1].Spec.Source.TargetRevision).To(Equal("v1.0.0"))
+
+			condition := getErrorOccurredCondition()
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(managedgitopsv1alpha1.GitOpsDeploymentSetReasonResourcesUpToDate))
+			Expect(set.Status.Resources).To(Equal([]managedgitopsv1alpha1.GitOpsDeploymentSetResourceStatus{
+				{Name: "my-app-dev"}, {Name: "my-app-prod"},
+			}))
+		})
+
+		It("should update GitOpsDeployments that differ from the template, and delete those that are no longer generated", func() {
+			err := k8sClient.Create(ctx, set)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = reconcileSet()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(listGitOpsDeployments()).To(HaveLen(2))
+
+			By("adding a label to a generated GitOpsDeployment, which should be preserved")
+			gitopsDepl := &managedgitopsv1alpha1.GitOpsDeployment{}
+			err = k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace.Name, Name: "my-app-dev"}, gitopsDepl)
+			Expect(err).ToNot(HaveOccurred())
+			gitopsDepl.Labels["user-label"] = "value"
+			err = k8sClient.Update(ctx, gitopsDepl)
+			Expect(err).ToNot(HaveOccurred())
+
+			By("changing the revision of 'dev', and removing 'prod'")
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(set), set)
+			Expect(err).ToNot(HaveOccurred())
+			set.Spec.Generators[0].List.Elements = []map[string]string{{"env": "dev", "revision": "feature"}}
+			err = k8sClient.Update(ctx, set)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = reconcileSet()
+			Expect(err).ToNot(HaveOccurred())
+
+			gitopsDepls := listGitOpsDeployments()
+			Expect(gitopsDepls).To(HaveLen(1))
+			Expect(gitopsDepls[0].Name).To(Equal("my-app-dev"))
+			Expect(gitopsDepls[0].Spec.Source.TargetRevision).To(Equal("feature"))
+			Expect(gitopsDepls[0].Labels).To(Equal(map[string]string{"env": "dev", "user-label": "value"}))
+
+			Expect(getErrorOccurredCondition().Status).To(Equal(metav1.ConditionFalse))
+			Expect(set.Status.Resources).To(Equal([]managedgitopsv1alpha1.GitOpsDeploymentSetResourceStatus{{Name: "my-app-dev"}}))
+		})
+
+		It("should not modify or delete a GitOpsDeployment that was not generated by the GitOpsDeploymentSet", func() {
+			existingGitOpsDepl := &managedgitopsv1alpha1.GitOpsDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-app-dev",
+					Namespace: namespace.Name,
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentSpec{
+					Source: managedgitopsv1alpha1.ApplicationSource{RepoURL: "https://github.com/other/other"},
+					Type:   managedgitopsv1alpha1.GitOpsDeploymentSpecType_Manual,
+				},
+			}
+			err := k8sClient.Create(ctx, existingGitOpsDepl)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = k8sClient.Create(ctx, set)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = reconcileSet()
+			Expect(err).To(HaveOccurred())
+
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(existingGitOpsDepl), existingGitOpsDepl)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(existingGitOpsDepl.Spec.Source.RepoURL).To(Equal("https://github.com/other/other"))
+
+			condition := getErrorOccurredCondition()
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(managedgitopsv1alpha1.GitOpsDeploymentSetReasonUpdateError))
+			Expect(condition.Message).To(ContainSubstring("was not generated by this GitOpsDeploymentSet"))
+
+			By("the other GitOpsDeployment should still be generated")
+			Expect(set.Status.Resources).To(Equal([]managedgitopsv1alpha1.GitOpsDeploymentSetResourceStatus{{Name: "my-app-prod"}}))
+		})
+
+		It("should report an error if more than one GitOpsDeployment would be generated with the same name", func() {
+			set.Spec.Template.Name = "my-app"
+			err := k8sClient.Create(ctx, set)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = reconcileSet()
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(listGitOpsDeployments()).To(BeEmpty())
+
+			condition := getErrorOccurredCondition()
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(managedgitopsv1alpha1.GitOpsDeploymentSetReasonTemplateError))
+			Expect(condition.Message).To(ContainSubstring("my-app"))
+		})
+
+		It("should create a GitOpsDeployment for each GitOpsDeploymentManagedEnvironment that matches the selector", func() {
+			for _, managedEnv := range []*managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: namespace.Name,
+						Labels: map[string]string{"tier": "non-prod", "region": "us-east"}},
+					Spec: managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec{APIURL: "https://staging.example.com:6443"},
+				},
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "production", Namespace: namespace.Name,
+						Labels: map[string]string{"tier": "prod", "region": "eu-west"}},
+					Spec: managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec{APIURL: "https://production.example.com:6443"},
+				},
+			} {
+				err := k8sClient.Create(ctx, managedEnv)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			set.Spec.Generators = []managedgitopsv1alpha1.GitOpsDeploymentSetGenerator{
+				{
+					ManagedEnvironment: &managedgitopsv1alpha1.ManagedEnvironmentGenerator{
+						Selector: metav1.LabelSelector{MatchLabels: map[string]string{"tier": "non-prod"}},
+					},
+				},
+			}
+			set.Spec.Template.Name = "my-app-{{name}}"
+			set.Spec.Template.Labels = map[string]string{"region": "{{metadata.labels.region}}"}
+			set.Spec.Template.Spec.Destination.Environment = "{{name}}"
+			err := k8sClient.Create(ctx, set)
+			Expect(err).ToNot(HaveOccurred())
+
+			By("verifying that the GitOpsDeploymentSet is reconciled on changes to the GitOpsDeploymentManagedEnvironments")
+			requests := reconciler.findGitOpsDeploymentSetsForManagedEnvironment(&managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment{
+				ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: namespace.Name},
+			})
+			Expect(requests).To(HaveLen(1))
+			Expect(requests[0].NamespacedName).To(Equal(client.ObjectKeyFromObject(set)))
+
+			_, err = reconcileSet()
+			Expect(err).ToNot(HaveOccurred())
+
+			gitopsDepls := listGitOpsDeployments()
+			Expect(gitopsDepls).To(HaveLen(1))
+			Expect(gitopsDepls[0].Name).To(Equal("my-app-staging"))
+			Expect(gitopsDepls[0].Labels).To(Equal(map[string]string{"region": "us-east"}))
+			Expect(gitopsDepls[0].Spec.Destination.Environment).To(Equal("staging"))
+		})
+
+		Context("with a Git generator, backed by a local bare Git repository", func() {
+
+			var repoDir string
+
+			BeforeEach(func() {
+				var err error
+				repoDir, err = createTestGitRepository([]string{
+					"environments/dev/kustomization.yaml",
+					"environments/staging/kustomization.yaml",
+					"environments/Prod_EU/kustomization.yaml",
+					"components/app/deployment.yaml",
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				Expect(os.RemoveAll(repoDir)).To(Succeed())
+			})
+
+			It("should create a GitOpsDeployment for each directory that matches the generator", func() {
+				set.Spec.Generators = []managedgitopsv1alpha1.GitOpsDeploymentSetGenerator{
+					{
+						Git: &managedgitopsv1alpha1.GitGenerator{
+							RepoURL: filepath.Join(repoDir, "bare"),
+							Directories: []managedgitopsv1alpha1.GitDirectoryGeneratorItem{
+								{Path: "environments/*"},
+								{Path: "environments/staging", Exclude: true},
+							},
+						},
+					},
+				}
+				set.Spec.Template.Name = "my-app-{{path.basenameNormalized}}"
+				set.Spec.Template.Labels = map[string]string{"env": "{{path.basename}}"}
+				set.Spec.Template.Spec.Source.Path = "{{path}}"
+				err := k8sClient.Create(ctx, set)
+				Expect(err).ToNot(HaveOccurred())
+
+				res, err := reconcileSet()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(res.RequeueAfter).To(Equal(defaultGitGeneratorRequeueAfter))
+
+				gitopsDepls := listGitOpsDeployments()
+				Expect(gitopsDepls).To(HaveLen(2))
+				Expect(gitopsDepls[0].Name).To(Equal("my-app-dev"))
+				Expect(gitopsDepls[0].Spec.Source.Path).To(Equal("environments/dev"))
+				Expect(gitopsDepls[1].Name).To(Equal("my-app-prod-eu"))
+				Expect(gitopsDepls[1].Labels).To(Equal(map[string]string{"env": "Prod_EU"}))
+				Expect(gitopsDepls[1].Spec.Source.Path).To(Equal("environments/Prod_EU"))
+			})
+
+			It("should read the directories at the revision of the generator, if it is a branch or a commit SHA", func() {
+				bareRepo, err := git.PlainOpen(filepath.Join(repoDir, "bare"))
+				Expect(err).ToNot(HaveOccurred())
+				head, err := bareRepo.Head()
+				Expect(err).ToNot(HaveOccurred())
+
+				for _, revision := range []string{head.Name().Short(), head.Hash().String()} {
+					directories, err := listGitDirectories(filepath.Join(repoDir, "bare"), revision, nil)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(directories).To(Equal([]string{"components", "components/app", "environments",
+						"environments/Prod_EU", "environments/dev", "environments/staging"}))
+				}
+
+				_, err = listGitDirectories(filepath.Join(repoDir, "bare"), "does-not-exist", nil)
+				Expect(err).To(HaveOccurred())
+			})
+
+			It("should report an error if the repository cannot be cloned", func() {
+				set.Spec.Generators = []managedgitopsv1alpha1.GitOpsDeploymentSetGenerator{
+					{
+						Git: &managedgitopsv1alpha1.GitGenerator{
+							RepoURL:     filepath.Join(repoDir, "does-not-exist"),
+							Directories: []managedgitopsv1alpha1.GitDirectoryGeneratorItem{{Path: "*"}},
+						},
+					},
+				}
+				err := k8sClient.Create(ctx, set)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = reconcileSet()
+				Expect(err).To(HaveOccurred())
+
+				condition := getErrorOccurredCondition()
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(condition.Reason).To(Equal(managedgitopsv1alpha1.GitOpsDeploymentSetReasonGeneratorError))
+			})
+		})
+	})
+
+	Context("Test renderTemplate function", func() {
+
+		It("should replace parameter references, and leave unknown references as is", func() {
+			template := managedgitopsv1alpha1.GitOpsDeploymentSetTemplate{
+				GitOpsDeploymentSetTemplateMeta: managedgitopsv1alpha1.GitOpsDeploymentSetTemplateMeta{
+					Name:        "app-{{ env }}",
+					Annotations: map[string]string{"description": "{{description}}", "unknown": "{{unknown}}"},
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentSpec{
+					Source: managedgitopsv1alpha1.ApplicationSource{RepoURL: "{{repoURL}}"},
+				},
+			}
+
+			rendered, err := renderTemplate(template, map[string]string{
+				"env":         "dev",
+				"description": `a "quoted" value`,
+				"repoURL":     "https://github.com/test/test",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rendered.Name).To(Equal("app-dev"))
+			Expect(rendered.Annotations).To(Equal(map[string]string{"description": `a "quoted" value`, "unknown": "{{unknown}}"}))
+			Expect(rendered.Spec.Source.RepoURL).To(Equal("https://github.com/test/test"))
+
+			By("verifying that the original template was not modified")
+			Expect(template.Name).To(Equal("app-{{ env }}"))
+		})
+	})
+})
+
+// createTestGitRepository creates a Git repository containing the given files, in a new temporary directory, and then
+// clones it into a bare repository in the 'bare' subdirectory of that directory. Returns the temporary directory.
+func createTestGitRepository(files []string) (string, error) {
+
+	tempDir, err := os.MkdirTemp("", "gitopsdeploymentset-")
+	if err != nil {
+		return "", err
+	}
+
+	workDir := filepath.Join(tempDir, "work")
+
+	repo, err := git.PlainInit(workDir, false)
+	if err != nil {
+		return "", err
+	}
+
+	worktree, err := repo.Worktree()
+	if err != nil {
+		return "", err
+	}
+
+	for _, file := range files {
+		fullPath := filepath.Join(workDir, file)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0o750); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(fullPath, []byte("test: "+file+"\n"), 0o600); err != nil {
+			return "", err
+		}
+		if _, err := worktree.Add(file); err != nil {
+			return "", err
+		}
+	}
+
+	if _, err := worktree.Commit("initial commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+	}); err != nil {
+		return "", err
+	}
+
+	if _, err := git.PlainClone(filepath.Join(tempDir, "bare"), true, &git.CloneOptions{URL: workDir}); err != nil {
+		return "", err
+	}
+
+	return tempDir, nil
+}
@@ -0,0 +1,371 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managedgitops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	git "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
+	"github.com/redhat-appstudio/managed-gitops/backend/eventloop/shared_resource_loop"
+)
+
+// This file contains the generators of GitOpsDeploymentSet: each generator produces a list of parameter sets
+// (parameter name -> value), which are then used to render the template of the GitOpsDeploymentSet.
+
+var (
+	// templateParameterRegex matches a '{{parameter}}' reference within a template
+	templateParameterRegex = regexp.MustCompile(`\{\{\s*([-\w.]+)\s*\}\}`)
+
+	// invalidResourceNameCharsRegex matches the characters that are not valid in a K8s resource name
+	invalidResourceNameCharsRegex = regexp.MustCompile(`[^-a-z0-9.]+`)
+)
+
+// generateParameters returns the parameter sets produced by each of the generators of the GitOpsDeploymentSet, in order.
+func generateParameters(ctx context.Context, set managedgitopsv1alpha1.GitOpsDeploymentSet, k8sClient client.Client) ([]map[string]string, error) {
+
+	res := []map[string]string{}
+
+	for idx, generator := range set.Spec.Generators {
+
+		var params []map[string]string
+		var err error
+
+		switch {
+		case generator.List != nil:
+			params = generateListParameters(*generator.List)
+		case generator.Git != nil:
+			params, err = generateGitParameters(ctx, *generator.Git, set.Namespace, k8sClient)
+		case generator.ManagedEnvironment != nil:
+			params, err = generateManagedEnvironmentParameters(ctx, *generator.ManagedEnvironment, set.Namespace, k8sClient)
+		default:
+			err = fmt.Errorf("no generator type is specified")
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("generator %d: %v", idx, err)
+		}
+
+		res = append(res, params...)
+	}
+
+	return res, nil
+}
+
+// generateListParameters returns a parameter set for each element of the list
+func generateListParameters(generator managedgitopsv1alpha1.ListGenerator) []map[string]string {
+
+	res := []map[string]string{}
+	for _, element := range generator.Elements {
+		params := map[string]string{}
+		for key, value := range element {
+			params[key] = value
+		}
+		res = append(res, params)
+	}
+	return res
+}
+
+// generateGitParameters returns a parameter set for each directory of the Git repository that is matched by the generator
+func generateGitParameters(ctx context.Context, generator managedgitopsv1alpha1.GitGenerator, namespace string,
+	k8sClient client.Client) ([]map[string]string, error) {
+
+	auth, err := getGitAuthForRepository(ctx, generator.RepoURL, namespace, k8sClient)
+	if err != nil {
+		return nil, err
+	}
+
+	directories, err := listGitDirectories(generator.RepoURL, generator.Revision, auth)
+	if err != nil {
+		return nil, err
+	}
+
+	matchedDirectories, err := matchGitDirectories(directories, generator.Directories)
+	if err != nil {
+		return nil, err
+	}
+
+	res := []map[string]string{}
+	for _, directory := range matchedDirectories {
+		res = append(res, map[string]string{
+			"path":                    directory,
+			"path.basename":           path.Base(directory),
+			"path.basenameNormalized": normalizeResourceName(path.Base(directory)),
+		})
+	}
+
+	return res, nil
+}
+
+// getGitAuthForRepository returns the credentials of the GitOpsDeploymentRepositoryCredential (if any) in the
+// namespace for the repository, or nil if there are none.
+func getGitAuthForRepository(ctx context.Context, repoURL string, namespace string, k8sClient client.Client) (transport.AuthMethod, error) {
+
+	var repoCredList managedgitopsv1alpha1.GitOpsDeploymentRepositoryCredentialList
+	if err := k8sClient.List(ctx, &repoCredList, &client.ListOptions{Namespace: namespace}); err != nil {
+		return nil, fmt.Errorf("unable to list GitOpsDeploymentRepositoryCredentials: %v", err)
+	}
+
+	normalizedRepoURL := shared_resource_loop.NormalizeGitURL(repoURL)
+
+	for _, repoCred := range repoCredList.Items {
+
+		if shared_resource_loop.NormalizeGitURL(repoCred.Spec.Repository) != normalizedRepoURL {
+			continue
+		}
+
+		secret := &corev1.Secret{}
+		if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: repoCred.Spec.Secret}, secret); err != nil {
+			return nil, fmt.Errorf("unable to retrieve Secret '%s' of GitOpsDeploymentRepositoryCredential '%s': %v",
+				repoCred.Spec.Secret, repoCred.Name, err)
+		}
+
+		return shared_resource_loop.GitAuthFromSecret(secret)
+	}
+
+	return nil, nil
+}
+
+// listGitDirectories returns the path of every directory of the Git repository, at the given revision.
+func listGitDirectories(repoURL string, revision string, auth transport.AuthMethod) ([]string, error) {
+
+	repo, hash, err := cloneGitRevision(repoURL, revision, auth)
+	if err != nil {
+		return nil, fmt.Errorf("unable to clone revision '%s' of repository '%s': %v", revision, repoURL, err)
+	}
+
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve commit '%s' of repository '%s': %v", hash.String(), repoURL, err)
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve tree of commit '%s' of repository '%s': %v", hash.String(), repoURL, err)
+	}
+
+	res := []string{}
+
+	walker := object.NewTreeWalker(tree, true, nil)
+	defer walker.Close()
+
+	for {
+		name, entry, err := walker.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("unable to walk tree of commit '%s' of repository '%s': %v", hash.String(), repoURL, err)
+		}
+		if entry.Mode == filemode.Dir {
+			res = append(res, name)
+		}
+	}
+
+	sort.Strings(res)
+
+	return res, nil
+}
+
+// cloneGitRevision clones the revision (a branch, tag or commit SHA, or HEAD if empty) of the repository into memory,
+// and returns the commit of the revision.
+//
+// Branches and tags are cloned with a depth of 1, as only the tree of their latest commit is needed. A commit SHA cannot
+// be cloned by name, so if the revision is neither a branch nor a tag, the full repository is cloned instead.
+func cloneGitRevision(repoURL string, revision string, auth transport.AuthMethod) (*git.Repository, *plumbing.Hash, error) {
+
+	if revision == "" || revision == string(plumbing.HEAD) {
+		return cloneGitReference(repoURL, plumbing.HEAD, auth)
+	}
+
+	for _, referenceName := range []plumbing.ReferenceName{plumbing.NewBranchReferenceName(revision), plumbing.NewTagReferenceName(revision)} {
+		repo, hash, err := cloneGitReference(repoURL, referenceName, auth)
+		if err == nil {
+			return repo, hash, nil
+		} else if !errors.Is(err, git.NoMatchingRefSpecError{}) {
+			return nil, nil, err
+		}
+	}
+
+	repo, err := git.Clone(memory.NewStorage(), nil, &git.CloneOptions{
+		URL:  repoURL,
+		Auth: auth,
+		Tags: git.NoTags,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return repo, hash, nil
+}
+
+// cloneGitReference clones only the latest commit of the branch or tag of the repository into memory, and returns that commit.
+func cloneGitReference(repoURL string, referenceName plumbing.ReferenceName, auth transport.AuthMethod) (*git.Repository, *plumbing.Hash, error) {
+
+	repo, err := git.Clone(memory.NewStorage(), nil, &git.CloneOptions{
+		URL:           repoURL,
+		Auth:          auth,
+		ReferenceName: referenceName,
+		SingleBranch:  true,
+		Depth:         1,
+		Tags:          git.NoTags,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(plumbing.HEAD))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return repo, hash, nil
+}
+
+// matchGitDirectories returns the directories that match at least one of the (non-exclude) patterns, and none of the
+// exclude patterns.
+func matchGitDirectories(directories []string, items []managedgitopsv1alpha1.GitDirectoryGeneratorItem) ([]string, error) {
+
+	res := []string{}
+
+	for _, directory := range directories {
+
+		included := false
+		excluded := false
+
+		for _, item := range items {
+			match, err := path.Match(item.Path, directory)
+			if err != nil {
+				return nil, fmt.Errorf("invalid directory path pattern '%s': %v", item.Path, err)
+			}
+			if !match {
+				continue
+			}
+			if item.Exclude {
+				excluded = true
+			} else {
+				included = true
+			}
+		}
+
+		if included && !excluded {
+			res = append(res, directory)
+		}
+	}
+
+	return res, nil
+}
+
+// generateManagedEnvironmentParameters returns a parameter set for each GitOpsDeploymentManagedEnvironment in the
+// namespace that matches the selector of the generator.
+func generateManagedEnvironmentParameters(ctx context.Context, generator managedgitopsv1alpha1.ManagedEnvironmentGenerator,
+	namespace string, k8sClient client.Client) ([]map[string]string, error) {
+
+	selector, err := metav1.LabelSelectorAsSelector(&generator.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector: %v", err)
+	}
+
+	var managedEnvList managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentList
+	if err := k8sClient.List(ctx, &managedEnvList, &client.ListOptions{Namespace: namespace, LabelSelector: selector}); err != nil {
+		return nil, fmt.Errorf("unable to list GitOpsDeploymentManagedEnvironments: %v", err)
+	}
+
+	sort.Slice(managedEnvList.Items, func(i, j int) bool {
+		return managedEnvList.Items[i].Name < managedEnvList.Items[j].Name
+	})
+
+	res := []map[string]string{}
+	for _, managedEnv := range managedEnvList.Items {
+		params := map[string]string{
+			"name":   managedEnv.Name,
+			"apiURL": managedEnv.Spec.APIURL,
+		}
+		for key, value := range managedEnv.Labels {
+			params["metadata.labels."+key] = value
+		}
+		res = append(res, params)
+	}
+
+	return res, nil
+}
+
+// renderTemplate replaces each '{{parameter}}' reference in the string fields of the template with the value of the
+// parameter. References to parameters that are not defined are left as is.
+func renderTemplate(template managedgitopsv1alpha1.GitOpsDeploymentSetTemplate, params map[string]string) (*managedgitopsv1alpha1.GitOpsDeploymentSetTemplate, error) {
+
+	templateJSON, err := json.Marshal(template)
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal template: %v", err)
+	}
+
+	var replaceErr error
+
+	// The references are within JSON strings, so the values are escaped as JSON strings
+	renderedJSON := templateParameterRegex.ReplaceAllFunc(templateJSON, func(reference []byte) []byte {
+
+		name := string(templateParameterRegex.FindSubmatch(reference)[1])
+
+		value, exists := params[name]
+		if !exists {
+			return reference
+		}
+
+		valueJSON, err := json.Marshal(value)
+		if err != nil {
+			replaceErr = err
+			return reference
+		}
+		return valueJSON[1 : len(valueJSON)-1]
+	})
+	if replaceErr != nil {
+		return nil, fmt.Errorf("unable to render template: %v", replaceErr)
+	}
+
+	res := &managedgitopsv1alpha1.GitOpsDeploymentSetTemplate{}
+	if err := json.Unmarshal(renderedJSON, res); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal rendered template: %v", err)
+	}
+
+	return res, nil
+}
+
+// normalizeResourceName converts a string into a value that may be used within the name of a K8s resource
+func normalizeResourceName(name string) string {
+	return strings.Trim(invalidResourceNameCharsRegex.ReplaceAllString(strings.ToLower(name), "-"), "-.")
+}
@@ -71,6 +71,18 @@ func (r *GitOpsDeploymentSyncRunReconciler) Reconcile(ctx context.Context, req c
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *GitOpsDeploymentSyncRunReconciler) SetupWithManager(mgr ctrl.Manager) error {
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &managedgitopsv1alpha1.GitOpsDeploymentSyncRun{},
+		eventlooptypes.SyncRunGitOpsDeploymentNameIndex, func(obj client.Object) []string {
+			syncRun, ok := obj.(*managedgitopsv1alpha1.GitOpsDeploymentSyncRun)
+			if !ok {
+				return nil
+			}
+			return []string{syncRun.Spec.GitopsDeploymentName}
+		}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&managedgitopsv1alpha1.GitOpsDeploymentSyncRun{},
 			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
@@ -37,6 +37,11 @@ import (
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 )
 
+// managedEnvCASecretRefIndex is the field index of GitOpsDeploymentManagedEnvironments by '.spec.caSecretRef.name': it
+// allows the ManagedEnvironments that reference a CA Secret to be found, without listing every ManagedEnvironment in the
+// namespace of each Secret.
+const managedEnvCASecretRefIndex = "spec.caSecretRef.name"
+
 // SecretReconciler reconciles a Secret object
 type SecretReconciler struct {
 	client.Client
@@ -71,9 +76,15 @@ func (r *SecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 
 		// If the Secret exists, and is of the appropriate type, then find any ManagedEnvironments that reference that Secret in the Namespace
 
-		// Ignore non-ManagedEnv secrets
-		if secret.Type != sharedutil.ManagedEnvironmentSecretType {
-			return ctrl.Result{}, err
+		// Ignore Secrets that are not referenced by a ManagedEnv
+		if !isManagedEnvSecret(secret) {
+			isCASecret, err := isReferencedCASecret(ctx, secret, rClient)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			if !isCASecret {
+				return ctrl.Result{}, nil
+			}
 		}
 
 		// Locate any managed environments that reference this Secret, in the same Namespace
@@ -138,7 +149,9 @@ func processSecret(ctx context.Context, secret corev1.Secret, k8sClient client.C
 			continue
 		}
 
-		if managedEnvCR.Spec.ClusterCredentialsSecret == secret.Name {
+		// The Secret is either the cluster credentials Secret, or the CA Secret, of the managed environment
+		if (secret.Type == sharedutil.ManagedEnvironmentSecretType && managedEnvCR.Spec.ClusterCredentialsSecret == secret.Name) ||
+			(managedEnvCR.Spec.CASecretRef != nil && managedEnvCR.Spec.CASecretRef.Name == secret.Name) {
 			listOfManagedEnvCRsThatReferenceSecret = append(listOfManagedEnvCRsThatReferenceSecret, managedEnvCR)
 		}
 	}
@@ -148,25 +161,49 @@ func processSecret(ctx context.Context, secret corev1.Secret, k8sClient client.C
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *SecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
+
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment{},
+		managedEnvCASecretRefIndex, func(obj client.Object) []string {
+			managedEnv, ok := obj.(*managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment)
+			if !ok || managedEnv.Spec.CASecretRef == nil {
+				return nil
+			}
+			return []string{managedEnv.Spec.CASecretRef.Name}
+		}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&corev1.Secret{}).
-		WithEventFilter(filterManagedEnvSecrets()).
+		WithEventFilter(filterManagedEnvSecrets(r.Client)).
 		Complete(r)
 }
 
-func filterManagedEnvSecrets() predicate.Predicate {
+// filterManagedEnvSecrets filters out the Secrets that are not referenced by a ManagedEnvironment: the client is used to
+// find the ManagedEnvironments that reference a Secret as their CA Secret.
+func filterManagedEnvSecrets(k8sClient client.Client) predicate.Predicate {
+
+	isManagedEnvOrCASecret := func(o client.Object) bool {
+		if isManagedEnvSecret(o) {
+			return true
+		}
+		isCASecret, err := isReferencedCASecret(context.Background(), o, k8sClient)
+		// On error, the Secret is reconciled, so that the check is retried by Reconcile
+		return err != nil || isCASecret
+	}
+
 	return predicate.Funcs{
 		CreateFunc: func(createEvent event.CreateEvent) bool {
-			return isManagedEnvSecret(createEvent.Object)
+			return isManagedEnvOrCASecret(createEvent.Object)
 		},
 		DeleteFunc: func(deleteEvent event.DeleteEvent) bool {
-			return isManagedEnvSecret(deleteEvent.Object)
+			return isManagedEnvOrCASecret(deleteEvent.Object)
 		},
 		GenericFunc: func(genericEvent event.GenericEvent) bool {
-			return isManagedEnvSecret(genericEvent.Object)
+			return isManagedEnvOrCASecret(genericEvent.Object)
 		},
 		UpdateFunc: func(e event.UpdateEvent) bool {
-			return isManagedEnvSecret(e.ObjectNew)
+			return isManagedEnvOrCASecret(e.ObjectNew)
 		},
 	}
 
@@ -180,6 +217,30 @@ func isManagedEnvSecret(o client.Object) bool {
 	return false
 }
 
+// isReferencedCASecret returns true if the Secret is referenced by the '.spec.caSecretRef' field of a ManagedEnvironment
+// in its namespace.
+func isReferencedCASecret(ctx context.Context, o client.Object, k8sClient client.Client) (bool, error) {
+	secret, ok := o.(*corev1.Secret)
+	if !ok {
+		return false, nil
+	}
+
+	managedEnvList := managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentList{}
+	if err := k8sClient.List(ctx, &managedEnvList, client.InNamespace(secret.Namespace),
+		client.MatchingFields{managedEnvCASecretRefIndex: secret.Name}); err != nil {
+		return false, fmt.Errorf("unable to list Managed Environment resources in namespace '%s': %v", secret.Namespace, err)
+	}
+
+	// Not every client supports field indexes (for example, the fake client ignores them), so the name is checked here as well.
+	for _, managedEnv := range managedEnvList.Items {
+		if managedEnv.Spec.CASecretRef != nil && managedEnv.Spec.CASecretRef.Name == secret.Name {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 // isFilteredOutNamespace filters out a set of namepaces that are known not to contain
 // Secrets that are used/referenced by the ManagedEnvironment CR.
 // - This is not for security purposes, but rather to reduce the number of K8s API requests when running on OpenShift clusters.
@@ -32,6 +32,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 )
 
 var _ = Describe("Secret Controller Test", func() {
@@ -132,10 +133,92 @@ var _ = Describe("Secret Controller Test", func() {
 
 		})
 
+		It("reconciles on a CA secret, with 1 managed env CR referring to it via caSecretRef", func() {
+			// secret of type TLS, and 1 managed env referring to it as its CA secret
+			// expect: 1
+			credentialsSecret := createSecretForManagedEnv("my-secret", true, *namespace, k8sClient)
+			managedEnv := createManagedEnvTargetingSecret("managed-env", credentialsSecret, *namespace, k8sClient)
+
+			caSecret := corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-ca-secret",
+					Namespace: namespace.Name,
+				},
+				Type: corev1.SecretTypeTLS,
+			}
+			err := k8sClient.Create(context.Background(), &caSecret)
+			Expect(err).ToNot(HaveOccurred())
+
+			managedEnv.Spec.CASecretRef = &managedgitopsv1alpha1.ManagedEnvironmentCASecretRef{Name: caSecret.Name}
+			err = k8sClient.Update(context.Background(), &managedEnv)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: caSecret.Namespace,
+					Name:      caSecret.Name,
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mockProcessor.requestsReceived).Should(HaveLen(1))
+		})
+
+		It("ignores an Opaque secret that is not referenced by the caSecretRef of a managed env CR", func() {
+			// secret of type Opaque, and 1 managed env referring to a different CA secret
+			// expect: 0
+			credentialsSecret := createSecretForManagedEnv("my-secret", true, *namespace, k8sClient)
+			managedEnv := createManagedEnvTargetingSecret("managed-env", credentialsSecret, *namespace, k8sClient)
+
+			managedEnv.Spec.CASecretRef = &managedgitopsv1alpha1.ManagedEnvironmentCASecretRef{Name: "my-ca-secret"}
+			err := k8sClient.Update(context.Background(), &managedEnv)
+			Expect(err).ToNot(HaveOccurred())
+
+			unrelatedSecret := corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "unrelated-secret",
+					Namespace: namespace.Name,
+				},
+				Type: corev1.SecretTypeOpaque,
+			}
+			err = k8sClient.Create(context.Background(), &unrelatedSecret)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = reconciler.Reconcile(context.Background(), ctrl.Request{
+				NamespacedName: types.NamespacedName{
+					Namespace: unrelatedSecret.Namespace,
+					Name:      unrelatedSecret.Name,
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(mockProcessor.requestsReceived).Should(BeEmpty())
+		})
+
 	})
 
 	Context("Test filterManagedEnvSecrets predicate", func() {
-		predicate := filterManagedEnvSecrets()
+		var predicate predicate.Predicate
+
+		BeforeEach(func() {
+			scheme, argocdNamespace, kubesystemNamespace, _, err := tests.GenericTestSetup()
+			Expect(err).ToNot(HaveOccurred())
+
+			// A managed env that refers to the 'test-ca' secret as its CA secret
+			managedEnv := &managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "managed-env",
+					Namespace: "my-user",
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec{
+					APIURL:                   "https://api.fake-unit-test-data.origin-ci-int-gce.dev.rhcloud.com:6443",
+					ClusterCredentialsSecret: "my-secret",
+					CASecretRef:              &managedgitopsv1alpha1.ManagedEnvironmentCASecretRef{Name: "test-ca"},
+				},
+			}
+
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(argocdNamespace, kubesystemNamespace, managedEnv).Build()
+
+			predicate = filterManagedEnvSecrets(k8sClient)
+		})
 
 		assertAllEvents := func(obj client.Object, expected bool) {
 			Expect(predicate.Create(event.CreateEvent{
@@ -171,6 +254,40 @@ var _ = Describe("Secret Controller Test", func() {
 			assertAllEvents(secret, false)
 		})
 
+		It("should return true for all events if the secret is referenced by the caSecretRef of a managed env", func() {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-ca",
+					Namespace: "my-user",
+				},
+				Type: corev1.SecretTypeTLS,
+			}
+			assertAllEvents(secret, true)
+		})
+
+		It("should return false for all events if an Opaque or TLS secret is not referenced by a managed env", func() {
+			for _, secretType := range []corev1.SecretType{corev1.SecretTypeOpaque, corev1.SecretTypeTLS} {
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test",
+						Namespace: "my-user",
+					},
+					Type: secretType,
+				}
+				assertAllEvents(secret, false)
+			}
+
+			// A secret with the same name as the CA secret, but in a different namespace
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-ca",
+					Namespace: "other-user",
+				},
+				Type: corev1.SecretTypeTLS,
+			}
+			assertAllEvents(secret, false)
+		})
+
 		It("should return true for all events if the secret is of type managed-environment", func() {
 			secret := &corev1.Secret{
 				ObjectMeta: metav1.ObjectMeta{
@@ -9,6 +9,7 @@ import (
 	logutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util/log"
 	"github.com/redhat-appstudio/managed-gitops/backend/eventloop/eventlooptypes"
 	"github.com/redhat-appstudio/managed-gitops/backend/eventloop/shared_resource_loop"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -81,6 +82,9 @@ type ApplicationEventQueueLoop struct {
 
 	// Client is a K8s client for accessing GitOps service resources
 	Client client.Client
+
+	// EventRecorder is used to record Kubernetes Events on the resources handled by this Application Event Loop
+	EventRecorder record.EventRecorder
 }
 
 // StartApplicationEventQueueLoop will start the Application Event Loop for the GitOpsDeployment referenced
@@ -94,7 +98,7 @@ func StartApplicationEventQueueLoop(ctx context.Context, aeqlParam ApplicationEv
 		aeqlParam.GitopsDeploymentNamespace,
 		aeqlParam.WorkspaceID,
 		aeqlParam.SharedResourceEventLoop,
-		defaultApplicationEventRunnerFactory{}, // use the default factory
+		defaultApplicationEventRunnerFactory{eventRecorder: aeqlParam.EventRecorder}, // use the default factory
 	)
 }
 
@@ -261,6 +265,15 @@ func processApplicationEventQueueLoopMessage(ctx context.Context, newEvent Reque
 				log.V(logutil.LogLevel_Debug).Info("Ignoring post-shutdown managed environment event")
 			}
 
+		} else if eventLoopMessage.ReqResource == eventlooptypes.GitOpsDeploymentDiffTypeName {
+
+			// Diffs are processed by the deployment runner, as they require the Application of the GitOpsDeployment
+			if !state.deploymentEventRunnerShutdown {
+				state.waitingDeploymentEvents = append(state.waitingDeploymentEvents, &newEvent)
+			} else {
+				log.V(logutil.LogLevel_Debug).Info("Ignoring post-shutdown diff event")
+			}
+
 		} else if eventLoopMessage.EventType == eventlooptypes.UpdateDeploymentStatusTick {
 
 			if !state.deploymentEventRunnerShutdown {
@@ -299,7 +312,8 @@ func processApplicationEventQueueLoopMessage(ctx context.Context, newEvent Reque
 			startNewStatusUpdateTimer(ctx, k8sClient, input, log)
 
 		} else if eventLoopMessage.ReqResource == eventlooptypes.GitOpsDeploymentTypeName ||
-			eventLoopMessage.ReqResource == eventlooptypes.GitOpsDeploymentManagedEnvironmentTypeName {
+			eventLoopMessage.ReqResource == eventlooptypes.GitOpsDeploymentManagedEnvironmentTypeName ||
+			eventLoopMessage.ReqResource == eventlooptypes.GitOpsDeploymentDiffTypeName {
 
 			if state.activeDeploymentEvent.Message.Event != newEvent.Message.Event {
 				log.Error(nil, "SEVERE: unmatched deployment event work item",
@@ -443,15 +457,17 @@ type applicationEventRunnerFactory interface {
 }
 
 type defaultApplicationEventRunnerFactory struct {
+	// eventRecorder is passed to the runners, to record Kubernetes Events
+	eventRecorder record.EventRecorder
 }
 
 var _ applicationEventRunnerFactory = defaultApplicationEventRunnerFactory{}
 
 // createNewApplicationEventLoopRunner is a simple wrapper around the default function.
-func (defaultApplicationEventRunnerFactory) createNewApplicationEventLoopRunner(informWorkCompleteChan chan RequestMessage,
+func (d defaultApplicationEventRunnerFactory) createNewApplicationEventLoopRunner(informWorkCompleteChan chan RequestMessage,
 	sharedResourceEventLoop *shared_resource_loop.SharedResourceEventLoop,
 	gitopsDeplName string, gitopsDeplNamespace string, workspaceID string, debugContext string) chan *eventlooptypes.EventLoopEvent {
 
 	return startNewApplicationEventLoopRunner(informWorkCompleteChan, sharedResourceEventLoop, gitopsDeplName, gitopsDeplNamespace,
-		workspaceID, debugContext)
+		workspaceID, debugContext, d.eventRecorder)
 }
@@ -18,6 +18,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -63,13 +65,14 @@ import (
 
 func startNewApplicationEventLoopRunner(informWorkCompleteChan chan RequestMessage,
 	sharedResourceEventLoop *shared_resource_loop.SharedResourceEventLoop,
-	gitopsDeplName string, gitopsDeplNamespace, workspaceID string, debugContext string) chan *eventlooptypes.EventLoopEvent {
+	gitopsDeplName string, gitopsDeplNamespace, workspaceID string, debugContext string,
+	eventRecorder record.EventRecorder) chan *eventlooptypes.EventLoopEvent {
 
 	inputChannel := make(chan *eventlooptypes.EventLoopEvent)
 
 	go func() {
 		applicationEventLoopRunner(inputChannel, informWorkCompleteChan, sharedResourceEventLoop, gitopsDeplName, gitopsDeplNamespace,
-			workspaceID, debugContext)
+			workspaceID, debugContext, eventRecorder)
 	}()
 
 	return inputChannel
@@ -79,7 +82,7 @@ func startNewApplicationEventLoopRunner(informWorkCompleteChan chan RequestMessa
 func applicationEventLoopRunner(inputChannel chan *eventlooptypes.EventLoopEvent,
 	informWorkCompleteChan chan RequestMessage,
 	sharedResourceEventLoop *shared_resource_loop.SharedResourceEventLoop, gitopsDeploymentName string,
-	gitopsDeploymentNamespace string, namespaceID string, debugContext string) {
+	gitopsDeploymentNamespace string, namespaceID string, debugContext string, eventRecorder record.EventRecorder) {
 
 	outerContext := context.Background()
 	log := log.FromContext(outerContext).
@@ -130,6 +133,7 @@ func applicationEventLoopRunner(inputChannel chan *eventlooptypes.EventLoopEvent
 					log:                     log,
 					workspaceID:             namespaceID,
 					k8sClientFactory:        shared_resource_loop.DefaultK8sClientFactory{},
+					eventRecorder:           eventRecorder,
 				}
 
 				var err error
@@ -169,6 +173,11 @@ func applicationEventLoopRunner(inputChannel chan *eventlooptypes.EventLoopEvent
 
 					signalledShutdown, err = handleManagedEnvironmentModified(ctx, gitopsDeploymentName, newEvent, action, dbQueriesUnscoped, log)
 
+				} else if newEvent.EventType == eventlooptypes.DiffModified {
+
+					// Handle all GitOpsDeploymentDiff related events
+					err = action.applicationEventRunner_handleDiffModified(ctx, scopedDBQueries)
+
 				} else {
 					log.Error(nil, "SEVERE: Unrecognized event type", "event type", newEvent.EventType)
 				}
@@ -343,6 +352,7 @@ func handleManagedEnvironmentModified(ctx context.Context, expectedResourceName
 			log:                     action.log,
 			workspaceID:             action.workspaceID,
 			k8sClientFactory:        shared_resource_loop.DefaultK8sClientFactory{},
+			eventRecorder:           action.eventRecorder,
 		}
 
 		signalledShutdown, err := handleDeploymentModified(ctx, newEvent, newAction, dbQueries, log)
@@ -357,6 +367,12 @@ func handleManagedEnvironmentModified(ctx context.Context, expectedResourceName
 func handleDeploymentModified(ctx context.Context, newEvent *eventlooptypes.EventLoopEvent, action applicationEventLoopRunner_Action,
 	scopedDBQueries db.ApplicationScopedQueries, log logr.Logger) (bool, error) {
 
+	// Retrieve the GitOpsDeployment before it is processed: the status will reflect (at least) this generation of it.
+	processedGitOpsDepl, clientError := getMatchingGitOpsDeployment(ctx, newEvent.Request.Name, newEvent.Request.Namespace, newEvent.Client)
+	if clientError != nil && !apierr.IsNotFound(clientError) {
+		return false, fmt.Errorf("couldn't fetch the GitOpsDeployment instance: %v", clientError)
+	}
+
 	// Handle all GitOpsDeployment related events
 	signalledShutdown, _, _, _, err := action.applicationEventRunner_handleDeploymentModified(ctx, scopedDBQueries)
 
@@ -378,12 +394,32 @@ func handleDeploymentModified(ctx context.Context, newEvent *eventlooptypes.Even
 	conditionManager := condition.NewConditionManager()
 	adapter := newGitOpsDeploymentAdapter(gitopsDepl, log, newEvent.Client, conditionManager, ctx)
 
+	// Conditions that were written by an earlier version of the GitOps Service must be normalized before they can be
+	// updated: this is done on the first reconcile of each GitOpsDeployment.
+	if normalizeError := adapter.normalizeLegacyConditions(); normalizeError != nil {
+		return false, normalizeError
+	}
+
 	// Plug any conditions based on the "err" msg
 	if setConditionError := adapter.setGitOpsDeploymentCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionErrorOccurred,
 		managedgitopsv1alpha1.GitopsDeploymentReasonErrorOccurred, err); setConditionError != nil {
 		return false, setConditionError
 	}
 
+	// Report the generation of the GitOpsDeployment that was processed, unless it was since deleted and recreated
+	if processedGitOpsDepl.UID == gitopsDepl.UID {
+		if setGenerationError := adapter.setObservedGeneration(processedGitOpsDepl.Generation); setGenerationError != nil {
+			return false, setGenerationError
+		}
+
+		// A change to .spec.suspend is reported as soon as it is applied, rather than on the next Application status update
+		if err == nil && processedGitOpsDepl.Spec.Suspend == gitopsDepl.Spec.Suspend {
+			if setSuspendedError := adapter.setSuspendedCondition(); setSuspendedError != nil {
+				return false, setSuspendedError
+			}
+		}
+	}
+
 	if err == nil {
 		return signalledShutdown, nil
 	} else {
@@ -422,4 +458,17 @@ type applicationEventLoopRunner_Action struct {
 
 	// k8sClientFactory enabled the creation of K8s API clients to target various environments
 	k8sClientFactory shared_resource_loop.SRLK8sClientFactory
+
+	// eventRecorder is used to record Kubernetes Events on the GitOpsDeployment/GitOpsDeploymentSyncRun resources,
+	// as they move through their lifecycle (for example, when a sync starts or fails).
+	// - May be nil (for example, in unit tests), in which case no events are recorded.
+	eventRecorder record.EventRecorder
+}
+
+// recordEvent records a Kubernetes Event on the given object, if an EventRecorder is available.
+func (a applicationEventLoopRunner_Action) recordEvent(object runtime.Object, eventType string, reason string, messageFmt string, args ...interface{}) {
+	if a.eventRecorder == nil {
+		return
+	}
+	a.eventRecorder.Eventf(object, eventType, reason, messageFmt, args...)
 }
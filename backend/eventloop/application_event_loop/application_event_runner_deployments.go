@@ -27,6 +27,7 @@ import (
 	goyaml "gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
@@ -42,8 +43,9 @@ const (
 	deploymentModifiedResult_Updated  deploymentModifiedResult = "updatedApp"
 	deploymentModifiedResult_NoChange deploymentModifiedResult = "noChangeInApp"
 
-	prunePropagationPolicy = "PrunePropagationPolicy=background"
-	appProjectPrefix       = "app-project-"
+	prunePropagationPolicy       = "PrunePropagationPolicy=background"
+	prunePropagationPolicyPrefix = "PrunePropagationPolicy="
+	appProjectPrefix             = "app-project-"
 )
 
 // This file is responsible for processing events related to GitOpsDeployment CR.
@@ -112,7 +114,23 @@ func (a *applicationEventLoopRunner_Action) applicationEventRunner_handleDeploym
 	if !isGitOpsDeploymentDeleted(gitopsDeployment) {
 		// Perform basic validation of GitOpsDeployment values
 
-		if gitopsDeployment.Spec.Source.Path == "" {
+		if gitopsDeployment.Spec.HasMultipleSources() {
+
+			for _, source := range gitopsDeployment.Spec.Sources {
+				// Sources that only provide a 'ref' (for example, to a repository of Helm values) do not require a path
+				if source.Path == "" && source.Ref == "" {
+					userError := managedgitopsv1alpha1.GitOpsDeploymentUserError_SourcesPathIsRequired
+					return signalledShutdown_false, nil, nil, deploymentModifiedResult_Failed,
+						gitopserrors.NewUserDevError(userError, fmt.Errorf(userError))
+
+				} else if source.Path == "/" {
+					userError := managedgitopsv1alpha1.GitOpsDeploymentUserError_SourcesInvalidPathSlash
+					return signalledShutdown_false, nil, nil, deploymentModifiedResult_Failed,
+						gitopserrors.NewUserDevError(userError, fmt.Errorf(userError))
+				}
+			}
+
+		} else if gitopsDeployment.Spec.Source.Path == "" {
 			userError := managedgitopsv1alpha1.GitOpsDeploymentUserError_PathIsRequired
 			return signalledShutdown_false, nil, nil, deploymentModifiedResult_Failed,
 				gitopserrors.NewUserDevError(userError, fmt.Errorf(userError))
@@ -187,6 +205,10 @@ func (a *applicationEventLoopRunner_Action) applicationEventRunner_handleDeploym
 			application, gitopsEngineInstance, deplModifiedResult, err :=
 				a.handleNewGitOpsDeplEvent(ctx, *gitopsDeployment, clusterUser, dbQueries)
 
+			if err == nil {
+				err = a.handleRefreshRequest(ctx, gitopsDeployment, application, gitopsEngineInstance, clusterUser, dbQueries)
+			}
+
 			// Since the GitOpsDeployment still exists, don't signal shutdown
 			return signalledShutdown_false, application, gitopsEngineInstance, deplModifiedResult, err
 
@@ -196,6 +218,10 @@ func (a *applicationEventLoopRunner_Action) applicationEventRunner_handleDeploym
 			application, gitopsEngineInstance, deplModifiedResult, err := a.handleUpdatedGitOpsDeplEvent(ctx, currentDeplToAppMapping,
 				*gitopsDeployment, clusterUser, dbQueries)
 
+			if err == nil {
+				err = a.handleRefreshRequest(ctx, gitopsDeployment, application, gitopsEngineInstance, clusterUser, dbQueries)
+			}
+
 			// Since the GitOpsDeployment still exists, don't signal shutdown
 			return signalledShutdown_false, application, gitopsEngineInstance, deplModifiedResult, err
 		}
@@ -273,28 +299,9 @@ func (a applicationEventLoopRunner_Action) handleNewGitOpsDeplEvent(ctx context.
 		return nil, nil, deploymentModifiedResult_Failed, gitopserrors.NewUserDevError(userError, devError)
 	}
 
-	// Create AppProjectRepository row based on GitopsDeployment and
-	// The RepositoryCredentialsID field is nil when creating an AppProjectRepository based on GitopsDeployment because the value of AppProjectRepository is generated based on an Application.
-	appProjectRepoCredDB := db.AppProjectRepository{
-		Clusteruser_id:          clusterUser.Clusteruser_id,
-		RepositorycredentialsID: "",
-		RepoURL:                 sharedloop.NormalizeGitURL(gitopsDeployment.Spec.Source.RepoURL),
-	}
-
-	if err := dbQueries.GetAppProjectRepositoryByClusterUserAndRepoURL(ctx, &appProjectRepoCredDB); err != nil {
-		if db.IsResultNotFoundError(err) {
-			if err := dbQueries.CreateAppProjectRepository(ctx, &appProjectRepoCredDB); err != nil {
-				a.log.Error(err, "Unable to create appProjectRepository based on GitopsDeployment", appProjectRepoCredDB.GetAsLogKeyValues()...)
-
-				return nil, nil, deploymentModifiedResult_Failed, gitopserrors.NewDevOnlyError(err)
-			}
-
-			a.log.Info("Created new AppProjectRepository in DB based on GitopsDeployment: "+appProjectRepoCredDB.AppprojectRepositoryID, appProjectRepoCredDB.GetAsLogKeyValues()...)
-		} else {
-			a.log.Error(err, "Unable to retrieve AppProjectRepository from database based on GitopsDeployment: "+appProjectRepoCredDB.AppprojectRepositoryID, appProjectRepoCredDB.GetAsLogKeyValues()...)
-
-			return nil, nil, deploymentModifiedResult_Failed, gitopserrors.NewDevOnlyError(err)
-		}
+	// Create AppProjectRepository rows based on the source(s) of the GitopsDeployment
+	if err := a.ensureAppProjectRepositoriesExist(ctx, gitopsDeployment.Spec, *clusterUser, dbQueries); err != nil {
+		return nil, nil, deploymentModifiedResult_Failed, gitopserrors.NewDevOnlyError(err)
 	}
 
 	specFieldInput := argoCDSpecInput{
@@ -306,9 +313,14 @@ func (a applicationEventLoopRunner_Action) handleNewGitOpsDeplEvent(ctx context.
 		sourceRepoURL:        gitopsDeployment.Spec.Source.RepoURL,
 		sourcePath:           gitopsDeployment.Spec.Source.Path,
 		sourceTargetRevision: gitopsDeployment.Spec.Source.TargetRevision,
+		sourceHelm:           gitopsDeployment.Spec.Source.Helm,
+		sourceKustomize:      gitopsDeployment.Spec.Source.Kustomize,
+		sources:              gitopsDeployment.Spec.Sources,
+		ignoreDifferences:    gitopsDeployment.Spec.IgnoreDifferences,
 		// syncOptions:       if non-empty, it gets updated below.
-		automated: strings.EqualFold(gitopsDeployment.Spec.Type, managedgitopsv1alpha1.GitOpsDeploymentSpecType_Automated),
-		project:   appProjectPrefix + appProjectRepoCredDB.Clusteruser_id,
+		automated:      isAutomatedSyncEnabled(gitopsDeployment),
+		project:        appProjectPrefix + clusterUser.Clusteruser_id,
+		deletionPolicy: string(gitopsDeployment.Spec.DeletionPolicy),
 	}
 
 	// If AppProject-based isolation is disabled, then just default to using 'default' as the project field in the Argo CD Application
@@ -327,6 +339,12 @@ func (a applicationEventLoopRunner_Action) handleNewGitOpsDeplEvent(ctx context.
 
 	}
 
+	if gitopsDeployment.Spec.SyncPolicy != nil {
+		specFieldInput.syncPolicyAutomated = gitopsDeployment.Spec.SyncPolicy.Automated
+		specFieldInput.syncPolicyRetry = gitopsDeployment.Spec.SyncPolicy.Retry
+		specFieldInput.prunePropagationPolicy = string(gitopsDeployment.Spec.SyncPolicy.PrunePropagationPolicy)
+	}
+
 	specFieldText, err := createSpecField(specFieldInput)
 	if err != nil {
 		a.log.Error(err, "SEVERE: unable to marshal generated YAML")
@@ -420,6 +438,9 @@ func (a applicationEventLoopRunner_Action) handleNewGitOpsDeplEvent(ctx context.
 		return nil, nil, deploymentModifiedResult_Failed, gitopserrors.NewDevOnlyError(err)
 	}
 
+	a.recordEvent(&gitopsDeployment, corev1.EventTypeNormal, eventlooptypes.EventReasonApplicationCreated,
+		"Created Argo CD Application '%s'", application.Name)
+
 	return &application, engineInstance, deploymentModifiedResult_Created, nil
 }
 
@@ -458,6 +479,15 @@ func (a applicationEventLoopRunner_Action) handleDeleteGitOpsDeplEvent(ctx conte
 
 		deplToAppMapping := (*deplToAppMappingList)[idx]
 
+		// If the GitOpsDeployment that is being deleted still exists (because of the deletion finalizer), ensure that the
+		// Argo CD Application has its latest deletion policy, before the Application is deleted.
+		if isGitOpsDeploymentDeleted(gitopsDepl) && gitopsDepl != nil && deplToAppMapping.Deploymenttoapplicationmapping_uid_id == string(gitopsDepl.UID) {
+			if err := a.updateDeletionPolicyOfApplication(ctx, gitopsDepl, &deplToAppMapping, clusterUser, apiNamespace, dbQueries); err != nil {
+				a.log.Error(err, "unable to update the deletion policy of the Application", "applicationID", deplToAppMapping.Application_id)
+				return false, gitopserrors.NewDevOnlyError(err)
+			}
+		}
+
 		// Clean up the database entries
 		itemSignalledShutdown, err := a.cleanOldGitOpsDeploymentEntry(ctx, &deplToAppMapping, clusterUser, apiNamespace, dbQueries)
 		if err != nil {
@@ -543,7 +573,7 @@ func (a applicationEventLoopRunner_Action) reconcileManagedEnvironmentOfGitOpsDe
 	// Ask the event loop to ensure that the managed environment exists, is up-to-date, and is valid (can be connected to using k8s client)
 	sharedResourceRes, err := a.sharedResourceEventLoop.ReconcileSharedManagedEnv(ctx, a.workspaceClient, gitopsDeplNamespace,
 		gitopsDeployment.Spec.Destination.Environment, a.eventResourceNamespace, isWorkspaceTarget,
-		a.k8sClientFactory, a.log)
+		a.k8sClientFactory, a.eventRecorder, a.log)
 
 	if err != nil {
 		return nil, nil, "", fmt.Errorf("unable to get or create managed environment when reconciling for GitOpsDeployment: %v", err)
@@ -657,35 +687,9 @@ func (a applicationEventLoopRunner_Action) handleUpdatedGitOpsDeplEvent(ctx cont
 		return nil, nil, deploymentModifiedResult_Failed, gitopserrors.NewUserDevError(userError, devError)
 	}
 
-	// Before updating Application ensure that AppProjectRepository row has been created (if necessary)
-	appProjectRepoCredDB := db.AppProjectRepository{
-		Clusteruser_id: clusterUser.Clusteruser_id,
-		RepoURL:        sharedloop.NormalizeGitURL(gitopsDeployment.Spec.Source.RepoURL),
-	}
-
-	if err := dbQueries.GetAppProjectRepositoryByClusterUserAndRepoURL(ctx, &appProjectRepoCredDB); err != nil {
-
-		// If AppProjectRepository is not present in DB, create it.
-		if db.IsResultNotFoundError(err) {
-			appProjectRepoCredDB := db.AppProjectRepository{
-				Clusteruser_id:          clusterUser.Clusteruser_id,
-				RepositorycredentialsID: "",
-				RepoURL:                 sharedloop.NormalizeGitURL(gitopsDeployment.Spec.Source.RepoURL),
-			}
-
-			if err := dbQueries.CreateAppProjectRepository(ctx, &appProjectRepoCredDB); err != nil {
-				a.log.Error(err, "Unable to create appProjectRepository based on GitopsDeployment", appProjectRepoCredDB.GetAsLogKeyValues()...)
-
-				return nil, nil, deploymentModifiedResult_Failed, gitopserrors.NewDevOnlyError(err)
-			}
-
-			a.log.Info("Created new AppProjectRepository in DB based on GitopsDeployment: "+appProjectRepoCredDB.AppprojectRepositoryID, appProjectRepoCredDB.GetAsLogKeyValues()...)
-
-		} else {
-			a.log.Error(err, "Unable to retrieve appProjectRepository based on GitopsDeployment", appProjectRepoCredDB.GetAsLogKeyValues()...)
-			return nil, nil, deploymentModifiedResult_Failed, gitopserrors.NewDevOnlyError(err)
-		}
-
+	// Before updating Application ensure that AppProjectRepository rows have been created (if necessary)
+	if err := a.ensureAppProjectRepositoriesExist(ctx, gitopsDeployment.Spec, *clusterUser, dbQueries); err != nil {
+		return nil, nil, deploymentModifiedResult_Failed, gitopserrors.NewDevOnlyError(err)
 	}
 
 	specFieldInput := argoCDSpecInput{
@@ -696,9 +700,14 @@ func (a applicationEventLoopRunner_Action) handleUpdatedGitOpsDeplEvent(ctx cont
 		sourceRepoURL:        gitopsDeployment.Spec.Source.RepoURL,
 		sourcePath:           gitopsDeployment.Spec.Source.Path,
 		sourceTargetRevision: gitopsDeployment.Spec.Source.TargetRevision,
+		sourceHelm:           gitopsDeployment.Spec.Source.Helm,
+		sourceKustomize:      gitopsDeployment.Spec.Source.Kustomize,
+		sources:              gitopsDeployment.Spec.Sources,
+		ignoreDifferences:    gitopsDeployment.Spec.IgnoreDifferences,
 		// syncOptions:       if non-empty, it gets updated below.
-		automated: strings.EqualFold(gitopsDeployment.Spec.Type, managedgitopsv1alpha1.GitOpsDeploymentSpecType_Automated),
-		project:   appProjectPrefix + appProjectRepoCredDB.Clusteruser_id,
+		automated:      isAutomatedSyncEnabled(gitopsDeployment),
+		project:        appProjectPrefix + clusterUser.Clusteruser_id,
+		deletionPolicy: string(gitopsDeployment.Spec.DeletionPolicy),
 	}
 
 	// If AppProject-based isolation is disabled, then just default to using 'default' as the project field in the Argo CD Application
@@ -713,6 +722,13 @@ func (a applicationEventLoopRunner_Action) handleUpdatedGitOpsDeplEvent(ctx cont
 
 		specFieldInput.syncOptions = managedgitopsv1alpha1.SyncOptionToStringSlice(gitopsDeployment.Spec.SyncPolicy.SyncOptions)
 	}
+
+	if gitopsDeployment.Spec.SyncPolicy != nil {
+		specFieldInput.syncPolicyAutomated = gitopsDeployment.Spec.SyncPolicy.Automated
+		specFieldInput.syncPolicyRetry = gitopsDeployment.Spec.SyncPolicy.Retry
+		specFieldInput.prunePropagationPolicy = string(gitopsDeployment.Spec.SyncPolicy.PrunePropagationPolicy)
+	}
+
 	shouldUpdateApplication := false
 
 	// If the spec field changed from what is in the database, we should update the application
@@ -809,10 +825,204 @@ func (a applicationEventLoopRunner_Action) handleUpdatedGitOpsDeplEvent(ctx cont
 		return nil, nil, deploymentModifiedResult_Failed, gitopserrors.NewDevOnlyError(err)
 	}
 
+	a.recordEvent(&gitopsDeployment, corev1.EventTypeNormal, eventlooptypes.EventReasonApplicationUpdated,
+		"Updated Argo CD Application '%s'", application.Name)
+
 	return application, engineInstance, deploymentModifiedResult_Updated, nil
 
 }
 
+// updateDeletionPolicyOfApplication ensures that the Application row (and thus the corresponding Argo CD Application) has the
+// deletion policy of the GitOpsDeployment. This is required as the deletion policy may have been changed immediately
+// before the GitOpsDeployment was deleted, in which case the change will not yet have been applied to the Application.
+func (a applicationEventLoopRunner_Action) updateDeletionPolicyOfApplication(ctx context.Context, gitopsDepl *managedgitopsv1alpha1.GitOpsDeployment,
+	deplToAppMapping *db.DeploymentToApplicationMapping, clusterUser *db.ClusterUser, apiNamespace corev1.Namespace, dbQueries db.ApplicationScopedQueries) error {
+
+	log := a.log.WithValues("applicationID", deplToAppMapping.Application_id)
+
+	dbApplication := db.Application{
+		Application_id: deplToAppMapping.Application_id,
+	}
+	if err := dbQueries.GetApplicationById(ctx, &dbApplication); err != nil {
+		if db.IsResultNotFoundError(err) {
+			// The Application no longer exists, so there is nothing to update
+			return nil
+		}
+		return err
+	}
+
+	specFieldApp := fauxargocd.FauxApplication{}
+	if err := goyaml.Unmarshal([]byte(dbApplication.Spec_field), &specFieldApp); err != nil {
+		log.Error(err, "SEVERE: unable to unmarshal DB application spec field")
+		return err
+	}
+
+	deletionPolicy := string(gitopsDepl.Spec.DeletionPolicy)
+	if specFieldApp.Annotations[argosharedutil.ArgoCDApplicationDeletionPolicyAnnotation] == deletionPolicy {
+		// No change required
+		return nil
+	}
+
+	if deletionPolicy == "" {
+		specFieldApp.Annotations = nil
+	} else {
+		specFieldApp.Annotations = map[string]string{
+			argosharedutil.ArgoCDApplicationDeletionPolicyAnnotation: deletionPolicy,
+		}
+	}
+
+	specFieldBytes, err := goyaml.Marshal(specFieldApp)
+	if err != nil {
+		log.Error(err, "SEVERE: unable to marshal DB application spec field")
+		return err
+	}
+	dbApplication.Spec_field = string(specFieldBytes)
+
+	if err := dbQueries.UpdateApplication(ctx, &dbApplication); err != nil {
+		log.Error(err, "unable to update the deletion policy of the Application")
+		return err
+	}
+	log.Info("Updated the deletion policy of the Application, before deleting it", "deletionPolicy", deletionPolicy)
+
+	// Inform the cluster-agent of the change to the Application, and wait for it to be applied
+	gitopsEngineInstance, err := a.sharedResourceEventLoop.GetGitopsEngineInstanceById(ctx, dbApplication.Engine_instance_inst_id, a.workspaceClient, apiNamespace, a.log)
+	if err != nil {
+		log.Error(err, "unable to retrieve gitopsengineinstance", "gitopsEngineID", dbApplication.Engine_instance_inst_id)
+		return err
+	}
+	if gitopsEngineInstance == nil {
+		err = fmt.Errorf("gitopsengineinstance is nil, expected non-nil:  %v", gitopsEngineInstance)
+		log.Error(err, "unexpected nil value of required objects")
+		return err
+	}
+
+	gitopsEngineClient, err := a.k8sClientFactory.GetK8sClientForGitOpsEngineInstance(ctx, gitopsEngineInstance)
+	if err != nil {
+		log.Error(err, "could not retrieve client for gitops engine instance", "instance", gitopsEngineInstance.Gitopsengineinstance_id)
+		return err
+	}
+
+	dbOperationInput := db.Operation{
+		Instance_id:   dbApplication.Engine_instance_inst_id,
+		Resource_id:   dbApplication.Application_id,
+		Resource_type: db.OperationResourceType_Application,
+	}
+
+	waitForOperation := !a.testOnlySkipCreateOperation // if it's for a unit test, we don't wait for the operation
+	k8sOperation, dbOperation, err := operations.CreateOperation(ctx, waitForOperation, dbOperationInput,
+		clusterUser.Clusteruser_id, gitopsEngineInstance.Namespace_name, dbQueries, gitopsEngineClient, log)
+	if err != nil {
+		log.Error(err, "unable to create operation", "operation", dbOperationInput.ShortString())
+		return err
+	}
+
+	if err := operations.CleanupOperation(ctx, *dbOperation, *k8sOperation, dbQueries, gitopsEngineClient, !a.testOnlySkipCreateOperation, log); err != nil {
+		log.Error(err, "unable to cleanup operation", "operation", dbOperationInput.ShortString())
+		return err
+	}
+
+	return nil
+}
+
+// handleRefreshRequest handles a refresh of the GitOpsDeployment, if one was requested via the refresh annotation.
+// The cluster-agent is informed of the request (via Operation), and once the Argo CD Application has been refreshed,
+// the annotation is removed from the GitOpsDeployment, and the time of the refresh is reported in its status.
+func (a applicationEventLoopRunner_Action) handleRefreshRequest(ctx context.Context, gitopsDeployment *managedgitopsv1alpha1.GitOpsDeployment,
+	application *db.Application, gitopsEngineInstance *db.GitopsEngineInstance, clusterUser *db.ClusterUser,
+	dbQueries db.ApplicationScopedQueries) gitopserrors.UserError {
+
+	refreshType, exists := gitopsDeployment.Annotations[managedgitopsv1alpha1.AnnotationKeyRefresh]
+	if !exists || application == nil || gitopsEngineInstance == nil {
+		return nil
+	}
+
+	var resourceType db.OperationResourceType
+	switch managedgitopsv1alpha1.RefreshType(refreshType) {
+	case managedgitopsv1alpha1.RefreshType_Normal:
+		resourceType = db.OperationResourceType_ApplicationRefresh
+	case managedgitopsv1alpha1.RefreshType_Hard:
+		resourceType = db.OperationResourceType_ApplicationHardRefresh
+	default:
+		userError := fmt.Sprintf(managedgitopsv1alpha1.GitOpsDeploymentUserError_InvalidRefreshType, refreshType)
+		return gitopserrors.NewUserDevError(userError, fmt.Errorf(userError))
+	}
+
+	log := a.log.WithValues("applicationID", application.Application_id, "refreshType", refreshType)
+
+	gitopsEngineClient, err := a.k8sClientFactory.GetK8sClientForGitOpsEngineInstance(ctx, gitopsEngineInstance)
+	if err != nil {
+		log.Error(err, "could not retrieve client for gitops engine instance", "instance", gitopsEngineInstance.Gitopsengineinstance_id)
+		return gitopserrors.NewDevOnlyError(err)
+	}
+
+	dbOperationInput := db.Operation{
+		Instance_id:   gitopsEngineInstance.Gitopsengineinstance_id,
+		Resource_id:   application.Application_id,
+		Resource_type: resourceType,
+	}
+
+	waitForOperation := !a.testOnlySkipCreateOperation // if it's for a unit test, we don't wait for the operation
+	k8sOperation, dbOperation, err := operations.CreateOperation(ctx, waitForOperation, dbOperationInput,
+		clusterUser.Clusteruser_id, gitopsEngineInstance.Namespace_name, dbQueries, gitopsEngineClient, log)
+	if err != nil {
+		log.Error(err, "unable to create operation", "operation", dbOperationInput.ShortString())
+		return gitopserrors.NewDevOnlyError(err)
+	}
+
+	if err := operations.CleanupOperation(ctx, *dbOperation, *k8sOperation, dbQueries, gitopsEngineClient, !a.testOnlySkipCreateOperation, log); err != nil {
+		log.Error(err, "unable to cleanup operation", "operation", dbOperationInput.ShortString())
+		return gitopserrors.NewDevOnlyError(err)
+	}
+
+	if dbOperation.State == db.OperationState_Failed {
+		userError := "unable to refresh the GitOpsDeployment: " + dbOperation.Human_readable_state
+		return gitopserrors.NewUserDevError(userError, fmt.Errorf(userError))
+	}
+
+	log.Info("Refreshed the Application of the GitOpsDeployment")
+
+	if err := removeRefreshAnnotationAndSetLastRefreshedAt(ctx, a.workspaceClient, gitopsDeployment, refreshType, metav1.Now()); err != nil {
+		log.Error(err, "unable to remove the refresh annotation from the GitOpsDeployment")
+		return gitopserrors.NewDevOnlyError(err)
+	}
+
+	return nil
+}
+
+// removeRefreshAnnotationAndSetLastRefreshedAt removes the refresh annotation from the GitOpsDeployment, and sets the
+// time of the last refresh in its status.
+// - The annotation is only removed if it still has the value that was handled: if it was changed in the meantime,
+// the new value will be handled on the next event.
+func removeRefreshAnnotationAndSetLastRefreshedAt(ctx context.Context, k8sClient client.Client,
+	gitopsDeploymentParam *managedgitopsv1alpha1.GitOpsDeployment, refreshType string, now metav1.Time) error {
+
+	gitopsDeployment := &managedgitopsv1alpha1.GitOpsDeployment{}
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDeploymentParam), gitopsDeployment); err != nil {
+			if apierr.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+
+		if gitopsDeployment.UID != gitopsDeploymentParam.UID {
+			// The GitOpsDeployment was deleted and recreated, so the refresh no longer applies to it
+			return nil
+		}
+
+		if value, exists := gitopsDeployment.Annotations[managedgitopsv1alpha1.AnnotationKeyRefresh]; exists && value == refreshType {
+			delete(gitopsDeployment.Annotations, managedgitopsv1alpha1.AnnotationKeyRefresh)
+			if err := k8sClient.Update(ctx, gitopsDeployment); err != nil {
+				return err
+			}
+		}
+
+		gitopsDeployment.Status.LastRefreshedAt = &now
+		return k8sClient.Status().Update(ctx, gitopsDeployment)
+	})
+}
+
 func (a applicationEventLoopRunner_Action) cleanOldGitOpsDeploymentEntry(ctx context.Context,
 	deplToAppMapping *db.DeploymentToApplicationMapping, clusterUser *db.ClusterUser,
 	apiNamespace corev1.Namespace, dbQueries db.ApplicationScopedQueries) (bool, error) {
@@ -914,20 +1124,23 @@ func (a applicationEventLoopRunner_Action) cleanOldGitOpsDeploymentEntry(ctx con
 		return false, err
 	}
 
-	appProjectRepoCredDB := db.AppProjectRepository{
-		Clusteruser_id: clusterUser.Clusteruser_id,
-		RepoURL:        sharedloop.NormalizeGitURL(specFieldAppFromDB.Spec.Source.RepoURL),
-	}
+	// 5) Remove AppProjectRepository rows from database as GitopsDeployment is deleted
+	a.log.Info("GitOpsDeployment was deleted, so deleting AppProjectRepository row(s) from database")
+	for _, repoURL := range getSourceRepoURLsOfSpecField(specFieldAppFromDB) {
 
-	// 5) Remove AppProjectRepository from database as GitopsDeployment is deleted
-	a.log.Info("GitOpsDeployment was deleted, so deleting AppProjectRepository row from database")
-	rowsDeleted, err = dbQueries.DeleteAppProjectRepositoryByClusterUserAndRepoURL(ctx, &appProjectRepoCredDB)
-	if err != nil {
-		// Log the error, but continue
-		log.Error(err, "unable to delete appProject by cluster_user_id and repoURL")
-	} else if rowsDeleted == 0 {
-		// Log the error, but continue
-		log.V(logutil.LogLevel_Warn).Error(nil, "unexpected number of rows deleted for appProject", "rowsDeleted", rowsDeleted)
+		appProjectRepoCredDB := db.AppProjectRepository{
+			Clusteruser_id: clusterUser.Clusteruser_id,
+			RepoURL:        repoURL,
+		}
+
+		rowsDeleted, err = dbQueries.DeleteAppProjectRepositoryByClusterUserAndRepoURL(ctx, &appProjectRepoCredDB)
+		if err != nil {
+			// Log the error, but continue
+			log.Error(err, "unable to delete appProject by cluster_user_id and repoURL")
+		} else if rowsDeleted == 0 {
+			// Log the error, but continue
+			log.V(logutil.LogLevel_Warn).Error(nil, "unexpected number of rows deleted for appProject", "rowsDeleted", rowsDeleted)
+		}
 	}
 
 	gitopsEngineInstance, err := a.sharedResourceEventLoop.GetGitopsEngineInstanceById(ctx, dbApplication.Engine_instance_inst_id, a.workspaceClient, apiNamespace, a.log)
@@ -1044,6 +1257,9 @@ func (a *applicationEventLoopRunner_Action) applicationEventRunner_handleUpdateD
 		}
 	}
 
+	// Conditions that were written before .status.conditions was a list of metav1.Conditions cannot be written back as-is
+	normalizeLegacyConditions(gitopsDeployment.Status.Conditions)
+
 	// 4) Update the health and status field of the GitOpsDepl CR
 
 	// Update the gitopsDeployment instance with health and status values (fetched from the database)
@@ -1053,28 +1269,42 @@ func (a *applicationEventLoopRunner_Action) applicationEventRunner_handleUpdateD
 	gitopsDeployment.Status.Sync.Revision = applicationState.Revision
 
 	// We update the GitopsDeployment .status.conditions with the conditions from the Argo CD Application, if the conditions column of ApplicationState row is non empty.
-	newGitopsDeplConditions := []managedgitopsv1alpha1.GitOpsDeploymentCondition{}
+	newApplicationConditions := []managedgitopsv1alpha1.ApplicationCondition{}
 	if len(applicationState.Conditions) != 0 {
-		if err := yaml.Unmarshal(applicationState.Conditions, &newGitopsDeplConditions); err != nil {
+		if err := yaml.Unmarshal(applicationState.Conditions, &newApplicationConditions); err != nil {
 			log.Error(err, "failed to unmarshal ApplicationState conditions")
 			return crUpdated_false, err
 		}
 	}
 
 	conditionManager := condition.NewConditionManager()
-	for _, c := range newGitopsDeplConditions {
+	for _, c := range newApplicationConditions {
 		// If the new condition already exists, then update it with the latest values.
-		conditionManager.SetCondition(&gitopsDeployment.Status.Conditions, c.Type, managedgitopsv1alpha1.GitOpsConditionStatusTrue, managedgitopsv1alpha1.GitOpsDeploymentReasonType(c.Type), c.Message)
+		conditionManager.SetCondition(&gitopsDeployment.Status.Conditions, managedgitopsv1alpha1.GitOpsDeploymentConditionType(c.Type),
+			managedgitopsv1alpha1.GitOpsConditionStatusTrue, managedgitopsv1alpha1.GitOpsDeploymentReasonType(c.Type), c.Message)
 	}
 
 	// Go through the existing conditions and check if they are present in the list of new conditions. If they are absent then it can marked as resolved.
 	for _, c := range gitopsDeployment.Status.Conditions {
+		conditionType := managedgitopsv1alpha1.GitOpsDeploymentConditionType(c.Type)
+
+		// These conditions are not Argo CD Application conditions: they are handled below, or (for
+		// ManagedEnvironmentReachable) by the ManagedEnvironment prober.
+		if conditionType == managedgitopsv1alpha1.GitOpsDeploymentConditionSuspended ||
+			conditionType == managedgitopsv1alpha1.GitOpsDeploymentConditionRolledBack || isReadinessCondition(conditionType) ||
+			conditionType == managedgitopsv1alpha1.GitOpsDeploymentConditionManagedEnvironmentReachable {
+			continue
+		}
+
 		reason := c.Type + "Resolved"
-		if !conditionManager.HasCondition(&newGitopsDeplConditions, c.Type) && c.Reason != managedgitopsv1alpha1.GitOpsDeploymentReasonType(reason) {
-			conditionManager.SetCondition(&gitopsDeployment.Status.Conditions, c.Type, managedgitopsv1alpha1.GitOpsConditionStatusFalse, managedgitopsv1alpha1.GitOpsDeploymentReasonType(reason), "")
+		if !hasApplicationCondition(newApplicationConditions, c.Type) && c.Reason != reason {
+			conditionManager.SetCondition(&gitopsDeployment.Status.Conditions, conditionType, managedgitopsv1alpha1.GitOpsConditionStatusFalse, managedgitopsv1alpha1.GitOpsDeploymentReasonType(reason), "")
 		}
 	}
 
+	setSuspendedCondition(gitopsDeployment, conditionManager)
+	resolveRolledBackCondition(gitopsDeployment, conditionManager)
+
 	// Fetch the list of resources created by deployment from table and update local gitopsDeployment instance.
 	var err error
 	gitopsDeployment.Status.Resources, err = decompressResourceData(applicationState.Resources)
@@ -1089,6 +1319,12 @@ func (a *applicationEventLoopRunner_Action) applicationEventRunner_handleUpdateD
 		return crUpdated_false, err
 	}
 
+	gitopsDeployment.Status.History, err = decompressDeploymentHistory(applicationState.History)
+	if err != nil {
+		log.Error(err, "unable to decompress history byte array received from table.")
+		return crUpdated_false, err
+	}
+
 	var comparedTo fauxargocd.FauxComparedTo
 	comparedTo, err = retrieveComparedToFieldInApplicationState(applicationState.ReconciledState)
 	if err != nil {
@@ -1118,9 +1354,26 @@ func (a *applicationEventLoopRunner_Action) applicationEventRunner_handleUpdateD
 	gitopsDeployment.Status.ReconciledState.Source.Path = comparedTo.Source.Path
 	gitopsDeployment.Status.ReconciledState.Source.RepoURL = comparedTo.Source.RepoURL
 	gitopsDeployment.Status.ReconciledState.Source.Branch = comparedTo.Source.TargetRevision
+	gitopsDeployment.Status.ReconciledState.Sources = nil
+	for _, source := range comparedTo.Sources {
+		gitopsDeployment.Status.ReconciledState.Sources = append(gitopsDeployment.Status.ReconciledState.Sources,
+			managedgitopsv1alpha1.GitOpsDeploymentSource{
+				Path:    source.Path,
+				RepoURL: source.RepoURL,
+				Branch:  source.TargetRevision,
+			})
+	}
 	gitopsDeployment.Status.ReconciledState.Destination.Name = comparedTo.Destination.Name
 	gitopsDeployment.Status.ReconciledState.Destination.Namespace = comparedTo.Destination.Namespace
 
+	setReadinessConditions(gitopsDeployment)
+
+	// Report the results of the most recent sync to the corresponding GitOpsDeploymentSyncRun, if any.
+	// - An error here should not prevent the GitOpsDeployment status from being updated, so it is only logged.
+	if err := a.updateLatestSyncRunStatus(ctx, gitopsDeployment, gitopsDeployment.Status.OperationState); err != nil {
+		log.Error(err, "unable to update the status of the latest GitOpsDeploymentSyncRun in deploymentStatusTick")
+	}
+
 	// If nothing has changed in the status field, our work is done.
 	if reflect.DeepEqual(gitopsDeployment.Status, originalGitOpsDeployment.Status) {
 		return crUpdated_false, nil
@@ -1133,12 +1386,63 @@ func (a *applicationEventLoopRunner_Action) applicationEventRunner_handleUpdateD
 
 	log.V(logutil.LogLevel_Debug).Info("Updated status in deploymentStatusTick")
 
+	a.recordDeploymentStatusEvents(gitopsDeployment, originalGitOpsDeployment.Status)
+
 	// NOTE: make sure to preserve the existing conditions fields that are in the status field of the CR, when updating the status!
 
 	return crUpdated_true, nil
 
 }
 
+// recordDeploymentStatusEvents records Kubernetes Events on the GitOpsDeployment for any sync or health transitions
+// between the previous status, and the current status, of the GitOpsDeployment.
+func (a applicationEventLoopRunner_Action) recordDeploymentStatusEvents(gitopsDeployment *managedgitopsv1alpha1.GitOpsDeployment,
+	previousStatus managedgitopsv1alpha1.GitOpsDeploymentStatus) {
+
+	// Sync operation transitions
+	if operationState := gitopsDeployment.Status.OperationState; operationState != nil {
+
+		previousOperationState := previousStatus.OperationState
+
+		// A new operation is one that started after the previous operation (if any)
+		isNewOperation := previousOperationState == nil || !operationState.StartedAt.Equal(&previousOperationState.StartedAt)
+
+		if isNewOperation || operationState.Phase != previousOperationState.Phase {
+
+			// A dry-run sync doesn't change the cluster, so the events should not claim that it did.
+			syncType := "Sync"
+			if operationState.Operation.DryRun() {
+				syncType = "Dry-run sync"
+			}
+
+			switch operationState.Phase {
+			case managedgitopsv1alpha1.OperationRunning:
+				a.recordEvent(gitopsDeployment, corev1.EventTypeNormal, eventlooptypes.EventReasonSyncStarted,
+					"%s started", syncType)
+			case managedgitopsv1alpha1.OperationSucceeded:
+				a.recordEvent(gitopsDeployment, corev1.EventTypeNormal, eventlooptypes.EventReasonSyncSucceeded,
+					"%s succeeded to revision '%s'", syncType, gitopsDeployment.Status.Sync.Revision)
+			case managedgitopsv1alpha1.OperationFailed, managedgitopsv1alpha1.OperationError:
+				a.recordEvent(gitopsDeployment, corev1.EventTypeWarning, eventlooptypes.EventReasonSyncFailed,
+					"%s failed: %s", syncType, operationState.Message)
+			}
+		}
+	}
+
+	// Health transitions
+	previousHealth := previousStatus.Health.Status
+	currentHealth := gitopsDeployment.Status.Health.Status
+
+	if currentHealth == managedgitopsv1alpha1.HeathStatusCodeDegraded && previousHealth != managedgitopsv1alpha1.HeathStatusCodeDegraded {
+		a.recordEvent(gitopsDeployment, corev1.EventTypeWarning, eventlooptypes.EventReasonHealthDegraded,
+			"Health degraded: %s", gitopsDeployment.Status.Health.Message)
+
+	} else if currentHealth == managedgitopsv1alpha1.HeathStatusCodeHealthy && previousHealth == managedgitopsv1alpha1.HeathStatusCodeDegraded {
+		a.recordEvent(gitopsDeployment, corev1.EventTypeNormal, eventlooptypes.EventReasonHealthRecovered,
+			"Health recovered")
+	}
+}
+
 // gitOpsDeploymentAdapter is an "adapter" for GitOpsDeployment allowing you to easily plug any other related
 // API component (i.e. for adding Conditions, look at setGitOpsDeploymentCondition() method)
 // Same principle can be used for others, e.g. Finalizers, or any other field which is part of the GitOpsDeployment CRD
@@ -1203,7 +1507,7 @@ func (g *gitOpsDeploymentAdapter) setGitOpsDeploymentCondition(conditionType man
 		if g.conditionManager.HasCondition(conditions, conditionType) {
 			reason = reason + "Resolved"
 			// Check the condition and mark it as resolved, if it's resolved
-			if cond, _ := g.conditionManager.FindCondition(conditions, conditionType); cond.Reason != reason {
+			if cond, _ := g.conditionManager.FindCondition(conditions, conditionType); cond.Reason != string(reason) {
 				g.conditionManager.SetCondition(conditions, conditionType,
 					managedgitopsv1alpha1.GitOpsConditionStatus(corev1.ConditionFalse), reason, "")
 
@@ -1217,6 +1521,275 @@ func (g *gitOpsDeploymentAdapter) setGitOpsDeploymentCondition(conditionType man
 	return nil
 }
 
+// setObservedGeneration sets .status.observedGeneration to the generation of the GitOpsDeployment that was processed by
+// the event loop, and updates the readiness conditions that depend on it.
+func (g *gitOpsDeploymentAdapter) setObservedGeneration(observedGeneration int64) error {
+
+	originalStatus := g.gitOpsDeployment.Status.DeepCopy()
+
+	if observedGeneration > g.gitOpsDeployment.Status.ObservedGeneration {
+		g.gitOpsDeployment.Status.ObservedGeneration = observedGeneration
+	}
+
+	setReadinessConditions(g.gitOpsDeployment)
+
+	if reflect.DeepEqual(*originalStatus, g.gitOpsDeployment.Status) {
+		return nil
+	}
+
+	return g.client.Status().Update(g.ctx, g.gitOpsDeployment, &client.UpdateOptions{})
+}
+
+// normalizeLegacyConditions updates the status of the GitOpsDeployment if any of its conditions were written before
+// .status.conditions was a list of metav1.Conditions: see normalizeLegacyConditions.
+func (g *gitOpsDeploymentAdapter) normalizeLegacyConditions() error {
+
+	if !normalizeLegacyConditions(g.gitOpsDeployment.Status.Conditions) {
+		return nil
+	}
+
+	g.logger.Info("Normalized the legacy conditions of the GitOpsDeployment")
+
+	return g.client.Status().Update(g.ctx, g.gitOpsDeployment, &client.UpdateOptions{})
+}
+
+// normalizeLegacyConditions sets the fields that are required by metav1.Condition, but that were optional in the
+// GitOpsDeploymentCondition type that .status.conditions previously used:
+// - A missing reason is set based on the type and status of the condition, using the same convention as SetCondition
+// of the condition manager (for example, 'ErrorOccurred' if True, and 'ErrorOccurredResolved' if False).
+// - A missing lastTransitionTime is set to the current time.
+// - A missing or unrecognized status, which cannot have come from the condition manager, is set to Unknown.
+//
+// Without this, the status of a GitOpsDeployment that was last updated by an earlier version of the GitOps Service
+// would be rejected by the API server on update. Returns true if any of the conditions were modified.
+func normalizeLegacyConditions(conditions []metav1.Condition) bool {
+
+	modified := false
+
+	for i := range conditions {
+		c := &conditions[i]
+
+		if c.Status != metav1.ConditionTrue && c.Status != metav1.ConditionFalse && c.Status != metav1.ConditionUnknown {
+			c.Status = metav1.ConditionUnknown
+			modified = true
+		}
+
+		if c.Reason == "" {
+			switch c.Status {
+			case metav1.ConditionTrue:
+				c.Reason = c.Type
+			case metav1.ConditionFalse:
+				c.Reason = c.Type + "Resolved"
+			default:
+				c.Reason = string(managedgitopsv1alpha1.GitopsDeploymentReasonStatusUnknown)
+			}
+			modified = true
+		}
+
+		if c.LastTransitionTime.IsZero() {
+			c.LastTransitionTime = metav1.Now()
+			modified = true
+		}
+	}
+
+	return modified
+}
+
+// setSuspendedCondition updates the Suspended condition of the GitOpsDeployment, once a change to .spec.suspend has
+// been applied to the Argo CD Application.
+func (g *gitOpsDeploymentAdapter) setSuspendedCondition() error {
+
+	originalConditions := append([]metav1.Condition{}, g.gitOpsDeployment.Status.Conditions...)
+
+	setSuspendedCondition(g.gitOpsDeployment, g.conditionManager)
+
+	if reflect.DeepEqual(originalConditions, g.gitOpsDeployment.Status.Conditions) {
+		return nil
+	}
+
+	return g.client.Status().Update(g.ctx, g.gitOpsDeployment, &client.UpdateOptions{})
+}
+
+// isReadinessCondition returns true for the conditions that are set by setReadinessConditions.
+func isReadinessCondition(conditionType managedgitopsv1alpha1.GitOpsDeploymentConditionType) bool {
+	return conditionType == managedgitopsv1alpha1.GitOpsDeploymentConditionReady ||
+		conditionType == managedgitopsv1alpha1.GitOpsDeploymentConditionSynced ||
+		conditionType == managedgitopsv1alpha1.GitOpsDeploymentConditionHealthy ||
+		conditionType == managedgitopsv1alpha1.GitOpsDeploymentConditionProgressing
+}
+
+// setReadinessConditions sets the Synced, Healthy, Progressing and Ready conditions of the GitOpsDeployment, based on
+// the rest of its status. The last transition time of a condition is only updated when its status changes.
+func setReadinessConditions(gitopsDeployment *managedgitopsv1alpha1.GitOpsDeployment) {
+
+	status := &gitopsDeployment.Status
+
+	setCondition := func(conditionType managedgitopsv1alpha1.GitOpsDeploymentConditionType, conditionStatus metav1.ConditionStatus,
+		reason managedgitopsv1alpha1.GitOpsDeploymentReasonType, message string) {
+
+		meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+			Type:               string(conditionType),
+			Status:             conditionStatus,
+			Reason:             string(reason),
+			Message:            message,
+			ObservedGeneration: status.ObservedGeneration,
+		})
+	}
+
+	reconciling := status.ObservedGeneration < gitopsDeployment.Generation
+	const reconcilingMessage = "the latest spec of the GitOpsDeployment has not yet been processed"
+
+	// 1) Synced: whether the deployed resources are in sync with the GitOps repository
+	switch status.Sync.Status {
+	case managedgitopsv1alpha1.SyncStatusCodeSynced:
+		setCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionSynced, metav1.ConditionTrue,
+			managedgitopsv1alpha1.GitOpsDeploymentReasonType(status.Sync.Status), "")
+	case managedgitopsv1alpha1.SyncStatusCodeOutOfSync:
+		setCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionSynced, metav1.ConditionFalse,
+			managedgitopsv1alpha1.GitOpsDeploymentReasonType(status.Sync.Status), "the deployed resources are out of sync with the GitOps repository")
+	default:
+		setCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionSynced, metav1.ConditionUnknown,
+			managedgitopsv1alpha1.GitopsDeploymentReasonStatusUnknown, "")
+	}
+
+	// 2) Healthy: whether the deployed resources are healthy
+	switch status.Health.Status {
+	case managedgitopsv1alpha1.HeathStatusCodeHealthy:
+		setCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionHealthy, metav1.ConditionTrue,
+			managedgitopsv1alpha1.GitOpsDeploymentReasonType(status.Health.Status), status.Health.Message)
+	case "", managedgitopsv1alpha1.HeathStatusCodeUnknown:
+		setCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionHealthy, metav1.ConditionUnknown,
+			managedgitopsv1alpha1.GitopsDeploymentReasonStatusUnknown, status.Health.Message)
+	default:
+		setCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionHealthy, metav1.ConditionFalse,
+			managedgitopsv1alpha1.GitOpsDeploymentReasonType(status.Health.Status), status.Health.Message)
+	}
+
+	// 3) Progressing: whether the GitOpsDeployment, or the resources it deploys, are still changing
+	if reconciling {
+		setCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionProgressing, metav1.ConditionTrue,
+			managedgitopsv1alpha1.GitopsDeploymentReasonReconciling, reconcilingMessage)
+
+	} else if status.OperationState != nil && status.OperationState.Phase == managedgitopsv1alpha1.OperationRunning {
+		setCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionProgressing, metav1.ConditionTrue,
+			managedgitopsv1alpha1.GitopsDeploymentReasonSyncRunning, status.OperationState.Message)
+
+	} else if status.Health.Status == managedgitopsv1alpha1.HeathStatusCodeProgressing {
+		setCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionProgressing, metav1.ConditionTrue,
+			managedgitopsv1alpha1.GitopsDeploymentReasonProgressing, status.Health.Message)
+
+	} else {
+		setCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionProgressing, metav1.ConditionFalse,
+			managedgitopsv1alpha1.GitopsDeploymentReasonIdle, "")
+	}
+
+	// 4) Ready: the latest spec was processed without error, and the deployed resources are in sync and healthy
+	errorOccurred := meta.FindStatusCondition(status.Conditions, string(managedgitopsv1alpha1.GitOpsDeploymentConditionErrorOccurred))
+
+	if errorOccurred != nil && errorOccurred.Status == metav1.ConditionTrue {
+		setCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionReady, metav1.ConditionFalse,
+			managedgitopsv1alpha1.GitopsDeploymentReasonErrorOccurred, errorOccurred.Message)
+
+	} else if gitopsDeployment.Spec.Suspend {
+		setCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionReady, metav1.ConditionFalse,
+			managedgitopsv1alpha1.GitopsDeploymentReasonSuspended, managedgitopsv1alpha1.GitOpsDeploymentUserError_Suspended)
+
+	} else if reconciling {
+		setCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionReady, metav1.ConditionFalse,
+			managedgitopsv1alpha1.GitopsDeploymentReasonReconciling, reconcilingMessage)
+
+	} else if status.Sync.Status != managedgitopsv1alpha1.SyncStatusCodeSynced {
+		setCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionReady, metav1.ConditionFalse,
+			managedgitopsv1alpha1.GitopsDeploymentReasonNotSynced,
+			fmt.Sprintf("the deployed resources are not in sync with the GitOps repository (sync status: '%s')", status.Sync.Status))
+
+	} else if status.Health.Status != managedgitopsv1alpha1.HeathStatusCodeHealthy {
+		setCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionReady, metav1.ConditionFalse,
+			managedgitopsv1alpha1.GitopsDeploymentReasonNotHealthy,
+			fmt.Sprintf("the deployed resources are not healthy (health status: '%s')", status.Health.Status))
+
+	} else {
+		setCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionReady, metav1.ConditionTrue,
+			managedgitopsv1alpha1.GitopsDeploymentReasonReady, "")
+	}
+}
+
+// hasApplicationCondition returns true if a condition of the given type was reported by Argo CD.
+func hasApplicationCondition(conditions []managedgitopsv1alpha1.ApplicationCondition, conditionType string) bool {
+	for _, c := range conditions {
+		if string(c.Type) == conditionType {
+			return true
+		}
+	}
+	return false
+}
+
+// setSuspendedCondition sets the Suspended condition of the GitOpsDeployment if it is suspended, or marks the condition as
+// resolved if it is no longer suspended. The condition is only updated if its status or reason has changed.
+func setSuspendedCondition(gitopsDeployment *managedgitopsv1alpha1.GitOpsDeployment, conditionManager condition.Conditions) {
+
+	conditions := &gitopsDeployment.Status.Conditions
+	conditionType := managedgitopsv1alpha1.GitOpsDeploymentConditionSuspended
+
+	if gitopsDeployment.Spec.Suspend {
+		if existing, exists := findExistingCondition(*conditions, conditionType); exists &&
+			existing.Status == metav1.ConditionTrue {
+			return
+		}
+		conditionManager.SetCondition(conditions, conditionType, managedgitopsv1alpha1.GitOpsConditionStatusTrue,
+			managedgitopsv1alpha1.GitopsDeploymentReasonSuspended, managedgitopsv1alpha1.GitOpsDeploymentUserError_Suspended)
+
+	} else if existing, exists := findExistingCondition(*conditions, conditionType); exists &&
+		existing.Status != metav1.ConditionFalse {
+
+		conditionManager.SetCondition(conditions, conditionType, managedgitopsv1alpha1.GitOpsConditionStatusFalse,
+			managedgitopsv1alpha1.GitopsDeploymentReasonSuspended+"Resolved", "")
+	}
+}
+
+// resolveRolledBackCondition marks the RolledBack condition of the GitOpsDeployment as resolved, once the spec of the
+// GitOpsDeployment has changed since it was rolled back (at which point automated sync is re-enabled).
+func resolveRolledBackCondition(gitopsDeployment *managedgitopsv1alpha1.GitOpsDeployment, conditionManager condition.Conditions) {
+
+	conditionType := managedgitopsv1alpha1.GitOpsDeploymentConditionRolledBack
+
+	if existing, exists := findExistingCondition(gitopsDeployment.Status.Conditions, conditionType); exists &&
+		existing.Status == metav1.ConditionTrue && !isRolledBack(*gitopsDeployment) {
+
+		conditionManager.SetCondition(&gitopsDeployment.Status.Conditions, conditionType, managedgitopsv1alpha1.GitOpsConditionStatusFalse,
+			managedgitopsv1alpha1.GitopsDeploymentReasonRolledBack+"Resolved", "")
+	}
+}
+
+// isRolledBack returns true if the GitOpsDeployment was rolled back, and its spec has not changed since.
+func isRolledBack(gitopsDeployment managedgitopsv1alpha1.GitOpsDeployment) bool {
+	rolledBack, exists := findExistingCondition(gitopsDeployment.Status.Conditions, managedgitopsv1alpha1.GitOpsDeploymentConditionRolledBack)
+
+	return exists && rolledBack.Status == metav1.ConditionTrue && rolledBack.ObservedGeneration == gitopsDeployment.Generation
+}
+
+// isAutomatedSyncEnabled returns true if the Argo CD Application of the GitOpsDeployment should be automatically synced.
+// - A suspended GitOpsDeployment should not be automatically synced, so the automated sync policy is removed.
+// - Likewise for a GitOpsDeployment that was rolled back, until its spec changes: otherwise, Argo CD would immediately
+// sync it back to the latest revision.
+func isAutomatedSyncEnabled(gitopsDeployment managedgitopsv1alpha1.GitOpsDeployment) bool {
+	return strings.EqualFold(gitopsDeployment.Spec.Type, managedgitopsv1alpha1.GitOpsDeploymentSpecType_Automated) &&
+		!gitopsDeployment.Spec.Suspend && !isRolledBack(gitopsDeployment)
+}
+
+// findExistingCondition returns the condition of the given type, if it exists. Unlike FindCondition of the condition
+// manager, the condition is not added if it does not exist.
+func findExistingCondition(conditions []metav1.Condition,
+	conditionType managedgitopsv1alpha1.GitOpsDeploymentConditionType) (metav1.Condition, bool) {
+
+	for _, c := range conditions {
+		if c.Type == string(conditionType) {
+			return c, true
+		}
+	}
+	return metav1.Condition{}, false
+}
+
 func checkValidSyncOption(syncOptions []managedgitopsv1alpha1.SyncOption) gitopserrors.UserError {
 
 	for _, syncOptionString := range syncOptions {
@@ -1224,8 +1797,7 @@ func checkValidSyncOption(syncOptions []managedgitopsv1alpha1.SyncOption) gitops
 		match := true
 
 		// Check for SyncOption string
-		if !(syncOptionString == managedgitopsv1alpha1.SyncOptions_CreateNamespace_true ||
-			syncOptionString == managedgitopsv1alpha1.SyncOptions_CreateNamespace_false) {
+		if !managedgitopsv1alpha1.IsSupportedSyncOption(syncOptionString) {
 			match = false
 		}
 
@@ -1251,11 +1823,23 @@ type argoCDSpecInput struct {
 	sourceRepoURL        string
 	sourcePath           string
 	sourceTargetRevision string
-	syncOptions          []string
+	sourceHelm           *managedgitopsv1alpha1.ApplicationSourceHelm
+	sourceKustomize      *managedgitopsv1alpha1.ApplicationSourceKustomize
+	// sources is only set for GitOpsDeployments with multiple sources, in which case the 'source*' fields are empty.
+	sources     []managedgitopsv1alpha1.ApplicationSource
+	syncOptions []string
+	// MAKE SURE YOU SANITIZE ANY NEW FIELDS THAT ARE ADDED!!!!
+	ignoreDifferences []managedgitopsv1alpha1.ResourceIgnoreDifferences
 	// MAKE SURE YOU SANITIZE ANY NEW FIELDS THAT ARE ADDED!!!!
 	automated bool
+	// syncPolicyAutomated and syncPolicyRetry are only used if automated is true. If nil, the defaults are used.
+	syncPolicyAutomated *managedgitopsv1alpha1.SyncPolicyAutomated
+	syncPolicyRetry     *managedgitopsv1alpha1.RetryStrategy
 	// MAKE SURE YOU SANITIZE ANY NEW FIELDS THAT ARE ADDED!!!!
 	project string
+	// prunePropagationPolicy and deletionPolicy are optional: if empty, the defaults are used.
+	prunePropagationPolicy string
+	deletionPolicy         string
 
 	// Hopefully you are getting the message, here :)
 }
@@ -1283,19 +1867,152 @@ func createSpecField(fieldsParam argoCDSpecInput) (string, error) {
 		return res
 	}
 
+	// sanitizeHelm sanitizes all the Helm fields, with the exception of 'values', which is a block of YAML.
+	sanitizeHelm := func(input *managedgitopsv1alpha1.ApplicationSourceHelm) *managedgitopsv1alpha1.ApplicationSourceHelm {
+		if input == nil {
+			return nil
+		}
+		res := &managedgitopsv1alpha1.ApplicationSourceHelm{
+			ValueFiles:  sanitizeArray(input.ValueFiles),
+			ReleaseName: sanitize(input.ReleaseName),
+			Values:      input.Values, // not sanitized: it is only ever marshalled as a YAML string value
+			SkipCrds:    input.SkipCrds,
+		}
+		for _, param := range input.Parameters {
+			res.Parameters = append(res.Parameters, managedgitopsv1alpha1.HelmParameter{
+				Name:        sanitize(param.Name),
+				Value:       sanitize(param.Value),
+				ForceString: param.ForceString,
+			})
+		}
+		return res
+	}
+
+	sanitizeMap := func(input map[string]string) map[string]string {
+		if input == nil {
+			return nil
+		}
+		res := map[string]string{}
+		for key, value := range input {
+			res[sanitize(key)] = sanitize(value)
+		}
+		return res
+	}
+
+	sanitizeKustomizeSelector := func(input *managedgitopsv1alpha1.KustomizeSelector) *managedgitopsv1alpha1.KustomizeSelector {
+		if input == nil {
+			return nil
+		}
+		return &managedgitopsv1alpha1.KustomizeSelector{
+			Group:              sanitize(input.Group),
+			Version:            sanitize(input.Version),
+			Kind:               sanitize(input.Kind),
+			Name:               sanitize(input.Name),
+			Namespace:          sanitize(input.Namespace),
+			AnnotationSelector: sanitize(input.AnnotationSelector),
+			LabelSelector:      sanitize(input.LabelSelector),
+		}
+	}
+
+	// sanitizeKustomize sanitizes all the Kustomize fields, with the exception of the images, the values of the
+	// annotations, and the patches, which may legitimately contain the characters removed by sanitize.
+	sanitizeKustomize := func(input *managedgitopsv1alpha1.ApplicationSourceKustomize) *managedgitopsv1alpha1.ApplicationSourceKustomize {
+		if input == nil {
+			return nil
+		}
+		res := &managedgitopsv1alpha1.ApplicationSourceKustomize{
+			NamePrefix:   sanitize(input.NamePrefix),
+			NameSuffix:   sanitize(input.NameSuffix),
+			Images:       input.Images, // not sanitized: they are only ever marshalled as YAML string values
+			CommonLabels: sanitizeMap(input.CommonLabels),
+		}
+		if input.CommonAnnotations != nil {
+			res.CommonAnnotations = map[string]string{}
+			for key, value := range input.CommonAnnotations {
+				// The value is not sanitized: it is only ever marshalled as a YAML string value
+				res.CommonAnnotations[sanitize(key)] = value
+			}
+		}
+		for _, patch := range input.Patches {
+			res.Patches = append(res.Patches, managedgitopsv1alpha1.KustomizePatch{
+				Patch:  patch.Patch, // not sanitized: it is only ever marshalled as a YAML string value
+				Target: sanitizeKustomizeSelector(patch.Target),
+			})
+		}
+		return res
+	}
+
+	sanitizeSources := func(input []managedgitopsv1alpha1.ApplicationSource) []managedgitopsv1alpha1.ApplicationSource {
+		var res []managedgitopsv1alpha1.ApplicationSource
+		for _, source := range input {
+			res = append(res, managedgitopsv1alpha1.ApplicationSource{
+				RepoURL:        sanitize(source.RepoURL),
+				Path:           sanitize(source.Path),
+				TargetRevision: sanitize(source.TargetRevision),
+				Helm:           sanitizeHelm(source.Helm),
+				Kustomize:      sanitizeKustomize(source.Kustomize),
+				Ref:            sanitize(source.Ref),
+			})
+		}
+		return res
+	}
+
+	sanitizeRetry := func(input *managedgitopsv1alpha1.RetryStrategy) *managedgitopsv1alpha1.RetryStrategy {
+		if input == nil {
+			return nil
+		}
+		res := &managedgitopsv1alpha1.RetryStrategy{
+			Limit: input.Limit,
+		}
+		if input.Backoff != nil {
+			res.Backoff = &managedgitopsv1alpha1.Backoff{
+				Duration:    sanitize(input.Backoff.Duration),
+				Factor:      input.Backoff.Factor,
+				MaxDuration: sanitize(input.Backoff.MaxDuration),
+			}
+		}
+		return res
+	}
+
+	// sanitizeIgnoreDifferences sanitizes all the fields, with the exception of the JQ path expressions, which may
+	// require quotes.
+	sanitizeIgnoreDifferences := func(input []managedgitopsv1alpha1.ResourceIgnoreDifferences) []managedgitopsv1alpha1.ResourceIgnoreDifferences {
+		var res []managedgitopsv1alpha1.ResourceIgnoreDifferences
+		for _, ignoreDifferences := range input {
+			res = append(res, managedgitopsv1alpha1.ResourceIgnoreDifferences{
+				Group:                 sanitize(ignoreDifferences.Group),
+				Kind:                  sanitize(ignoreDifferences.Kind),
+				Name:                  sanitize(ignoreDifferences.Name),
+				Namespace:             sanitize(ignoreDifferences.Namespace),
+				JSONPointers:          sanitizeArray(ignoreDifferences.JSONPointers),
+				JQPathExpressions:     ignoreDifferences.JQPathExpressions, // not sanitized: they are only ever marshalled as YAML string values
+				ManagedFieldsManagers: sanitizeArray(ignoreDifferences.ManagedFieldsManagers),
+			})
+		}
+		return res
+	}
+
 	fields := argoCDSpecInput{
 		// MAKE SURE YOU SANITIZE ANY NEW FIELDS THAT ARE ADDED!!!!
 		crName:               sanitize(fieldsParam.crName),
 		crNamespace:          sanitize(fieldsParam.crNamespace),
 		destinationNamespace: sanitize(fieldsParam.destinationNamespace),
 		// MAKE SURE YOU SANITIZE ANY NEW FIELDS THAT ARE ADDED!!!!
-		destinationName:      sanitize(fieldsParam.destinationName),
-		sourceRepoURL:        sanitize(fieldsParam.sourceRepoURL),
-		sourcePath:           sanitize(fieldsParam.sourcePath),
-		sourceTargetRevision: sanitize(fieldsParam.sourceTargetRevision),
-		syncOptions:          sanitizeArray(fieldsParam.syncOptions),
-		automated:            fieldsParam.automated,
-		project:              sanitize(fieldsParam.project),
+		destinationName:        sanitize(fieldsParam.destinationName),
+		sourceRepoURL:          sanitize(fieldsParam.sourceRepoURL),
+		sourcePath:             sanitize(fieldsParam.sourcePath),
+		sourceTargetRevision:   sanitize(fieldsParam.sourceTargetRevision),
+		sourceHelm:             sanitizeHelm(fieldsParam.sourceHelm),
+		sourceKustomize:        sanitizeKustomize(fieldsParam.sourceKustomize),
+		sources:                sanitizeSources(fieldsParam.sources),
+		ignoreDifferences:      sanitizeIgnoreDifferences(fieldsParam.ignoreDifferences),
+		syncOptions:            sanitizeArray(fieldsParam.syncOptions),
+		automated:              fieldsParam.automated,
+		syncPolicyAutomated:    fieldsParam.syncPolicyAutomated,
+		syncPolicyRetry:        sanitizeRetry(fieldsParam.syncPolicyRetry),
+		project:                sanitize(fieldsParam.project),
+		prunePropagationPolicy: sanitize(fieldsParam.prunePropagationPolicy),
+		deletionPolicy:         sanitize(fieldsParam.deletionPolicy),
 		// MAKE SURE YOU SANITIZE ANY NEW FIELDS THAT ARE ADDED!!!!
 		// Hopefully you are getting the message, here :)
 	}
@@ -1323,23 +2040,57 @@ func createSpecField(fieldsParam argoCDSpecInput) (string, error) {
 		},
 	}
 
+	if fields.deletionPolicy != "" {
+		application.FauxObjectMeta.Annotations = map[string]string{
+			argosharedutil.ArgoCDApplicationDeletionPolicyAnnotation: fields.deletionPolicy,
+		}
+	}
+
+	application.Spec.Source.Helm = convertToFauxApplicationSourceHelm(fields.sourceHelm)
+	application.Spec.Source.Kustomize = convertToFauxApplicationSourceKustomize(fields.sourceKustomize)
+
+	for _, source := range fields.sources {
+		application.Spec.Sources = append(application.Spec.Sources, fauxargocd.ApplicationSource{
+			RepoURL:        source.RepoURL,
+			Path:           source.Path,
+			TargetRevision: source.TargetRevision,
+			Helm:           convertToFauxApplicationSourceHelm(source.Helm),
+			Kustomize:      convertToFauxApplicationSourceKustomize(source.Kustomize),
+			Ref:            source.Ref,
+		})
+	}
+
+	for _, ignoreDifferences := range fields.ignoreDifferences {
+		application.Spec.IgnoreDifferences = append(application.Spec.IgnoreDifferences, fauxargocd.ResourceIgnoreDifferences{
+			Group:                 ignoreDifferences.Group,
+			Kind:                  ignoreDifferences.Kind,
+			Name:                  ignoreDifferences.Name,
+			Namespace:             ignoreDifferences.Namespace,
+			JSONPointers:          ignoreDifferences.JSONPointers,
+			JQPathExpressions:     ignoreDifferences.JQPathExpressions,
+			ManagedFieldsManagers: ignoreDifferences.ManagedFieldsManagers,
+		})
+	}
+
+	syncOptionPrunePropagationPolicy := prunePropagationPolicy
+	if fields.prunePropagationPolicy != "" {
+		syncOptionPrunePropagationPolicy = prunePropagationPolicyPrefix + fields.prunePropagationPolicy
+	}
+
 	if fields.automated {
 		application.Spec.SyncPolicy = &fauxargocd.SyncPolicy{
-			Automated: &fauxargocd.SyncPolicyAutomated{
-				Prune:      true,
-				SelfHeal:   true,
-				AllowEmpty: true,
-			},
+			Automated: convertToFauxSyncPolicyAutomated(fields.syncPolicyAutomated),
 			SyncOptions: fauxargocd.SyncOptions{
-				prunePropagationPolicy,
+				syncOptionPrunePropagationPolicy,
 			},
-			Retry: &fauxargocd.RetryStrategy{
-				Limit: -1,
-				Backoff: &fauxargocd.Backoff{
-					Duration:    "5s",
-					Factor:      getInt64Pointer(2),
-					MaxDuration: "3m",
-				},
+			Retry: convertToFauxRetryStrategy(fields.syncPolicyRetry),
+		}
+
+	} else if fields.prunePropagationPolicy != "" {
+		// The prune propagation policy also applies to manual syncs, so it is only omitted if it was not specified.
+		application.Spec.SyncPolicy = &fauxargocd.SyncPolicy{
+			SyncOptions: fauxargocd.SyncOptions{
+				syncOptionPrunePropagationPolicy,
 			},
 		}
 
@@ -1412,6 +2163,187 @@ func decompressOperationState(operationStateBytes []byte) (*managedgitopsv1alpha
 	return operationState, nil
 }
 
+// Decompress byte array received from table and then convert it into a list of DeploymentHistoryEntry.
+func decompressDeploymentHistory(historyBytes []byte) ([]managedgitopsv1alpha1.DeploymentHistoryEntry, error) {
+	if len(historyBytes) == 0 {
+		return nil, nil
+	}
+
+	var history []managedgitopsv1alpha1.DeploymentHistoryEntry
+
+	objBytes, err := sharedutil.DecompressObject(historyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress history data: %v", err)
+	}
+
+	// Convert byte array to DeploymentHistoryEntry array
+	err = goyaml.Unmarshal(objBytes, &history)
+	if err != nil {
+		return nil, fmt.Errorf("unable to Unmarshal history data: %v", err)
+	}
+
+	return history, nil
+}
+
+// convertToFauxApplicationSourceHelm converts the (already sanitized) Helm options of a GitOpsDeployment source into the Argo CD equivalent
+func convertToFauxApplicationSourceHelm(sourceHelm *managedgitopsv1alpha1.ApplicationSourceHelm) *fauxargocd.ApplicationSourceHelm {
+	if sourceHelm == nil {
+		return nil
+	}
+
+	helm := &fauxargocd.ApplicationSourceHelm{
+		ValueFiles:  sourceHelm.ValueFiles,
+		ReleaseName: sourceHelm.ReleaseName,
+		Values:      sourceHelm.Values,
+		SkipCrds:    sourceHelm.SkipCrds,
+	}
+	for _, param := range sourceHelm.Parameters {
+		helm.Parameters = append(helm.Parameters, fauxargocd.HelmParameter{
+			Name:        param.Name,
+			Value:       param.Value,
+			ForceString: param.ForceString,
+		})
+	}
+	return helm
+}
+
+// convertToFauxApplicationSourceKustomize converts the (already sanitized) Kustomize options of a GitOpsDeployment source into the Argo CD equivalent
+func convertToFauxApplicationSourceKustomize(sourceKustomize *managedgitopsv1alpha1.ApplicationSourceKustomize) *fauxargocd.ApplicationSourceKustomize {
+	if sourceKustomize == nil {
+		return nil
+	}
+
+	res := &fauxargocd.ApplicationSourceKustomize{
+		NamePrefix:        sourceKustomize.NamePrefix,
+		NameSuffix:        sourceKustomize.NameSuffix,
+		Images:            sourceKustomize.Images,
+		CommonLabels:      sourceKustomize.CommonLabels,
+		CommonAnnotations: sourceKustomize.CommonAnnotations,
+	}
+
+	for _, patch := range sourceKustomize.Patches {
+		fauxPatch := fauxargocd.KustomizePatch{
+			Patch: patch.Patch,
+		}
+		if patch.Target != nil {
+			fauxPatch.Target = &fauxargocd.KustomizeSelector{
+				Group:              patch.Target.Group,
+				Version:            patch.Target.Version,
+				Kind:               patch.Target.Kind,
+				Name:               patch.Target.Name,
+				Namespace:          patch.Target.Namespace,
+				AnnotationSelector: patch.Target.AnnotationSelector,
+				LabelSelector:      patch.Target.LabelSelector,
+			}
+		}
+		res.Patches = append(res.Patches, fauxPatch)
+	}
+
+	return res
+}
+
+// convertToFauxSyncPolicyAutomated converts the automated sync policy of a GitOpsDeployment into the Argo CD equivalent.
+// Any fields that are not specified are set to their default value.
+func convertToFauxSyncPolicyAutomated(syncPolicyAutomated *managedgitopsv1alpha1.SyncPolicyAutomated) *fauxargocd.SyncPolicyAutomated {
+
+	res := managedgitopsv1alpha1.DefaultSyncPolicyAutomated()
+
+	if syncPolicyAutomated != nil {
+		if syncPolicyAutomated.Prune != nil {
+			res.Prune = syncPolicyAutomated.Prune
+		}
+		if syncPolicyAutomated.SelfHeal != nil {
+			res.SelfHeal = syncPolicyAutomated.SelfHeal
+		}
+		if syncPolicyAutomated.AllowEmpty != nil {
+			res.AllowEmpty = syncPolicyAutomated.AllowEmpty
+		}
+	}
+
+	return &fauxargocd.SyncPolicyAutomated{
+		Prune:      *res.Prune,
+		SelfHeal:   *res.SelfHeal,
+		AllowEmpty: *res.AllowEmpty,
+	}
+}
+
+// convertToFauxRetryStrategy converts the (already sanitized) retry strategy of a GitOpsDeployment into the Argo CD
+// equivalent. If no retry strategy is specified, the default is used.
+func convertToFauxRetryStrategy(retry *managedgitopsv1alpha1.RetryStrategy) *fauxargocd.RetryStrategy {
+
+	if retry == nil {
+		retry = managedgitopsv1alpha1.DefaultRetryStrategy()
+	}
+
+	res := &fauxargocd.RetryStrategy{
+		Limit: retry.Limit,
+	}
+
+	if retry.Backoff != nil {
+		res.Backoff = &fauxargocd.Backoff{
+			Duration:    retry.Backoff.Duration,
+			Factor:      retry.Backoff.Factor,
+			MaxDuration: retry.Backoff.MaxDuration,
+		}
+	}
+
+	return res
+}
+
+// ensureAppProjectRepositoriesExist creates an AppProjectRepository row for each of the repository URLs of the GitOpsDeployment
+// sources, if one does not already exist. This ensures the repositories are included in the AppProject of the user.
+func (a applicationEventLoopRunner_Action) ensureAppProjectRepositoriesExist(ctx context.Context, spec managedgitopsv1alpha1.GitOpsDeploymentSpec,
+	clusterUser db.ClusterUser, dbQueries db.ApplicationScopedQueries) error {
+
+	for _, source := range spec.GetSources() {
+
+		// The RepositoryCredentialsID field is nil when creating an AppProjectRepository based on GitopsDeployment because the value of AppProjectRepository is generated based on an Application.
+		appProjectRepoCredDB := db.AppProjectRepository{
+			Clusteruser_id:          clusterUser.Clusteruser_id,
+			RepositorycredentialsID: "",
+			RepoURL:                 sharedloop.NormalizeGitURL(source.RepoURL),
+		}
+
+		if err := dbQueries.GetAppProjectRepositoryByClusterUserAndRepoURL(ctx, &appProjectRepoCredDB); err != nil {
+
+			if !db.IsResultNotFoundError(err) {
+				a.log.Error(err, "Unable to retrieve AppProjectRepository from database based on GitopsDeployment", appProjectRepoCredDB.GetAsLogKeyValues()...)
+				return err
+			}
+
+			// If AppProjectRepository is not present in DB, create it.
+			if err := dbQueries.CreateAppProjectRepository(ctx, &appProjectRepoCredDB); err != nil {
+				a.log.Error(err, "Unable to create appProjectRepository based on GitopsDeployment", appProjectRepoCredDB.GetAsLogKeyValues()...)
+				return err
+			}
+
+			a.log.Info("Created new AppProjectRepository in DB based on GitopsDeployment: "+appProjectRepoCredDB.AppprojectRepositoryID, appProjectRepoCredDB.GetAsLogKeyValues()...)
+		}
+	}
+
+	return nil
+}
+
+// getSourceRepoURLsOfSpecField returns the normalized repository URLs of all the sources of an Application spec field
+func getSourceRepoURLsOfSpecField(specFieldApp fauxargocd.FauxApplication) []string {
+
+	if len(specFieldApp.Spec.Sources) == 0 {
+		return []string{sharedloop.NormalizeGitURL(specFieldApp.Spec.Source.RepoURL)}
+	}
+
+	var res []string
+	seen := map[string]bool{}
+	for _, source := range specFieldApp.Spec.Sources {
+		repoURL := sharedloop.NormalizeGitURL(source.RepoURL)
+		// Multiple sources may be sourced from the same repository
+		if !seen[repoURL] {
+			seen[repoURL] = true
+			res = append(res, repoURL)
+		}
+	}
+	return res
+}
+
 func retrieveComparedToFieldInApplicationState(reconciledState string) (fauxargocd.FauxComparedTo, error) {
 	comparedTo := fauxargocd.FauxComparedTo{}
 
@@ -2,7 +2,9 @@ package application_event_loop
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -11,15 +13,21 @@ import (
 	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
 	db "github.com/redhat-appstudio/managed-gitops/backend-shared/db"
 	sharedutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util"
+	argosharedutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util/argocd"
 	"github.com/redhat-appstudio/managed-gitops/backend-shared/util/fauxargocd"
 	"github.com/redhat-appstudio/managed-gitops/backend-shared/util/tests"
+	"github.com/redhat-appstudio/managed-gitops/backend/condition"
 	"github.com/redhat-appstudio/managed-gitops/backend/eventloop/shared_resource_loop"
 	"gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -119,6 +127,343 @@ var _ = Describe("Application Event Runner Deployments", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(application).To(Equal(getValidApplication(true)))
 		})
+
+		It("Input spec with automated enabled should use the automated sync policy and retry strategy of the input, if specified", func() {
+			input := getFakeArgoCDSpecInput(true, false)
+			disabled := false
+			input.syncPolicyAutomated = &managedgitopsv1alpha1.SyncPolicyAutomated{
+				Prune:    &disabled,
+				SelfHeal: &disabled,
+			}
+			input.syncPolicyRetry = &managedgitopsv1alpha1.RetryStrategy{
+				Limit: 3,
+				Backoff: &managedgitopsv1alpha1.Backoff{
+					Duration:    "10s\n",
+					Factor:      getInt64Pointer(3),
+					MaxDuration: "'5m'",
+				},
+			}
+
+			application, err := createSpecField(input)
+			Expect(err).ToNot(HaveOccurred())
+
+			fauxApp := fauxargocd.FauxApplication{}
+			Expect(yaml.Unmarshal([]byte(application), &fauxApp)).To(Succeed())
+
+			By("verifying that unspecified automated fields use the default value")
+			Expect(fauxApp.Spec.SyncPolicy.Automated).To(Equal(&fauxargocd.SyncPolicyAutomated{
+				Prune:      false,
+				SelfHeal:   false,
+				AllowEmpty: true,
+			}))
+			Expect(fauxApp.Spec.SyncPolicy.Retry).To(Equal(&fauxargocd.RetryStrategy{
+				Limit: 3,
+				Backoff: &fauxargocd.Backoff{
+					Duration:    "10s",
+					Factor:      getInt64Pointer(3),
+					MaxDuration: "5m",
+				},
+			}))
+		})
+
+		It("Input spec with automated disabled should ignore the automated sync policy and retry strategy of the input", func() {
+			input := getFakeArgoCDSpecInput(false, false)
+			disabled := false
+			input.syncPolicyAutomated = &managedgitopsv1alpha1.SyncPolicyAutomated{Prune: &disabled}
+			input.syncPolicyRetry = &managedgitopsv1alpha1.RetryStrategy{Limit: 3}
+
+			application, err := createSpecField(input)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(application).To(Equal(getValidApplication(false)))
+		})
+
+		It("Input spec with sync options should forward the sync options to the sync policy", func() {
+			input := getFakeArgoCDSpecInput(false, false)
+			input.syncOptions = managedgitopsv1alpha1.SyncOptionToStringSlice(managedgitopsv1alpha1.SyncOptions{
+				managedgitopsv1alpha1.SyncOptions_ServerSideApply_true,
+				managedgitopsv1alpha1.SyncOptions_PruneLast_true,
+			})
+
+			application, err := createSpecField(input)
+			Expect(err).ToNot(HaveOccurred())
+
+			fauxApp := fauxargocd.FauxApplication{}
+			Expect(yaml.Unmarshal([]byte(application), &fauxApp)).To(Succeed())
+			Expect(fauxApp.Spec.SyncPolicy).ToNot(BeNil())
+			Expect(fauxApp.Spec.SyncPolicy.SyncOptions).To(Equal(fauxargocd.SyncOptions{"ServerSideApply=true", "PruneLast=true"}))
+		})
+
+		It("Input spec with a prune propagation policy should use it for both automated and manual syncs", func() {
+			input := getFakeArgoCDSpecInput(true, false)
+			input.prunePropagationPolicy = string(managedgitopsv1alpha1.PrunePropagationPolicy_Foreground)
+
+			application, err := createSpecField(input)
+			Expect(err).ToNot(HaveOccurred())
+
+			fauxApp := fauxargocd.FauxApplication{}
+			Expect(yaml.Unmarshal([]byte(application), &fauxApp)).To(Succeed())
+			Expect(fauxApp.Spec.SyncPolicy).ToNot(BeNil())
+			Expect(fauxApp.Spec.SyncPolicy.SyncOptions).To(Equal(fauxargocd.SyncOptions{"PrunePropagationPolicy=foreground"}))
+
+			input = getFakeArgoCDSpecInput(false, false)
+			input.prunePropagationPolicy = string(managedgitopsv1alpha1.PrunePropagationPolicy_Orphan)
+
+			application, err = createSpecField(input)
+			Expect(err).ToNot(HaveOccurred())
+
+			fauxApp = fauxargocd.FauxApplication{}
+			Expect(yaml.Unmarshal([]byte(application), &fauxApp)).To(Succeed())
+			Expect(fauxApp.Spec.SyncPolicy).ToNot(BeNil())
+			Expect(fauxApp.Spec.SyncPolicy.Automated).To(BeNil())
+			Expect(fauxApp.Spec.SyncPolicy.SyncOptions).To(Equal(fauxargocd.SyncOptions{"PrunePropagationPolicy=orphan"}))
+		})
+
+		It("Input spec with a deletion policy should set the deletion policy annotation", func() {
+			input := getFakeArgoCDSpecInput(false, false)
+			input.deletionPolicy = string(managedgitopsv1alpha1.DeletionPolicy_Orphan)
+
+			application, err := createSpecField(input)
+			Expect(err).ToNot(HaveOccurred())
+
+			fauxApp := fauxargocd.FauxApplication{}
+			Expect(yaml.Unmarshal([]byte(application), &fauxApp)).To(Succeed())
+			Expect(fauxApp.Annotations).To(Equal(map[string]string{
+				argosharedutil.ArgoCDApplicationDeletionPolicyAnnotation: "Orphan",
+			}))
+		})
+
+		It("checkValidSyncOption should accept all supported sync options, and reject unsupported sync options", func() {
+			Expect(checkValidSyncOption([]managedgitopsv1alpha1.SyncOption{
+				managedgitopsv1alpha1.SyncOptions_CreateNamespace_true,
+				managedgitopsv1alpha1.SyncOptions_ServerSideApply_true,
+				managedgitopsv1alpha1.SyncOptions_PruneLast_true,
+				managedgitopsv1alpha1.SyncOptions_ApplyOutOfSyncOnly_true,
+				managedgitopsv1alpha1.SyncOptions_Replace_true,
+				managedgitopsv1alpha1.SyncOptions_RespectIgnoreDifferences_true,
+				managedgitopsv1alpha1.SyncOptions_Validate_false,
+			})).To(BeNil())
+
+			Expect(checkValidSyncOption([]managedgitopsv1alpha1.SyncOption{"PruneLast=maybe"})).ToNot(BeNil())
+		})
+
+		It("Input spec with ignoreDifferences should set the ignoreDifferences field, and sanitize all fields other than jqPathExpressions", func() {
+			input := getFakeArgoCDSpecInput(false, false)
+			input.ignoreDifferences = []managedgitopsv1alpha1.ResourceIgnoreDifferences{
+				{
+					Group:                 "apps",
+					Kind:                  "Deployment\n",
+					JSONPointers:          []string{"/spec/'replicas'"},
+					JQPathExpressions:     []string{`.spec.template.spec.initContainers[] | select(.name == "injected")`},
+					ManagedFieldsManagers: []string{"kube-controller-manager"},
+				},
+			}
+
+			application, err := createSpecField(input)
+			Expect(err).ToNot(HaveOccurred())
+
+			fauxApp := fauxargocd.FauxApplication{}
+			Expect(yaml.Unmarshal([]byte(application), &fauxApp)).To(Succeed())
+
+			Expect(fauxApp.Spec.IgnoreDifferences).To(Equal([]fauxargocd.ResourceIgnoreDifferences{
+				{
+					Group:                 "apps",
+					Kind:                  "Deployment",
+					JSONPointers:          []string{"/spec/replicas"},
+					JQPathExpressions:     []string{`.spec.template.spec.initContainers[] | select(.name == "injected")`},
+					ManagedFieldsManagers: []string{"kube-controller-manager"},
+				},
+			}))
+		})
+
+		It("Input spec with Helm options should set the helm field of the source, and sanitize all fields other than values", func() {
+			input := getFakeArgoCDSpecInput(false, false)
+			input.sourceHelm = &managedgitopsv1alpha1.ApplicationSourceHelm{
+				ValueFiles:  []string{"values-prod.yaml\n"},
+				ReleaseName: "my-'release'",
+				Values:      "replicaCount: 2\nimage:\n  tag: \"v1\"\n",
+				SkipCrds:    true,
+				Parameters: []managedgitopsv1alpha1.HelmParameter{
+					{Name: "service.type", Value: "Node`Port`", ForceString: true},
+				},
+			}
+
+			application, err := createSpecField(input)
+			Expect(err).ToNot(HaveOccurred())
+
+			fauxApp := fauxargocd.FauxApplication{}
+			Expect(yaml.Unmarshal([]byte(application), &fauxApp)).To(Succeed())
+
+			Expect(fauxApp.Spec.Source.Helm).ToNot(BeNil())
+			Expect(fauxApp.Spec.Source.Helm.ValueFiles).To(Equal([]string{"values-prod.yaml"}))
+			Expect(fauxApp.Spec.Source.Helm.ReleaseName).To(Equal("my-release"))
+			Expect(fauxApp.Spec.Source.Helm.SkipCrds).To(BeTrue())
+			Expect(fauxApp.Spec.Source.Helm.Parameters).To(Equal([]fauxargocd.HelmParameter{
+				{Name: "service.type", Value: "NodePort", ForceString: true},
+			}))
+
+			By("verifying that the values field is passed through unmodified")
+			Expect(fauxApp.Spec.Source.Helm.Values).To(Equal(input.sourceHelm.Values))
+		})
+
+		It("Input spec without Helm options should not contain a helm field", func() {
+			input := getFakeArgoCDSpecInput(false, false)
+			application, err := createSpecField(input)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(application).ToNot(ContainSubstring("helm"))
+		})
+
+		It("Input spec with multiple sources should set the sources field, with sanitized values", func() {
+			input := getFakeArgoCDSpecInput(false, false)
+			input.sourceRepoURL = ""
+			input.sourcePath = ""
+			input.sourceTargetRevision = ""
+			input.sources = []managedgitopsv1alpha1.ApplicationSource{
+				{
+					RepoURL: "https://github.com/my-org/my-chart",
+					Path:    "chart\n",
+					Helm: &managedgitopsv1alpha1.ApplicationSourceHelm{
+						ValueFiles: []string{"$values/envs/prod/values.yaml"},
+					},
+				},
+				{
+					RepoURL:        "https://github.com/my-org/my-values",
+					TargetRevision: "'main'",
+					Ref:            "values",
+				},
+			}
+
+			application, err := createSpecField(input)
+			Expect(err).ToNot(HaveOccurred())
+
+			fauxApp := fauxargocd.FauxApplication{}
+			Expect(yaml.Unmarshal([]byte(application), &fauxApp)).To(Succeed())
+
+			Expect(fauxApp.Spec.Source.RepoURL).To(BeEmpty())
+			Expect(fauxApp.Spec.Sources).To(Equal(fauxargocd.ApplicationSources{
+				{
+					RepoURL: "https://github.com/my-org/my-chart",
+					Path:    "chart",
+					Helm: &fauxargocd.ApplicationSourceHelm{
+						ValueFiles: []string{"$values/envs/prod/values.yaml"},
+						Parameters: []fauxargocd.HelmParameter{},
+					},
+				},
+				{
+					RepoURL:        "https://github.com/my-org/my-values",
+					TargetRevision: "main",
+					Ref:            "values",
+				},
+			}))
+		})
+
+		It("Input spec with a single source should not contain a sources field", func() {
+			input := getFakeArgoCDSpecInput(false, false)
+			application, err := createSpecField(input)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(application).ToNot(ContainSubstring("sources"))
+		})
+
+		It("Input spec with Kustomize options should set the kustomize field of the source, with sanitized values", func() {
+			input := getFakeArgoCDSpecInput(false, false)
+			input.sourceKustomize = &managedgitopsv1alpha1.ApplicationSourceKustomize{
+				NamePrefix:        "prod-\n",
+				NameSuffix:        "-'v1'",
+				Images:            []string{"quay.io/org/app:v2"},
+				CommonLabels:      map[string]string{"app\n": "my-\"app\""},
+				CommonAnnotations: map[string]string{"team": "gitops"},
+			}
+
+			application, err := createSpecField(input)
+			Expect(err).ToNot(HaveOccurred())
+
+			fauxApp := fauxargocd.FauxApplication{}
+			Expect(yaml.Unmarshal([]byte(application), &fauxApp)).To(Succeed())
+
+			Expect(fauxApp.Spec.Source.Kustomize).To(Equal(&fauxargocd.ApplicationSourceKustomize{
+				NamePrefix:        "prod-",
+				NameSuffix:        "-v1",
+				Images:            []string{"quay.io/org/app:v2"},
+				CommonLabels:      map[string]string{"app": "my-app"},
+				CommonAnnotations: map[string]string{"team": "gitops"},
+			}))
+		})
+
+		It("Input spec with Kustomize options should not sanitize the images, annotation values and patches, which are validated by the webhook", func() {
+			patch := "- op: replace\n  path: /metadata/annotations/example.com~1owner\n  value: \"team 'a'\""
+
+			input := getFakeArgoCDSpecInput(false, false)
+			input.sourceKustomize = &managedgitopsv1alpha1.ApplicationSourceKustomize{
+				Images:            []string{"quay.io/org/app@sha256:3b0b4c7ab5b1e2a8c8d8e5f9c1f0a3d2e4b6c8a0f1e3d5c7b9a2c4e6f8a0b1c3"},
+				CommonAnnotations: map[string]string{"example.com/link": "https://example.com/?a=1&b=2;c=50%"},
+				Patches: []managedgitopsv1alpha1.KustomizePatch{{
+					Patch:  patch,
+					Target: &managedgitopsv1alpha1.KustomizeSelector{Kind: "Deployment", Name: "my-'app'"},
+				}},
+			}
+
+			application, err := createSpecField(input)
+			Expect(err).ToNot(HaveOccurred())
+
+			fauxApp := fauxargocd.FauxApplication{}
+			Expect(yaml.Unmarshal([]byte(application), &fauxApp)).To(Succeed())
+
+			Expect(fauxApp.Spec.Source.Kustomize).To(Equal(&fauxargocd.ApplicationSourceKustomize{
+				Images:            []string{"quay.io/org/app@sha256:3b0b4c7ab5b1e2a8c8d8e5f9c1f0a3d2e4b6c8a0f1e3d5c7b9a2c4e6f8a0b1c3"},
+				CommonLabels:      map[string]string{},
+				CommonAnnotations: map[string]string{"example.com/link": "https://example.com/?a=1&b=2;c=50%"},
+				Patches: []fauxargocd.KustomizePatch{{
+					Patch:  patch,
+					Target: &fauxargocd.KustomizeSelector{Kind: "Deployment", Name: "my-app"},
+				}},
+			}))
+		})
+
+		It("Input spec with fields that were not validated by the webhook should not be able to modify the rest of the Application", func() {
+			// The fields which are not sanitized are validated by the webhook, but a GitOpsDeployment may have been
+			// created before the webhook validated them, or while the webhook was disabled.
+			injection := "\"\n'\nproject: default\ndestination:\n  server: https://kubernetes.default.svc\n---\nkind: Secret\n"
+
+			input := getFakeArgoCDSpecInput(false, false)
+			input.sourceHelm = &managedgitopsv1alpha1.ApplicationSourceHelm{
+				Values: injection,
+			}
+			input.sourceKustomize = &managedgitopsv1alpha1.ApplicationSourceKustomize{
+				Images:            []string{injection},
+				CommonAnnotations: map[string]string{"example.com/link": injection},
+				Patches:           []managedgitopsv1alpha1.KustomizePatch{{Patch: injection}},
+			}
+			input.ignoreDifferences = []managedgitopsv1alpha1.ResourceIgnoreDifferences{
+				{Kind: "Deployment", JQPathExpressions: []string{injection}},
+			}
+
+			application, err := createSpecField(input)
+			Expect(err).ToNot(HaveOccurred())
+
+			By("verifying that the Application is a single YAML document, with only the expected top-level fields")
+			decoder := yaml.NewDecoder(strings.NewReader(application))
+			document := map[string]interface{}{}
+			Expect(decoder.Decode(&document)).To(Succeed())
+			Expect(document).To(HaveLen(3))
+			Expect(document).To(HaveKey("fauxtypemeta"))
+			Expect(document).To(HaveKey("fauxobjectmeta"))
+			Expect(document).To(HaveKey("spec"))
+			Expect(decoder.Decode(&map[string]interface{}{})).To(MatchError(io.EOF))
+
+			By("verifying that the project and destination are unchanged, and that the fields are passed through unmodified")
+			fauxApp := fauxargocd.FauxApplication{}
+			Expect(yaml.Unmarshal([]byte(application), &fauxApp)).To(Succeed())
+
+			Expect(fauxApp.Spec.Project).To(Equal(input.project))
+			Expect(fauxApp.Spec.Destination).To(Equal(fauxargocd.ApplicationDestination{
+				Name:      input.destinationName,
+				Namespace: input.destinationNamespace,
+			}))
+			Expect(fauxApp.Spec.Source.Helm.Values).To(Equal(injection))
+			Expect(fauxApp.Spec.Source.Kustomize.Images).To(Equal([]string{injection}))
+			Expect(fauxApp.Spec.Source.Kustomize.CommonAnnotations).To(Equal(map[string]string{"example.com/link": injection}))
+			Expect(fauxApp.Spec.Source.Kustomize.Patches[0].Patch).To(Equal(injection))
+			Expect(fauxApp.Spec.IgnoreDifferences[0].JQPathExpressions).To(Equal([]string{injection}))
+		})
 	})
 })
 
@@ -914,6 +1259,56 @@ var _ = Describe("ApplicationEventLoop Handle deployment modified Test", func()
 
 		})
 
+		It("should keep automated sync disabled after a rollback, so that the rolled back revision stays deployed, until the spec of the GitOpsDeployment changes", func() {
+
+			getSpecFieldSyncPolicy := func(application *db.Application) *fauxargocd.SyncPolicy {
+				specFieldApp := fauxargocd.FauxApplication{}
+				Expect(yaml.Unmarshal([]byte(application.Spec_field), &specFieldApp)).To(Succeed())
+				return specFieldApp.Spec.SyncPolicy
+			}
+
+			By("calling handleDeploymentModified to simulate a new GitOpsDeployment, with automated sync")
+			_, applicationDBRow, _, result, userDevErr := appEventLoopRunnerAction.applicationEventRunner_handleDeploymentModified(ctx, dbQueries)
+			Expect(userDevErr).To(BeNil())
+			Expect(result).To(Equal(deploymentModifiedResult_Created))
+			Expect(getSpecFieldSyncPolicy(applicationDBRow).Automated).ToNot(BeNil())
+
+			By("simulating a successful rollback of the GitOpsDeployment")
+			err := appEventLoopRunnerAction.keepRollbackDeployed(ctx, gitopsDepl.Name,
+				managedgitopsv1alpha1.DeploymentHistoryEntry{Revision: "abc123"}, dbQueries)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDepl), gitopsDepl)
+			Expect(err).ToNot(HaveOccurred())
+			rolledBack := meta.FindStatusCondition(gitopsDepl.Status.Conditions, string(managedgitopsv1alpha1.GitOpsDeploymentConditionRolledBack))
+			Expect(rolledBack).ToNot(BeNil())
+			Expect(rolledBack.Status).To(Equal(metav1.ConditionTrue))
+			Expect(rolledBack.ObservedGeneration).To(Equal(gitopsDepl.Generation))
+			Expect(rolledBack.Message).To(ContainSubstring("abc123"))
+
+			By("verifying that the Application no longer has automated sync, so Argo CD will not sync it back to the latest revision")
+			err = dbQueries.GetApplicationById(ctx, applicationDBRow)
+			Expect(err).ToNot(HaveOccurred())
+			syncPolicy := getSpecFieldSyncPolicy(applicationDBRow)
+			Expect(syncPolicy == nil || syncPolicy.Automated == nil).To(BeTrue())
+
+			By("verifying that reprocessing the unchanged GitOpsDeployment does not re-enable automated sync")
+			_, _, _, result, userDevErr = appEventLoopRunnerAction.applicationEventRunner_handleDeploymentModified(ctx, dbQueries)
+			Expect(userDevErr).To(BeNil())
+			Expect(result).To(Equal(deploymentModifiedResult_NoChange))
+
+			By("changing the spec of the GitOpsDeployment, which re-enables automated sync")
+			gitopsDepl.Spec.Source.TargetRevision = "def-commit"
+			gitopsDepl.Generation++
+			err = k8sClient.Update(ctx, gitopsDepl)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, applicationDBRow, _, result, userDevErr = appEventLoopRunnerAction.applicationEventRunner_handleDeploymentModified(ctx, dbQueries)
+			Expect(userDevErr).To(BeNil())
+			Expect(result).To(Equal(deploymentModifiedResult_Updated))
+			Expect(getSpecFieldSyncPolicy(applicationDBRow).Automated).ToNot(BeNil())
+		})
+
 		It("should return an error if the Argo CD Application name field changed between when the GitOpsDeployment was created, and when it was updated", func() {
 
 			By("calling handleDeploymentModified to simulate a new GitOpsDeployment")
@@ -1083,3 +1478,557 @@ var _ = Describe("ApplicationEventLoop Handle deployment modified Test", func()
 		})
 	})
 })
+
+var _ = Describe("setSuspendedCondition", func() {
+
+	var gitopsDepl *managedgitopsv1alpha1.GitOpsDeployment
+
+	BeforeEach(func() {
+		gitopsDepl = &managedgitopsv1alpha1.GitOpsDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-depl",
+				Namespace: "test-ns",
+			},
+		}
+	})
+
+	It("should not add the Suspended condition if the GitOpsDeployment was never suspended", func() {
+		setSuspendedCondition(gitopsDepl, condition.NewConditionManager())
+		Expect(gitopsDepl.Status.Conditions).To(BeEmpty())
+	})
+
+	It("should set the Suspended condition to True when suspended, and resolve it when resumed", func() {
+		conditionManager := condition.NewConditionManager()
+
+		By("suspending the GitOpsDeployment")
+		gitopsDepl.Spec.Suspend = true
+		setSuspendedCondition(gitopsDepl, conditionManager)
+
+		Expect(gitopsDepl.Status.Conditions).To(HaveLen(1))
+		cond := gitopsDepl.Status.Conditions[0]
+		Expect(cond.Type).To(Equal(string(managedgitopsv1alpha1.GitOpsDeploymentConditionSuspended)))
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(cond.Reason).To(Equal(string(managedgitopsv1alpha1.GitopsDeploymentReasonSuspended)))
+		Expect(cond.Message).To(Equal(managedgitopsv1alpha1.GitOpsDeploymentUserError_Suspended))
+
+		By("calling it again, the existing condition should not be modified")
+		setSuspendedCondition(gitopsDepl, conditionManager)
+		Expect(gitopsDepl.Status.Conditions).To(HaveLen(1))
+		Expect(gitopsDepl.Status.Conditions[0]).To(Equal(cond))
+
+		By("resuming the GitOpsDeployment")
+		gitopsDepl.Spec.Suspend = false
+		setSuspendedCondition(gitopsDepl, conditionManager)
+
+		Expect(gitopsDepl.Status.Conditions).To(HaveLen(1))
+		Expect(gitopsDepl.Status.Conditions[0].Status).To(Equal(metav1.ConditionFalse))
+		Expect(gitopsDepl.Status.Conditions[0].Reason).To(Equal(string(managedgitopsv1alpha1.GitopsDeploymentReasonSuspended + "Resolved")))
+	})
+})
+
+var _ = Describe("isAutomatedSyncEnabled and resolveRolledBackCondition", func() {
+
+	var gitopsDepl *managedgitopsv1alpha1.GitOpsDeployment
+
+	BeforeEach(func() {
+		gitopsDepl = &managedgitopsv1alpha1.GitOpsDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "test-depl",
+				Namespace:  "test-ns",
+				Generation: 2,
+			},
+			Spec: managedgitopsv1alpha1.GitOpsDeploymentSpec{
+				Type: managedgitopsv1alpha1.GitOpsDeploymentSpecType_Automated,
+			},
+			Status: managedgitopsv1alpha1.GitOpsDeploymentStatus{
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(managedgitopsv1alpha1.GitOpsDeploymentConditionRolledBack),
+						Status:             metav1.ConditionTrue,
+						Reason:             string(managedgitopsv1alpha1.GitopsDeploymentReasonRolledBack),
+						ObservedGeneration: 2,
+					},
+				},
+			},
+		}
+	})
+
+	It("should disable automated sync of a GitOpsDeployment that was rolled back, until its spec changes", func() {
+		Expect(isAutomatedSyncEnabled(*gitopsDepl)).To(BeFalse())
+
+		By("keeping the RolledBack condition, while the spec is unchanged")
+		resolveRolledBackCondition(gitopsDepl, condition.NewConditionManager())
+		Expect(gitopsDepl.Status.Conditions[0].Status).To(Equal(metav1.ConditionTrue))
+
+		By("changing the spec of the GitOpsDeployment")
+		gitopsDepl.Generation = 3
+		Expect(isAutomatedSyncEnabled(*gitopsDepl)).To(BeTrue())
+
+		resolveRolledBackCondition(gitopsDepl, condition.NewConditionManager())
+		Expect(gitopsDepl.Status.Conditions[0].Status).To(Equal(metav1.ConditionFalse))
+		Expect(gitopsDepl.Status.Conditions[0].Reason).To(Equal(string(managedgitopsv1alpha1.GitopsDeploymentReasonRolledBack + "Resolved")))
+		Expect(isAutomatedSyncEnabled(*gitopsDepl)).To(BeTrue())
+	})
+
+	It("should disable automated sync of a suspended or manual GitOpsDeployment", func() {
+		gitopsDepl.Status.Conditions = nil
+		Expect(isAutomatedSyncEnabled(*gitopsDepl)).To(BeTrue())
+
+		gitopsDepl.Spec.Suspend = true
+		Expect(isAutomatedSyncEnabled(*gitopsDepl)).To(BeFalse())
+
+		gitopsDepl.Spec.Suspend = false
+		gitopsDepl.Spec.Type = managedgitopsv1alpha1.GitOpsDeploymentSpecType_Manual
+		Expect(isAutomatedSyncEnabled(*gitopsDepl)).To(BeFalse())
+	})
+})
+
+var _ = Describe("Refresh of a GitOpsDeployment via the refresh annotation", func() {
+
+	var (
+		ctx        context.Context
+		k8sClient  client.Client
+		gitopsDepl *managedgitopsv1alpha1.GitOpsDeployment
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme, _, _, workspace, err := tests.GenericTestSetup()
+		Expect(err).ToNot(HaveOccurred())
+
+		gitopsDepl = &managedgitopsv1alpha1.GitOpsDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-gitops-depl",
+				Namespace: workspace.Name,
+				UID:       uuid.NewUUID(),
+				Annotations: map[string]string{
+					managedgitopsv1alpha1.AnnotationKeyRefresh: string(managedgitopsv1alpha1.RefreshType_Hard),
+					"other-annotation":                         "value",
+				},
+			},
+		}
+
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(gitopsDepl, workspace).Build()
+	})
+
+	It("should remove the refresh annotation and set .status.lastRefreshedAt", func() {
+		now := metav1.Now()
+		err := removeRefreshAnnotationAndSetLastRefreshedAt(ctx, k8sClient, gitopsDepl, string(managedgitopsv1alpha1.RefreshType_Hard), now)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDepl), gitopsDepl)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gitopsDepl.Annotations).ToNot(HaveKey(managedgitopsv1alpha1.AnnotationKeyRefresh))
+		Expect(gitopsDepl.Annotations).To(HaveKeyWithValue("other-annotation", "value"))
+		Expect(gitopsDepl.Status.LastRefreshedAt).ToNot(BeNil())
+		Expect(gitopsDepl.Status.LastRefreshedAt.Unix()).To(Equal(now.Unix()))
+	})
+
+	It("should not remove the refresh annotation if it was changed after the refresh was requested", func() {
+		err := removeRefreshAnnotationAndSetLastRefreshedAt(ctx, k8sClient, gitopsDepl, string(managedgitopsv1alpha1.RefreshType_Normal), metav1.Now())
+		Expect(err).ToNot(HaveOccurred())
+
+		err = k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDepl), gitopsDepl)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gitopsDepl.Annotations).To(HaveKeyWithValue(managedgitopsv1alpha1.AnnotationKeyRefresh, string(managedgitopsv1alpha1.RefreshType_Hard)))
+		Expect(gitopsDepl.Status.LastRefreshedAt).ToNot(BeNil())
+	})
+
+	It("should not modify a GitOpsDeployment that was recreated with the same name", func() {
+		recreatedDepl := gitopsDepl.DeepCopy()
+		recreatedDepl.UID = uuid.NewUUID()
+
+		err := removeRefreshAnnotationAndSetLastRefreshedAt(ctx, k8sClient, recreatedDepl, string(managedgitopsv1alpha1.RefreshType_Hard), metav1.Now())
+		Expect(err).ToNot(HaveOccurred())
+
+		err = k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDepl), gitopsDepl)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gitopsDepl.Annotations).To(HaveKey(managedgitopsv1alpha1.AnnotationKeyRefresh))
+		Expect(gitopsDepl.Status.LastRefreshedAt).To(BeNil())
+	})
+
+	It("should return a user error if the refresh annotation has an invalid value", func() {
+		gitopsDepl.Annotations[managedgitopsv1alpha1.AnnotationKeyRefresh] = "soft"
+
+		a := applicationEventLoopRunner_Action{
+			workspaceClient: k8sClient,
+			log:             log.FromContext(ctx),
+		}
+
+		userErr := a.handleRefreshRequest(ctx, gitopsDepl, &db.Application{}, &db.GitopsEngineInstance{}, &db.ClusterUser{}, nil)
+		Expect(userErr).ToNot(BeNil())
+		Expect(userErr.UserError()).To(Equal(fmt.Sprintf(managedgitopsv1alpha1.GitOpsDeploymentUserError_InvalidRefreshType, "soft")))
+	})
+
+	It("should do nothing if the refresh annotation is not set", func() {
+		delete(gitopsDepl.Annotations, managedgitopsv1alpha1.AnnotationKeyRefresh)
+
+		a := applicationEventLoopRunner_Action{
+			workspaceClient: k8sClient,
+			log:             log.FromContext(ctx),
+		}
+
+		Expect(a.handleRefreshRequest(ctx, gitopsDepl, &db.Application{}, &db.GitopsEngineInstance{}, &db.ClusterUser{}, nil)).To(BeNil())
+	})
+})
+
+var _ = Describe("setReadinessConditions", func() {
+
+	var gitopsDepl *managedgitopsv1alpha1.GitOpsDeployment
+
+	findCondition := func(conditionType managedgitopsv1alpha1.GitOpsDeploymentConditionType) *metav1.Condition {
+		return meta.FindStatusCondition(gitopsDepl.Status.Conditions, string(conditionType))
+	}
+
+	BeforeEach(func() {
+		gitopsDepl = &managedgitopsv1alpha1.GitOpsDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "test-depl",
+				Namespace:  "test-ns",
+				Generation: 2,
+			},
+			Status: managedgitopsv1alpha1.GitOpsDeploymentStatus{
+				ObservedGeneration: 2,
+				Sync:               managedgitopsv1alpha1.SyncStatus{Status: managedgitopsv1alpha1.SyncStatusCodeSynced},
+				Health:             managedgitopsv1alpha1.HealthStatus{Status: managedgitopsv1alpha1.HeathStatusCodeHealthy},
+			},
+		}
+	})
+
+	It("should set Ready to True when the GitOpsDeployment is synced and healthy", func() {
+		setReadinessConditions(gitopsDepl)
+
+		Expect(gitopsDepl.Status.Conditions).To(HaveLen(4))
+		for _, conditionType := range []managedgitopsv1alpha1.GitOpsDeploymentConditionType{
+			managedgitopsv1alpha1.GitOpsDeploymentConditionReady,
+			managedgitopsv1alpha1.GitOpsDeploymentConditionSynced,
+			managedgitopsv1alpha1.GitOpsDeploymentConditionHealthy,
+		} {
+			cond := findCondition(conditionType)
+			Expect(cond).ToNot(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue), string(conditionType))
+			Expect(cond.ObservedGeneration).To(Equal(int64(2)))
+		}
+
+		Expect(findCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionProgressing).Status).To(Equal(metav1.ConditionFalse))
+
+		By("calling it again, the conditions should not be modified")
+		conditions := append([]metav1.Condition{}, gitopsDepl.Status.Conditions...)
+		setReadinessConditions(gitopsDepl)
+		Expect(gitopsDepl.Status.Conditions).To(Equal(conditions))
+	})
+
+	It("should set Ready to False, and Progressing to True, when the latest generation has not been processed", func() {
+		gitopsDepl.Generation = 3
+
+		setReadinessConditions(gitopsDepl)
+
+		ready := findCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionReady)
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal(string(managedgitopsv1alpha1.GitopsDeploymentReasonReconciling)))
+
+		progressing := findCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionProgressing)
+		Expect(progressing.Status).To(Equal(metav1.ConditionTrue))
+		Expect(progressing.Reason).To(Equal(string(managedgitopsv1alpha1.GitopsDeploymentReasonReconciling)))
+	})
+
+	It("should set Ready to False when an error occurred", func() {
+		gitopsDepl.Status.Conditions = []metav1.Condition{{
+			Type:    string(managedgitopsv1alpha1.GitOpsDeploymentConditionErrorOccurred),
+			Status:  metav1.ConditionTrue,
+			Reason:  string(managedgitopsv1alpha1.GitopsDeploymentReasonErrorOccurred),
+			Message: "an error",
+		}}
+
+		setReadinessConditions(gitopsDepl)
+
+		ready := findCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionReady)
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal(string(managedgitopsv1alpha1.GitopsDeploymentReasonErrorOccurred)))
+		Expect(ready.Message).To(Equal("an error"))
+	})
+
+	It("should set Ready and Synced to False when the GitOpsDeployment is out of sync", func() {
+		gitopsDepl.Status.Sync.Status = managedgitopsv1alpha1.SyncStatusCodeOutOfSync
+
+		setReadinessConditions(gitopsDepl)
+
+		Expect(findCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionSynced).Status).To(Equal(metav1.ConditionFalse))
+		ready := findCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionReady)
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal(string(managedgitopsv1alpha1.GitopsDeploymentReasonNotSynced)))
+	})
+
+	It("should set Ready and Healthy to False, and Progressing to True, when the resources are progressing", func() {
+		gitopsDepl.Status.Health.Status = managedgitopsv1alpha1.HeathStatusCodeProgressing
+
+		setReadinessConditions(gitopsDepl)
+
+		healthy := findCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionHealthy)
+		Expect(healthy.Status).To(Equal(metav1.ConditionFalse))
+		Expect(healthy.Reason).To(Equal(string(managedgitopsv1alpha1.HeathStatusCodeProgressing)))
+
+		Expect(findCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionProgressing).Status).To(Equal(metav1.ConditionTrue))
+
+		ready := findCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionReady)
+		Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+		Expect(ready.Reason).To(Equal(string(managedgitopsv1alpha1.GitopsDeploymentReasonNotHealthy)))
+	})
+
+	It("should report Synced and Healthy as Unknown when the status is not yet known", func() {
+		gitopsDepl.Status.Sync.Status = ""
+		gitopsDepl.Status.Health.Status = ""
+
+		setReadinessConditions(gitopsDepl)
+
+		Expect(findCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionSynced).Status).To(Equal(metav1.ConditionUnknown))
+		Expect(findCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionHealthy).Status).To(Equal(metav1.ConditionUnknown))
+		Expect(findCondition(managedgitopsv1alpha1.GitOpsDeploymentConditionReady).Status).To(Equal(metav1.ConditionFalse))
+	})
+})
+
+var _ = Describe("gitOpsDeploymentAdapter setObservedGeneration", func() {
+
+	It("should set .status.observedGeneration and the readiness conditions", func() {
+		ctx := context.Background()
+
+		scheme, _, _, workspace, err := tests.GenericTestSetup()
+		Expect(err).ToNot(HaveOccurred())
+
+		gitopsDepl := &managedgitopsv1alpha1.GitOpsDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "my-gitops-depl",
+				Namespace:  workspace.Name,
+				Generation: 2,
+			},
+		}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gitopsDepl, workspace).Build()
+
+		err = k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDepl), gitopsDepl)
+		Expect(err).ToNot(HaveOccurred())
+
+		adapter := newGitOpsDeploymentAdapter(gitopsDepl, log.FromContext(ctx), k8sClient, condition.NewConditionManager(), ctx)
+		Expect(adapter.setObservedGeneration(2)).To(Succeed())
+
+		err = k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDepl), gitopsDepl)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(gitopsDepl.Status.ObservedGeneration).To(Equal(int64(2)))
+		Expect(meta.FindStatusCondition(gitopsDepl.Status.Conditions, string(managedgitopsv1alpha1.GitOpsDeploymentConditionReady))).ToNot(BeNil())
+
+		By("an older generation should not replace a newer observed generation")
+		Expect(adapter.setObservedGeneration(1)).To(Succeed())
+		Expect(gitopsDepl.Status.ObservedGeneration).To(Equal(int64(2)))
+	})
+})
+
+var _ = Describe("gitOpsDeploymentAdapter normalizeLegacyConditions", func() {
+
+	It("should normalize conditions that were written before .status.conditions was a list of metav1.Conditions", func() {
+		ctx := context.Background()
+
+		scheme, _, _, workspace, err := tests.GenericTestSetup()
+		Expect(err).ToNot(HaveOccurred())
+
+		// A status written by an earlier version of the GitOps Service: 'reason' and 'lastTransitionTime' were
+		// optional, and each condition had a 'lastProbeTime'.
+		legacyStatus := `{
+			"conditions": [
+				{"type": "ErrorOccurred", "status": "True", "message": "an error occurred", "lastProbeTime": "2023-01-01T00:00:00Z"},
+				{"type": "SyncError", "status": "False", "lastProbeTime": "2023-01-01T00:00:00Z", "lastTransitionTime": "2023-01-01T00:00:00Z"},
+				{"type": "ComparisonError", "reason": "ComparisonError", "lastProbeTime": "2023-01-01T00:00:00Z"}
+			]
+		}`
+
+		gitopsDepl := &managedgitopsv1alpha1.GitOpsDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-gitops-depl",
+				Namespace: workspace.Name,
+			},
+		}
+		Expect(json.Unmarshal([]byte(legacyStatus), &gitopsDepl.Status)).To(Succeed())
+		Expect(metav1validation.ValidateConditions(gitopsDepl.Status.Conditions, field.NewPath("status", "conditions"))).ToNot(BeEmpty(),
+			"the legacy conditions should not be valid metav1.Conditions")
+
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gitopsDepl, workspace).Build()
+
+		err = k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDepl), gitopsDepl)
+		Expect(err).ToNot(HaveOccurred())
+
+		adapter := newGitOpsDeploymentAdapter(gitopsDepl, log.FromContext(ctx), k8sClient, condition.NewConditionManager(), ctx)
+		Expect(adapter.normalizeLegacyConditions()).To(Succeed())
+
+		err = k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDepl), gitopsDepl)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(metav1validation.ValidateConditions(gitopsDepl.Status.Conditions, field.NewPath("status", "conditions"))).To(BeEmpty())
+
+		errorOccurred := meta.FindStatusCondition(gitopsDepl.Status.Conditions, string(managedgitopsv1alpha1.GitOpsDeploymentConditionErrorOccurred))
+		Expect(errorOccurred).ToNot(BeNil())
+		Expect(errorOccurred.Status).To(Equal(metav1.ConditionTrue))
+		Expect(errorOccurred.Reason).To(Equal(string(managedgitopsv1alpha1.GitopsDeploymentReasonErrorOccurred)))
+		Expect(errorOccurred.Message).To(Equal("an error occurred"))
+		Expect(errorOccurred.LastTransitionTime.IsZero()).To(BeFalse())
+
+		By("preserving an existing lastTransitionTime")
+		syncError := meta.FindStatusCondition(gitopsDepl.Status.Conditions, string(managedgitopsv1alpha1.GitOpsDeploymentConditionSyncError))
+		Expect(syncError).ToNot(BeNil())
+		Expect(syncError.Reason).To(Equal(string(managedgitopsv1alpha1.GitOpsDeploymentConditionSyncError) + "Resolved"))
+		Expect(syncError.LastTransitionTime.UTC()).To(Equal(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+		By("setting a missing status to Unknown, and preserving an existing reason")
+		comparisonError := meta.FindStatusCondition(gitopsDepl.Status.Conditions, "ComparisonError")
+		Expect(comparisonError).ToNot(BeNil())
+		Expect(comparisonError.Status).To(Equal(metav1.ConditionUnknown))
+		Expect(comparisonError.Reason).To(Equal("ComparisonError"))
+
+		By("leaving conditions that are already valid unchanged")
+		Expect(normalizeLegacyConditions(gitopsDepl.Status.Conditions)).To(BeFalse())
+	})
+})
+
+var _ = Describe("gitOpsDeploymentAdapter setSuspendedCondition", func() {
+
+	It("should set the Suspended condition when .spec.suspend is set, and resolve it when .spec.suspend is unset", func() {
+		ctx := context.Background()
+
+		scheme, _, _, workspace, err := tests.GenericTestSetup()
+		Expect(err).ToNot(HaveOccurred())
+
+		gitopsDepl := &managedgitopsv1alpha1.GitOpsDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-gitops-depl",
+				Namespace: workspace.Name,
+			},
+			Spec: managedgitopsv1alpha1.GitOpsDeploymentSpec{
+				Suspend: true,
+			},
+		}
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(gitopsDepl, workspace).Build()
+
+		err = k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDepl), gitopsDepl)
+		Expect(err).ToNot(HaveOccurred())
+
+		adapter := newGitOpsDeploymentAdapter(gitopsDepl, log.FromContext(ctx), k8sClient, condition.NewConditionManager(), ctx)
+		Expect(adapter.setSuspendedCondition()).To(Succeed())
+
+		err = k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDepl), gitopsDepl)
+		Expect(err).ToNot(HaveOccurred())
+		suspended := meta.FindStatusCondition(gitopsDepl.Status.Conditions, string(managedgitopsv1alpha1.GitOpsDeploymentConditionSuspended))
+		Expect(suspended).ToNot(BeNil())
+		Expect(suspended.Status).To(Equal(metav1.ConditionTrue))
+		Expect(suspended.Reason).To(Equal(string(managedgitopsv1alpha1.GitopsDeploymentReasonSuspended)))
+
+		By("unsetting .spec.suspend, which should resolve the condition")
+		gitopsDepl.Spec.Suspend = false
+		Expect(k8sClient.Update(ctx, gitopsDepl)).To(Succeed())
+		Expect(adapter.setSuspendedCondition()).To(Succeed())
+
+		err = k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDepl), gitopsDepl)
+		Expect(err).ToNot(HaveOccurred())
+		suspended = meta.FindStatusCondition(gitopsDepl.Status.Conditions, string(managedgitopsv1alpha1.GitOpsDeploymentConditionSuspended))
+		Expect(suspended).ToNot(BeNil())
+		Expect(suspended.Status).To(Equal(metav1.ConditionFalse))
+	})
+})
+
+var _ = Describe("recordDeploymentStatusEvents", func() {
+
+	var recorder *record.FakeRecorder
+	var action applicationEventLoopRunner_Action
+	var gitopsDepl *managedgitopsv1alpha1.GitOpsDeployment
+
+	startedAt := metav1.NewTime(time.Now().Add(-1 * time.Minute))
+
+	BeforeEach(func() {
+		recorder = record.NewFakeRecorder(10)
+		action = applicationEventLoopRunner_Action{eventRecorder: recorder}
+
+		gitopsDepl = &managedgitopsv1alpha1.GitOpsDeployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-depl", Namespace: "test-ns"},
+			Status: managedgitopsv1alpha1.GitOpsDeploymentStatus{
+				Health: managedgitopsv1alpha1.HealthStatus{Status: managedgitopsv1alpha1.HeathStatusCodeHealthy},
+				Sync:   managedgitopsv1alpha1.SyncStatus{Status: managedgitopsv1alpha1.SyncStatusCodeSynced, Revision: "abc123"},
+			},
+		}
+	})
+
+	It("should record events as a sync operation starts and then succeeds", func() {
+		previousStatus := *gitopsDepl.Status.DeepCopy()
+
+		gitopsDepl.Status.OperationState = &managedgitopsv1alpha1.OperationState{
+			Phase:     managedgitopsv1alpha1.OperationRunning,
+			StartedAt: startedAt,
+		}
+		action.recordDeploymentStatusEvents(gitopsDepl, previousStatus)
+		Expect(recorder.Events).To(Receive(Equal("Normal SyncStarted Sync started")))
+
+		previousStatus = *gitopsDepl.Status.DeepCopy()
+		gitopsDepl.Status.OperationState.Phase = managedgitopsv1alpha1.OperationSucceeded
+		action.recordDeploymentStatusEvents(gitopsDepl, previousStatus)
+		Expect(recorder.Events).To(Receive(Equal("Normal SyncSucceeded Sync succeeded to revision 'abc123'")))
+
+		By("no further events should be recorded if the operation state is unchanged")
+		action.recordDeploymentStatusEvents(gitopsDepl, *gitopsDepl.Status.DeepCopy())
+		Expect(recorder.Events).ToNot(Receive())
+	})
+
+	It("should describe a dry-run sync operation as such", func() {
+		previousStatus := *gitopsDepl.Status.DeepCopy()
+
+		gitopsDepl.Status.OperationState = &managedgitopsv1alpha1.OperationState{
+			Operation: managedgitopsv1alpha1.ApplicationOperation{
+				Sync: &managedgitopsv1alpha1.SyncOperation{DryRun: true},
+			},
+			Phase:     managedgitopsv1alpha1.OperationSucceeded,
+			StartedAt: startedAt,
+		}
+		action.recordDeploymentStatusEvents(gitopsDepl, previousStatus)
+		Expect(recorder.Events).To(Receive(Equal("Normal SyncSucceeded Dry-run sync succeeded to revision 'abc123'")))
+	})
+
+	It("should record a Warning event when a sync operation fails", func() {
+		previousStatus := *gitopsDepl.Status.DeepCopy()
+
+		gitopsDepl.Status.OperationState = &managedgitopsv1alpha1.OperationState{
+			Phase:     managedgitopsv1alpha1.OperationFailed,
+			Message:   "one or more objects failed to apply",
+			StartedAt: startedAt,
+		}
+		action.recordDeploymentStatusEvents(gitopsDepl, previousStatus)
+		Expect(recorder.Events).To(Receive(Equal("Warning SyncFailed Sync failed: one or more objects failed to apply")))
+	})
+
+	It("should record events when the health degrades and then recovers", func() {
+		previousStatus := *gitopsDepl.Status.DeepCopy()
+
+		gitopsDepl.Status.Health = managedgitopsv1alpha1.HealthStatus{
+			Status:  managedgitopsv1alpha1.HeathStatusCodeDegraded,
+			Message: "Deployment has exceeded its progress deadline",
+		}
+		action.recordDeploymentStatusEvents(gitopsDepl, previousStatus)
+		Expect(recorder.Events).To(Receive(Equal("Warning HealthDegraded Health degraded: Deployment has exceeded its progress deadline")))
+
+		previousStatus = *gitopsDepl.Status.DeepCopy()
+		gitopsDepl.Status.Health = managedgitopsv1alpha1.HealthStatus{Status: managedgitopsv1alpha1.HeathStatusCodeHealthy}
+		action.recordDeploymentStatusEvents(gitopsDepl, previousStatus)
+		Expect(recorder.Events).To(Receive(Equal("Normal HealthRecovered Health recovered")))
+	})
+
+	It("should not record a recovered event when the resources become healthy without having been degraded", func() {
+		gitopsDepl.Status.Health.Status = managedgitopsv1alpha1.HeathStatusCodeProgressing
+		previousStatus := *gitopsDepl.Status.DeepCopy()
+
+		gitopsDepl.Status.Health.Status = managedgitopsv1alpha1.HeathStatusCodeHealthy
+		action.recordDeploymentStatusEvents(gitopsDepl, previousStatus)
+		Expect(recorder.Events).ToNot(Receive())
+	})
+
+	It("should not panic when no EventRecorder is available", func() {
+		action = applicationEventLoopRunner_Action{}
+		gitopsDepl.Status.Health.Status = managedgitopsv1alpha1.HeathStatusCodeDegraded
+
+		Expect(func() {
+			action.recordDeploymentStatusEvents(gitopsDepl, managedgitopsv1alpha1.GitOpsDeploymentStatus{})
+		}).ToNot(Panic())
+	})
+})
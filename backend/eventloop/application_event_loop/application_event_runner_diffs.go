@@ -0,0 +1,218 @@
+package application_event_loop
+
+import (
+	"context"
+	"fmt"
+
+	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
+	db "github.com/redhat-appstudio/managed-gitops/backend-shared/db"
+	sharedutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util"
+	"github.com/redhat-appstudio/managed-gitops/backend-shared/util/gitopserrors"
+	"github.com/redhat-appstudio/managed-gitops/backend-shared/util/operations"
+	goyaml "gopkg.in/yaml.v2"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// This file is responsible for processing events related to GitOpsDeploymentDiff CR.
+//
+// A GitOpsDeploymentDiff has no corresponding database entries: on each change to the .spec of the CR, the cluster-agent
+// is asked (via an Operation) to compute the diff of the Argo CD Application of the GitOpsDeployment, and the result is
+// written to the .status of the CR.
+
+func (a *applicationEventLoopRunner_Action) applicationEventRunner_handleDiffModified(ctx context.Context, dbQueries db.ApplicationScopedQueries) error {
+
+	log := a.log
+
+	diffCR := &managedgitopsv1alpha1.GitOpsDeploymentDiff{}
+	if err := a.workspaceClient.Get(ctx, client.ObjectKey{Namespace: a.eventResourceNamespace, Name: a.eventResourceName}, diffCR); err != nil {
+		if apierr.IsNotFound(err) {
+			// The diff was deleted, and there is nothing to clean up, so no more work to do.
+			return nil
+		}
+		return fmt.Errorf("unable to retrieve GitOpsDeploymentDiff: %v", err)
+	}
+
+	if diffCR.Status.ObservedGeneration == diffCR.Generation &&
+		(diffCR.Status.Phase == managedgitopsv1alpha1.GitOpsDeploymentDiffPhase_Completed ||
+			diffCR.Status.Phase == managedgitopsv1alpha1.GitOpsDeploymentDiffPhase_Failed) {
+		// The diff has already been computed for the current spec of the GitOpsDeploymentDiff
+		return nil
+	}
+
+	if diffCR.Status.Phase != managedgitopsv1alpha1.GitOpsDeploymentDiffPhase_Pending {
+		diffCR.Status = managedgitopsv1alpha1.GitOpsDeploymentDiffStatus{
+			ObservedGeneration: diffCR.Status.ObservedGeneration,
+			Phase:              managedgitopsv1alpha1.GitOpsDeploymentDiffPhase_Pending,
+		}
+		if err := a.workspaceClient.Status().Update(ctx, diffCR); err != nil {
+			return fmt.Errorf("failed to update the status of GitOpsDeploymentDiff: %v", err)
+		}
+	}
+
+	resourceDiffs, userError := a.computeGitOpsDeploymentDiff(ctx, diffCR, dbQueries)
+
+	now := metav1.Now()
+	newStatus := managedgitopsv1alpha1.GitOpsDeploymentDiffStatus{
+		ObservedGeneration: diffCR.Generation,
+		ComparedAt:         &now,
+	}
+
+	if userError != nil {
+		errMsg := userError.UserError()
+		if errMsg == "" {
+			errMsg = gitopserrors.UnknownError
+		}
+		newStatus.Phase = managedgitopsv1alpha1.GitOpsDeploymentDiffPhase_Failed
+		newStatus.Message = errMsg
+
+	} else {
+		newStatus.Phase = managedgitopsv1alpha1.GitOpsDeploymentDiffPhase_Completed
+		newStatus.Resources = resourceDiffs
+		log.Info("Computed the diff of GitOpsDeployment", "gitopsDeploymentName", diffCR.Spec.GitopsDeploymentName,
+			"outOfSyncResources", len(resourceDiffs))
+	}
+
+	diffCR.Status = newStatus
+	if err := a.workspaceClient.Status().Update(ctx, diffCR); err != nil {
+		return fmt.Errorf("failed to update the status of GitOpsDeploymentDiff: %v", err)
+	}
+
+	if userError != nil {
+		return userError.DevError()
+	}
+
+	return nil
+}
+
+// computeGitOpsDeploymentDiff informs the cluster-agent (via Operation) that the diff of the Application of the
+// GitOpsDeployment referenced by the GitOpsDeploymentDiff is requested, waits for the Operation to complete, and
+// returns the diff that the cluster-agent stored in the result of the Operation.
+func (a *applicationEventLoopRunner_Action) computeGitOpsDeploymentDiff(ctx context.Context, diffCR *managedgitopsv1alpha1.GitOpsDeploymentDiff,
+	dbQueries db.ApplicationScopedQueries) ([]managedgitopsv1alpha1.ResourceDiff, gitopserrors.UserError) {
+
+	log := a.log.WithValues("gitopsDeploymentName", diffCR.Spec.GitopsDeploymentName)
+
+	namespace := corev1.Namespace{}
+	if err := a.workspaceClient.Get(ctx, types.NamespacedName{Name: a.eventResourceNamespace}, &namespace); err != nil {
+		userError := fmt.Sprintf("unable to retrieve the contents of the namespace '%s' containing the API resource '%s'. Does it exist?",
+			a.eventResourceNamespace, a.eventResourceName)
+		devError := fmt.Errorf("unable to retrieve namespace '%s': %v", a.eventResourceNamespace, err)
+		return nil, gitopserrors.NewUserDevError(userError, devError)
+	}
+
+	clusterUser, _, err := a.sharedResourceEventLoop.GetOrCreateClusterUserByNamespaceUID(ctx, a.workspaceClient, namespace, log)
+	if err != nil {
+		devError := fmt.Errorf("unable to retrieve cluster user in computeGitOpsDeploymentDiff, '%s': %v", string(namespace.UID), err)
+		return nil, gitopserrors.NewDevOnlyError(devError)
+	}
+
+	// 1) Retrieve the GitOpsDeployment referenced by the GitOpsDeploymentDiff, and the corresponding Application
+	gitopsDepl := &managedgitopsv1alpha1.GitOpsDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      diffCR.Spec.GitopsDeploymentName,
+			Namespace: diffCR.Namespace,
+		},
+	}
+	if err := a.workspaceClient.Get(ctx, client.ObjectKeyFromObject(gitopsDepl), gitopsDepl); err != nil {
+		if apierr.IsNotFound(err) {
+			userError := fmt.Sprintf("Unable to retrieve GitOpsDeployment '%s' referenced by the GitOpsDeploymentDiff", gitopsDepl.Name)
+			return nil, gitopserrors.NewUserDevError(userError, fmt.Errorf("unable to retrieve gitopsdeployment referenced in diff: %v", err))
+		}
+		log.Error(err, "unable to retrieve gitopsdeployment referenced in diff")
+		return nil, gitopserrors.NewDevOnlyError(err)
+	}
+
+	deplToAppMapping := &db.DeploymentToApplicationMapping{Deploymenttoapplicationmapping_uid_id: string(gitopsDepl.UID)}
+	if err := dbQueries.GetDeploymentToApplicationMappingByDeplId(ctx, deplToAppMapping); err != nil {
+		if db.IsResultNotFoundError(err) {
+			userError := fmt.Sprintf("GitOpsDeployment '%s' referenced by the GitOpsDeploymentDiff has not yet been deployed", gitopsDepl.Name)
+			return nil, gitopserrors.NewUserDevError(userError, err)
+		}
+		log.Error(err, "unable to retrieve deployment to application mapping, on diff modified", "uid", string(gitopsDepl.UID))
+		return nil, gitopserrors.NewDevOnlyError(err)
+	}
+
+	application := &db.Application{Application_id: deplToAppMapping.Application_id}
+	if err := dbQueries.GetApplicationById(ctx, application); err != nil {
+		log.Error(err, "unable to retrieve application, on diff modified", "applicationId", deplToAppMapping.Application_id)
+		return nil, gitopserrors.NewDevOnlyError(err)
+	}
+
+	gitopsEngineInstance, err := a.sharedResourceEventLoop.GetGitopsEngineInstanceById(ctx, application.Engine_instance_inst_id,
+		a.workspaceClient, namespace, log)
+	if err != nil {
+		log.Error(err, "unable to retrieve gitopsengineinstance, on diff modified", "instanceId", application.Engine_instance_inst_id)
+		return nil, gitopserrors.NewDevOnlyError(err)
+	}
+	if gitopsEngineInstance == nil {
+		err := fmt.Errorf("gitopsengineinstance is nil, expected non-nil: %v", application.Engine_instance_inst_id)
+		log.Error(err, "unexpected nil value of required objects")
+		return nil, gitopserrors.NewDevOnlyError(err)
+	}
+
+	gitopsEngineClient, err := a.k8sClientFactory.GetK8sClientForGitOpsEngineInstance(ctx, gitopsEngineInstance)
+	if err != nil {
+		log.Error(err, "could not retrieve client for gitops engine instance", "instance", gitopsEngineInstance.Gitopsengineinstance_id)
+		return nil, gitopserrors.NewDevOnlyError(err)
+	}
+
+	// 2) Request the diff from the cluster-agent, and wait for it to be computed
+	dbOperationInput := db.Operation{
+		Instance_id:   gitopsEngineInstance.Gitopsengineinstance_id,
+		Resource_id:   application.Application_id,
+		Resource_type: db.OperationResourceType_ApplicationDiff,
+	}
+
+	waitForOperation := !a.testOnlySkipCreateOperation // if it's for a unit test, we don't wait for the operation
+	k8sOperation, dbOperation, err := operations.CreateOperation(ctx, waitForOperation, dbOperationInput,
+		clusterUser.Clusteruser_id, gitopsEngineInstance.Namespace_name, dbQueries, gitopsEngineClient, log)
+	if err != nil {
+		log.Error(err, "unable to create operation", "operation", dbOperationInput.ShortString())
+		return nil, gitopserrors.NewDevOnlyError(err)
+	}
+
+	if err := operations.CleanupOperation(ctx, *dbOperation, *k8sOperation, dbQueries, gitopsEngineClient, !a.testOnlySkipCreateOperation, log); err != nil {
+		log.Error(err, "unable to cleanup operation", "operation", dbOperationInput.ShortString())
+		return nil, gitopserrors.NewDevOnlyError(err)
+	}
+
+	if dbOperation.State == db.OperationState_Failed {
+		userError := "unable to compute the diff of the GitOpsDeployment: " + dbOperation.Human_readable_state
+		return nil, gitopserrors.NewUserDevError(userError, fmt.Errorf(userError))
+	}
+
+	// 3) Retrieve the diff from the result of the Operation
+	resourceDiffs, err := decompressResourceDiffs(dbOperation.Operation_result)
+	if err != nil {
+		log.Error(err, "unable to decompress the result of the operation", "operation", dbOperationInput.ShortString())
+		return nil, gitopserrors.NewDevOnlyError(err)
+	}
+
+	return resourceDiffs, nil
+}
+
+// Decompress byte array received from table and then convert it into a list of ResourceDiff.
+func decompressResourceDiffs(resultBytes []byte) ([]managedgitopsv1alpha1.ResourceDiff, error) {
+	if len(resultBytes) == 0 {
+		return nil, nil
+	}
+
+	var resourceDiffs []managedgitopsv1alpha1.ResourceDiff
+
+	objBytes, err := sharedutil.DecompressObject(resultBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress diff data: %v", err)
+	}
+
+	// Convert byte array to ResourceDiff array
+	err = goyaml.Unmarshal(objBytes, &resourceDiffs)
+	if err != nil {
+		return nil, fmt.Errorf("unable to Unmarshal diff data: %v", err)
+	}
+
+	return resourceDiffs, nil
+}
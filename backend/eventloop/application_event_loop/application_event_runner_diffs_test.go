@@ -0,0 +1,214 @@
+package application_event_loop
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
+	db "github.com/redhat-appstudio/managed-gitops/backend-shared/db"
+	sharedutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util"
+	"github.com/redhat-appstudio/managed-gitops/backend-shared/util/tests"
+	"github.com/redhat-appstudio/managed-gitops/backend/eventloop/shared_resource_loop"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	fake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("Application Event Runner Diffs", func() {
+
+	Context("Handle GitOpsDeploymentDiff", func() {
+
+		var (
+			k8sClient         *sharedutil.ProxyClient
+			gitopsDepl        *managedgitopsv1alpha1.GitOpsDeployment
+			gitopsDeplDiff    *managedgitopsv1alpha1.GitOpsDeploymentDiff
+			applicationAction applicationEventLoopRunner_Action
+			informer          sharedutil.ListEventReceiver
+		)
+		ctx := context.Background()
+
+		BeforeEach(func() {
+			scheme, argocdNamespace, kubesystemNamespace, workspace, err := tests.GenericTestSetup()
+			Expect(err).ToNot(HaveOccurred())
+
+			gitopsDepl = &managedgitopsv1alpha1.GitOpsDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-gitops-depl",
+					Namespace: workspace.Name,
+					UID:       uuid.NewUUID(),
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentSpec{
+					Type: managedgitopsv1alpha1.GitOpsDeploymentSpecType_Manual,
+					Source: managedgitopsv1alpha1.ApplicationSource{
+						Path:    "resources/test-data/sample-gitops-repository/environments/overlays/dev",
+						RepoURL: "https://github.com/test/test",
+					},
+				},
+			}
+
+			gitopsDeplDiff = &managedgitopsv1alpha1.GitOpsDeploymentDiff{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "gitops-diff",
+					Namespace:  gitopsDepl.Namespace,
+					UID:        uuid.NewUUID(),
+					Generation: 1,
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentDiffSpec{
+					GitopsDeploymentName: gitopsDepl.Name,
+				},
+			}
+
+			innerClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(workspace, argocdNamespace, kubesystemNamespace, gitopsDepl).Build()
+
+			informer = sharedutil.ListEventReceiver{}
+			k8sClient = &sharedutil.ProxyClient{
+				InnerClient: innerClient,
+				Informer:    &informer,
+			}
+
+			applicationAction = applicationEventLoopRunner_Action{
+				eventResourceName:           gitopsDeplDiff.Name,
+				eventResourceNamespace:      gitopsDeplDiff.Namespace,
+				sharedResourceEventLoop:     shared_resource_loop.NewSharedResourceLoop(),
+				workspaceClient:             k8sClient,
+				log:                         log.FromContext(ctx),
+				workspaceID:                 string(workspace.UID),
+				testOnlySkipCreateOperation: true,
+				k8sClientFactory: MockSRLK8sClientFactory{
+					fakeClient: k8sClient,
+				},
+			}
+		})
+
+		It("should do nothing if the GitOpsDeploymentDiff doesn't exist", func() {
+			err := applicationAction.applicationEventRunner_handleDiffModified(ctx, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(informer.Events).To(HaveLen(1))
+			Expect(informer.Events[0].Action).To(Equal(sharedutil.Get))
+		})
+
+		It("should not compute the diff again if it was already computed for the current generation", func() {
+			gitopsDeplDiff.Status = managedgitopsv1alpha1.GitOpsDeploymentDiffStatus{
+				ObservedGeneration: gitopsDeplDiff.Generation,
+				Phase:              managedgitopsv1alpha1.GitOpsDeploymentDiffPhase_Completed,
+			}
+			err := k8sClient.Create(ctx, gitopsDeplDiff)
+			Expect(err).ToNot(HaveOccurred())
+			informer.Events = nil
+
+			err = applicationAction.applicationEventRunner_handleDiffModified(ctx, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			By("verify that the GitOpsDeploymentDiff was only read, and not updated")
+			for _, event := range informer.Events {
+				Expect(event.Action).To(Equal(sharedutil.Get))
+			}
+		})
+
+		Context("with a database", func() {
+
+			var dbQueries db.AllDatabaseQueries
+
+			BeforeEach(func() {
+				err := db.SetupForTestingDBGinkgo()
+				Expect(err).ToNot(HaveOccurred())
+
+				dbQueries, err = db.NewUnsafePostgresDBQueries(true, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				deploymentAction := applicationAction
+				deploymentAction.eventResourceName = gitopsDepl.Name
+
+				_, _, _, _, userDevErr := deploymentAction.applicationEventRunner_handleDeploymentModified(ctx, dbQueries)
+				Expect(userDevErr).To(BeNil())
+			})
+
+			AfterEach(func() {
+				dbQueries.CloseDatabase()
+			})
+
+			It("should request the diff from the cluster-agent via an ApplicationDiff Operation, and report it as completed", func() {
+				err := k8sClient.Create(ctx, gitopsDeplDiff)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = applicationAction.applicationEventRunner_handleDiffModified(ctx, dbQueries)
+				Expect(err).ToNot(HaveOccurred())
+
+				By("verify that an Operation of type ApplicationDiff was created for the Application")
+				deplToAppMapping := &db.DeploymentToApplicationMapping{Deploymenttoapplicationmapping_uid_id: string(gitopsDepl.UID)}
+				err = dbQueries.GetDeploymentToApplicationMappingByDeplId(ctx, deplToAppMapping)
+				Expect(err).ToNot(HaveOccurred())
+
+				var dbOperations []db.Operation
+				err = dbQueries.UnsafeListAllOperations(ctx, &dbOperations)
+				Expect(err).ToNot(HaveOccurred())
+
+				diffOperationFound := false
+				for _, dbOperation := range dbOperations {
+					if dbOperation.Resource_type == db.OperationResourceType_ApplicationDiff &&
+						dbOperation.Resource_id == deplToAppMapping.Application_id {
+						diffOperationFound = true
+					}
+				}
+				Expect(diffOperationFound).To(BeTrue())
+
+				By("verify the status of the GitOpsDeploymentDiff")
+				err = k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDeplDiff), gitopsDeplDiff)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(gitopsDeplDiff.Status.Phase).To(Equal(managedgitopsv1alpha1.GitOpsDeploymentDiffPhase_Completed))
+				Expect(gitopsDeplDiff.Status.ObservedGeneration).To(Equal(gitopsDeplDiff.Generation))
+				Expect(gitopsDeplDiff.Status.ComparedAt).ToNot(BeNil())
+			})
+
+			It("should report the diff as failed if the GitOpsDeployment doesn't exist", func() {
+				gitopsDeplDiff.Spec.GitopsDeploymentName = "does-not-exist"
+				err := k8sClient.Create(ctx, gitopsDeplDiff)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = applicationAction.applicationEventRunner_handleDiffModified(ctx, dbQueries)
+				Expect(err).To(HaveOccurred())
+
+				err = k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDeplDiff), gitopsDeplDiff)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(gitopsDeplDiff.Status.Phase).To(Equal(managedgitopsv1alpha1.GitOpsDeploymentDiffPhase_Failed))
+				Expect(gitopsDeplDiff.Status.Message).To(ContainSubstring("does-not-exist"))
+			})
+		})
+	})
+
+	Context("Check decompressResourceDiffs function.", func() {
+		It("Should decompress the result of an Operation and return the list of ResourceDiff.", func() {
+			resourceDiffs := []managedgitopsv1alpha1.ResourceDiff{
+				{
+					Kind:      "ConfigMap",
+					Namespace: "my-namespace",
+					Name:      "my-config-map",
+					Diff:      "--- live\n+++ target\n@@ -1 +1 @@\n-key: old\n+key: new\n",
+				},
+				{
+					Group: "apps",
+					Kind:  "Deployment",
+					Name:  "my-deployment",
+					Diff:  "--- live\n+++ target\n",
+				},
+			}
+
+			compressedDiffs, err := sharedutil.CompressObject(resourceDiffs)
+			Expect(err).ToNot(HaveOccurred())
+
+			diffsOut, err := decompressResourceDiffs(compressedDiffs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diffsOut).To(Equal(resourceDiffs))
+		})
+
+		It("Shouldn't decompress if an empty result is provided", func() {
+			diffsOut, err := decompressResourceDiffs(nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(diffsOut).To(BeNil())
+		})
+	})
+})
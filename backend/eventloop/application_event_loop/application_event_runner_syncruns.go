@@ -3,6 +3,7 @@ package application_event_loop
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"time"
 
 	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
@@ -12,11 +13,14 @@ import (
 	"github.com/redhat-appstudio/managed-gitops/backend-shared/util/gitopserrors"
 	logutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util/log"
 	"github.com/redhat-appstudio/managed-gitops/backend-shared/util/operations"
+	"github.com/redhat-appstudio/managed-gitops/backend/condition"
 	"github.com/redhat-appstudio/managed-gitops/backend/eventloop/eventlooptypes"
+	goyaml "gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -24,6 +28,12 @@ const (
 	ErrDeploymentNameIsImmutable = "deployment name field is immutable: changing it from its initial value is not supported"
 
 	ErrRevisionIsImmutable = "revision change is not supported: changing it from its initial value is not supported"
+
+	ErrRollbackIsImmutable = "rollback target change is not supported: changing it from its initial value is not supported"
+
+	ErrSyncOptionsAreImmutable = "sync options change is not supported: changing prune, dryRun, force, strategy or resources from their initial values is not supported"
+
+	SyncRunTerminatedMessage = "the sync was terminated, as requested by .spec.terminate"
 )
 
 // This file is responsible for processing events related to GitOpsDeploymentSyncRun CR.
@@ -289,13 +299,22 @@ func (a *applicationEventLoopRunner_Action) applicationEventRunner_handleSyncRun
 		}
 
 		// return an error if 'Automated' sync policy is enabled. Argo CD doesn't allow syncing an Application with automated sync policy.
-		if gitopsDepl.Spec.Type != managedgitopsv1alpha1.GitOpsDeploymentSpecType_Manual {
+		// - Rollbacks are the exception: the cluster-agent disables automated sync before the rollback (see keepRollbackDeployed).
+		if gitopsDepl.Spec.Type != managedgitopsv1alpha1.GitOpsDeploymentSpecType_Manual && !syncRunCR.Spec.IsRollback() {
 			userErr := fmt.Sprintf("invalid GitOpsDeploymentSyncRun '%s'. Syncing a GitOpsDeployment with Automated sync policy is not allowed", syncRunCR.Name)
 			devErr := fmt.Errorf(userErr)
 			log.Error(devErr, "failed to process GitOpsDeploymentSyncRun")
 			return gitopserrors.NewUserDevError(userErr, devErr)
 		}
 
+		// return an error if a new SyncRun is created for a suspended GitOpsDeployment: nothing should be deployed until it is resumed.
+		if gitopsDepl.Spec.Suspend && !dbEntryExists {
+			userErr := fmt.Sprintf("invalid GitOpsDeploymentSyncRun '%s'. Syncing a suspended GitOpsDeployment is not allowed", syncRunCR.Name)
+			devErr := fmt.Errorf(userErr)
+			log.Error(devErr, "failed to process GitOpsDeploymentSyncRun")
+			return gitopserrors.NewUserDevError(userErr, devErr)
+		}
+
 		// The GitopsDepl CR exists, so use the UID of the CR to retrieve the database entry, if possible
 		deplToAppMapping := &db.DeploymentToApplicationMapping{Deploymenttoapplicationmapping_uid_id: string(gitopsDepl.UID)}
 
@@ -321,14 +340,31 @@ func (a *applicationEventLoopRunner_Action) applicationEventRunner_handleSyncRun
 			// Handle update:
 			// If both GitOpsDeploymentSyncRun CR and the DB entry exists, then the CR is being updated.
 			// Validate and return an error if the immutable fields are updated.
-			return a.handleUpdatedGitOpsDeplSyncRunEvent(ctx, syncRunCR, dbQueries, syncOperation)
+			return a.handleUpdatedGitOpsDeplSyncRunEvent(ctx, syncRunCR, dbQueries, syncOperation, namespace, *clusterUser)
 		} else {
 			// Handle create:
 			// If the gitopsdeplsyncrun CR exists, but the database entry doesn't, then this is the first time we
 			// have seen the GitOpsDeplSyncRun CR.
 			// Create it in the DB and create the operation.
 
-			return a.handleNewGitOpsDeplSyncRunEvent(ctx, syncRunCR, dbQueries, application, gitopsEngineInstance, namespace, *clusterUser)
+			if syncRunCR.Spec.Terminate {
+				userErr := fmt.Sprintf("invalid GitOpsDeploymentSyncRun '%s'. A GitOpsDeploymentSyncRun may not be created with .spec.terminate set", syncRunCR.Name)
+				devErr := fmt.Errorf(userErr)
+				log.Error(devErr, "failed to process GitOpsDeploymentSyncRun")
+				return gitopserrors.NewUserDevError(userErr, devErr)
+			}
+
+			var rollbackTarget *managedgitopsv1alpha1.DeploymentHistoryEntry
+			if syncRunCR.Spec.IsRollback() {
+				if rollbackTarget = findRollbackTarget(syncRunCR.Spec, gitopsDepl.Status.History); rollbackTarget == nil {
+					userErr := fmt.Sprintf("invalid GitOpsDeploymentSyncRun '%s'. Unable to locate a successful deployment to roll back to in the history of GitOpsDeployment '%s'", syncRunCR.Name, gitopsDepl.Name)
+					devErr := fmt.Errorf(userErr)
+					log.Error(devErr, "failed to process GitOpsDeploymentSyncRun")
+					return gitopserrors.NewUserDevError(userErr, devErr)
+				}
+			}
+
+			return a.handleNewGitOpsDeplSyncRunEvent(ctx, syncRunCR, dbQueries, application, gitopsEngineInstance, namespace, *clusterUser, rollbackTarget)
 		}
 
 	}
@@ -394,6 +430,44 @@ func (a *applicationEventLoopRunner_Action) handleDeletedGitOpsDeplSyncRunEvent(
 		return nil
 	}
 
+	// Terminate the SyncOperation, if it is running
+	if err := a.terminateSyncOperation(ctx, dbQueries, syncOperation, namespace, *clusterUser); err != nil {
+		return err
+	}
+
+	var allErrors error
+
+	// Remove the mappings and their associated operations and syncoperations.
+	for idx := range apiCRToDBList {
+
+		apiCRToDB := apiCRToDBList[idx]
+
+		err := a.cleanupOldSyncDBEntry(ctx, &apiCRToDB, *clusterUser, dbQueries)
+		if err != nil {
+			if allErrors == nil {
+				allErrors = err
+			} else {
+				allErrors = fmt.Errorf("error: %v error: %v", err, allErrors)
+			}
+		}
+	}
+
+	if allErrors != nil {
+		return gitopserrors.NewDevOnlyError(allErrors)
+	}
+
+	// Success: the CR no longer exists, and we have completed cleanup.
+	return nil
+
+}
+
+// terminateSyncOperation updates the desired state of the SyncOperation to 'Terminated', and informs the cluster-agent
+// component (via Operation), so that it can terminate the Argo CD sync operation, if it is still running.
+func (a *applicationEventLoopRunner_Action) terminateSyncOperation(ctx context.Context, dbQueries db.ApplicationScopedQueries, syncOperation db.SyncOperation,
+	namespace corev1.Namespace, clusterUser db.ClusterUser) gitopserrors.UserError {
+
+	log := a.log
+
 	// 1) Update the state of the SyncOperation DB table to say that we want to terminate it, if it is runing
 	syncOperation.DesiredState = db.SyncOperation_DesiredState_Terminated
 	if err := dbQueries.UpdateSyncOperation(ctx, &syncOperation); err != nil {
@@ -434,7 +508,7 @@ func (a *applicationEventLoopRunner_Action) handleDeletedGitOpsDeplSyncRunEvent(
 	// 2) Create the operation, in order to inform the cluster agent it needs to cancel the sync operation
 	operationClient, err := a.k8sClientFactory.GetK8sClientForGitOpsEngineInstance(ctx, gitopsEngineInstance)
 	if err != nil {
-		log.Error(err, "unable to retrieve gitopsengine instance from handleSyncRunModified, when resource was deleted")
+		log.Error(err, "unable to retrieve gitopsengine instance from handleSyncRunModified, when terminating sync operation")
 		return gitopserrors.NewDevOnlyError(err)
 	}
 
@@ -442,50 +516,114 @@ func (a *applicationEventLoopRunner_Action) handleDeletedGitOpsDeplSyncRunEvent(
 	k8sOperation, dbOperation, err := operations.CreateOperation(ctx, waitForOperation, dbOperationInput, clusterUser.Clusteruser_id,
 		gitopsEngineInstance.Namespace_name, dbQueries, operationClient, log)
 	if err != nil {
-		log.Error(err, "could not create operation, when resource was deleted", "namespace", gitopsEngineInstance.Namespace_name)
+		log.Error(err, "could not create operation, when terminating sync operation", "namespace", gitopsEngineInstance.Namespace_name)
 
 		return gitopserrors.NewDevOnlyError(err)
 	}
 
-	// 3) Clean up the operation and database table entries
+	// 3) Clean up the operation
 	if err := operations.CleanupOperation(ctx, *dbOperation, *k8sOperation, dbQueries, operationClient, !a.testOnlySkipCreateOperation, log); err != nil {
 		return gitopserrors.NewDevOnlyError(err)
 	}
 
-	var allErrors error
+	return nil
+}
 
-	// Remove the mappings and their associated operations and syncoperations.
-	for idx := range apiCRToDBList {
+// handleTerminatedGitOpsDeplSyncRunEvent handles GitOpsDeploymentSyncRun events where the user has requested that the sync
+// be terminated, by setting .spec.terminate.
+// In this case, we terminate the SyncOperation and mark the GitOpsDeploymentSyncRun as 'Terminated'. Unlike when the
+// GitOpsDeploymentSyncRun is deleted, the database entries are retained, as the resource (and its status) still exists.
+//
+// Returns:
+// - error is non-nil, if an error occurred
+func (a *applicationEventLoopRunner_Action) handleTerminatedGitOpsDeplSyncRunEvent(ctx context.Context, syncRunCR *managedgitopsv1alpha1.GitOpsDeploymentSyncRun,
+	dbQueries db.ApplicationScopedQueries, syncOperation db.SyncOperation, namespace corev1.Namespace, clusterUser db.ClusterUser) gitopserrors.UserError {
 
-		apiCRToDB := apiCRToDBList[idx]
+	log := a.log
+	log.Info("Received GitOpsDeploymentSyncRun event for a GitOpsDeploymentSyncRun resource that should be terminated")
 
-		err := a.cleanupOldSyncDBEntry(ctx, &apiCRToDB, *clusterUser, dbQueries)
-		if err != nil {
-			if allErrors == nil {
-				allErrors = err
-			} else {
-				allErrors = fmt.Errorf("error: %v error: %v", err, allErrors)
-			}
-		}
+	if err := a.terminateSyncOperation(ctx, dbQueries, syncOperation, namespace, clusterUser); err != nil {
+		return err
 	}
 
-	if allErrors != nil {
-		return gitopserrors.NewDevOnlyError(allErrors)
+	if err := a.setSyncRunTerminatedStatus(ctx, syncRunCR); err != nil && !apierr.IsNotFound(err) {
+		log.Error(err, "unable to update the status of GitOpsDeploymentSyncRun as terminated")
+		return gitopserrors.NewDevOnlyError(err)
 	}
 
-	// Success: the CR no longer exists, and we have completed cleanup.
 	return nil
+}
+
+// setSyncRunTerminatedStatus retrieves the latest version of the GitOpsDeploymentSyncRun, and marks it as terminated.
+func (a *applicationEventLoopRunner_Action) setSyncRunTerminatedStatus(ctx context.Context, syncRunCRParam *managedgitopsv1alpha1.GitOpsDeploymentSyncRun) error {
+
+	syncRunCR, err := getGitOpsDeploymentSyncRun(ctx, a.workspaceClient, syncRunCRParam.Name, syncRunCRParam.Namespace)
+	if err != nil {
+		return err
+	}
+
+	if syncRunCR.UID != syncRunCRParam.UID {
+		// The SyncRun was deleted and recreated, so this status no longer applies to it
+		return nil
+	}
+
+	setSyncRunTerminated(syncRunCR, metav1.Now())
+
+	return a.workspaceClient.Status().Update(ctx, syncRunCR)
+}
 
+// setSyncRunTerminated sets the status of the GitOpsDeploymentSyncRun to indicate that the sync was terminated.
+func setSyncRunTerminated(syncRunCR *managedgitopsv1alpha1.GitOpsDeploymentSyncRun, now metav1.Time) {
+
+	syncRunCR.Status.Phase = managedgitopsv1alpha1.SyncRunPhase_Terminated
+	syncRunCR.Status.Message = SyncRunTerminatedMessage
+	syncRunCR.Status.FinishedAt = &now
+	if syncRunCR.Status.StartedAt == nil {
+		syncRunCR.Status.StartedAt = &now
+	}
+
+	if syncRunCR.Status.Rollback != nil && syncRunCR.Status.Rollback.Phase == managedgitopsv1alpha1.OperationRunning {
+		syncRunCR.Status.Rollback.Phase = managedgitopsv1alpha1.OperationFailed
+		syncRunCR.Status.Rollback.Message = SyncRunTerminatedMessage
+	}
+}
+
+// findRollbackTarget returns the entry of the GitOpsDeployment's deployment history that the GitOpsDeploymentSyncRun
+// requests a rollback to, or nil if there is no such entry.
+// - Only entries with an ID can be rolled back to: these are the deployments that are known to Argo CD.
+// - When a revision is requested, the most recent deployment of that revision is returned.
+func findRollbackTarget(spec managedgitopsv1alpha1.GitOpsDeploymentSyncRunSpec, history []managedgitopsv1alpha1.DeploymentHistoryEntry) *managedgitopsv1alpha1.DeploymentHistoryEntry {
+
+	// History is ordered from oldest to newest, so iterate in reverse to find the most recent match
+	for idx := len(history) - 1; idx >= 0; idx-- {
+		entry := history[idx]
+
+		if entry.ID == nil {
+			continue
+		}
+
+		if spec.RollbackToHistoryID != nil && *entry.ID == *spec.RollbackToHistoryID {
+			return &entry
+		}
+
+		if spec.RollbackToRevision != "" && entry.Revision == spec.RollbackToRevision {
+			return &entry
+		}
+	}
+
+	return nil
 }
 
 // handleNewGitOpsDeplSyncRunEvent handles GitOpsDeploymentSyncRun events where the user has just created a new GitOpsDeploymentSyncRun resource.
 // In this case, we need to create SyncOperation and APICRToDBMapping rows in the database.
 //
 // Finally, we need to inform the cluster-agent component (via Operation), so that it can sync the Argo CD Application.
+// If rollbackTarget is non-nil, the cluster-agent will instead roll back the Argo CD Application to that deployment,
+// and the progress of the rollback is reported in the status of the GitOpsDeploymentSyncRun.
 //
 // Returns:
 // - error is non-nil, if an error occurred
-func (a *applicationEventLoopRunner_Action) handleNewGitOpsDeplSyncRunEvent(ctx context.Context, syncRunCRParam *managedgitopsv1alpha1.GitOpsDeploymentSyncRun, dbQueries db.ApplicationScopedQueries, application *db.Application, gitopsEngineInstance *db.GitopsEngineInstance, namespace corev1.Namespace, clusterUser db.ClusterUser) gitopserrors.UserError {
+func (a *applicationEventLoopRunner_Action) handleNewGitOpsDeplSyncRunEvent(ctx context.Context, syncRunCRParam *managedgitopsv1alpha1.GitOpsDeploymentSyncRun, dbQueries db.ApplicationScopedQueries, application *db.Application, gitopsEngineInstance *db.GitopsEngineInstance, namespace corev1.Namespace, clusterUser db.ClusterUser, rollbackTarget *managedgitopsv1alpha1.DeploymentHistoryEntry) gitopserrors.UserError {
 
 	log := a.log
 	log.Info("Received GitOpsDeploymentSyncRun event for a new GitOpsDeploymentSyncRun resource")
@@ -511,6 +649,15 @@ func (a *applicationEventLoopRunner_Action) handleNewGitOpsDeplSyncRunEvent(ctx
 		Revision:            syncRunCRParam.Spec.RevisionID,
 		DesiredState:        db.SyncOperation_DesiredState_Running,
 	}
+	if rollbackTarget != nil {
+		syncOperation.Revision = rollbackTarget.Revision
+		syncOperation.RollbackHistoryID = rollbackTarget.ID
+	}
+	if err := setSyncOperationOptions(syncOperation, syncRunCRParam.Spec); err != nil {
+		log.Error(err, "unable to convert the sync options of GitOpsDeploymentSyncRun")
+
+		return gitopserrors.NewDevOnlyError(err)
+	}
 	if err := dbQueries.CreateSyncOperation(ctx, syncOperation); err != nil {
 		log.Error(err, "unable to create sync operation in database")
 
@@ -551,6 +698,21 @@ func (a *applicationEventLoopRunner_Action) handleNewGitOpsDeplSyncRunEvent(ctx
 		return gitopserrors.NewDevOnlyError(err)
 	}
 
+	if rollbackTarget != nil {
+		rollbackStatus := managedgitopsv1alpha1.SyncRunRollbackStatus{
+			HistoryID: *rollbackTarget.ID,
+			Revision:  rollbackTarget.Revision,
+			Phase:     managedgitopsv1alpha1.OperationRunning,
+		}
+		if err := a.setSyncRunRollbackStatus(ctx, syncRunCRParam, rollbackStatus); err != nil {
+			log.Error(err, "unable to update the rollback status of GitOpsDeploymentSyncRun")
+
+			dbutil.DisposeApplicationScopedResources(ctx, createdResources, dbQueries, log)
+
+			return gitopserrors.NewDevOnlyError(err)
+		}
+	}
+
 	dbOperationInput := db.Operation{
 		Instance_id:   gitopsEngineInstance.Gitopsengineinstance_id,
 		Resource_id:   syncOperation.SyncOperation_id,
@@ -570,6 +732,9 @@ func (a *applicationEventLoopRunner_Action) handleNewGitOpsDeplSyncRunEvent(ctx
 
 	backoff := sharedutil.ExponentialBackoff{Factor: 1.3, Min: time.Millisecond * 1000, Max: time.Second * 10, Jitter: true}
 
+	// terminateRequested is true if the user requested that the sync be terminated, while we were waiting for it to complete
+	terminateRequested := false
+
 outer_for:
 
 	for {
@@ -587,6 +752,11 @@ outer_for:
 			break outer_for
 		}
 
+		// Report the progress of the sync, while we wait for it to complete
+		if err := a.updateSyncRunLifecycleStatus(ctx, syncRunCRParam, dbQueries, application.Application_id, dbOperation); err != nil && !apierr.IsNotFound(err) {
+			log.Error(err, "unable to update the status of GitOpsDeploymentSyncRun")
+		}
+
 		currentSyncRunCR := syncRunCRParam.DeepCopy()
 		if err := a.workspaceClient.Get(ctx, client.ObjectKeyFromObject(currentSyncRunCR), currentSyncRunCR); err != nil {
 
@@ -608,25 +778,324 @@ outer_for:
 				log.Info("The SyncRun CR UID has changed, versus the SyncRun CR that we began with, exiting the sync process")
 				break outer_for
 			}
+
+			if currentSyncRunCR.Spec.Terminate {
+				// The user has requested that the sync be terminated: stop waiting, and terminate it below.
+				log.Info("The SyncRun CR has requested that the sync be terminated, exiting the sync process")
+				terminateRequested = true
+				break outer_for
+			}
 		}
 
 		backoff.DelayOnFail(ctx)
 
 	}
 
+	// Report the result of the sync: if the operation is not yet complete (for example, because the SyncRun was deleted),
+	// the last known state is reported.
+	if err := a.updateSyncRunLifecycleStatus(ctx, syncRunCRParam, dbQueries, application.Application_id, dbOperation); err != nil && !apierr.IsNotFound(err) {
+		log.Error(err, "unable to update the status of GitOpsDeploymentSyncRun")
+	}
+
+	// Report the result of the rollback, once the cluster-agent has finished processing the Operation
+	if rollbackTarget != nil && (dbOperation.State == db.OperationState_Completed || dbOperation.State == db.OperationState_Failed) {
+		rollbackStatus := managedgitopsv1alpha1.SyncRunRollbackStatus{
+			HistoryID: *rollbackTarget.ID,
+			Revision:  rollbackTarget.Revision,
+			Phase:     managedgitopsv1alpha1.OperationSucceeded,
+		}
+		if dbOperation.State == db.OperationState_Failed {
+			rollbackStatus.Phase = managedgitopsv1alpha1.OperationFailed
+			rollbackStatus.Message = dbOperation.Human_readable_state
+		}
+
+		if err := a.setSyncRunRollbackStatus(ctx, syncRunCRParam, rollbackStatus); err != nil && !apierr.IsNotFound(err) {
+			log.Error(err, "unable to update the rollback status of GitOpsDeploymentSyncRun")
+		}
+
+		if rollbackStatus.Phase == managedgitopsv1alpha1.OperationSucceeded {
+			if err := a.keepRollbackDeployed(ctx, syncRunCRParam.Spec.GitopsDeploymentName, *rollbackTarget, dbQueries); err != nil {
+				log.Error(err, "unable to disable automated sync of GitOpsDeployment after rollback")
+			}
+		}
+	}
+
 	if err := operations.CleanupOperation(ctx, *dbOperation, *k8sOperation, dbQueries, operationClient, !a.testOnlySkipCreateOperation, log); err != nil {
 		return gitopserrors.NewDevOnlyError(err)
 	}
 
+	if terminateRequested {
+		return a.handleTerminatedGitOpsDeplSyncRunEvent(ctx, syncRunCRParam, dbQueries, *syncOperation, namespace, clusterUser)
+	}
+
+	return nil
+}
+
+// updateSyncRunLifecycleStatus retrieves the latest version of the GitOpsDeploymentSyncRun, and updates its phase, timestamps,
+// revision and per-resource results, based on the state of its Operation and the operation state of the Argo CD Application.
+func (a *applicationEventLoopRunner_Action) updateSyncRunLifecycleStatus(ctx context.Context, syncRunCRParam *managedgitopsv1alpha1.GitOpsDeploymentSyncRun,
+	dbQueries db.ApplicationScopedQueries, applicationID string, dbOperation *db.Operation) error {
+
+	syncRunCR, err := getGitOpsDeploymentSyncRun(ctx, a.workspaceClient, syncRunCRParam.Name, syncRunCRParam.Namespace)
+	if err != nil {
+		return err
+	}
+
+	if syncRunCR.UID != syncRunCRParam.UID {
+		// The SyncRun was deleted and recreated, so this status no longer applies to it
+		return nil
+	}
+
+	applicationState := db.ApplicationState{Applicationstate_application_id: applicationID}
+	if err := dbQueries.GetApplicationStateById(ctx, &applicationState); err != nil && !db.IsResultNotFoundError(err) {
+		return err
+	}
+
+	operationState, err := decompressOperationState(applicationState.OperationState)
+	if err != nil {
+		return err
+	}
+
+	originalStatus := syncRunCR.Status.DeepCopy()
+
+	setSyncRunLifecycleStatus(syncRunCR, dbOperation, operationState, metav1.Now())
+
+	if reflect.DeepEqual(*originalStatus, syncRunCR.Status) {
+		return nil
+	}
+
+	if err := a.workspaceClient.Status().Update(ctx, syncRunCR); err != nil {
+		return err
+	}
+
+	a.recordSyncRunPhaseEvent(syncRunCR, originalStatus.Phase)
+
+	return nil
+}
+
+// updateLatestSyncRunStatus updates the lifecycle status of the most recently created GitOpsDeploymentSyncRun of the
+// GitOpsDeployment, from the operation state of the Argo CD Application.
+// - This ensures the results of a sync are reported, even when they only become available after the Operation of the
+// SyncRun has completed.
+func (a *applicationEventLoopRunner_Action) updateLatestSyncRunStatus(ctx context.Context, gitopsDeployment *managedgitopsv1alpha1.GitOpsDeployment,
+	operationState *managedgitopsv1alpha1.OperationState) error {
+
+	syncRunList := managedgitopsv1alpha1.GitOpsDeploymentSyncRunList{}
+	if err := a.workspaceClient.List(ctx, &syncRunList, client.InNamespace(gitopsDeployment.Namespace),
+		client.MatchingFields{eventlooptypes.SyncRunGitOpsDeploymentNameIndex: gitopsDeployment.Name}); err != nil {
+		return err
+	}
+
+	var latestSyncRun *managedgitopsv1alpha1.GitOpsDeploymentSyncRun
+	for idx := range syncRunList.Items {
+		syncRun := &syncRunList.Items[idx]
+
+		// Not every client supports field indexes (for example, the fake client ignores them), so the name is checked here as well.
+		if syncRun.Spec.GitopsDeploymentName != gitopsDeployment.Name {
+			continue
+		}
+
+		if latestSyncRun == nil || latestSyncRun.CreationTimestamp.Before(&syncRun.CreationTimestamp) {
+			latestSyncRun = syncRun
+		}
+	}
+
+	// SyncRuns that have not yet been processed by the event loop have no phase, and are not updated here
+	if latestSyncRun == nil || latestSyncRun.Status.Phase == "" {
+		return nil
+	}
+
+	originalStatus := latestSyncRun.Status.DeepCopy()
+
+	setSyncRunLifecycleStatus(latestSyncRun, nil, operationState, metav1.Now())
+
+	if reflect.DeepEqual(*originalStatus, latestSyncRun.Status) {
+		return nil
+	}
+
+	if err := a.workspaceClient.Status().Update(ctx, latestSyncRun); err != nil {
+		return err
+	}
+
+	a.recordSyncRunPhaseEvent(latestSyncRun, originalStatus.Phase)
+
+	return nil
+}
+
+// recordSyncRunPhaseEvent records a Kubernetes Event on the GitOpsDeploymentSyncRun if its phase has changed from the
+// previous phase to one of Running, Succeeded or Failed.
+func (a *applicationEventLoopRunner_Action) recordSyncRunPhaseEvent(syncRunCR *managedgitopsv1alpha1.GitOpsDeploymentSyncRun,
+	previousPhase managedgitopsv1alpha1.SyncRunPhase) {
+
+	if syncRunCR.Status.Phase == previousPhase {
+		return
+	}
+
+	syncType := "Sync"
+	if syncRunCR.Spec.DryRun {
+		syncType = "Dry-run sync"
+	}
+
+	switch syncRunCR.Status.Phase {
+	case managedgitopsv1alpha1.SyncRunPhase_Running:
+		a.recordEvent(syncRunCR, corev1.EventTypeNormal, eventlooptypes.EventReasonSyncStarted,
+			"%s of GitOpsDeployment '%s' started", syncType, syncRunCR.Spec.GitopsDeploymentName)
+	case managedgitopsv1alpha1.SyncRunPhase_Succeeded:
+		a.recordEvent(syncRunCR, corev1.EventTypeNormal, eventlooptypes.EventReasonSyncSucceeded,
+			"%s of GitOpsDeployment '%s' succeeded", syncType, syncRunCR.Spec.GitopsDeploymentName)
+	case managedgitopsv1alpha1.SyncRunPhase_Failed:
+		a.recordEvent(syncRunCR, corev1.EventTypeWarning, eventlooptypes.EventReasonSyncFailed,
+			"%s of GitOpsDeployment '%s' failed: %s", syncType, syncRunCR.Spec.GitopsDeploymentName, syncRunCR.Status.Message)
+	}
+}
+
+// setSyncRunLifecycleStatus sets the phase, timestamps, revision and per-resource results of the GitOpsDeploymentSyncRun:
+// - operationState, if non-nil, is the operation state of the Argo CD Application. It is only used if the operation
+// started after the SyncRun was created (otherwise it describes a previous sync).
+// - dbOperation, if non-nil, is the Operation of the SyncRun: when known, its state determines the phase.
+func setSyncRunLifecycleStatus(syncRunCR *managedgitopsv1alpha1.GitOpsDeploymentSyncRun, dbOperation *db.Operation,
+	operationState *managedgitopsv1alpha1.OperationState, now metav1.Time) {
+
+	status := &syncRunCR.Status
+
+	if operationState != nil && !operationState.StartedAt.Before(&syncRunCR.CreationTimestamp) {
+
+		startedAt := operationState.StartedAt
+		status.StartedAt = &startedAt
+		if status.Phase != managedgitopsv1alpha1.SyncRunPhase_Terminated {
+			// Retain the reason that the sync was terminated
+			status.Message = operationState.Message
+		}
+
+		if operationState.SyncResult != nil {
+			status.Revision = operationState.SyncResult.Revision
+			status.Resources = nil
+			for _, resource := range operationState.SyncResult.Resources {
+				if resource != nil {
+					status.Resources = append(status.Resources, *resource)
+				}
+			}
+		}
+
+		if operationState.FinishedAt != nil {
+			finishedAt := *operationState.FinishedAt
+			status.FinishedAt = &finishedAt
+
+			if dbOperation == nil && !status.Phase.IsCompleted() {
+				if operationState.Phase == managedgitopsv1alpha1.OperationSucceeded {
+					status.Phase = managedgitopsv1alpha1.SyncRunPhase_Succeeded
+				} else {
+					status.Phase = managedgitopsv1alpha1.SyncRunPhase_Failed
+				}
+			}
+
+		} else if dbOperation == nil && status.Phase == managedgitopsv1alpha1.SyncRunPhase_Pending {
+			status.Phase = managedgitopsv1alpha1.SyncRunPhase_Running
+		}
+	}
+
+	if dbOperation != nil {
+		switch dbOperation.State {
+		case db.OperationState_Waiting:
+			if status.Phase == "" {
+				status.Phase = managedgitopsv1alpha1.SyncRunPhase_Pending
+			}
+		case db.OperationState_In_Progress:
+			status.Phase = managedgitopsv1alpha1.SyncRunPhase_Running
+		case db.OperationState_Completed:
+			status.Phase = managedgitopsv1alpha1.SyncRunPhase_Succeeded
+		case db.OperationState_Failed:
+			status.Phase = managedgitopsv1alpha1.SyncRunPhase_Failed
+			if dbOperation.Human_readable_state != "" {
+				status.Message = dbOperation.Human_readable_state
+			}
+		}
+	}
+
+	if status.Phase == managedgitopsv1alpha1.SyncRunPhase_Running && status.StartedAt == nil {
+		status.StartedAt = &now
+	}
+
+	if status.Phase.IsCompleted() {
+		if status.StartedAt == nil {
+			status.StartedAt = &now
+		}
+		if status.FinishedAt == nil {
+			status.FinishedAt = &now
+		}
+	}
+}
+
+// setSyncRunRollbackStatus retrieves the latest version of the GitOpsDeploymentSyncRun, and updates its .status.rollback field.
+func (a *applicationEventLoopRunner_Action) setSyncRunRollbackStatus(ctx context.Context, syncRunCRParam *managedgitopsv1alpha1.GitOpsDeploymentSyncRun, rollbackStatus managedgitopsv1alpha1.SyncRunRollbackStatus) error {
+
+	syncRunCR, err := getGitOpsDeploymentSyncRun(ctx, a.workspaceClient, syncRunCRParam.Name, syncRunCRParam.Namespace)
+	if err != nil {
+		return err
+	}
+
+	if syncRunCR.UID != syncRunCRParam.UID {
+		// The SyncRun was deleted and recreated, so this status no longer applies to it
+		return nil
+	}
+
+	syncRunCR.Status.Rollback = &rollbackStatus
+
+	return a.workspaceClient.Status().Update(ctx, syncRunCR)
+}
+
+// keepRollbackDeployed sets the RolledBack condition of a GitOpsDeployment that was rolled back, and then updates its
+// Application DB row (and thus its Argo CD Application) so that automated sync remains disabled, until the spec of the
+// GitOpsDeployment is changed.
+//
+// The cluster-agent disables automated sync before the rollback: without this, the automated sync policy of the
+// Application DB row would be restored by the cluster-agent, and Argo CD would then sync it back to the latest revision.
+func (a *applicationEventLoopRunner_Action) keepRollbackDeployed(ctx context.Context, gitopsDeploymentName string,
+	rollbackTarget managedgitopsv1alpha1.DeploymentHistoryEntry, dbQueries db.ApplicationScopedQueries) error {
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		gitopsDeployment := &managedgitopsv1alpha1.GitOpsDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      gitopsDeploymentName,
+				Namespace: a.eventResourceNamespace,
+			},
+		}
+		if err := a.workspaceClient.Get(ctx, client.ObjectKeyFromObject(gitopsDeployment), gitopsDeployment); err != nil {
+			return err
+		}
+
+		conditionManager := condition.NewConditionManager()
+		conditionManager.SetCondition(&gitopsDeployment.Status.Conditions, managedgitopsv1alpha1.GitOpsDeploymentConditionRolledBack,
+			managedgitopsv1alpha1.GitOpsConditionStatusTrue, managedgitopsv1alpha1.GitopsDeploymentReasonRolledBack,
+			fmt.Sprintf(managedgitopsv1alpha1.GitOpsDeploymentUserError_RolledBack, rollbackTarget.Revision))
+
+		rolledBack, _ := conditionManager.FindCondition(&gitopsDeployment.Status.Conditions, managedgitopsv1alpha1.GitOpsDeploymentConditionRolledBack)
+		rolledBack.ObservedGeneration = gitopsDeployment.Generation
+
+		return a.workspaceClient.Status().Update(ctx, gitopsDeployment)
+	}); err != nil {
+		return fmt.Errorf("unable to set the RolledBack condition of GitOpsDeployment: %v", err)
+	}
+
+	// Process the GitOpsDeployment, which updates the Application DB row based on the RolledBack condition.
+	deploymentAction := *a
+	deploymentAction.eventResourceName = gitopsDeploymentName
+
+	if _, _, _, _, err := deploymentAction.applicationEventRunner_handleDeploymentModified(ctx, dbQueries); err != nil {
+		return fmt.Errorf("unable to update the Application of GitOpsDeployment after rollback: %v", err.DevError())
+	}
+
 	return nil
 }
 
 // handleUpdatedGitOpsDeplSyncRunEvent handles GitOpsDeploymentSyncRun events where the user has just updated an existing GitOpsDeploymentSyncRun resource.
 // In this case, we need to ensure that the immutable fields GitOpsDeploymentName and RevisionID are not updated.
+// If the user has requested that the sync be terminated (and it has not yet completed), the sync is terminated.
 //
 // Returns:
 // - error is non-nil, if an error occurred
-func (a *applicationEventLoopRunner_Action) handleUpdatedGitOpsDeplSyncRunEvent(ctx context.Context, syncRunCR *managedgitopsv1alpha1.GitOpsDeploymentSyncRun, dbQueries db.ApplicationScopedQueries, syncOperation db.SyncOperation) gitopserrors.UserError {
+func (a *applicationEventLoopRunner_Action) handleUpdatedGitOpsDeplSyncRunEvent(ctx context.Context, syncRunCR *managedgitopsv1alpha1.GitOpsDeploymentSyncRun, dbQueries db.ApplicationScopedQueries, syncOperation db.SyncOperation,
+	namespace corev1.Namespace, clusterUser db.ClusterUser) gitopserrors.UserError {
 	log := a.log
 	log.Info("Received GitOpsDeploymentSyncRun event for an existing GitOpsDeploymentSyncRun resource")
 
@@ -636,15 +1105,103 @@ func (a *applicationEventLoopRunner_Action) handleUpdatedGitOpsDeplSyncRunEvent(
 		return gitopserrors.NewUserDevError(ErrDeploymentNameIsImmutable, err)
 	}
 
-	if syncOperation.Revision != syncRunCR.Spec.RevisionID {
+	if syncOperation.RollbackHistoryID != nil {
+		// The SyncOperation is a rollback: the revision was resolved from the history of the GitOpsDeployment, so
+		// instead verify that the rollback target has not changed.
+		if !syncRunCR.Spec.IsRollback() || (syncRunCR.Spec.RollbackToHistoryID != nil && *syncRunCR.Spec.RollbackToHistoryID != *syncOperation.RollbackHistoryID) ||
+			(syncRunCR.Spec.RollbackToRevision != "" && syncRunCR.Spec.RollbackToRevision != syncOperation.Revision) {
+
+			err := fmt.Errorf(ErrRollbackIsImmutable)
+			log.Error(err, ErrRollbackIsImmutable)
+			return gitopserrors.NewUserDevError(ErrRollbackIsImmutable, err)
+		}
+
+	} else if syncOperation.Revision != syncRunCR.Spec.RevisionID {
 		err := fmt.Errorf(ErrRevisionIsImmutable)
 		log.Error(err, ErrRevisionIsImmutable)
 		return gitopserrors.NewUserDevError(ErrRevisionIsImmutable, err)
 	}
 
+	unchanged, err := syncOperationOptionsMatch(syncOperation, syncRunCR.Spec)
+	if err != nil {
+		log.Error(err, "unable to compare the sync options of GitOpsDeploymentSyncRun")
+		return gitopserrors.NewDevOnlyError(err)
+	}
+	if !unchanged {
+		err := fmt.Errorf(ErrSyncOptionsAreImmutable)
+		log.Error(err, ErrSyncOptionsAreImmutable)
+		return gitopserrors.NewUserDevError(ErrSyncOptionsAreImmutable, err)
+	}
+
+	if syncRunCR.Spec.Terminate && syncOperation.DesiredState == db.SyncOperation_DesiredState_Running && !syncRunCR.Status.Phase.IsCompleted() {
+		return a.handleTerminatedGitOpsDeplSyncRunEvent(ctx, syncRunCR, dbQueries, syncOperation, namespace, clusterUser)
+	}
+
 	return nil
 }
 
+// setSyncOperationOptions copies the sync options (prune, dryRun, force, strategy and resources) of the
+// GitOpsDeploymentSyncRun into the SyncOperation.
+func setSyncOperationOptions(syncOperation *db.SyncOperation, spec managedgitopsv1alpha1.GitOpsDeploymentSyncRunSpec) error {
+
+	syncOperation.Prune = spec.Prune
+	syncOperation.DryRun = spec.DryRun
+	syncOperation.Force = spec.Force
+	syncOperation.SyncStrategy = string(spec.Strategy)
+	syncOperation.Resources = nil
+
+	if len(spec.Resources) > 0 {
+		resources, err := sharedutil.CompressObject(spec.Resources)
+		if err != nil {
+			return fmt.Errorf("unable to compress the resources of the sync operation: %v", err)
+		}
+		syncOperation.Resources = resources
+	}
+
+	return nil
+}
+
+// syncOperationOptionsMatch returns true if the sync options of the SyncOperation are the same as those of the GitOpsDeploymentSyncRun.
+func syncOperationOptionsMatch(syncOperation db.SyncOperation, spec managedgitopsv1alpha1.GitOpsDeploymentSyncRunSpec) (bool, error) {
+
+	if syncOperation.Prune != spec.Prune || syncOperation.DryRun != spec.DryRun || syncOperation.Force != spec.Force ||
+		syncOperation.SyncStrategy != string(spec.Strategy) {
+		return false, nil
+	}
+
+	resources, err := decompressSyncOperationResources(syncOperation.Resources)
+	if err != nil {
+		return false, err
+	}
+
+	if len(resources) == 0 && len(spec.Resources) == 0 {
+		return true, nil
+	}
+
+	return reflect.DeepEqual(resources, spec.Resources), nil
+}
+
+// Decompress byte array received from table and then convert it into a list of SyncOperationResource.
+func decompressSyncOperationResources(resourcesBytes []byte) ([]managedgitopsv1alpha1.SyncOperationResource, error) {
+	if len(resourcesBytes) == 0 {
+		return nil, nil
+	}
+
+	var resources []managedgitopsv1alpha1.SyncOperationResource
+
+	objBytes, err := sharedutil.DecompressObject(resourcesBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress sync operation resources: %v", err)
+	}
+
+	err = goyaml.Unmarshal(objBytes, &resources)
+	if err != nil {
+		return nil, fmt.Errorf("unable to Unmarshal sync operation resources: %v", err)
+	}
+
+	return resources, nil
+}
+
 func (a *applicationEventLoopRunner_Action) cleanupOldSyncDBEntry(ctx context.Context, apiCRToDB *db.APICRToDatabaseMapping,
 	clusterUser db.ClusterUser, dbQueries db.ApplicationScopedQueries) error {
 
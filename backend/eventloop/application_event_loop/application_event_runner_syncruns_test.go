@@ -3,6 +3,7 @@ package application_event_loop
 import (
 	"context"
 	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -14,6 +15,8 @@ import (
 	"github.com/redhat-appstudio/managed-gitops/backend/eventloop/shared_resource_loop"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -117,6 +120,12 @@ var _ = Describe("Application Event Runner SyncRuns", func() {
 			Expect(syncOperation.DeploymentNameField).Should(Equal(gitopsDeplSyncRun.Spec.GitopsDeploymentName))
 			Expect(syncOperation.Revision).Should(Equal(gitopsDeplSyncRun.Spec.RevisionID))
 
+			By("verify that the SyncRun is reported as pending, as the Operation has not yet been processed")
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDeplSyncRun), gitopsDeplSyncRun)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(gitopsDeplSyncRun.Status.Phase).To(Equal(managedgitopsv1alpha1.SyncRunPhase_Pending))
+			Expect(gitopsDeplSyncRun.Status.StartedAt).To(BeNil())
+
 			By("verify if an Operation CR is created")
 			operationCreated, operationDeleted := false, false
 			for _, event := range informer.Events {
@@ -183,6 +192,68 @@ var _ = Describe("Application Event Runner SyncRuns", func() {
 			Expect(userDevErr.UserError()).Should(Equal(ErrRevisionIsImmutable))
 		})
 
+		It("should persist the sync options of a GitOpsDeploymentSyncRun in the SyncOperation, and treat them as immutable", func() {
+
+			By("create a SyncRun CR with sync options")
+			syncRun := managedgitopsv1alpha1.GitOpsDeploymentSyncRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "syncrun-options",
+					Namespace: gitopsDepl.Namespace,
+					UID:       uuid.NewUUID(),
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentSyncRunSpec{
+					GitopsDeploymentName: gitopsDepl.Name,
+					RevisionID:           "HEAD",
+					Prune:                true,
+					Force:                true,
+					Strategy:             managedgitopsv1alpha1.SyncRunStrategyType_Apply,
+					Resources: []managedgitopsv1alpha1.SyncOperationResource{
+						{Group: "apps", Kind: "Deployment", Name: "my-deployment", Namespace: "my-namespace"},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, &syncRun)
+			Expect(err).ToNot(HaveOccurred())
+
+			applicationAction.eventResourceName = syncRun.Name
+			userDevErr := applicationAction.applicationEventRunner_handleSyncRunModifiedInternal(ctx, dbQueries)
+			Expect(userDevErr).To(BeNil())
+
+			By("check if the SyncOperation entry contains the sync options")
+			mapping := db.APICRToDatabaseMapping{
+				APIResourceType: db.APICRToDatabaseMapping_ResourceType_GitOpsDeploymentSyncRun,
+				APIResourceUID:  string(syncRun.UID),
+				DBRelationType:  db.APICRToDatabaseMapping_DBRelationType_SyncOperation,
+			}
+			err = dbQueries.GetDatabaseMappingForAPICR(ctx, &mapping)
+			Expect(err).ToNot(HaveOccurred())
+
+			syncOperation := db.SyncOperation{SyncOperation_id: mapping.DBRelationKey}
+			err = dbQueries.GetSyncOperationById(ctx, &syncOperation)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(syncOperation.Prune).To(BeTrue())
+			Expect(syncOperation.DryRun).To(BeFalse())
+			Expect(syncOperation.Force).To(BeTrue())
+			Expect(syncOperation.SyncStrategy).To(Equal("apply"))
+
+			resources, err := decompressSyncOperationResources(syncOperation.Resources)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(resources).To(Equal(syncRun.Spec.Resources))
+
+			By("verify that the sync options of the SyncRun are immutable")
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(&syncRun), &syncRun)
+			Expect(err).ToNot(HaveOccurred())
+
+			syncRun.Spec.Prune = false
+			err = k8sClient.Update(ctx, &syncRun)
+			Expect(err).ToNot(HaveOccurred())
+
+			userDevErr = applicationAction.applicationEventRunner_handleSyncRunModifiedInternal(ctx, dbQueries)
+			Expect(userDevErr.DevError().Error()).Should(Equal(ErrSyncOptionsAreImmutable))
+			Expect(userDevErr.UserError()).Should(Equal(ErrSyncOptionsAreImmutable))
+		})
+
 		It("should terminate the SyncOperation and create an Operation when the SyncRun CR is deleted", func() {
 			mapping := db.APICRToDatabaseMapping{
 				APIResourceType:      db.APICRToDatabaseMapping_ResourceType_GitOpsDeploymentSyncRun,
@@ -228,6 +299,54 @@ var _ = Describe("Application Event Runner SyncRuns", func() {
 			Expect(db.IsResultNotFoundError(err)).To(BeTrue())
 		})
 
+		It("should terminate the SyncOperation, and retain the SyncRun CR and its DB entries, when .spec.terminate is set", func() {
+			mapping := db.APICRToDatabaseMapping{
+				APIResourceType:      db.APICRToDatabaseMapping_ResourceType_GitOpsDeploymentSyncRun,
+				APIResourceName:      gitopsDeplSyncRun.Name,
+				APIResourceNamespace: gitopsDeplSyncRun.Namespace,
+				APIResourceUID:       string(gitopsDeplSyncRun.UID),
+				DBRelationType:       db.APICRToDatabaseMapping_DBRelationType_SyncOperation,
+			}
+			err := dbQueries.GetDatabaseMappingForAPICR(ctx, &mapping)
+			Expect(err).ToNot(HaveOccurred())
+
+			By("request that the sync be terminated")
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDeplSyncRun), gitopsDeplSyncRun)
+			Expect(err).ToNot(HaveOccurred())
+			gitopsDeplSyncRun.Spec.Terminate = true
+			err = k8sClient.Update(ctx, gitopsDeplSyncRun)
+			Expect(err).ToNot(HaveOccurred())
+
+			informer.Events = nil
+			userDevErr := applicationAction.applicationEventRunner_handleSyncRunModifiedInternal(ctx, dbQueries)
+			Expect(userDevErr).To(BeNil())
+
+			By("check if the sync operation row is retained, and marked as terminated")
+			syncOperation := db.SyncOperation{SyncOperation_id: mapping.DBRelationKey}
+			err = dbQueries.GetSyncOperationById(ctx, &syncOperation)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(syncOperation.DesiredState).To(Equal(db.SyncOperation_DesiredState_Terminated))
+
+			err = dbQueries.GetDatabaseMappingForAPICR(ctx, &mapping)
+			Expect(err).ToNot(HaveOccurred())
+
+			By("check if a new Operation is created to handle sync termination")
+			operationCreated := false
+			for _, event := range informer.Events {
+				if event.Action == sharedutil.Create && event.ObjectTypeOf() == "Operation" {
+					operationCreated = true
+				}
+			}
+			Expect(operationCreated).To(BeTrue())
+
+			By("check if the SyncRun CR is reported as terminated")
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDeplSyncRun), gitopsDeplSyncRun)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(gitopsDeplSyncRun.Status.Phase).To(Equal(managedgitopsv1alpha1.SyncRunPhase_Terminated))
+			Expect(gitopsDeplSyncRun.Status.Message).To(Equal(SyncRunTerminatedMessage))
+			Expect(gitopsDeplSyncRun.Status.FinishedAt).ToNot(BeNil())
+		})
+
 		It("should delete the SyncOperation DB resources without creating an Operation if the Application ID is empty", func() {
 			mapping := db.APICRToDatabaseMapping{
 				APIResourceType:      db.APICRToDatabaseMapping_ResourceType_GitOpsDeploymentSyncRun,
@@ -351,6 +470,161 @@ var _ = Describe("Application Event Runner SyncRuns", func() {
 			Expect(userDevErr.UserError()).Should(Equal(expectedErr))
 		})
 
+		It("should return an error for a suspended GitOpsDeployment", func() {
+
+			By("create a suspended GitOpsDeployment with Manual sync policy")
+			gitopsDeplSuspended := managedgitopsv1alpha1.GitOpsDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-depl-suspended",
+					Namespace: gitopsDepl.Namespace,
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentSpec{
+					Type:    managedgitopsv1alpha1.GitOpsDeploymentSpecType_Manual,
+					Suspend: true,
+				},
+			}
+
+			err := k8sClient.Create(ctx, &gitopsDeplSuspended)
+			Expect(err).ToNot(HaveOccurred())
+
+			By("create a SyncRun CR pointing to the above GitOpsDeployment")
+			syncRun := managedgitopsv1alpha1.GitOpsDeploymentSyncRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "syncrun",
+					Namespace: gitopsDeplSuspended.Namespace,
+					UID:       uuid.NewUUID(),
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentSyncRunSpec{
+					GitopsDeploymentName: gitopsDeplSuspended.Name,
+				},
+			}
+
+			err = k8sClient.Create(ctx, &syncRun)
+			Expect(err).ToNot(HaveOccurred())
+
+			By("check if an error is returned")
+			expectedErr := fmt.Sprintf("invalid GitOpsDeploymentSyncRun '%s'. Syncing a suspended GitOpsDeployment is not allowed", syncRun.Name)
+
+			applicationAction.eventResourceName = "syncrun"
+			userDevErr := applicationAction.applicationEventRunner_handleSyncRunModifiedInternal(ctx, dbQueries)
+			Expect(userDevErr.DevError().Error()).Should(Equal(expectedErr))
+			Expect(userDevErr.UserError()).Should(Equal(expectedErr))
+		})
+
+		It("should roll back a GitOpsDeployment with Automated sync policy to an entry of its deployment history", func() {
+
+			By("create a GitOpsDeployment with Automated sync policy, that has previously been deployed")
+			gitopsDeplAutomated := managedgitopsv1alpha1.GitOpsDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-depl-rollback",
+					Namespace: gitopsDepl.Namespace,
+					UID:       uuid.NewUUID(),
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentSpec{
+					Type: managedgitopsv1alpha1.GitOpsDeploymentSpecType_Automated,
+					Source: managedgitopsv1alpha1.ApplicationSource{
+						Path:    "resources/test-data/sample-gitops-repository/environments/overlays/dev",
+						RepoURL: "https://github.com/test/test",
+					},
+				},
+				Status: managedgitopsv1alpha1.GitOpsDeploymentStatus{
+					History: []managedgitopsv1alpha1.DeploymentHistoryEntry{
+						{ID: pointer.Int64(0), Revision: "rev-a", SyncResult: managedgitopsv1alpha1.OperationSucceeded},
+						{Revision: "rev-b", SyncResult: managedgitopsv1alpha1.OperationFailed},
+						{ID: pointer.Int64(1), Revision: "rev-c", SyncResult: managedgitopsv1alpha1.OperationSucceeded},
+					},
+				},
+			}
+
+			err := k8sClient.Create(ctx, &gitopsDeplAutomated)
+			Expect(err).ToNot(HaveOccurred())
+
+			newAppAction := applicationAction
+			newAppAction.eventResourceName = gitopsDeplAutomated.Name
+			_, _, _, _, userDevErr := newAppAction.applicationEventRunner_handleDeploymentModified(ctx, dbQueries)
+			Expect(userDevErr).To(BeNil())
+
+			By("create a SyncRun CR that rolls back the above GitOpsDeployment to its first deployment")
+			syncRun := managedgitopsv1alpha1.GitOpsDeploymentSyncRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "syncrun-rollback",
+					Namespace: gitopsDeplAutomated.Namespace,
+					UID:       uuid.NewUUID(),
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentSyncRunSpec{
+					GitopsDeploymentName: gitopsDeplAutomated.Name,
+					RollbackToRevision:   "rev-a",
+				},
+			}
+
+			err = k8sClient.Create(ctx, &syncRun)
+			Expect(err).ToNot(HaveOccurred())
+
+			applicationAction.eventResourceName = syncRun.Name
+			userDevErr = applicationAction.applicationEventRunner_handleSyncRunModifiedInternal(ctx, dbQueries)
+			Expect(userDevErr).To(BeNil())
+
+			By("check if the SyncOperation entry references the history entry")
+			mapping := db.APICRToDatabaseMapping{
+				APIResourceType: db.APICRToDatabaseMapping_ResourceType_GitOpsDeploymentSyncRun,
+				APIResourceUID:  string(syncRun.UID),
+				DBRelationType:  db.APICRToDatabaseMapping_DBRelationType_SyncOperation,
+			}
+			err = dbQueries.GetDatabaseMappingForAPICR(ctx, &mapping)
+			Expect(err).ToNot(HaveOccurred())
+
+			syncOperation := db.SyncOperation{SyncOperation_id: mapping.DBRelationKey}
+			err = dbQueries.GetSyncOperationById(ctx, &syncOperation)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(syncOperation.Revision).Should(Equal("rev-a"))
+			Expect(syncOperation.RollbackHistoryID).ShouldNot(BeNil())
+			Expect(*syncOperation.RollbackHistoryID).Should(Equal(int64(0)))
+
+			By("check if the rollback is reported in the status of the SyncRun")
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(&syncRun), &syncRun)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(syncRun.Status.Rollback).ShouldNot(BeNil())
+			Expect(syncRun.Status.Rollback.HistoryID).Should(Equal(int64(0)))
+			Expect(syncRun.Status.Rollback.Revision).Should(Equal("rev-a"))
+			Expect(syncRun.Status.Rollback.Phase).Should(Equal(managedgitopsv1alpha1.OperationRunning))
+
+			By("verify that the rollback target of the SyncRun is immutable")
+			syncRun.Spec.RollbackToRevision = "rev-c"
+			err = k8sClient.Update(ctx, &syncRun)
+			Expect(err).ToNot(HaveOccurred())
+
+			userDevErr = applicationAction.applicationEventRunner_handleSyncRunModifiedInternal(ctx, dbQueries)
+			Expect(userDevErr.DevError().Error()).Should(Equal(ErrRollbackIsImmutable))
+			Expect(userDevErr.UserError()).Should(Equal(ErrRollbackIsImmutable))
+		})
+
+		It("should return an error if the rollback target is not in the deployment history of the GitOpsDeployment", func() {
+
+			By("create a SyncRun CR that rolls back to a history entry that doesn't exist")
+			syncRun := managedgitopsv1alpha1.GitOpsDeploymentSyncRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "syncrun-rollback",
+					Namespace: gitopsDepl.Namespace,
+					UID:       uuid.NewUUID(),
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentSyncRunSpec{
+					GitopsDeploymentName: gitopsDepl.Name,
+					RollbackToHistoryID:  pointer.Int64(5),
+				},
+			}
+
+			err := k8sClient.Create(ctx, &syncRun)
+			Expect(err).ToNot(HaveOccurred())
+
+			By("check if an error is returned")
+			expectedErr := fmt.Sprintf("invalid GitOpsDeploymentSyncRun '%s'. Unable to locate a successful deployment to roll back to in the history of GitOpsDeployment '%s'", syncRun.Name, gitopsDepl.Name)
+
+			applicationAction.eventResourceName = syncRun.Name
+			userDevErr := applicationAction.applicationEventRunner_handleSyncRunModifiedInternal(ctx, dbQueries)
+			Expect(userDevErr.DevError().Error()).Should(Equal(expectedErr))
+			Expect(userDevErr.UserError()).Should(Equal(expectedErr))
+		})
+
 		It("should return true shutdown signal if neither CR nor DB entry exists", func() {
 			By("delete the SyncRun CR and the relevant DB details")
 			err := k8sClient.Delete(ctx, gitopsDeplSyncRun)
@@ -376,6 +650,293 @@ var _ = Describe("Application Event Runner SyncRuns", func() {
 		})
 	})
 
+	Context("Set the lifecycle status of a GitOpsDeploymentSyncRun", func() {
+
+		var syncRun *managedgitopsv1alpha1.GitOpsDeploymentSyncRun
+
+		createdAt := metav1.NewTime(time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC))
+		startedAt := metav1.NewTime(createdAt.Add(time.Minute))
+		finishedAt := metav1.NewTime(createdAt.Add(2 * time.Minute))
+		now := metav1.NewTime(createdAt.Add(3 * time.Minute))
+
+		newOperationState := func(phase managedgitopsv1alpha1.OperationPhase, finished bool) *managedgitopsv1alpha1.OperationState {
+			operationState := &managedgitopsv1alpha1.OperationState{
+				Phase:     phase,
+				Message:   "sync message",
+				StartedAt: startedAt,
+				SyncResult: &managedgitopsv1alpha1.SyncOperationResult{
+					Revision: "abc123",
+					Resources: managedgitopsv1alpha1.ResourceResults{
+						{Group: "apps", Kind: "Deployment", Name: "my-deployment", Status: managedgitopsv1alpha1.ResultCodeSynced},
+					},
+				},
+			}
+			if finished {
+				operationState.FinishedAt = &finishedAt
+			}
+			return operationState
+		}
+
+		BeforeEach(func() {
+			syncRun = &managedgitopsv1alpha1.GitOpsDeploymentSyncRun{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "syncrun",
+					CreationTimestamp: createdAt,
+				},
+			}
+		})
+
+		It("should report a SyncRun as pending, until its Operation is in progress", func() {
+			setSyncRunLifecycleStatus(syncRun, &db.Operation{State: db.OperationState_Waiting}, nil, now)
+			Expect(syncRun.Status.Phase).To(Equal(managedgitopsv1alpha1.SyncRunPhase_Pending))
+			Expect(syncRun.Status.StartedAt).To(BeNil())
+
+			setSyncRunLifecycleStatus(syncRun, &db.Operation{State: db.OperationState_In_Progress}, nil, now)
+			Expect(syncRun.Status.Phase).To(Equal(managedgitopsv1alpha1.SyncRunPhase_Running))
+			Expect(syncRun.Status.StartedAt).To(Equal(&now))
+		})
+
+		It("should report the revision and resource results of the Argo CD operation", func() {
+			setSyncRunLifecycleStatus(syncRun, &db.Operation{State: db.OperationState_Completed}, newOperationState(managedgitopsv1alpha1.OperationSucceeded, true), now)
+
+			Expect(syncRun.Status.Phase).To(Equal(managedgitopsv1alpha1.SyncRunPhase_Succeeded))
+			Expect(syncRun.Status.StartedAt).To(Equal(&startedAt))
+			Expect(syncRun.Status.FinishedAt).To(Equal(&finishedAt))
+			Expect(syncRun.Status.Revision).To(Equal("abc123"))
+			Expect(syncRun.Status.Message).To(Equal("sync message"))
+			Expect(syncRun.Status.Resources).To(Equal([]managedgitopsv1alpha1.ResourceResult{
+				{Group: "apps", Kind: "Deployment", Name: "my-deployment", Status: managedgitopsv1alpha1.ResultCodeSynced},
+			}))
+		})
+
+		It("should report the resource results of a dry-run Argo CD operation, which describe the changes that would be made", func() {
+			operationState := newOperationState(managedgitopsv1alpha1.OperationSucceeded, true)
+			operationState.Operation.Sync = &managedgitopsv1alpha1.SyncOperation{DryRun: true}
+			operationState.SyncResult.Resources = managedgitopsv1alpha1.ResourceResults{
+				{Kind: "ConfigMap", Name: "new-config-map", Status: managedgitopsv1alpha1.ResultCodeSynced,
+					Message: "configmap/new-config-map created (dry run)"},
+				{Group: "apps", Kind: "Deployment", Name: "my-deployment", Status: managedgitopsv1alpha1.ResultCodeSynced,
+					Message: "deployment.apps/my-deployment configured (dry run)"},
+				{Kind: "Service", Name: "old-service", Status: managedgitopsv1alpha1.ResultCodePruned,
+					Message: "pruned (dry run)"},
+			}
+
+			setSyncRunLifecycleStatus(syncRun, &db.Operation{State: db.OperationState_Completed}, operationState, now)
+
+			Expect(syncRun.Status.Phase).To(Equal(managedgitopsv1alpha1.SyncRunPhase_Succeeded))
+			Expect(syncRun.Status.Resources).To(HaveLen(3))
+			Expect(syncRun.Status.Resources[0].Message).To(Equal("configmap/new-config-map created (dry run)"))
+			Expect(syncRun.Status.Resources[1].Message).To(Equal("deployment.apps/my-deployment configured (dry run)"))
+			Expect(syncRun.Status.Resources[2].Status).To(Equal(managedgitopsv1alpha1.ResultCodePruned))
+		})
+
+		It("should report the error of a failed Operation", func() {
+			setSyncRunLifecycleStatus(syncRun, &db.Operation{State: db.OperationState_Failed, Human_readable_state: "sync failed"}, nil, now)
+
+			Expect(syncRun.Status.Phase).To(Equal(managedgitopsv1alpha1.SyncRunPhase_Failed))
+			Expect(syncRun.Status.Message).To(Equal("sync failed"))
+			Expect(syncRun.Status.FinishedAt).To(Equal(&now))
+		})
+
+		It("should ignore an Argo CD operation that started before the SyncRun was created", func() {
+			operationState := newOperationState(managedgitopsv1alpha1.OperationSucceeded, true)
+			operationState.StartedAt = metav1.NewTime(createdAt.Add(-time.Minute))
+
+			syncRun.Status.Phase = managedgitopsv1alpha1.SyncRunPhase_Running
+			setSyncRunLifecycleStatus(syncRun, nil, operationState, now)
+
+			Expect(syncRun.Status.Phase).To(Equal(managedgitopsv1alpha1.SyncRunPhase_Running))
+			Expect(syncRun.Status.Revision).To(BeEmpty())
+			Expect(syncRun.Status.Resources).To(BeNil())
+		})
+
+		It("should use the phase of the Argo CD operation, if the Operation is not known", func() {
+			syncRun.Status.Phase = managedgitopsv1alpha1.SyncRunPhase_Pending
+
+			setSyncRunLifecycleStatus(syncRun, nil, newOperationState(managedgitopsv1alpha1.OperationRunning, false), now)
+			Expect(syncRun.Status.Phase).To(Equal(managedgitopsv1alpha1.SyncRunPhase_Running))
+			Expect(syncRun.Status.FinishedAt).To(BeNil())
+
+			setSyncRunLifecycleStatus(syncRun, nil, newOperationState(managedgitopsv1alpha1.OperationFailed, true), now)
+			Expect(syncRun.Status.Phase).To(Equal(managedgitopsv1alpha1.SyncRunPhase_Failed))
+			Expect(syncRun.Status.FinishedAt).To(Equal(&finishedAt))
+		})
+
+		It("should not change the phase of a completed SyncRun, if the Operation is not known", func() {
+			syncRun.Status.Phase = managedgitopsv1alpha1.SyncRunPhase_Succeeded
+
+			setSyncRunLifecycleStatus(syncRun, nil, newOperationState(managedgitopsv1alpha1.OperationFailed, true), now)
+			Expect(syncRun.Status.Phase).To(Equal(managedgitopsv1alpha1.SyncRunPhase_Succeeded))
+			Expect(syncRun.Status.Revision).To(Equal("abc123"))
+		})
+
+		It("should report a SyncRun as terminated, and retain that phase once the Argo CD operation completes", func() {
+			syncRun.Status.Phase = managedgitopsv1alpha1.SyncRunPhase_Running
+			syncRun.Status.Rollback = &managedgitopsv1alpha1.SyncRunRollbackStatus{Phase: managedgitopsv1alpha1.OperationRunning}
+
+			setSyncRunTerminated(syncRun, now)
+			Expect(syncRun.Status.Phase).To(Equal(managedgitopsv1alpha1.SyncRunPhase_Terminated))
+			Expect(syncRun.Status.Message).To(Equal(SyncRunTerminatedMessage))
+			Expect(syncRun.Status.StartedAt).To(Equal(&now))
+			Expect(syncRun.Status.FinishedAt).To(Equal(&now))
+			Expect(syncRun.Status.Rollback.Phase).To(Equal(managedgitopsv1alpha1.OperationFailed))
+
+			setSyncRunLifecycleStatus(syncRun, nil, newOperationState(managedgitopsv1alpha1.OperationFailed, true), now)
+			Expect(syncRun.Status.Phase).To(Equal(managedgitopsv1alpha1.SyncRunPhase_Terminated))
+			Expect(syncRun.Status.Message).To(Equal(SyncRunTerminatedMessage))
+			Expect(syncRun.Status.Revision).To(Equal("abc123"))
+		})
+	})
+
+	Context("Update the status of the latest GitOpsDeploymentSyncRun of a GitOpsDeployment", func() {
+
+		It("should only update the most recently created SyncRun of the GitOpsDeployment", func() {
+			ctx := context.Background()
+
+			scheme, argocdNamespace, kubesystemNamespace, workspace, err := tests.GenericTestSetup()
+			Expect(err).ToNot(HaveOccurred())
+
+			gitopsDepl := &managedgitopsv1alpha1.GitOpsDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-gitops-depl",
+					Namespace: workspace.Name,
+				},
+			}
+
+			createdAt := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+
+			newSyncRun := func(name, deploymentName string, created metav1.Time) *managedgitopsv1alpha1.GitOpsDeploymentSyncRun {
+				return &managedgitopsv1alpha1.GitOpsDeploymentSyncRun{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              name,
+						Namespace:         workspace.Name,
+						CreationTimestamp: created,
+					},
+					Spec: managedgitopsv1alpha1.GitOpsDeploymentSyncRunSpec{
+						GitopsDeploymentName: deploymentName,
+					},
+					Status: managedgitopsv1alpha1.GitOpsDeploymentSyncRunStatus{
+						Phase: managedgitopsv1alpha1.SyncRunPhase_Running,
+					},
+				}
+			}
+
+			olderSyncRun := newSyncRun("older-syncrun", gitopsDepl.Name, createdAt)
+			latestSyncRun := newSyncRun("latest-syncrun", gitopsDepl.Name, metav1.NewTime(createdAt.Add(time.Minute)))
+			otherSyncRun := newSyncRun("other-syncrun", "other-gitops-depl", metav1.NewTime(createdAt.Add(2*time.Minute)))
+
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(workspace, argocdNamespace, kubesystemNamespace,
+				gitopsDepl, olderSyncRun, latestSyncRun, otherSyncRun).Build()
+
+			action := applicationEventLoopRunner_Action{
+				workspaceClient: k8sClient,
+				log:             log.FromContext(ctx),
+			}
+
+			finishedAt := metav1.NewTime(createdAt.Add(3 * time.Minute))
+			operationState := &managedgitopsv1alpha1.OperationState{
+				Phase:      managedgitopsv1alpha1.OperationSucceeded,
+				StartedAt:  metav1.NewTime(createdAt.Add(2 * time.Minute)),
+				FinishedAt: &finishedAt,
+				SyncResult: &managedgitopsv1alpha1.SyncOperationResult{Revision: "abc123"},
+			}
+
+			err = action.updateLatestSyncRunStatus(ctx, gitopsDepl, operationState)
+			Expect(err).ToNot(HaveOccurred())
+
+			By("verify that only the latest SyncRun of the GitOpsDeployment was updated")
+			for _, syncRun := range []*managedgitopsv1alpha1.GitOpsDeploymentSyncRun{olderSyncRun, latestSyncRun, otherSyncRun} {
+				err = k8sClient.Get(ctx, client.ObjectKeyFromObject(syncRun), syncRun)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			Expect(latestSyncRun.Status.Phase).To(Equal(managedgitopsv1alpha1.SyncRunPhase_Succeeded))
+			Expect(latestSyncRun.Status.Revision).To(Equal("abc123"))
+
+			Expect(olderSyncRun.Status.Phase).To(Equal(managedgitopsv1alpha1.SyncRunPhase_Running))
+			Expect(olderSyncRun.Status.Revision).To(BeEmpty())
+			Expect(otherSyncRun.Status.Phase).To(Equal(managedgitopsv1alpha1.SyncRunPhase_Running))
+			Expect(otherSyncRun.Status.Revision).To(BeEmpty())
+		})
+	})
+
+	Context("Compare the sync options of a SyncOperation and a GitOpsDeploymentSyncRun", func() {
+
+		spec := managedgitopsv1alpha1.GitOpsDeploymentSyncRunSpec{
+			DryRun:   true,
+			Strategy: managedgitopsv1alpha1.SyncRunStrategyType_Hook,
+			Resources: []managedgitopsv1alpha1.SyncOperationResource{
+				{Kind: "ConfigMap", Name: "my-config-map"},
+				{Group: "apps", Kind: "Deployment", Name: "my-deployment"},
+			},
+		}
+
+		It("should match the sync options that were set from the same spec", func() {
+			syncOperation := db.SyncOperation{}
+			Expect(setSyncOperationOptions(&syncOperation, spec)).To(Succeed())
+
+			match, err := syncOperationOptionsMatch(syncOperation, spec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(match).To(BeTrue())
+		})
+
+		It("should not match if the options or resources differ", func() {
+			syncOperation := db.SyncOperation{}
+			Expect(setSyncOperationOptions(&syncOperation, spec)).To(Succeed())
+
+			changedSpec := *spec.DeepCopy()
+			changedSpec.Strategy = managedgitopsv1alpha1.SyncRunStrategyType_Apply
+			match, err := syncOperationOptionsMatch(syncOperation, changedSpec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(match).To(BeFalse())
+
+			changedSpec = *spec.DeepCopy()
+			changedSpec.Resources = changedSpec.Resources[:1]
+			match, err = syncOperationOptionsMatch(syncOperation, changedSpec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(match).To(BeFalse())
+		})
+
+		It("should not store any resources if none are specified", func() {
+			syncOperation := db.SyncOperation{}
+			Expect(setSyncOperationOptions(&syncOperation, managedgitopsv1alpha1.GitOpsDeploymentSyncRunSpec{})).To(Succeed())
+			Expect(syncOperation.Resources).To(BeNil())
+
+			match, err := syncOperationOptionsMatch(syncOperation, managedgitopsv1alpha1.GitOpsDeploymentSyncRunSpec{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(match).To(BeTrue())
+		})
+	})
+
+	Context("Find the rollback target of a GitOpsDeploymentSyncRun", func() {
+
+		history := []managedgitopsv1alpha1.DeploymentHistoryEntry{
+			{ID: pointer.Int64(0), Revision: "rev-a"},
+			{ID: pointer.Int64(1), Revision: "rev-b"},
+			{Revision: "rev-a"},
+			{ID: pointer.Int64(2), Revision: "rev-a"},
+			{Revision: "rev-c"},
+		}
+
+		It("should return the entry with the given history ID", func() {
+			entry := findRollbackTarget(managedgitopsv1alpha1.GitOpsDeploymentSyncRunSpec{RollbackToHistoryID: pointer.Int64(0)}, history)
+			Expect(entry).ShouldNot(BeNil())
+			Expect(*entry.ID).Should(Equal(int64(0)))
+			Expect(entry.Revision).Should(Equal("rev-a"))
+		})
+
+		It("should return the most recent entry with an ID for the given revision", func() {
+			entry := findRollbackTarget(managedgitopsv1alpha1.GitOpsDeploymentSyncRunSpec{RollbackToRevision: "rev-a"}, history)
+			Expect(entry).ShouldNot(BeNil())
+			Expect(*entry.ID).Should(Equal(int64(2)))
+		})
+
+		It("should return nil if no entry with an ID matches", func() {
+			Expect(findRollbackTarget(managedgitopsv1alpha1.GitOpsDeploymentSyncRunSpec{RollbackToRevision: "rev-c"}, history)).Should(BeNil())
+			Expect(findRollbackTarget(managedgitopsv1alpha1.GitOpsDeploymentSyncRunSpec{RollbackToHistoryID: pointer.Int64(3)}, history)).Should(BeNil())
+		})
+	})
+
 	Context("Set GitOpsDeploymentSyncRun conditions", func() {
 
 		var (
@@ -507,3 +1068,50 @@ var _ = Describe("Application Event Runner SyncRuns", func() {
 		})
 	})
 })
+
+var _ = Describe("recordSyncRunPhaseEvent", func() {
+
+	It("should record an event only when the phase changes to Running, Succeeded or Failed", func() {
+		recorder := record.NewFakeRecorder(10)
+		action := &applicationEventLoopRunner_Action{eventRecorder: recorder}
+
+		syncRun := &managedgitopsv1alpha1.GitOpsDeploymentSyncRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-syncrun", Namespace: "test-ns"},
+			Spec:       managedgitopsv1alpha1.GitOpsDeploymentSyncRunSpec{GitopsDeploymentName: "test-depl"},
+			Status:     managedgitopsv1alpha1.GitOpsDeploymentSyncRunStatus{Phase: managedgitopsv1alpha1.SyncRunPhase_Pending},
+		}
+
+		action.recordSyncRunPhaseEvent(syncRun, "")
+		Expect(recorder.Events).ToNot(Receive())
+
+		syncRun.Status.Phase = managedgitopsv1alpha1.SyncRunPhase_Running
+		action.recordSyncRunPhaseEvent(syncRun, managedgitopsv1alpha1.SyncRunPhase_Pending)
+		Expect(recorder.Events).To(Receive(Equal("Normal SyncStarted Sync of GitOpsDeployment 'test-depl' started")))
+
+		action.recordSyncRunPhaseEvent(syncRun, managedgitopsv1alpha1.SyncRunPhase_Running)
+		Expect(recorder.Events).ToNot(Receive())
+
+		syncRun.Status.Phase = managedgitopsv1alpha1.SyncRunPhase_Succeeded
+		action.recordSyncRunPhaseEvent(syncRun, managedgitopsv1alpha1.SyncRunPhase_Running)
+		Expect(recorder.Events).To(Receive(Equal("Normal SyncSucceeded Sync of GitOpsDeployment 'test-depl' succeeded")))
+
+		syncRun.Status.Phase = managedgitopsv1alpha1.SyncRunPhase_Failed
+		syncRun.Status.Message = "an error"
+		action.recordSyncRunPhaseEvent(syncRun, managedgitopsv1alpha1.SyncRunPhase_Running)
+		Expect(recorder.Events).To(Receive(Equal("Warning SyncFailed Sync of GitOpsDeployment 'test-depl' failed: an error")))
+	})
+
+	It("should describe a dry-run sync as such", func() {
+		recorder := record.NewFakeRecorder(10)
+		action := &applicationEventLoopRunner_Action{eventRecorder: recorder}
+
+		syncRun := &managedgitopsv1alpha1.GitOpsDeploymentSyncRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-syncrun", Namespace: "test-ns"},
+			Spec:       managedgitopsv1alpha1.GitOpsDeploymentSyncRunSpec{GitopsDeploymentName: "test-depl", DryRun: true},
+			Status:     managedgitopsv1alpha1.GitOpsDeploymentSyncRunStatus{Phase: managedgitopsv1alpha1.SyncRunPhase_Succeeded},
+		}
+
+		action.recordSyncRunPhaseEvent(syncRun, managedgitopsv1alpha1.SyncRunPhase_Running)
+		Expect(recorder.Events).To(Receive(Equal("Normal SyncSucceeded Dry-run sync of GitOpsDeployment 'test-depl' succeeded")))
+	})
+})
@@ -34,11 +34,13 @@ import (
 	conditions "github.com/redhat-appstudio/managed-gitops/backend/condition"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/client-go/rest"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fake "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -426,8 +428,8 @@ var _ = Describe("ApplicationEventLoop Test", func() {
 
 				Expect(matchingCondition).ToNot(BeNil())
 				Expect(matchingCondition.Message).To(Equal(c.Message))
-				Expect(matchingCondition.Type).To(Equal(managedgitopsv1alpha1.GitOpsDeploymentConditionType(c.Type)))
-				Expect(matchingCondition.Status).To(Equal(managedgitopsv1alpha1.GitOpsConditionStatusTrue))
+				Expect(matchingCondition.Type).To(Equal(string(c.Type)))
+				Expect(matchingCondition.Status).To(Equal(metav1.ConditionTrue))
 			}
 
 			By("Update conditions in ApplicationState to be empty")
@@ -452,12 +454,20 @@ var _ = Describe("ApplicationEventLoop Test", func() {
 			Expect(clientErr).ToNot(HaveOccurred())
 
 			By("Verify that the status of existing GitOpsDeployment conditions is false as applicationState.conditions is empty")
-			for _, c := range gitopsDeployment.Status.Conditions {
-				Expect(c).ToNot(BeNil())
-				Expect(c.Message).To(BeEmpty())
-				Expect(c.Status).To(Equal(managedgitopsv1alpha1.GitOpsConditionStatusFalse))
+			for _, c := range appConditions {
+				matchingCondition, _ := conditions.NewConditionManager().FindCondition(&gitopsDeployment.Status.Conditions, managedgitopsv1alpha1.GitOpsDeploymentConditionType(c.Type))
+				Expect(matchingCondition.Message).To(BeEmpty())
+				Expect(matchingCondition.Status).To(Equal(metav1.ConditionFalse))
 			}
 
+			By("Verify that the Synced and Healthy conditions reflect the sync and health status")
+			syncedCondition := meta.FindStatusCondition(gitopsDeployment.Status.Conditions, string(managedgitopsv1alpha1.GitOpsDeploymentConditionSynced))
+			Expect(syncedCondition).ToNot(BeNil())
+			Expect(syncedCondition.Status).To(Equal(metav1.ConditionTrue))
+			healthyCondition := meta.FindStatusCondition(gitopsDeployment.Status.Conditions, string(managedgitopsv1alpha1.GitOpsDeploymentConditionHealthy))
+			Expect(healthyCondition).ToNot(BeNil())
+			Expect(healthyCondition.Status).To(Equal(metav1.ConditionTrue))
+
 			By("attempting to update the deployment status tick, even though nothing has changed.")
 			updated, err = a.applicationEventRunner_handleUpdateDeploymentStatusTick(ctx, gitopsDepl.Name, gitopsDepl.Namespace, dbQueries)
 			Expect(err).ToNot(HaveOccurred())
@@ -1178,6 +1188,62 @@ var _ = Describe("ApplicationEventLoop Test", func() {
 		})
 	})
 
+	Context("Check decompressDeploymentHistory function.", func() {
+		It("Should decompress history data and return the list of DeploymentHistoryEntry.", func() {
+
+			By("Creating sample history data.")
+			history := []managedgitopsv1alpha1.DeploymentHistoryEntry{
+				{
+					ID:       pointer.Int64(1),
+					Revision: "abc123",
+					Source: managedgitopsv1alpha1.GitOpsDeploymentSource{
+						RepoURL: "https://github.com/test/test",
+						Path:    "environments/prod",
+						Branch:  "main",
+					},
+					DeployStartedAt: &metav1.Time{Time: time.Now().Add(-time.Minute)},
+					DeployedAt:      metav1.Time{Time: time.Now()},
+					SyncResult:      managedgitopsv1alpha1.OperationSucceeded,
+				},
+				{
+					Revision: "def456",
+					InitiatedBy: managedgitopsv1alpha1.OperationInitiator{
+						Username: "jane",
+					},
+					DeployedAt: metav1.Time{Time: time.Now()},
+					SyncResult: managedgitopsv1alpha1.OperationFailed,
+					Message:    "one or more objects failed to apply",
+				},
+			}
+
+			compressedHistory, err := sharedutil.CompressObject(history)
+			Expect(err).ToNot(HaveOccurred())
+
+			By("Decompress data and verify the history")
+			historyOut, err := decompressDeploymentHistory(compressedHistory)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(historyOut).To(HaveLen(2))
+
+			Expect(*historyOut[0].ID).To(Equal(int64(1)))
+			Expect(historyOut[0].Revision).To(Equal("abc123"))
+			Expect(historyOut[0].Source).To(Equal(history[0].Source))
+			Expect(historyOut[0].DeployStartedAt.Equal(history[0].DeployStartedAt)).To(BeTrue())
+			Expect(historyOut[0].DeployedAt.Equal(&history[0].DeployedAt)).To(BeTrue())
+			Expect(historyOut[0].SyncResult).To(Equal(managedgitopsv1alpha1.OperationSucceeded))
+
+			Expect(historyOut[1].ID).To(BeNil())
+			Expect(historyOut[1].InitiatedBy.Username).To(Equal("jane"))
+			Expect(historyOut[1].SyncResult).To(Equal(managedgitopsv1alpha1.OperationFailed))
+			Expect(historyOut[1].Message).To(Equal(history[1].Message))
+		})
+
+		It("Shouldn't decompress if an empty history byte array is provided", func() {
+			history, err := decompressDeploymentHistory(nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(history).To(BeNil())
+		})
+	})
+
 	Context("Test removeFinalizerIfExist function", func() {
 
 		var (
@@ -1349,12 +1415,12 @@ var _ = Describe("GitOpsDeployment Conditions", func() {
 		Context("when the err has been resolved", func() {
 			BeforeEach(func() {
 				mockConditions.EXPECT().HasCondition(gomock.Any(), conditionType).Return(true)
-				mockConditions.EXPECT().FindCondition(gomock.Any(), conditionType).Return(&managedgitopsv1alpha1.GitOpsDeploymentCondition{}, true)
+				mockConditions.EXPECT().FindCondition(gomock.Any(), conditionType).Return(&metav1.Condition{}, true)
 			})
 			It("It should update the CR condition status as resolved", func() {
 				matcher := testStructs.NewGitopsDeploymentMatcher()
 				conditions := &gitopsDeployment.Status.Conditions
-				*conditions = append(*conditions, managedgitopsv1alpha1.GitOpsDeploymentCondition{})
+				*conditions = append(*conditions, metav1.Condition{})
 				mockClient.EXPECT().Status().Return(mockStatusWriter)
 				mockStatusWriter.EXPECT().Update(gomock.Any(), matcher, gomock.Any())
 				mockConditions.EXPECT().SetCondition(conditions, conditionType, managedgitopsv1alpha1.GitOpsConditionStatusFalse, managedgitopsv1alpha1.GitOpsDeploymentReasonType("ReconcileErrorResolved"), "").Times(1)
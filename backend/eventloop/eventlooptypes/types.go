@@ -20,11 +20,20 @@ const (
 	RepositoryCredentialModified EventLoopEventType = "RepositoryCredentialModified"
 	ManagedEnvironmentModified   EventLoopEventType = "ManagedEnvironmentModified"
 	SyncRunModified              EventLoopEventType = "SyncRunModified"
+	DiffModified                 EventLoopEventType = "DiffModified"
 	UpdateDeploymentStatusTick   EventLoopEventType = "UpdateDeploymentStatusTick"
+
+	// ServiceAccountTokenRotationDue indicates that the token of the ServiceAccount of a ManagedEnvironment should be
+	// rotated, if it is older than the rotation period
+	ServiceAccountTokenRotationDue EventLoopEventType = "ServiceAccountTokenRotationDue"
 )
 
 const KubeSystemNamespace = "kube-system"
 
+// SyncRunGitOpsDeploymentNameIndex is the field index of GitOpsDeploymentSyncRuns by '.spec.gitopsDeploymentName': it
+// allows the SyncRuns of a GitOpsDeployment to be listed, without listing every SyncRun in the namespace.
+const SyncRunGitOpsDeploymentNameIndex = "spec.gitopsDeploymentName"
+
 // EventLoopEvent tracks an event received from the controllers in the apis/managed-gitops/v1alpha1 package.
 // For example, when a GitOpsDeployment is created/modified/deleted, an EventLoopEvent is created and
 // is then processed by the event loops.
@@ -90,6 +99,7 @@ const (
 	GitOpsDeploymentSyncRunTypeName              GitOpsResourceType = "GitOpsDeploymentSyncRun"
 	GitOpsDeploymentRepositoryCredentialTypeName GitOpsResourceType = "GitOpsDeploymentRepositoryCredential"
 	GitOpsDeploymentManagedEnvironmentTypeName   GitOpsResourceType = "GitOpsDeploymentManagedEnvironmentTypeName"
+	GitOpsDeploymentDiffTypeName                 GitOpsResourceType = "GitOpsDeploymentDiff"
 )
 
 func GetWorkspaceIDFromNamespaceID(namespace corev1.Namespace) string {
@@ -136,3 +146,36 @@ func GetK8sClientForServiceWorkspace() (client.Client, error) {
 	return k8sClient, nil
 
 }
+
+// Reasons of the Kubernetes Events that are recorded by the event loops on the API resources they reconcile.
+const (
+	// EventReasonApplicationCreated indicates the Argo CD Application of a GitOpsDeployment was created
+	EventReasonApplicationCreated = "ApplicationCreated"
+	// EventReasonApplicationUpdated indicates the Argo CD Application of a GitOpsDeployment was updated
+	EventReasonApplicationUpdated = "ApplicationUpdated"
+
+	// EventReasonSyncStarted indicates a sync operation has started
+	EventReasonSyncStarted = "SyncStarted"
+	// EventReasonSyncSucceeded indicates a sync operation has succeeded
+	EventReasonSyncSucceeded = "SyncSucceeded"
+	// EventReasonSyncFailed indicates a sync operation has failed
+	EventReasonSyncFailed = "SyncFailed"
+
+	// EventReasonHealthDegraded indicates the resources deployed by a GitOpsDeployment have become degraded
+	EventReasonHealthDegraded = "HealthDegraded"
+	// EventReasonHealthRecovered indicates the resources deployed by a GitOpsDeployment are healthy again, after being degraded
+	EventReasonHealthRecovered = "HealthRecovered"
+
+	// EventReasonConnectionFailed indicates that the GitOps Service was unable to connect to a ManagedEnvironment
+	EventReasonConnectionFailed = "ConnectionFailed"
+	// EventReasonConnectionRestored indicates that the GitOps Service is able to connect to a ManagedEnvironment again,
+	// after being unable to
+	EventReasonConnectionRestored = "ConnectionRestored"
+
+	// EventReasonServiceAccountTokenRotated indicates that the token of the ServiceAccount that was created on the cluster
+	// of a ManagedEnvironment was rotated
+	EventReasonServiceAccountTokenRotated = "ServiceAccountTokenRotated"
+	// EventReasonServiceAccountTokenRotationFailed indicates that the GitOps Service was unable to rotate the token of the
+	// ServiceAccount that was created on the cluster of a ManagedEnvironment
+	EventReasonServiceAccountTokenRotationFailed = "ServiceAccountTokenRotationFailed"
+)
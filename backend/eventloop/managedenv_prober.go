@@ -0,0 +1,313 @@
+package eventloop
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
+	db "github.com/redhat-appstudio/managed-gitops/backend-shared/db"
+	sharedutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util"
+	logutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util/log"
+	"github.com/redhat-appstudio/managed-gitops/backend/eventloop/eventlooptypes"
+	sharedresourceloop "github.com/redhat-appstudio/managed-gitops/backend/eventloop/shared_resource_loop"
+)
+
+const (
+	// ManagedEnvProbeIntervalEnvVar is the environment variable that may be set to a duration (for example, '5m') to
+	// change how often the connection to each ManagedEnvironment is probed. A value of '0' disables the probes.
+	ManagedEnvProbeIntervalEnvVar = "MANAGED_ENVIRONMENT_PROBE_INTERVAL"
+
+	defaultManagedEnvProbeInterval = 5 * time.Minute  // Default interval between probes of each ManagedEnvironment
+	minManagedEnvProbeInterval     = 30 * time.Second // Minimum interval between probes, to avoid continuously probing every cluster
+	managedEnvProbeTimeout         = 30 * time.Second // Maximum time a single probe may take, before the cluster is considered unreachable
+)
+
+// ManagedEnvironmentProber periodically probes the connection to the cluster of each GitOpsDeploymentManagedEnvironment,
+// using the cluster credentials stored in the database. The result is reported in the 'Reachable' condition of the
+// ManagedEnvironment, and in the 'ManagedEnvironmentReachable' condition of the GitOpsDeployments that deploy to it.
+type ManagedEnvironmentProber struct {
+	client.Client
+	DB               db.DatabaseQueries
+	K8sClientFactory sharedresourceloop.SRLK8sClientFactory
+
+	// EventRecorder is used to record Kubernetes Events when the connection to a ManagedEnvironment fails or is restored
+	EventRecorder record.EventRecorder
+
+	// Interval is the time between the end of one round of probes and the beginning of the next
+	Interval time.Duration
+}
+
+// GetManagedEnvProbeInterval returns the interval between probes of each ManagedEnvironment, which may be set via the
+// ManagedEnvProbeIntervalEnvVar environment variable. Intervals shorter than minManagedEnvProbeInterval (other than '0',
+// which disables the probes) are ignored, and the default interval is used instead.
+func GetManagedEnvProbeInterval(logger logr.Logger) time.Duration {
+	interval := os.Getenv(ManagedEnvProbeIntervalEnvVar)
+	if interval == "" {
+		return defaultManagedEnvProbeInterval
+	}
+	value, err := time.ParseDuration(interval)
+	if err != nil || value < 0 {
+		logger.Error(err, fmt.Sprintf("value of env var %s is not a valid duration", ManagedEnvProbeIntervalEnvVar))
+		return defaultManagedEnvProbeInterval
+	}
+	if value != 0 && value < minManagedEnvProbeInterval {
+		logger.Error(nil, fmt.Sprintf("value of env var %s is less than the minimum of %v", ManagedEnvProbeIntervalEnvVar,
+			minManagedEnvProbeInterval))
+		return defaultManagedEnvProbeInterval
+	}
+	return value
+}
+
+// StartManagedEnvironmentProber starts probing the ManagedEnvironments, at the configured interval.
+func (p *ManagedEnvironmentProber) StartManagedEnvironmentProber() {
+	p.startTimerForNextCycle()
+}
+
+func (p *ManagedEnvironmentProber) startTimerForNextCycle() {
+	go func() {
+		// Timer to trigger the probes
+		timer := time.NewTimer(p.Interval)
+		<-timer.C
+
+		ctx := context.Background()
+		log := log.FromContext(ctx).
+			WithName(logutil.LogLogger_managed_gitops).
+			WithValues("component", "managed-environment-prober")
+
+		_, _ = sharedutil.CatchPanic(func() error {
+
+			probeManagedEnvironments(ctx, p.Client, p.DB, p.K8sClientFactory, p.EventRecorder, log)
+
+			return nil
+		})
+
+		// Kick off the timer again, once the old task runs.
+		// This ensures that at least 'Interval' time elapses from the end of one run to the beginning of another.
+		p.startTimerForNextCycle()
+	}()
+}
+
+// managedEnvProbeResult is the result of probing the connection to a ManagedEnvironment
+type managedEnvProbeResult struct {
+	// err is nil if the cluster was reachable, or the error that occurred while connecting otherwise
+	err error
+}
+
+// probeManagedEnvironments probes the connection to each ManagedEnvironment, then updates the conditions of the
+// ManagedEnvironments, and of the GitOpsDeployments that deploy to them.
+func probeManagedEnvironments(ctx context.Context, k8sClient client.Client, dbQueries db.DatabaseQueries,
+	k8sClientFactory sharedresourceloop.SRLK8sClientFactory, eventRecorder record.EventRecorder, l logr.Logger) {
+
+	log := l.WithValues("job", "probeManagedEnvironments")
+
+	var managedEnvList managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentList
+	if err := k8sClient.List(ctx, &managedEnvList); err != nil {
+		log.Error(err, "unable to list GitOpsDeploymentManagedEnvironments")
+		return
+	}
+
+	// The results of the probes, by namespace, then by ManagedEnvironment name
+	results := map[string]map[string]managedEnvProbeResult{}
+
+	for i := range managedEnvList.Items {
+		managedEnv := managedEnvList.Items[i]
+
+		if managedEnv.DeletionTimestamp != nil {
+			continue
+		}
+
+		result, probed := probeManagedEnvironment(ctx, k8sClient, dbQueries, k8sClientFactory, managedEnv, eventRecorder, log)
+		if !probed {
+			continue
+		}
+
+		if _, exists := results[managedEnv.Namespace]; !exists {
+			results[managedEnv.Namespace] = map[string]managedEnvProbeResult{}
+		}
+		results[managedEnv.Namespace][managedEnv.Name] = result
+	}
+
+	var gitopsDeplList managedgitopsv1alpha1.GitOpsDeploymentList
+	if err := k8sClient.List(ctx, &gitopsDeplList); err != nil {
+		log.Error(err, "unable to list GitOpsDeployments")
+		return
+	}
+
+	for i := range gitopsDeplList.Items {
+		gitopsDepl := gitopsDeplList.Items[i]
+
+		var result *managedEnvProbeResult
+		if gitopsDepl.Spec.Destination.Environment != "" {
+			if res, exists := results[gitopsDepl.Namespace][gitopsDepl.Spec.Destination.Environment]; exists {
+				result = &res
+			}
+		}
+
+		if err := updateGitOpsDeploymentReachableCondition(ctx, k8sClient, gitopsDepl, result); err != nil {
+			log.Error(err, "unable to update the ManagedEnvironmentReachable condition of GitOpsDeployment",
+				"name", gitopsDepl.Name, "namespace", gitopsDepl.Namespace)
+		}
+	}
+}
+
+// probeManagedEnvironment probes the connection to the ManagedEnvironment, and updates its status with the result.
+// Returns false if the connection could not be probed, for example because the ManagedEnvironment has not yet been
+// processed, and so has no cluster credentials.
+func probeManagedEnvironment(ctx context.Context, k8sClient client.Client, dbQueries db.DatabaseQueries,
+	k8sClientFactory sharedresourceloop.SRLK8sClientFactory, managedEnv managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment,
+	eventRecorder record.EventRecorder, l logr.Logger) (managedEnvProbeResult, bool) {
+
+	log := l.WithValues("name", managedEnv.Name, "namespace", managedEnv.Namespace)
+
+	clusterCreds, err := sharedresourceloop.GetClusterCredentialsForManagedEnvironment(ctx, managedEnv, dbQueries)
+	if err != nil {
+		log.Error(err, "unable to retrieve the cluster credentials of the GitOpsDeploymentManagedEnvironment")
+		return managedEnvProbeResult{}, false
+	}
+	if clusterCreds == nil {
+		return managedEnvProbeResult{}, false
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, managedEnvProbeTimeout)
+	defer cancel()
+
+	start := time.Now()
+	probeErr := sharedresourceloop.VerifyManagedEnvironmentConnection(probeCtx, *clusterCreds, managedEnv, k8sClientFactory)
+	latency := time.Since(start)
+
+	if probeErr != nil {
+		log.Info("GitOpsDeploymentManagedEnvironment is unreachable", "error", probeErr.Error())
+	}
+
+	result := managedEnvProbeResult{err: probeErr}
+
+	if err := updateManagedEnvironmentReachableStatus(ctx, k8sClient, managedEnv, result, metav1.NewTime(start), latency, eventRecorder); err != nil {
+		log.Error(err, "unable to update the Reachable condition of GitOpsDeploymentManagedEnvironment")
+	}
+
+	return result, true
+}
+
+// updateManagedEnvironmentReachableStatus sets the Reachable condition, and last probe time and latency, of the
+// ManagedEnvironment. An Event is recorded when the ManagedEnvironment becomes unreachable, or reachable again.
+func updateManagedEnvironmentReachableStatus(ctx context.Context, k8sClient client.Client,
+	managedEnv managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment, result managedEnvProbeResult,
+	probeTime metav1.Time, latency time.Duration, eventRecorder record.EventRecorder) error {
+
+	condition := metav1.Condition{
+		Type:    managedgitopsv1alpha1.ManagedEnvironmentStatusReachable,
+		Status:  metav1.ConditionTrue,
+		Reason:  string(managedgitopsv1alpha1.ConditionReasonReachable),
+		Message: "",
+	}
+	if result.err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = string(managedgitopsv1alpha1.ConditionReasonUnreachable)
+		condition.Message = result.err.Error()
+	}
+
+	var previousStatus metav1.ConditionStatus
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+
+		if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(&managedEnv), &managedEnv); err != nil {
+			return err
+		}
+
+		previousStatus = ""
+		if previousCondition := meta.FindStatusCondition(managedEnv.Status.Conditions, condition.Type); previousCondition != nil {
+			previousStatus = previousCondition.Status
+		}
+
+		meta.SetStatusCondition(&managedEnv.Status.Conditions, condition)
+		managedEnv.Status.LastProbeTime = &probeTime
+		managedEnv.Status.LastProbeLatency = &metav1.Duration{Duration: latency.Round(time.Millisecond)}
+
+		return k8sClient.Status().Update(ctx, &managedEnv)
+	})
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+
+	if previousStatus != condition.Status {
+		if condition.Status == metav1.ConditionFalse {
+			eventRecorder.Event(&managedEnv, corev1.EventTypeWarning, eventlooptypes.EventReasonConnectionFailed,
+				"the cluster is unreachable: "+condition.Message)
+		} else if previousStatus == metav1.ConditionFalse {
+			eventRecorder.Event(&managedEnv, corev1.EventTypeNormal, eventlooptypes.EventReasonConnectionRestored,
+				"the cluster is reachable again")
+		}
+	}
+
+	return nil
+}
+
+// updateGitOpsDeploymentReachableCondition sets the ManagedEnvironmentReachable condition of the GitOpsDeployment to
+// the result of the probe of the ManagedEnvironment it deploys to. If there is no result (for example, because the
+// GitOpsDeployment does not deploy to a ManagedEnvironment), the condition is removed.
+func updateGitOpsDeploymentReachableCondition(ctx context.Context, k8sClient client.Client,
+	gitopsDepl managedgitopsv1alpha1.GitOpsDeployment, result *managedEnvProbeResult) error {
+
+	conditionType := string(managedgitopsv1alpha1.GitOpsDeploymentConditionManagedEnvironmentReachable)
+
+	// Avoid updating the GitOpsDeployment if the condition is unchanged
+	existingCondition := meta.FindStatusCondition(gitopsDepl.Status.Conditions, conditionType)
+	if result == nil && existingCondition == nil {
+		return nil
+	}
+
+	var condition metav1.Condition
+	if result != nil {
+		condition = metav1.Condition{
+			Type:   conditionType,
+			Status: metav1.ConditionTrue,
+			Reason: string(managedgitopsv1alpha1.GitopsDeploymentReasonManagedEnvironmentReachable),
+		}
+		if result.err != nil {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = string(managedgitopsv1alpha1.GitopsDeploymentReasonManagedEnvironmentUnreachable)
+			condition.Message = fmt.Sprintf("the GitOpsDeploymentManagedEnvironment '%s' is unreachable: %v",
+				gitopsDepl.Spec.Destination.Environment, result.err)
+		}
+
+		if existingCondition != nil && existingCondition.Status == condition.Status &&
+			existingCondition.Reason == condition.Reason && existingCondition.Message == condition.Message {
+			return nil
+		}
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+
+		if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(&gitopsDepl), &gitopsDepl); err != nil {
+			return err
+		}
+
+		if result == nil {
+			meta.RemoveStatusCondition(&gitopsDepl.Status.Conditions, conditionType)
+		} else {
+			meta.SetStatusCondition(&gitopsDepl.Status.Conditions, condition)
+		}
+
+		return k8sClient.Status().Update(ctx, &gitopsDepl)
+	})
+	if apierr.IsNotFound(err) {
+		return nil
+	}
+
+	return err
+}
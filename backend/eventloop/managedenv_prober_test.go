@@ -0,0 +1,266 @@
+package eventloop
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
+	db "github.com/redhat-appstudio/managed-gitops/backend-shared/db"
+	"github.com/redhat-appstudio/managed-gitops/backend-shared/util/tests"
+	"github.com/redhat-appstudio/managed-gitops/backend/eventloop/eventlooptypes"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logger "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("ManagedEnvironment Prober Tests", func() {
+
+	Context("Testing probeManagedEnvironments function", func() {
+
+		var log logr.Logger
+		var ctx context.Context
+		var dbq db.AllDatabaseQueries
+		var k8sClient client.Client
+		var apiNamespace *corev1.Namespace
+		var managedEnvCr *managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment
+		var gitopsDepl *managedgitopsv1alpha1.GitOpsDeployment
+		var eventRecorder *record.FakeRecorder
+
+		getReachableCondition := func() *metav1.Condition {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(managedEnvCr), managedEnvCr)
+			Expect(err).ToNot(HaveOccurred())
+			return meta.FindStatusCondition(managedEnvCr.Status.Conditions, managedgitopsv1alpha1.ManagedEnvironmentStatusReachable)
+		}
+
+		getGitOpsDeploymentReachableCondition := func() *metav1.Condition {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDepl), gitopsDepl)
+			Expect(err).ToNot(HaveOccurred())
+			return meta.FindStatusCondition(gitopsDepl.Status.Conditions,
+				string(managedgitopsv1alpha1.GitOpsDeploymentConditionManagedEnvironmentReachable))
+		}
+
+		BeforeEach(func() {
+			scheme,
+				argocdNamespace,
+				kubesystemNamespace,
+				namespace,
+				err := tests.GenericTestSetup()
+			Expect(err).ToNot(HaveOccurred())
+			apiNamespace = namespace
+
+			managedEnvCr = &managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-managed-env",
+					Namespace: apiNamespace.Name,
+					UID:       uuid.NewUUID(),
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec{
+					APIURL:                     "https://api.fake-unit-test-data.origin-ci-int-gce.dev.rhcloud.com:6443",
+					ClusterCredentialsSecret:   "test-managed-env-secret",
+					AllowInsecureSkipTLSVerify: true,
+				},
+			}
+
+			gitopsDepl = &managedgitopsv1alpha1.GitOpsDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-gitops-depl",
+					Namespace: apiNamespace.Name,
+					UID:       uuid.NewUUID(),
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentSpec{
+					Source: managedgitopsv1alpha1.ApplicationSource{
+						RepoURL: "https://github.com/redhat-appstudio/managed-gitops",
+						Path:    "resources/test-data/sample-gitops-repository/environments/overlays/dev",
+					},
+					Destination: managedgitopsv1alpha1.ApplicationDestination{
+						Environment: managedEnvCr.Name,
+						Namespace:   "jgw",
+					},
+					Type: managedgitopsv1alpha1.GitOpsDeploymentSpecType_Automated,
+				},
+			}
+
+			// Create fake client
+			k8sClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(apiNamespace, argocdNamespace, kubesystemNamespace, managedEnvCr, gitopsDepl).
+				Build()
+
+			err = db.SetupForTestingDBGinkgo()
+			Expect(err).ToNot(HaveOccurred())
+
+			ctx = context.Background()
+			log = logger.FromContext(ctx)
+			dbq, err = db.NewUnsafePostgresDBQueries(true, true)
+			Expect(err).ToNot(HaveOccurred())
+
+			eventRecorder = record.NewFakeRecorder(10)
+
+			By("Create required DB entries.")
+
+			clusterCredentialsDb := db.ClusterCredentials{
+				Clustercredentials_cred_id:  "test-" + string(uuid.NewUUID()),
+				Host:                        managedEnvCr.Spec.APIURL,
+				Kube_config:                 "kube-config",
+				Kube_config_context:         "kube-config-context",
+				Serviceaccount_bearer_token: "serviceaccount_bearer_token",
+				Serviceaccount_ns:           "Serviceaccount_ns",
+			}
+			err = dbq.CreateClusterCredentials(ctx, &clusterCredentialsDb)
+			Expect(err).ToNot(HaveOccurred())
+
+			managedEnvironmentDb := db.ManagedEnvironment{
+				Managedenvironment_id: "test-env-" + string(managedEnvCr.UID),
+				Clustercredentials_id: clusterCredentialsDb.Clustercredentials_cred_id,
+				Name:                  managedEnvCr.Name,
+			}
+			err = dbq.CreateManagedEnvironment(ctx, &managedEnvironmentDb)
+			Expect(err).ToNot(HaveOccurred())
+
+			apiCRToDatabaseMappingDb := db.APICRToDatabaseMapping{
+				APIResourceType:      db.APICRToDatabaseMapping_ResourceType_GitOpsDeploymentManagedEnvironment,
+				APIResourceUID:       string(managedEnvCr.UID),
+				APIResourceName:      managedEnvCr.Name,
+				APIResourceNamespace: managedEnvCr.Namespace,
+				NamespaceUID:         string(apiNamespace.UID),
+				DBRelationType:       db.APICRToDatabaseMapping_DBRelationType_ManagedEnvironment,
+				DBRelationKey:        managedEnvironmentDb.Managedenvironment_id,
+			}
+			err = dbq.CreateAPICRToDatabaseMapping(ctx, &apiCRToDatabaseMappingDb)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should report a reachable ManagedEnvironment on the ManagedEnvironment and its GitOpsDeployments", func() {
+			defer dbq.CloseDatabase()
+
+			probeManagedEnvironments(ctx, k8sClient, dbq, MockSRLK8sClientFactory{fakeClient: k8sClient}, eventRecorder, log)
+
+			condition := getReachableCondition()
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+			Expect(condition.Reason).To(Equal(string(managedgitopsv1alpha1.ConditionReasonReachable)))
+			Expect(managedEnvCr.Status.LastProbeTime).ToNot(BeNil())
+			Expect(managedEnvCr.Status.LastProbeLatency).ToNot(BeNil())
+
+			condition = getGitOpsDeploymentReachableCondition()
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+
+			Expect(eventRecorder.Events).To(BeEmpty())
+		})
+
+		It("should report an unreachable ManagedEnvironment, then report it as reachable again once it recovers", func() {
+			defer dbq.CloseDatabase()
+
+			By("probing a ManagedEnvironment whose namespace does not exist, so that the connection cannot be verified")
+			managedEnvCr.Spec.Namespaces = []string{"does-not-exist"}
+			err := k8sClient.Update(ctx, managedEnvCr)
+			Expect(err).ToNot(HaveOccurred())
+
+			probeManagedEnvironments(ctx, k8sClient, dbq, MockSRLK8sClientFactory{fakeClient: k8sClient}, eventRecorder, log)
+
+			condition := getReachableCondition()
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(string(managedgitopsv1alpha1.ConditionReasonUnreachable)))
+
+			condition = getGitOpsDeploymentReachableCondition()
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal(string(managedgitopsv1alpha1.GitopsDeploymentReasonManagedEnvironmentUnreachable)))
+			Expect(condition.Message).To(ContainSubstring(managedEnvCr.Name))
+
+			Expect(eventRecorder.Events).To(Receive(ContainSubstring(eventlooptypes.EventReasonConnectionFailed)))
+
+			By("creating the namespace, so that the connection can be verified again")
+			err = k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "does-not-exist"}})
+			Expect(err).ToNot(HaveOccurred())
+
+			probeManagedEnvironments(ctx, k8sClient, dbq, MockSRLK8sClientFactory{fakeClient: k8sClient}, eventRecorder, log)
+
+			Expect(getReachableCondition().Status).To(Equal(metav1.ConditionTrue))
+			Expect(getGitOpsDeploymentReachableCondition().Status).To(Equal(metav1.ConditionTrue))
+			Expect(eventRecorder.Events).To(Receive(ContainSubstring(eventlooptypes.EventReasonConnectionRestored)))
+		})
+
+		It("should remove the condition from a GitOpsDeployment that no longer deploys to a ManagedEnvironment", func() {
+			defer dbq.CloseDatabase()
+
+			probeManagedEnvironments(ctx, k8sClient, dbq, MockSRLK8sClientFactory{fakeClient: k8sClient}, eventRecorder, log)
+			Expect(getGitOpsDeploymentReachableCondition()).ToNot(BeNil())
+
+			gitopsDepl.Spec.Destination.Environment = ""
+			err := k8sClient.Update(ctx, gitopsDepl)
+			Expect(err).ToNot(HaveOccurred())
+
+			probeManagedEnvironments(ctx, k8sClient, dbq, MockSRLK8sClientFactory{fakeClient: k8sClient}, eventRecorder, log)
+			Expect(getGitOpsDeploymentReachableCondition()).To(BeNil())
+		})
+
+		It("should not probe a ManagedEnvironment that has not yet been processed", func() {
+			defer dbq.CloseDatabase()
+
+			otherManagedEnv := &managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "not-yet-processed",
+					Namespace: apiNamespace.Name,
+					UID:       uuid.NewUUID(),
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec{
+					APIURL:                   "https://api2.fake-unit-test-data.origin-ci-int-gce.dev.rhcloud.com:6443",
+					ClusterCredentialsSecret: "not-yet-processed-secret",
+				},
+			}
+			err := k8sClient.Create(ctx, otherManagedEnv)
+			Expect(err).ToNot(HaveOccurred())
+
+			probeManagedEnvironments(ctx, k8sClient, dbq, MockSRLK8sClientFactory{fakeClient: k8sClient}, eventRecorder, log)
+
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(otherManagedEnv), otherManagedEnv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(otherManagedEnv.Status.Conditions).To(BeEmpty())
+			Expect(otherManagedEnv.Status.LastProbeTime).To(BeNil())
+		})
+	})
+
+	Context("Testing GetManagedEnvProbeInterval function", func() {
+
+		AfterEach(func() {
+			os.Unsetenv(ManagedEnvProbeIntervalEnvVar)
+		})
+
+		It("should return the interval from the environment variable, or the default if it is not valid", func() {
+			log := logger.FromContext(context.Background())
+
+			Expect(GetManagedEnvProbeInterval(log)).To(Equal(defaultManagedEnvProbeInterval))
+
+			os.Setenv(ManagedEnvProbeIntervalEnvVar, "90s")
+			Expect(GetManagedEnvProbeInterval(log)).To(Equal(90 * time.Second))
+
+			os.Setenv(ManagedEnvProbeIntervalEnvVar, "0")
+			Expect(GetManagedEnvProbeInterval(log)).To(Equal(time.Duration(0)))
+
+			os.Setenv(ManagedEnvProbeIntervalEnvVar, "not-a-duration")
+			Expect(GetManagedEnvProbeInterval(log)).To(Equal(defaultManagedEnvProbeInterval))
+
+			By("ignoring intervals that are shorter than the minimum")
+			os.Setenv(ManagedEnvProbeIntervalEnvVar, "1ms")
+			Expect(GetManagedEnvProbeInterval(log)).To(Equal(defaultManagedEnvProbeInterval))
+
+			os.Setenv(ManagedEnvProbeIntervalEnvVar, "29s")
+			Expect(GetManagedEnvProbeInterval(log)).To(Equal(defaultManagedEnvProbeInterval))
+
+			os.Setenv(ManagedEnvProbeIntervalEnvVar, "30s")
+			Expect(GetManagedEnvProbeInterval(log)).To(Equal(30 * time.Second))
+		})
+	})
+})
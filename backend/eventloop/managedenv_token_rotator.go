@@ -0,0 +1,197 @@
+package eventloop
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
+	sharedutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util"
+	logutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util/log"
+	"github.com/redhat-appstudio/managed-gitops/backend/eventloop/eventlooptypes"
+	sharedresourceloop "github.com/redhat-appstudio/managed-gitops/backend/eventloop/shared_resource_loop"
+)
+
+const (
+	// ServiceAccountTokenRotationPeriodEnvVar is the environment variable that may be set to a duration (for example, '720h')
+	// to change how old the token of a ServiceAccount created via '.spec.createNewServiceAccount' may be, before it is
+	// rotated. A value of '0' disables the rotation.
+	ServiceAccountTokenRotationPeriodEnvVar = "SERVICE_ACCOUNT_TOKEN_ROTATION_PERIOD"
+
+	defaultServiceAccountTokenRotationPeriod = 30 * 24 * time.Hour // Default maximum age of a ServiceAccount token (30 days)
+	serviceAccountTokenRotationInterval      = 1 * time.Hour       // Time between checks for tokens that need to be rotated
+)
+
+// ServiceAccountTokenRotator periodically requests the rotation of the bearer tokens of the ServiceAccounts that were
+// created on the clusters of GitOpsDeploymentManagedEnvironments with '.spec.createNewServiceAccount' set.
+//
+// The tokens are rotated by the shared resource loop of the namespace of each ManagedEnvironment (once they are older than
+// the rotation period), so that the rotation does not race with other changes to the ManagedEnvironment.
+type ServiceAccountTokenRotator struct {
+	client.Client
+
+	// EventLoop receives the requests to rotate the token of a ManagedEnvironment, and passes them to the event loops
+	// of the namespace of the ManagedEnvironment.
+	EventLoop EventLoopEventReceiver
+}
+
+// EventLoopEventReceiver is implemented by the preprocess event loop, which passes the events it receives to the event
+// loops of the namespace of the event.
+type EventLoopEventReceiver interface {
+	EventReceived(req ctrl.Request, reqResource eventlooptypes.GitOpsResourceType, client client.Client,
+		eventType eventlooptypes.EventLoopEventType, namespaceID string)
+}
+
+// GetServiceAccountTokenRotationPeriod returns the maximum age of a ServiceAccount token, which may be set via the
+// ServiceAccountTokenRotationPeriodEnvVar environment variable.
+func GetServiceAccountTokenRotationPeriod(logger logr.Logger) time.Duration {
+	period := os.Getenv(ServiceAccountTokenRotationPeriodEnvVar)
+	if period == "" {
+		return defaultServiceAccountTokenRotationPeriod
+	}
+	value, err := time.ParseDuration(period)
+	if err != nil || value < 0 {
+		logger.Error(err, fmt.Sprintf("value of env var %s is not a valid duration", ServiceAccountTokenRotationPeriodEnvVar))
+		return defaultServiceAccountTokenRotationPeriod
+	}
+	return value
+}
+
+// StartServiceAccountTokenRotator starts checking for ServiceAccount tokens that need to be rotated.
+func (r *ServiceAccountTokenRotator) StartServiceAccountTokenRotator() {
+	r.startTimerForNextCycle()
+}
+
+func (r *ServiceAccountTokenRotator) startTimerForNextCycle() {
+	go func() {
+		// Timer to trigger the rotation
+		timer := time.NewTimer(serviceAccountTokenRotationInterval)
+		<-timer.C
+
+		ctx := context.Background()
+		log := log.FromContext(ctx).
+			WithName(logutil.LogLogger_managed_gitops).
+			WithValues("component", "service-account-token-rotator")
+
+		_, _ = sharedutil.CatchPanic(func() error {
+
+			requestServiceAccountTokenRotations(ctx, r.Client, r.EventLoop, log)
+
+			return nil
+		})
+
+		// Kick off the timer again, once the old task runs.
+		// This ensures that at least 'serviceAccountTokenRotationInterval' time elapses from the end of one run to the beginning of another.
+		r.startTimerForNextCycle()
+	}()
+}
+
+// requestServiceAccountTokenRotations sends a ServiceAccountTokenRotationDue event to the event loop, for each
+// ManagedEnvironment with '.spec.createNewServiceAccount' set.
+func requestServiceAccountTokenRotations(ctx context.Context, k8sClient client.Client, eventLoop EventLoopEventReceiver, l logr.Logger) {
+
+	log := l.WithValues("job", "requestServiceAccountTokenRotations")
+
+	var managedEnvList managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentList
+	if err := k8sClient.List(ctx, &managedEnvList); err != nil {
+		log.Error(err, "unable to list GitOpsDeploymentManagedEnvironments")
+		return
+	}
+
+	// The workspace ID of each namespace containing a ManagedEnvironment: namespace name -> workspace ID
+	workspaceIDs := map[string]string{}
+
+	for i := range managedEnvList.Items {
+		managedEnv := managedEnvList.Items[i]
+
+		if managedEnv.DeletionTimestamp != nil || !managedEnv.Spec.CreateNewServiceAccount {
+			continue
+		}
+
+		workspaceID, exists := workspaceIDs[managedEnv.Namespace]
+		if !exists {
+			namespace := corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: managedEnv.Namespace,
+				},
+			}
+			if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(&namespace), &namespace); err != nil {
+				log.Error(err, "unable to retrieve namespace of GitOpsDeploymentManagedEnvironment", "namespace", managedEnv.Namespace)
+				continue
+			}
+			workspaceID = eventlooptypes.GetWorkspaceIDFromNamespaceID(namespace)
+			workspaceIDs[managedEnv.Namespace] = workspaceID
+		}
+
+		eventLoop.EventReceived(ctrl.Request{NamespacedName: client.ObjectKeyFromObject(&managedEnv)},
+			eventlooptypes.GitOpsDeploymentManagedEnvironmentTypeName, k8sClient, eventlooptypes.ServiceAccountTokenRotationDue, workspaceID)
+	}
+}
+
+// processServiceAccountTokenRotation asks the shared resource loop to rotate the ServiceAccount token of the ManagedEnvironment
+// CR, if it is older than the rotation period. An Event is recorded on the ManagedEnvironment with the result.
+//
+// Returns true if the task should be retried, false otherwise, plus an error
+func processServiceAccountTokenRotation(ctx context.Context, req ctrl.Request, apiNamespaceClient client.Client,
+	sharedResourceLoop *sharedresourceloop.SharedResourceEventLoop, k8sClientFactory sharedresourceloop.SRLK8sClientFactory,
+	eventRecorder record.EventRecorder, l logr.Logger) (bool, error) {
+	const retry, noRetry = true, false
+
+	log := l.WithValues("name", req.Name, "namespace", req.Namespace)
+
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: req.Namespace,
+		},
+	}
+	if err := apiNamespaceClient.Get(ctx, client.ObjectKeyFromObject(namespace), namespace); err != nil {
+		if !apierr.IsNotFound(err) {
+			return retry, fmt.Errorf("unexpected error in retrieving namespace of managed env CR: %v", err)
+		}
+		return noRetry, nil
+	}
+
+	managedEnv := managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      req.Name,
+			Namespace: req.Namespace,
+		},
+	}
+	if err := apiNamespaceClient.Get(ctx, client.ObjectKeyFromObject(&managedEnv), &managedEnv); err != nil {
+		if !apierr.IsNotFound(err) {
+			return retry, fmt.Errorf("unexpected error in retrieving managed env CR: %v", err)
+		}
+		return noRetry, nil
+	}
+
+	if managedEnv.DeletionTimestamp != nil || !managedEnv.Spec.CreateNewServiceAccount {
+		return noRetry, nil
+	}
+
+	rotated, err := sharedResourceLoop.RotateServiceAccountToken(ctx, apiNamespaceClient, *namespace, managedEnv,
+		GetServiceAccountTokenRotationPeriod(log), k8sClientFactory, log)
+	if err != nil {
+		eventRecorder.Event(&managedEnv, corev1.EventTypeWarning, eventlooptypes.EventReasonServiceAccountTokenRotationFailed,
+			"unable to rotate the service account token: "+err.Error())
+
+		// The rotation is not retried until the next check for tokens that need to be rotated, as it may have partially succeeded.
+		return noRetry, fmt.Errorf("unable to rotate the service account token of GitOpsDeploymentManagedEnvironment: %v", err)
+	}
+
+	if rotated {
+		eventRecorder.Event(&managedEnv, corev1.EventTypeNormal, eventlooptypes.EventReasonServiceAccountTokenRotated,
+			"the service account token was rotated")
+	}
+
+	return noRetry, nil
+}
@@ -0,0 +1,194 @@
+package eventloop
+
+import (
+	"context"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
+	"github.com/redhat-appstudio/managed-gitops/backend-shared/util/tests"
+	"github.com/redhat-appstudio/managed-gitops/backend/eventloop/eventlooptypes"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logger "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("ServiceAccount Token Rotator Tests", func() {
+
+	Context("Testing requestServiceAccountTokenRotations function", func() {
+
+		var ctx context.Context
+		var k8sClient client.Client
+		var apiNamespace *corev1.Namespace
+		var eventLoop *mockEventLoopEventReceiver
+
+		newManagedEnv := func(name string, createNewServiceAccount bool) *managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment {
+			return &managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: apiNamespace.Name,
+					UID:       uuid.NewUUID(),
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec{
+					APIURL:                   "https://api.fake-unit-test-data.origin-ci-int-gce.dev.rhcloud.com:6443",
+					ClusterCredentialsSecret: name + "-secret",
+					CreateNewServiceAccount:  createNewServiceAccount,
+				},
+			}
+		}
+
+		BeforeEach(func() {
+			scheme,
+				argocdNamespace,
+				kubesystemNamespace,
+				namespace,
+				err := tests.GenericTestSetup()
+			Expect(err).ToNot(HaveOccurred())
+
+			apiNamespace = namespace
+
+			now := metav1.Now()
+			deletedManagedEnv := newManagedEnv("deleted-managed-env", true)
+			deletedManagedEnv.DeletionTimestamp = &now
+			deletedManagedEnv.Finalizers = []string{"test-finalizer"}
+
+			k8sClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(apiNamespace, argocdNamespace, kubesystemNamespace,
+					newManagedEnv("new-service-account-managed-env", true),
+					newManagedEnv("existing-credentials-managed-env", false),
+					deletedManagedEnv).
+				Build()
+
+			ctx = context.Background()
+			eventLoop = &mockEventLoopEventReceiver{}
+		})
+
+		It("should only request the rotation of ManagedEnvironments that use a ServiceAccount created by the GitOps Service", func() {
+
+			requestServiceAccountTokenRotations(ctx, k8sClient, eventLoop, logger.FromContext(ctx))
+
+			Expect(eventLoop.events).To(HaveLen(1))
+
+			event := eventLoop.events[0]
+			Expect(event.Request.Name).To(Equal("new-service-account-managed-env"))
+			Expect(event.Request.Namespace).To(Equal(apiNamespace.Name))
+			Expect(event.ReqResource).To(Equal(eventlooptypes.GitOpsDeploymentManagedEnvironmentTypeName))
+			Expect(event.EventType).To(Equal(eventlooptypes.ServiceAccountTokenRotationDue))
+			Expect(event.WorkspaceID).To(Equal(string(apiNamespace.UID)))
+		})
+	})
+
+	Context("Testing processServiceAccountTokenRotation function", func() {
+
+		var ctx context.Context
+		var k8sClient client.Client
+		var apiNamespace *corev1.Namespace
+		var eventRecorder *record.FakeRecorder
+
+		BeforeEach(func() {
+			scheme,
+				argocdNamespace,
+				kubesystemNamespace,
+				namespace,
+				err := tests.GenericTestSetup()
+			Expect(err).ToNot(HaveOccurred())
+
+			apiNamespace = namespace
+
+			managedEnvCr := &managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "existing-credentials-managed-env",
+					Namespace: apiNamespace.Name,
+					UID:       uuid.NewUUID(),
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec{
+					APIURL:                   "https://api.fake-unit-test-data.origin-ci-int-gce.dev.rhcloud.com:6443",
+					ClusterCredentialsSecret: "existing-credentials-managed-env-secret",
+				},
+			}
+
+			k8sClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(apiNamespace, argocdNamespace, kubesystemNamespace, managedEnvCr).
+				Build()
+
+			ctx = context.Background()
+			eventRecorder = record.NewFakeRecorder(10)
+		})
+
+		It("should not rotate the token of a ManagedEnvironment that no longer exists", func() {
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: apiNamespace.Name, Name: "missing-managed-env"}}
+
+			retry, err := processServiceAccountTokenRotation(ctx, req, k8sClient, nil, nil, eventRecorder, logger.FromContext(ctx))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(retry).To(BeFalse())
+			Expect(eventRecorder.Events).To(BeEmpty())
+		})
+
+		It("should not rotate the token of a ManagedEnvironment that does not use a ServiceAccount created by the GitOps Service", func() {
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: apiNamespace.Name, Name: "existing-credentials-managed-env"}}
+
+			retry, err := processServiceAccountTokenRotation(ctx, req, k8sClient, nil, nil, eventRecorder, logger.FromContext(ctx))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(retry).To(BeFalse())
+			Expect(eventRecorder.Events).To(BeEmpty())
+		})
+	})
+
+	Context("Testing GetServiceAccountTokenRotationPeriod function", func() {
+
+		AfterEach(func() {
+			os.Unsetenv(ServiceAccountTokenRotationPeriodEnvVar)
+		})
+
+		It("should return the default rotation period of 30 days, if the env var is not set", func() {
+			os.Unsetenv(ServiceAccountTokenRotationPeriodEnvVar)
+			Expect(GetServiceAccountTokenRotationPeriod(logger.FromContext(context.Background()))).To(Equal(30 * 24 * time.Hour))
+		})
+
+		It("should return the rotation period of the env var, if it is valid", func() {
+			os.Setenv(ServiceAccountTokenRotationPeriodEnvVar, "168h")
+			Expect(GetServiceAccountTokenRotationPeriod(logger.FromContext(context.Background()))).To(Equal(7 * 24 * time.Hour))
+
+			os.Setenv(ServiceAccountTokenRotationPeriodEnvVar, "0")
+			Expect(GetServiceAccountTokenRotationPeriod(logger.FromContext(context.Background()))).To(Equal(time.Duration(0)))
+		})
+
+		It("should return the default rotation period, if the env var is invalid", func() {
+			os.Setenv(ServiceAccountTokenRotationPeriodEnvVar, "30 days")
+			Expect(GetServiceAccountTokenRotationPeriod(logger.FromContext(context.Background()))).To(Equal(defaultServiceAccountTokenRotationPeriod))
+		})
+	})
+})
+
+type receivedEvent struct {
+	Request     ctrl.Request
+	ReqResource eventlooptypes.GitOpsResourceType
+	EventType   eventlooptypes.EventLoopEventType
+	WorkspaceID string
+}
+
+// mockEventLoopEventReceiver records the events that it receives.
+type mockEventLoopEventReceiver struct {
+	events []receivedEvent
+}
+
+func (m *mockEventLoopEventReceiver) EventReceived(req ctrl.Request, reqResource eventlooptypes.GitOpsResourceType,
+	client client.Client, eventType eventlooptypes.EventLoopEventType, namespaceID string) {
+
+	m.events = append(m.events, receivedEvent{
+		Request:     req,
+		ReqResource: reqResource,
+		EventType:   eventType,
+		WorkspaceID: namespaceID,
+	})
+}
@@ -8,6 +8,7 @@ import (
 	logutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util/log"
 	"github.com/redhat-appstudio/managed-gitops/backend/eventloop"
 	"github.com/redhat-appstudio/managed-gitops/backend/eventloop/eventlooptypes"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -34,12 +35,14 @@ type PreprocessEventLoop struct {
 	nextStep              *eventloop.ControllerEventLoop
 }
 
-func NewPreprocessEventLoop() *PreprocessEventLoop {
+// NewPreprocessEventLoop starts the event loops: events recorded by the event loops (for example, on GitOpsDeployments)
+// are reported via 'eventRecorder'.
+func NewPreprocessEventLoop(eventRecorder record.EventRecorder) *PreprocessEventLoop {
 	channel := make(chan eventlooptypes.EventLoopEvent)
 
 	res := &PreprocessEventLoop{}
 	res.eventLoopInputChannel = channel
-	res.nextStep = eventloop.NewControllerEventLoop()
+	res.nextStep = eventloop.NewControllerEventLoop(eventRecorder)
 
 	go preprocessEventLoopRouter(channel, res.nextStep)
 
@@ -3,6 +3,7 @@ package shared_resource_loop
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -15,6 +16,7 @@ import (
 	logutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util/log"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -121,7 +123,7 @@ func (srEventLoop *SharedResourceEventLoop) GetGitopsEngineInstanceById(ctx cont
 func (srEventLoop *SharedResourceEventLoop) ReconcileSharedManagedEnv(ctx context.Context,
 	workspaceClient client.Client, workspaceNamespace corev1.Namespace,
 	managedEnvironmentCRName string, managedEnvironmentCRNamespace string, isWorkspaceTarget bool,
-	k8sClientFactory SRLK8sClientFactory, l logr.Logger) (SharedResourceManagedEnvContainer, error) {
+	k8sClientFactory SRLK8sClientFactory, eventRecorder record.EventRecorder, l logr.Logger) (SharedResourceManagedEnvContainer, error) {
 
 	res := newSharedResourceManagedEnvContainer()
 
@@ -135,6 +137,7 @@ func (srEventLoop *SharedResourceEventLoop) ReconcileSharedManagedEnv(ctx contex
 		managedEnvironmentCRNamespace: managedEnvironmentCRNamespace,
 		isWorkspaceTarget:             isWorkspaceTarget,
 		k8sClientFactory:              k8sClientFactory,
+		eventRecorder:                 eventRecorder,
 	}
 
 	responseChannel := make(chan any)
@@ -213,6 +216,52 @@ func (srEventLoop *SharedResourceEventLoop) ReconcileRepositoryCredential(ctx co
 
 }
 
+// RotateServiceAccountToken rotates the token of the ServiceAccount that was created on the cluster of the ManagedEnvironment CR
+// (when .spec.createNewServiceAccount is true), if the token is older than 'rotationPeriod'. The rotation is performed
+// from within the shared resource loop, so that it does not race with other changes to the managed environment.
+//
+// Returns true if the token was rotated, false otherwise.
+func (srEventLoop *SharedResourceEventLoop) RotateServiceAccountToken(ctx context.Context,
+	workspaceClient client.Client, workspaceNamespace corev1.Namespace,
+	managedEnvCR managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment, rotationPeriod time.Duration,
+	k8sClientFactory SRLK8sClientFactory, l logr.Logger) (bool, error) {
+
+	request := sharedResourceLoopMessage_rotateServiceAccountTokenRequest{
+		managedEnvCR:     managedEnvCR,
+		rotationPeriod:   rotationPeriod,
+		k8sClientFactory: k8sClientFactory,
+	}
+
+	responseChannel := make(chan any)
+
+	msg := sharedResourceLoopMessage{
+		log:                l,
+		ctx:                ctx,
+		workspaceClient:    workspaceClient,
+		workspaceNamespace: workspaceNamespace,
+		messageType:        sharedResourceLoopMessage_rotateServiceAccountToken,
+		responseChannel:    responseChannel,
+		payload:            request,
+	}
+
+	srEventLoop.inputChannel <- msg
+
+	var rawResponse any
+
+	select {
+	case rawResponse = <-responseChannel:
+	case <-ctx.Done():
+		return false, fmt.Errorf("context cancelled in RotateServiceAccountToken")
+	}
+
+	response, ok := rawResponse.(sharedResourceLoopMessage_rotateServiceAccountTokenResponse)
+	if !ok {
+		return false, fmt.Errorf("SEVERE: unexpected response type")
+	}
+	return response.rotated, response.err
+
+}
+
 func NewSharedResourceLoop() *SharedResourceEventLoop {
 
 	sharedResourceEventLoop := &SharedResourceEventLoop{
@@ -231,6 +280,7 @@ const (
 	sharedResourceLoopMessage_getOrCreateClusterUserByNamespaceUID sharedResourceLoopMessageType = "getOrCreateClusterUserByNamespaceUID"
 	sharedResourceLoopMessage_getGitopsEngineInstanceById          sharedResourceLoopMessageType = "getGitopsEngineInstanceById"
 	sharedResourceLoopMessage_reconcileRepositoryCredential        sharedResourceLoopMessageType = "reconcileRepositoryCredential"
+	sharedResourceLoopMessage_rotateServiceAccountToken            sharedResourceLoopMessageType = "rotateServiceAccountToken"
 )
 
 type sharedResourceLoopMessage struct {
@@ -256,6 +306,7 @@ type sharedResourceLoopMessage_getOrCreateSharedResourceManagedEnvRequest struct
 	managedEnvironmentCRNamespace string
 	isWorkspaceTarget             bool
 	k8sClientFactory              SRLK8sClientFactory
+	eventRecorder                 record.EventRecorder
 }
 
 type sharedResourceLoopMessage_getOrCreateSharedResourcesResponse struct {
@@ -274,6 +325,17 @@ type sharedResourceLoopMessage_reconcileRepositoryCredentialResponse struct {
 	repositoryCredential *db.RepositoryCredentials
 }
 
+type sharedResourceLoopMessage_rotateServiceAccountTokenRequest struct {
+	managedEnvCR     managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment
+	rotationPeriod   time.Duration
+	k8sClientFactory SRLK8sClientFactory
+}
+
+type sharedResourceLoopMessage_rotateServiceAccountTokenResponse struct {
+	err     error
+	rotated bool
+}
+
 func newSharedResourceManagedEnvContainer() SharedResourceManagedEnvContainer {
 	return SharedResourceManagedEnvContainer{
 		ClusterUser:          nil,
@@ -359,7 +421,7 @@ func processSharedResourceMessage(ctx context.Context, msg sharedResourceLoopMes
 
 		res, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, msg.workspaceClient, payload.managedEnvironmentCRName,
 			payload.managedEnvironmentCRNamespace, payload.isWorkspaceTarget, msg.workspaceNamespace,
-			payload.k8sClientFactory, dbQueries, l)
+			payload.k8sClientFactory, payload.eventRecorder, dbQueries, l)
 
 		response := sharedResourceLoopMessage_getOrCreateSharedResourcesResponse{
 			err:               err,
@@ -434,6 +496,32 @@ func processSharedResourceMessage(ctx context.Context, msg sharedResourceLoopMes
 			msg.responseChannel <- response
 		}()
 
+	} else if msg.messageType == sharedResourceLoopMessage_rotateServiceAccountToken {
+
+		var err error
+		var rotated bool
+
+		payload, ok := (msg.payload).(sharedResourceLoopMessage_rotateServiceAccountTokenRequest)
+		if ok {
+
+			rotated, err = internalProcessMessage_RotateServiceAccountToken(ctx, payload.managedEnvCR, payload.rotationPeriod,
+				payload.k8sClientFactory, dbQueries, l)
+
+		} else {
+			err = fmt.Errorf("SEVERE - unexpected cast in internalSharedResourceEventLoop")
+			l.Error(err, err.Error())
+		}
+
+		response := sharedResourceLoopMessage_rotateServiceAccountTokenResponse{
+			rotated: rotated,
+			err:     err,
+		}
+
+		// Reply on a separate goroutine so cancelled callers don't block the event loop
+		go func() {
+			msg.responseChannel <- response
+		}()
+
 	} else {
 		l.Error(nil, "SEVERE: unrecognized sharedResourceLoopMessageType: "+string(msg.messageType))
 	}
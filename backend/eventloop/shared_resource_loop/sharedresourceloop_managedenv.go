@@ -2,6 +2,8 @@ package shared_resource_loop
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"errors"
 	"fmt"
 	"sort"
@@ -24,12 +26,19 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	controllerLog "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
 const (
 	KubeconfigKey = "kubeconfig"
+
+	// The keys of a managed environment Secret that contains a bearer token, rather than a kubeconfig: see isTokenSecret
+	TokenKey             = "token"
+	CACertificateKey     = "ca.crt"
+	ClientCertificateKey = "tls.crt"
+	ClientKeyKey         = "tls.key"
 )
 
 func internalProcessMessage_ReconcileSharedManagedEnv(ctx context.Context, workspaceClient client.Client,
@@ -38,6 +47,7 @@ func internalProcessMessage_ReconcileSharedManagedEnv(ctx context.Context, works
 	isWorkspaceTarget bool,
 	workspaceNamespace corev1.Namespace,
 	k8sClientFactory SRLK8sClientFactory,
+	eventRecorder record.EventRecorder,
 	dbQueries db.DatabaseQueries,
 	log logr.Logger) (SharedResourceManagedEnvContainer, error) {
 
@@ -47,7 +57,7 @@ func internalProcessMessage_ReconcileSharedManagedEnv(ctx context.Context, works
 	if condition.reason != "" && condition.managedEnvCR.Name != "" {
 
 		// If a metav1.Condition{} needs to be set, set it here.
-		updateManagedEnvironmentConnectionStatus(ctx, condition.managedEnvCR, workspaceClient, condition, log)
+		updateManagedEnvironmentConnectionStatus(ctx, condition.managedEnvCR, workspaceClient, eventRecorder, condition, log)
 
 	}
 
@@ -194,11 +204,19 @@ func internalProcessMessage_internalReconcileSharedManagedEnv(ctx context.Contex
 			}, errors.New(msg)
 	}
 
+	caBundle, reason, err := getManagedEnvironmentCABundle(ctx, managedEnvironmentCR, secretCR, workspaceClient)
+	if err != nil {
+		return newSharedResourceManagedEnvContainer(),
+			convertErrToEnvInitCondition(reason, err, managedEnvironmentCR), err
+	}
+
 	// We found the managed env, now verify that the ManagedEnv's .spec values match the corresponding fields in the ClusterCredentials row
 	if clusterCreds.Host != managedEnvironmentCR.Spec.APIURL ||
 		clusterCreds.AllowInsecureSkipTLSVerify != managedEnvironmentCR.Spec.AllowInsecureSkipTLSVerify ||
 		clusterCreds.ClusterResources != managedEnvironmentCR.Spec.ClusterResources ||
-		clusterCreds.Namespaces != managedEnvNamespaceSliceList {
+		clusterCreds.Namespaces != managedEnvNamespaceSliceList ||
+		clusterCreds.Ca_bundle != caBundle ||
+		clusterCreds.Tls_server_name != managedEnvironmentCR.Spec.TLSServerName {
 		// C) If at least one of the fields in the managed env CR has changed, then replace the cluster credentials of the managed environment
 		return replaceExistingManagedEnv(ctx, gitopsEngineClient, workspaceClient, *clusterUser, isNewUser, managedEnvironmentCR, secretCR, *managedEnv,
 			workspaceNamespace, k8sClientFactory, dbQueries, log)
@@ -818,49 +836,59 @@ func createNewClusterCredentials(ctx context.Context, managedEnvironment managed
 	secret corev1.Secret, k8sClientFactory SRLK8sClientFactory, dbQueries db.DatabaseQueries, log logr.Logger,
 	workspaceClient client.Client) (db.ClusterCredentials, connectionInitializedCondition, error) {
 
-	if secret.Type != sharedutil.ManagedEnvironmentSecretType {
-		err := fmt.Errorf("invalid secret type: %s", secret.Type)
-		return db.ClusterCredentials{},
-			convertErrToEnvInitCondition(managedgitopsv1alpha1.ConditionReasonInvalidSecretType, err, managedEnvironment),
-			err
+	clusterCredentials, condition, err := generateClusterCredentialsFromSecret(ctx, managedEnvironment, secret, k8sClientFactory, log, workspaceClient)
+	if err != nil {
+		return db.ClusterCredentials{}, condition, err
 	}
 
-	kubeconfig, exists := secret.Data[KubeconfigKey]
-	if !exists {
-		err := fmt.Errorf("missing %s field in Secret", KubeconfigKey)
+	if err := dbQueries.CreateClusterCredentials(ctx, &clusterCredentials); err != nil {
+		log.Error(err, "Unable to create ClusterCredentials for ManagedEnvironment", clusterCredentials.GetAsLogKeyValues()...)
+
+		return db.ClusterCredentials{}, connectionInitializedCondition{
+			managedEnvCR: managedEnvironment,
+			status:       metav1.ConditionUnknown,
+			reason:       managedgitopsv1alpha1.ConditionReasonUnableToCreateClusterCredentials,
+			message:      gitopserrors.UnknownError,
+		}, fmt.Errorf("unable to create cluster credentials for host '%s': %w", clusterCredentials.Host, err)
 
-		return db.ClusterCredentials{},
-			convertErrToEnvInitCondition(managedgitopsv1alpha1.ConditionReasonMissingKubeConfigField, err, managedEnvironment),
-			err
 	}
+	log.Info("Created ClusterCredentials for ManagedEnvironment", clusterCredentials.GetAsLogKeyValues()...)
 
-	// Load the kubeconfig from the field
-	config, err := clientcmd.Load(kubeconfig)
-	if err != nil {
-		err := fmt.Errorf("unable to parse kubeconfig data: %w", err)
+	return clusterCredentials, createSuccessEnvInitCondition(managedEnvironment), nil
+
+}
 
+// generateClusterCredentialsFromSecret reads the connection details of the cluster from the Secret of the managed environment,
+// verifies that they can be used to connect to the cluster, and returns them as a ClusterCredentials (which is not yet
+// created in the database).
+// The Secret may either contain a kubeconfig, or a bearer token along with the CA certificates of the cluster: see isTokenSecret.
+func generateClusterCredentialsFromSecret(ctx context.Context, managedEnvironment managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment,
+	secret corev1.Secret, k8sClientFactory SRLK8sClientFactory, log logr.Logger,
+	workspaceClient client.Client) (db.ClusterCredentials, connectionInitializedCondition, error) {
+
+	if secret.Type != sharedutil.ManagedEnvironmentSecretType {
+		err := fmt.Errorf("invalid secret type: %s", secret.Type)
 		return db.ClusterCredentials{},
-			convertErrToEnvInitCondition(managedgitopsv1alpha1.ConditionReasonUnableToParseKubeconfigData, err, managedEnvironment),
+			convertErrToEnvInitCondition(managedgitopsv1alpha1.ConditionReasonInvalidSecretType, err, managedEnvironment),
 			err
-
 	}
 
-	matchingContextName, matchingContext, err := locateContextThatMatchesAPIURL(config, managedEnvironment.Spec.APIURL)
+	caBundle, reason, err := getManagedEnvironmentCABundle(ctx, managedEnvironment, secret, workspaceClient)
 	if err != nil {
-		return db.ClusterCredentials{},
-			convertErrToEnvInitCondition(managedgitopsv1alpha1.ConditionReasonUnableToLocateContext, err, managedEnvironment),
-			err
+		return db.ClusterCredentials{}, convertErrToEnvInitCondition(reason, err, managedEnvironment), err
 	}
 
-	clientConfig := clientcmd.NewNonInteractiveClientConfig(*config, matchingContextName, &clientcmd.ConfigOverrides{}, nil)
+	var restConfig *rest.Config
+	var secretCreds managedEnvSecretCredentials
+	var condition connectionInitializedCondition
 
-	restConfig, err := clientConfig.ClientConfig()
+	if isTokenSecret(secret) {
+		restConfig, secretCreds, condition, err = getRestConfigFromTokenSecret(secret, managedEnvironment, caBundle)
+	} else {
+		restConfig, secretCreds, condition, err = getRestConfigFromKubeconfigSecret(secret, managedEnvironment)
+	}
 	if err != nil {
-		err := fmt.Errorf("unable to retrieve restConfig from managed environment secret: %w", err)
-
-		return db.ClusterCredentials{},
-			convertErrToEnvInitCondition(managedgitopsv1alpha1.ConditionReasonUnableToRetrieveRestConfig, err, managedEnvironment),
-			err
+		return db.ClusterCredentials{}, condition, err
 	}
 
 	k8sClient, err := k8sClientFactory.BuildK8sClient(restConfig)
@@ -872,11 +900,11 @@ func createNewClusterCredentials(ctx context.Context, managedEnvironment managed
 			err
 	}
 
-	var saBearerToken string
 	log.Info("createNewServiceAccount is ", "CreateNewServiceAccount", managedEnvironment.Spec.CreateNewServiceAccount)
 	if managedEnvironment.Spec.CreateNewServiceAccount {
-		// This is the original behaviour, where we create a new service account
-		saBearerToken, _, err = sharedutil.InstallServiceAccount(ctx, k8sClient, string(managedEnvironment.UID), serviceAccountNamespaceKubeSystem, log)
+		// This is the original behaviour, where we create a new service account, and Argo CD uses its token (rather
+		// than the credentials from the Secret)
+		saBearerToken, _, err := sharedutil.InstallServiceAccount(ctx, k8sClient, string(managedEnvironment.UID), serviceAccountNamespaceKubeSystem, log)
 		if err != nil {
 			err2 := fmt.Errorf("unable to install service account from secret '%s': %w", secret.Name, err)
 
@@ -885,32 +913,7 @@ func createNewClusterCredentials(ctx context.Context, managedEnvironment managed
 				err2
 
 		}
-	} else {
-		// If an existing service account is used instead, we just simply take the provided secret as the token for the cluster credentials
-
-		val, exists := config.AuthInfos[matchingContext.AuthInfo]
-
-		if !exists {
-			msg := "unable to extract remote cluster configuration from kubeconfig, missing auth info for " + matchingContextName
-			return db.ClusterCredentials{}, connectionInitializedCondition{
-				managedEnvCR: managedEnvironment,
-				status:       metav1.ConditionFalse,
-				reason:       managedgitopsv1alpha1.ConditionReasonUnableToParseKubeconfigData,
-				message:      msg,
-			}, fmt.Errorf("%s", msg)
-		}
-
-		if val.Token == "" {
-			msg := fmt.Sprintf("kubeconfig must have a service account token for the user in context \"%s\". client-certificate is not supported at this time.", matchingContextName)
-			return db.ClusterCredentials{}, connectionInitializedCondition{
-				managedEnvCR: managedEnvironment,
-				status:       metav1.ConditionFalse,
-				reason:       managedgitopsv1alpha1.ConditionReasonUnableToParseKubeconfigData,
-				message:      msg,
-			}, fmt.Errorf("%s", msg)
-		}
-
-		saBearerToken = val.Token
+		secretCreds = managedEnvSecretCredentials{bearerToken: saBearerToken}
 	}
 
 	// Convert the .spec.namespaces field to a comma-separated list of namespaces
@@ -937,11 +940,15 @@ func createNewClusterCredentials(ctx context.Context, managedEnvironment managed
 		Host:                        managedEnvironment.Spec.APIURL,
 		Kube_config:                 "",
 		Kube_config_context:         "",
-		Serviceaccount_bearer_token: saBearerToken,
+		Serviceaccount_bearer_token: secretCreds.bearerToken,
 		Serviceaccount_ns:           serviceAccountNamespaceKubeSystem,
 		AllowInsecureSkipTLSVerify:  insecureVerifyTLS,
 		Namespaces:                  namespacesField,
 		ClusterResources:            managedEnvironment.Spec.ClusterResources,
+		Ca_bundle:                   caBundle,
+		Tls_server_name:             managedEnvironment.Spec.TLSServerName,
+		Client_certificate_data:     secretCreds.clientCertificateData,
+		Client_key_data:             secretCreds.clientKeyData,
 	}
 	// If an existing service account is used instead, we should verify the cluster credentials based on the provided token
 	if !managedEnvironment.Spec.CreateNewServiceAccount {
@@ -955,7 +962,8 @@ func createNewClusterCredentials(ctx context.Context, managedEnvironment managed
 			if apierr.IsForbidden(err) {
 				message = "Provided service account does not have permission to access resources in the cluster. Verify that the service account has the correct Role and RoleBinding."
 			} else if isCertificateSignedByUnknownAuthority(err) {
-				message = "Certificate signed by unknown authority. Note that the '.spec.allowInsecureSkipTLSVerify' field can be used to ignore this error."
+				message = "Certificate signed by unknown authority. The CA certificates of the cluster can be specified via the '.spec.caBundle' or '.spec.caSecretRef' fields. " +
+					"Alternatively, the '.spec.allowInsecureSkipTLSVerify' field can be used to ignore this error."
 			}
 			return db.ClusterCredentials{}, connectionInitializedCondition{
 				managedEnvCR: managedEnvironment,
@@ -967,21 +975,170 @@ func createNewClusterCredentials(ctx context.Context, managedEnvironment managed
 		}
 	}
 
-	if err := dbQueries.CreateClusterCredentials(ctx, &clusterCredentials); err != nil {
-		log.Error(err, "Unable to create ClusterCredentials for ManagedEnvironment", clusterCredentials.GetAsLogKeyValues()...)
+	return clusterCredentials, connectionInitializedCondition{}, nil
+}
 
-		return db.ClusterCredentials{}, connectionInitializedCondition{
-			managedEnvCR: managedEnvironment,
-			status:       metav1.ConditionUnknown,
-			reason:       managedgitopsv1alpha1.ConditionReasonUnableToCreateClusterCredentials,
-			message:      gitopserrors.UnknownError,
-		}, fmt.Errorf("unable to create cluster credentials for host '%s': %w", clusterCredentials.Host, err)
+// managedEnvSecretCredentials are the credentials, read from the Secret of a managed environment, that Argo CD uses to
+// connect to the cluster (unless a new ServiceAccount is created).
+type managedEnvSecretCredentials struct {
+	bearerToken           string
+	clientCertificateData string
+	clientKeyData         string
+}
 
+// isTokenSecret returns true if the Secret of a managed environment contains a bearer token ('token' key), the
+// CA certificates of the cluster ('ca.crt' key), and optionally a client certificate and key ('tls.crt' and 'tls.key'
+// keys), rather than a kubeconfig ('kubeconfig' key).
+func isTokenSecret(secret corev1.Secret) bool {
+
+	if _, exists := secret.Data[KubeconfigKey]; exists {
+		return false
 	}
-	log.Info("Created ClusterCredentials for ManagedEnvironment", clusterCredentials.GetAsLogKeyValues()...)
 
-	return clusterCredentials, createSuccessEnvInitCondition(managedEnvironment), nil
+	for _, key := range []string{TokenKey, CACertificateKey, ClientCertificateKey, ClientKeyKey} {
+		if _, exists := secret.Data[key]; exists {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getRestConfigFromTokenSecret returns a rest.Config for the cluster of the managed environment, using the bearer token
+// (and the optional client certificate) from the Secret. The CA certificates of the Secret are part of the caBundle param.
+func getRestConfigFromTokenSecret(secret corev1.Secret, managedEnvironment managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment,
+	caBundle string) (*rest.Config, managedEnvSecretCredentials, connectionInitializedCondition, error) {
+
+	token := strings.TrimSpace(string(secret.Data[TokenKey]))
+	if token == "" {
+		err := fmt.Errorf("missing %s field in Secret", TokenKey)
+		return nil, managedEnvSecretCredentials{},
+			convertErrToEnvInitCondition(managedgitopsv1alpha1.ConditionReasonMissingTokenField, err, managedEnvironment),
+			err
+	}
 
+	clientCert := secret.Data[ClientCertificateKey]
+	clientKey := secret.Data[ClientKeyKey]
+
+	// The client certificate and key are optional, but one may not be specified without the other
+	if len(clientCert) == 0 && len(clientKey) > 0 {
+		err := fmt.Errorf("missing %s field in Secret: it is required when the %s field is specified", ClientCertificateKey, ClientKeyKey)
+		return nil, managedEnvSecretCredentials{},
+			convertErrToEnvInitCondition(managedgitopsv1alpha1.ConditionReasonMissingClientCertificateField, err, managedEnvironment),
+			err
+	}
+	if len(clientCert) > 0 && len(clientKey) == 0 {
+		err := fmt.Errorf("missing %s field in Secret: it is required when the %s field is specified", ClientKeyKey, ClientCertificateKey)
+		return nil, managedEnvSecretCredentials{},
+			convertErrToEnvInitCondition(managedgitopsv1alpha1.ConditionReasonMissingClientKeyField, err, managedEnvironment),
+			err
+	}
+	if len(clientCert) > 0 {
+		if _, err := tls.X509KeyPair(clientCert, clientKey); err != nil {
+			err := fmt.Errorf("unable to parse the client certificate and key in the %s and %s fields of the Secret: %w",
+				ClientCertificateKey, ClientKeyKey, err)
+			return nil, managedEnvSecretCredentials{},
+				convertErrToEnvInitCondition(managedgitopsv1alpha1.ConditionReasonInvalidClientCertificate, err, managedEnvironment),
+				err
+		}
+	}
+
+	secretCreds := managedEnvSecretCredentials{
+		bearerToken:           token,
+		clientCertificateData: string(clientCert),
+		clientKeyData:         string(clientKey),
+	}
+
+	restConfig := &rest.Config{
+		Host:        managedEnvironment.Spec.APIURL,
+		BearerToken: secretCreds.bearerToken,
+		TLSClientConfig: rest.TLSClientConfig{
+			Insecure:   managedEnvironment.Spec.AllowInsecureSkipTLSVerify,
+			ServerName: managedEnvironment.Spec.TLSServerName,
+			CertData:   clientCert,
+			KeyData:    clientKey,
+		},
+	}
+	if caBundle != "" {
+		restConfig.CAData = []byte(caBundle)
+	}
+
+	return restConfig, secretCreds, connectionInitializedCondition{}, nil
+}
+
+// getRestConfigFromKubeconfigSecret returns a rest.Config for the cluster of the managed environment, using the context
+// of the kubeconfig from the Secret that matches the API URL of the managed environment.
+func getRestConfigFromKubeconfigSecret(secret corev1.Secret,
+	managedEnvironment managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment) (*rest.Config, managedEnvSecretCredentials, connectionInitializedCondition, error) {
+
+	kubeconfig, exists := secret.Data[KubeconfigKey]
+	if !exists {
+		err := fmt.Errorf("missing %s field in Secret", KubeconfigKey)
+
+		return nil, managedEnvSecretCredentials{},
+			convertErrToEnvInitCondition(managedgitopsv1alpha1.ConditionReasonMissingKubeConfigField, err, managedEnvironment),
+			err
+	}
+
+	// Load the kubeconfig from the field
+	config, err := clientcmd.Load(kubeconfig)
+	if err != nil {
+		err := fmt.Errorf("unable to parse kubeconfig data: %w", err)
+
+		return nil, managedEnvSecretCredentials{},
+			convertErrToEnvInitCondition(managedgitopsv1alpha1.ConditionReasonUnableToParseKubeconfigData, err, managedEnvironment),
+			err
+
+	}
+
+	matchingContextName, matchingContext, err := locateContextThatMatchesAPIURL(config, managedEnvironment.Spec.APIURL)
+	if err != nil {
+		return nil, managedEnvSecretCredentials{},
+			convertErrToEnvInitCondition(managedgitopsv1alpha1.ConditionReasonUnableToLocateContext, err, managedEnvironment),
+			err
+	}
+
+	clientConfig := clientcmd.NewNonInteractiveClientConfig(*config, matchingContextName, &clientcmd.ConfigOverrides{}, nil)
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		err := fmt.Errorf("unable to retrieve restConfig from managed environment secret: %w", err)
+
+		return nil, managedEnvSecretCredentials{},
+			convertErrToEnvInitCondition(managedgitopsv1alpha1.ConditionReasonUnableToRetrieveRestConfig, err, managedEnvironment),
+			err
+	}
+
+	if managedEnvironment.Spec.CreateNewServiceAccount {
+		// The token of the new service account is used, rather than the credentials of the kubeconfig
+		return restConfig, managedEnvSecretCredentials{}, connectionInitializedCondition{}, nil
+	}
+
+	// If an existing service account is used instead, we just simply take the provided secret as the token for the cluster credentials
+
+	val, exists := config.AuthInfos[matchingContext.AuthInfo]
+
+	if !exists {
+		msg := "unable to extract remote cluster configuration from kubeconfig, missing auth info for " + matchingContextName
+		return nil, managedEnvSecretCredentials{}, connectionInitializedCondition{
+			managedEnvCR: managedEnvironment,
+			status:       metav1.ConditionFalse,
+			reason:       managedgitopsv1alpha1.ConditionReasonUnableToParseKubeconfigData,
+			message:      msg,
+		}, fmt.Errorf("%s", msg)
+	}
+
+	if val.Token == "" {
+		msg := fmt.Sprintf("kubeconfig must have a service account token for the user in context \"%s\". client-certificate is not supported at this time.", matchingContextName)
+		return nil, managedEnvSecretCredentials{}, connectionInitializedCondition{
+			managedEnvCR: managedEnvironment,
+			status:       metav1.ConditionFalse,
+			reason:       managedgitopsv1alpha1.ConditionReasonUnableToParseKubeconfigData,
+			message:      msg,
+		}, fmt.Errorf("%s", msg)
+	}
+
+	return restConfig, managedEnvSecretCredentials{bearerToken: val.Token}, connectionInitializedCondition{}, nil
 }
 
 // locateContextThatMatchesAPIURL examines a kubeconfig (Config struct), and looks for the context that
@@ -1034,6 +1191,89 @@ func isCertificateSignedByUnknownAuthority(err error) bool {
 	return strings.HasSuffix(cause.Error(), "x509: certificate signed by unknown authority")
 }
 
+// getManagedEnvironmentCABundle returns the PEM-encoded CA bundle of the managed environment, from either the
+// .spec.caBundle field, or the Secret referenced by the .spec.caSecretRef field. If neither field is set, the CA
+// certificates of the managed environment Secret are used, if it is a token Secret (see isTokenSecret).
+// Returns "" if there is no CA bundle.
+// On error, the reason of the condition that should be set on the managed environment is also returned.
+func getManagedEnvironmentCABundle(ctx context.Context, managedEnvironment managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment,
+	secret corev1.Secret, workspaceClient client.Client) (string, managedgitopsv1alpha1.ManagedEnvironmentConditionReason, error) {
+
+	spec := managedEnvironment.Spec
+
+	if spec.CABundle == "" && spec.CASecretRef == nil {
+
+		// When TLS verification is disabled, the CA certificates of a token Secret (for example, a ServiceAccount
+		// token Secret, which always contains them) are not used.
+		if !isTokenSecret(secret) || spec.AllowInsecureSkipTLSVerify {
+			return "", "", nil
+		}
+
+		caCertificate := secret.Data[CACertificateKey]
+		if len(caCertificate) == 0 {
+			return "", managedgitopsv1alpha1.ConditionReasonMissingCACertificateField,
+				fmt.Errorf("missing %s field in Secret: it is required unless '.spec.caBundle', '.spec.caSecretRef' or "+
+					"'.spec.allowInsecureSkipTLSVerify' is specified", CACertificateKey)
+		}
+
+		if !x509.NewCertPool().AppendCertsFromPEM(caCertificate) {
+			return "", managedgitopsv1alpha1.ConditionReasonInvalidCABundle,
+				fmt.Errorf("the %s field of the Secret does not contain any valid PEM-encoded certificates", CACertificateKey)
+		}
+
+		return string(caCertificate), "", nil
+	}
+
+	if spec.CABundle != "" && spec.CASecretRef != nil {
+		return "", managedgitopsv1alpha1.ConditionReasonInvalidCABundle,
+			fmt.Errorf("only one of '.spec.caBundle' and '.spec.caSecretRef' may be specified")
+	}
+
+	if spec.AllowInsecureSkipTLSVerify {
+		return "", managedgitopsv1alpha1.ConditionReasonInvalidCABundle,
+			fmt.Errorf("'.spec.allowInsecureSkipTLSVerify' may not be specified together with '.spec.caBundle' or '.spec.caSecretRef'")
+	}
+
+	caBundle := spec.CABundle
+
+	if spec.CASecretRef != nil {
+
+		key := spec.CASecretRef.Key
+		if key == "" {
+			key = managedgitopsv1alpha1.DefaultCASecretKey
+		}
+
+		caSecret := corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      spec.CASecretRef.Name,
+				Namespace: managedEnvironment.Namespace,
+			},
+		}
+		if err := workspaceClient.Get(ctx, client.ObjectKeyFromObject(&caSecret), &caSecret); err != nil {
+			if apierr.IsNotFound(err) {
+				return "", managedgitopsv1alpha1.ConditionReasonInvalidCABundle,
+					fmt.Errorf("CA secret '%s' referenced by '.spec.caSecretRef' does not exist", caSecret.Name)
+			}
+			return "", managedgitopsv1alpha1.ConditionReasonKubeError,
+				fmt.Errorf("unable to retrieve CA secret '%s' referenced by '.spec.caSecretRef': %w", caSecret.Name, err)
+		}
+
+		value, exists := caSecret.Data[key]
+		if !exists {
+			return "", managedgitopsv1alpha1.ConditionReasonInvalidCABundle,
+				fmt.Errorf("missing %s field in CA secret '%s' referenced by '.spec.caSecretRef'", key, caSecret.Name)
+		}
+		caBundle = string(value)
+	}
+
+	if !x509.NewCertPool().AppendCertsFromPEM([]byte(caBundle)) {
+		return "", managedgitopsv1alpha1.ConditionReasonInvalidCABundle,
+			fmt.Errorf("the CA bundle does not contain any valid PEM-encoded certificates")
+	}
+
+	return caBundle, "", nil
+}
+
 // sanityTestCredentials returns true if we were able to successfully connect with the credentials, false otherwise.
 func sanityTestCredentials(clusterCreds db.ClusterCredentials) (*rest.Config, bool, error) {
 
@@ -1052,7 +1292,16 @@ func sanityTestCredentials(clusterCreds db.ClusterCredentials) (*rest.Config, bo
 
 	configParam.Insecure = clusterCreds.AllowInsecureSkipTLSVerify
 
-	configParam.ServerName = ""
+	if clusterCreds.Ca_bundle != "" {
+		configParam.CAData = []byte(clusterCreds.Ca_bundle)
+	}
+
+	if clusterCreds.Client_certificate_data != "" {
+		configParam.CertData = []byte(clusterCreds.Client_certificate_data)
+		configParam.KeyData = []byte(clusterCreds.Client_key_data)
+	}
+
+	configParam.ServerName = clusterCreds.Tls_server_name
 
 	return configParam, true, nil
 }
@@ -1137,9 +1386,10 @@ func createUnknownErrorEnvInitCondition() connectionInitializedCondition {
 // Updates the given managed environment's connection status condition to match the given status, reason and message.
 // If there is an existing status condition with the exact same status, reason and message, no update is made in order
 // to preserve the LastTransitionTime (see https://pkg.go.dev/k8s.io/apimachinery/pkg/apis/meta/v1#Condition.LastTransitionTime )
+// If the status changed, and the connection failed, a Warning Event is recorded on the managed environment.
 func updateManagedEnvironmentConnectionStatus(ctx context.Context,
 	managedEnvironment managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment,
-	client client.Client, connInitCondition connectionInitializedCondition, log logr.Logger) {
+	client client.Client, eventRecorder record.EventRecorder, connInitCondition connectionInitializedCondition, log logr.Logger) {
 
 	const conditionType = managedgitopsv1alpha1.ManagedEnvironmentStatusConnectionInitializationSucceeded
 	var condition *metav1.Condition = nil
@@ -1162,6 +1412,11 @@ func updateManagedEnvironmentConnectionStatus(ctx context.Context,
 		condition.Status = connInitCondition.status
 		if err := client.Status().Update(ctx, &managedEnvironment); err != nil {
 			log.Error(err, "updating managed environment status condition")
+			return
+		}
+
+		if connInitCondition.status == metav1.ConditionFalse {
+			eventRecorder.Event(&managedEnvironment, corev1.EventTypeWarning, eventlooptypes.EventReasonConnectionFailed, connInitCondition.message)
 		}
 	}
 }
@@ -1205,6 +1460,75 @@ func verifyClusterCredentialsWithNamespaceList(ctx context.Context, clusterCreds
 	return true, nil
 }
 
+// GetClusterCredentialsForManagedEnvironment returns the cluster credentials, stored in the database, of the
+// ManagedEnvironment CR. Returns nil if the ManagedEnvironment CR has not (yet) been processed, and so has no
+// cluster credentials.
+func GetClusterCredentialsForManagedEnvironment(ctx context.Context, managedEnvCR managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment,
+	dbQueries db.DatabaseQueries) (*db.ClusterCredentials, error) {
+
+	managedEnv, err := getManagedEnvironmentForCR(ctx, managedEnvCR, dbQueries)
+	if err != nil || managedEnv == nil {
+		return nil, err
+	}
+
+	clusterCreds := &db.ClusterCredentials{
+		Clustercredentials_cred_id: managedEnv.Clustercredentials_id,
+	}
+	if err := dbQueries.GetClusterCredentialsById(ctx, clusterCreds); err != nil {
+		if db.IsResultNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to retrieve cluster credentials for '%s': %w", clusterCreds.Clustercredentials_cred_id, err)
+	}
+
+	return clusterCreds, nil
+}
+
+// getManagedEnvironmentForCR returns the ManagedEnvironment database entry of the ManagedEnvironment CR, or nil if the
+// ManagedEnvironment CR has not (yet) been processed.
+func getManagedEnvironmentForCR(ctx context.Context, managedEnvCR managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment,
+	dbQueries db.DatabaseQueries) (*db.ManagedEnvironment, error) {
+
+	apiCRToDBMapping := db.APICRToDatabaseMapping{
+		APIResourceType: db.APICRToDatabaseMapping_ResourceType_GitOpsDeploymentManagedEnvironment,
+		APIResourceUID:  string(managedEnvCR.UID),
+		DBRelationType:  db.APICRToDatabaseMapping_DBRelationType_ManagedEnvironment,
+	}
+	if err := dbQueries.GetDatabaseMappingForAPICR(ctx, &apiCRToDBMapping); err != nil {
+		if db.IsResultNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to retrieve managed environment APICRToDatabaseMapping for %s: %w", apiCRToDBMapping.APIResourceUID, err)
+	}
+
+	managedEnv := &db.ManagedEnvironment{
+		Managedenvironment_id: apiCRToDBMapping.DBRelationKey,
+	}
+	if err := dbQueries.GetManagedEnvironmentById(ctx, managedEnv); err != nil {
+		if db.IsResultNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to retrieve managed environment '%s': %w", managedEnv.Managedenvironment_id, err)
+	}
+
+	return managedEnv, nil
+}
+
+// VerifyManagedEnvironmentConnection returns nil if we are able to connect to the cluster of the ManagedEnvironment CR
+// using the given cluster credentials, or the error that occurred otherwise.
+func VerifyManagedEnvironmentConnection(ctx context.Context, clusterCreds db.ClusterCredentials,
+	managedEnvCR managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment, k8sClientFactory SRLK8sClientFactory) error {
+
+	validClusterCreds, err := verifyClusterCredentialsWithNamespaceList(ctx, clusterCreds, managedEnvCR, k8sClientFactory)
+	if err != nil {
+		return err
+	}
+	if !validClusterCreds {
+		return fmt.Errorf("unable to connect to the cluster using the cluster credentials")
+	}
+	return nil
+}
+
 // Convert the .spec.namespaces field to a sorted, comma-separated list of namespaces
 func convertManagedEnvNamespacesFieldToCommaSeparatedList(namespaces []string) (string, error) {
 	if len(namespaces) == 0 {
@@ -18,15 +18,21 @@ import (
 	sharedutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util"
 	"github.com/redhat-appstudio/managed-gitops/backend-shared/util/tests"
 	"github.com/redhat-appstudio/managed-gitops/backend/eventloop/eventloop_test_util"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
@@ -135,7 +141,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 				By("calling reconcileSharedManagedEnv for the first time, and verifying the database rows are created")
 
 				src, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-					false, *namespace, mockFactory, dbQueries, log)
+					false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(src.ManagedEnv).To(Not(BeNil()))
 
@@ -148,7 +154,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 				Expect(err).ToNot(HaveOccurred())
 
 				src, err = internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-					false, *namespace, mockFactory, dbQueries, log)
+					false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(src.ManagedEnv).To(Not(BeNil()))
 				verifyResult(managedEnv, src)
@@ -168,7 +174,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 				Expect(err).ToNot(HaveOccurred())
 
 				src, err = internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-					false, *namespace, mockFactory, dbQueries, log)
+					false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 				Expect(err).ToNot(HaveOccurred())
 
 				// Update our copy of the ManagedEnvironment, since the call to reconcile will have added status to it.
@@ -188,7 +194,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 				err = k8sClient.Update(ctx, &managedEnv)
 				Expect(err).ToNot(HaveOccurred())
 				src, err = internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-					false, *namespace, mockFactory, dbQueries, log)
+					false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 				Expect(err).ToNot(HaveOccurred())
 
 				By("verifying the old cluster credentials have been deleted, after update")
@@ -215,7 +221,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 				oldManagedEnv := src.ManagedEnv
 
 				src, err = internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-					false, *namespace, mockFactory, dbQueries, log)
+					false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 				Expect(err).ToNot(HaveOccurred())
 
 				err = dbQueries.GetManagedEnvironmentById(ctx, oldManagedEnv)
@@ -263,7 +269,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 			Expect(err).ToNot(HaveOccurred())
 
 			src, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(src.ManagedEnv).ToNot(BeNil())
 
@@ -291,7 +297,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 			By("calling ReconcileSharedManagedEnv")
 			src, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(src.ManagedEnv).To(Not(BeNil()))
 
@@ -306,7 +312,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 			By("ensuring the LastTransitionTime is not updated if nothing has changed")
 			lastTransitionTime := managedEnv.Status.Conditions[0].LastTransitionTime
 			src, err = internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(src.ManagedEnv).To(Not(BeNil()))
 			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(&managedEnv), &managedEnv)
@@ -332,7 +338,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 			By("calling ReconcileSharedManagedEnv")
 			src, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(src.ManagedEnv).To(Not(BeNil()))
 
@@ -349,7 +355,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 			err = k8sClient.Update(ctx, &managedEnv)
 			Expect(err).ToNot(HaveOccurred())
 			src, err = internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 
 			By("ensuring the status condition is recreated")
 			Expect(err).ToNot(HaveOccurred())
@@ -366,7 +372,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 			err = k8sClient.Update(ctx, &managedEnv)
 			Expect(err).ToNot(HaveOccurred())
 			src, err = internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 
 			By("ensuring the status condition is recreated")
 			Expect(err).ToNot(HaveOccurred())
@@ -406,7 +412,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 			By("calling reconcile to create  new managed env")
 			src, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(src.ManagedEnv).To(BeNil())
 			Expect(err).To(HaveOccurred())
 			Expect(mockFactory.count).To(Equal(1))
@@ -434,7 +440,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 			By("first calling reconcile to create database entries for new managed env")
 			firstSrc, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(firstSrc.ManagedEnv).ToNot(BeNil())
 
@@ -452,7 +458,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 				realFakeClient: k8sClient,
 			}
 			src, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(src.ManagedEnv).To(BeNil())
 			Expect(err).To(HaveOccurred())
 			Expect(mockFactory.count).To(Equal(2))
@@ -480,7 +486,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 			By("first calling reconcile to create database entries for new managed env")
 			firstSrc, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(firstSrc.ManagedEnv).ToNot(BeNil())
 
@@ -499,7 +505,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 				realFakeClient: k8sClient,
 			}
 			src, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(src.ManagedEnv).To(BeNil())
 			Expect(err).To(HaveOccurred())
 			Expect(mockFactory.count).To(Equal(3))
@@ -528,7 +534,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 			By("first calling reconcile to create database entries for new managed env")
 			firstSrc, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(firstSrc.ManagedEnv).ToNot(BeNil())
 
@@ -547,7 +553,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 				realFakeClient: k8sClient,
 			}
 			src, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(src.ManagedEnv).To(BeNil())
 			Expect(err).To(HaveOccurred())
 			Expect(mockFactory.count).To(Equal(3))
@@ -559,7 +565,8 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 			Expect(managedEnv.Status.Conditions[0].Type).To(Equal(managedgitopsv1alpha1.ManagedEnvironmentStatusConnectionInitializationSucceeded))
 			Expect(managedEnv.Status.Conditions[0].Status).To(Equal(metav1.ConditionUnknown))
 			Expect(managedEnv.Status.Conditions[0].Reason).To(Equal(string(managedgitopsv1alpha1.ConditionReasonUnableToValidateClusterCredentials)))
-			Expect(managedEnv.Status.Conditions[0].Message).To(Equal("Certificate signed by unknown authority. Note that the '.spec.allowInsecureSkipTLSVerify' field can be used to ignore this error."))
+			Expect(managedEnv.Status.Conditions[0].Message).To(Equal("Certificate signed by unknown authority. The CA certificates of the cluster can be specified via the '.spec.caBundle' or '.spec.caSecretRef' fields. " +
+				"Alternatively, the '.spec.allowInsecureSkipTLSVerify' field can be used to ignore this error."))
 		})
 
 		It("should set the condition ConnectionInitializationSucceeded appropriately when the connection fails because of insufficient permissions to get a particular namespaces", func() {
@@ -577,7 +584,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 			By("first calling reconcile to create database entries for new managed env")
 			firstSrc, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(firstSrc.ManagedEnv).ToNot(BeNil())
 
@@ -596,7 +603,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 				realFakeClient: k8sClient,
 			}
 			src, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(src.ManagedEnv).To(BeNil())
 			Expect(err).To(HaveOccurred())
 			Expect(mockFactory.count).To(Equal(3))
@@ -625,7 +632,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 			By("first calling reconcile to create database entries for new managed env")
 			firstSrc, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(firstSrc.ManagedEnv).ToNot(BeNil())
 
@@ -644,7 +651,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 				realFakeClient: k8sClient,
 			}
 			src, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(src.ManagedEnv).ToNot(BeNil())
 			Expect(mockFactory.count).To(Equal(1))
@@ -692,7 +699,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 			By("first calling reconcile to create database entries for new managed env")
 			createRC, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(createRC.ManagedEnv).ToNot(BeNil())
 
@@ -728,7 +735,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 			By("calling reconcile, after deleting the CR, to ensure the database entries are reconciled")
 			deleteRC, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(deleteRC.ManagedEnv).To(BeNil())
 
@@ -776,7 +783,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 			By("calling reconcile on the managed env, which is missing a secret")
 			createRC, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).To(HaveOccurred())
 			Expect(createRC.ManagedEnv).To(BeNil())
 
@@ -800,7 +807,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 			By("first calling reconcile to create database entries for new managed env")
 			createRC, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(createRC.ManagedEnv).ToNot(BeNil())
 
@@ -809,7 +816,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 			By("call reconcile again, but without the cluster secret existing")
 			createRC, err = internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).To(HaveOccurred())
 			Expect(createRC.ManagedEnv).To(BeNil())
 
@@ -834,7 +841,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 			By("calling reconcile to create database entries for new managed env")
 			createRC, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(createRC.ManagedEnv).ToNot(BeNil())
 
@@ -861,7 +868,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 			By("call the reconcile function again")
 			createRC, err = internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(createRC.ManagedEnv).ToNot(BeNil())
 
@@ -877,6 +884,88 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 		})
 
+		It("should reconcile a ManagedEnvironment containing .spec.caSecretRef and .spec.tlsServerName values", func() {
+
+			managedEnv, secret := buildManagedEnvironmentForSRL()
+
+			caSecret := corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-my-managed-env-ca",
+					Namespace: managedEnv.Namespace,
+				},
+				Type: corev1.SecretTypeOpaque,
+				Data: map[string][]byte{
+					managedgitopsv1alpha1.DefaultCASecretKey: []byte(fakeCACertificate),
+				},
+			}
+
+			managedEnv.Spec.CASecretRef = &managedgitopsv1alpha1.ManagedEnvironmentCASecretRef{Name: caSecret.Name}
+			managedEnv.Spec.TLSServerName = "kubernetes.default.svc"
+
+			managedEnv.UID = "test-" + uuid.NewUUID()
+			secret.UID = "test-" + uuid.NewUUID()
+			eventloop_test_util.StartServiceAccountListenerOnFakeClient(ctx, string(managedEnv.UID), k8sClient)
+
+			err := k8sClient.Create(ctx, &managedEnv)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = k8sClient.Create(ctx, &secret)
+			Expect(err).ToNot(HaveOccurred())
+
+			By("calling reconcile before the CA Secret exists, which should fail")
+			createRC, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
+			Expect(err).To(HaveOccurred())
+			Expect(createRC.ManagedEnv).To(BeNil())
+
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(&managedEnv), &managedEnv)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(managedEnv.Status.Conditions).To(HaveLen(1))
+			Expect(managedEnv.Status.Conditions[0].Status).To(Equal(metav1.ConditionFalse))
+			Expect(managedEnv.Status.Conditions[0].Reason).To(Equal(string(managedgitopsv1alpha1.ConditionReasonInvalidCABundle)))
+
+			By("creating the CA Secret, and calling reconcile again")
+			err = k8sClient.Create(ctx, &caSecret)
+			Expect(err).ToNot(HaveOccurred())
+
+			createRC, err = internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(createRC.ManagedEnv).ToNot(BeNil())
+
+			By("ensuring cluster credentials should have expected values")
+			clusterCredentials := db.ClusterCredentials{
+				Clustercredentials_cred_id: createRC.ManagedEnv.Clustercredentials_id,
+			}
+			err = dbQueries.GetClusterCredentialsById(ctx, &clusterCredentials)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(clusterCredentials.Ca_bundle).To(Equal(fakeCACertificate), "should match the value from the CA Secret")
+			Expect(clusterCredentials.Tls_server_name).To(Equal("kubernetes.default.svc"), "should match values from managed env")
+
+			By("removing the CA Secret reference and TLS server name from the managed env .spec, to ensure the change is applied")
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(&managedEnv), &managedEnv)
+			Expect(err).ToNot(HaveOccurred())
+
+			managedEnv.Spec.CASecretRef = nil
+			managedEnv.Spec.TLSServerName = ""
+
+			err = k8sClient.Update(ctx, &managedEnv)
+			Expect(err).ToNot(HaveOccurred())
+
+			createRC, err = internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(createRC.ManagedEnv).ToNot(BeNil())
+
+			clusterCredentials = db.ClusterCredentials{
+				Clustercredentials_cred_id: createRC.ManagedEnv.Clustercredentials_id,
+			}
+			err = dbQueries.GetClusterCredentialsById(ctx, &clusterCredentials)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(clusterCredentials.Ca_bundle).To(BeEmpty())
+			Expect(clusterCredentials.Tls_server_name).To(BeEmpty())
+		})
+
 		It("should produce a useful error message if the user in the kubeconfig doesn't have a token", func() {
 			By("creating ManagedEnvironment/Secret, without creating a new ServiceAccount")
 
@@ -916,7 +1005,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 			By("calling reconcileSharedManagedEnv, which should produce the error")
 
 			src, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(src.ManagedEnv).To(BeNil())
 			Expect(err).To(HaveOccurred())
 			// Find the root error
@@ -942,7 +1031,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 			Expect(err).ToNot(HaveOccurred())
 
 			src, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(src.ManagedEnv).ToNot(BeNil())
 
@@ -990,7 +1079,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 			Expect(err).ToNot(HaveOccurred())
 
 			src, err = internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(src.ManagedEnv).To(BeNil())
 
@@ -1020,7 +1109,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 			By("calling ReconcileSharedManagedEnvironment")
 			src, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(src.ManagedEnv).ToNot(BeNil())
 
@@ -1059,7 +1148,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 			By("calling ReconcileSharedManagedEnv")
 			src, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(src.ManagedEnv).ToNot(BeNil())
 
@@ -1086,7 +1175,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 			By("calling ReconcileSharedManagedEnv and verifying that the ManagedEnvironment row was created")
 			src, err = internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-				false, *namespace, mockFactory, dbQueries, log)
+				false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 			Expect(err).ToNot(HaveOccurred())
 
 			err = dbQueries.GetManagedEnvironmentById(ctx, src.ManagedEnv)
@@ -1119,7 +1208,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 				By("first calling reconcile to create database entries for new managed env")
 				reconcileRes, err := internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-					false, *namespace, mockFactory, dbQueries, log)
+					false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(reconcileRes.ManagedEnv).ToNot(BeNil())
 
@@ -1140,7 +1229,7 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 
 				By("calling reconcile again to ensure the managed environment db entry is updated with the new value")
 				reconcileRes, err = internalProcessMessage_ReconcileSharedManagedEnv(ctx, k8sClient, managedEnv.Name, managedEnv.Namespace,
-					false, *namespace, mockFactory, dbQueries, log)
+					false, *namespace, mockFactory, &record.FakeRecorder{}, dbQueries, log)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(reconcileRes.ManagedEnv).ToNot(BeNil())
 
@@ -1188,6 +1277,73 @@ var _ = Describe("SharedResourceEventLoop ManagedEnvironment-related Test", func
 			Entry("a valid namespace, one invalid namespace", []string{"B", "a"}, "", true),
 		)
 
+		DescribeTable("Verify that getManagedEnvironmentCABundle returns the CA bundle of the managed environment, rejecting invalid CA bundles",
+			func(spec managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec, caSecretData map[string][]byte,
+				expectedResult string, expectedReason managedgitopsv1alpha1.ManagedEnvironmentConditionReason) {
+
+				scheme, _, _, _, err := tests.GenericTestSetup()
+				Expect(err).ToNot(HaveOccurred())
+				k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+				if caSecretData != nil {
+					err := k8sClient.Create(context.Background(), &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Name: "my-ca-secret", Namespace: "my-namespace"},
+						Data:       caSecretData,
+					})
+					Expect(err).ToNot(HaveOccurred())
+				}
+
+				managedEnv := managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment{
+					ObjectMeta: metav1.ObjectMeta{Name: "my-managed-env", Namespace: "my-namespace"},
+					Spec:       spec,
+				}
+
+				res, reason, err := getManagedEnvironmentCABundle(context.Background(), managedEnv, corev1.Secret{}, k8sClient)
+				Expect(res).To(Equal(expectedResult))
+				Expect(reason).To(Equal(expectedReason))
+				Expect(err != nil).To(Equal(expectedReason != ""))
+			},
+			Entry("no CA bundle", managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec{},
+				nil, "", managedgitopsv1alpha1.ManagedEnvironmentConditionReason("")),
+			Entry("a valid .spec.caBundle", managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec{CABundle: fakeCACertificate},
+				nil, fakeCACertificate, managedgitopsv1alpha1.ManagedEnvironmentConditionReason("")),
+			Entry("an invalid .spec.caBundle", managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec{CABundle: "not-a-certificate"},
+				nil, "", managedgitopsv1alpha1.ConditionReasonInvalidCABundle),
+			Entry("a .spec.caBundle with .spec.allowInsecureSkipTLSVerify",
+				managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec{CABundle: fakeCACertificate, AllowInsecureSkipTLSVerify: true},
+				nil, "", managedgitopsv1alpha1.ConditionReasonInvalidCABundle),
+			Entry("both .spec.caBundle and .spec.caSecretRef",
+				managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec{CABundle: fakeCACertificate,
+					CASecretRef: &managedgitopsv1alpha1.ManagedEnvironmentCASecretRef{Name: "my-ca-secret"}},
+				map[string][]byte{"ca.crt": []byte(fakeCACertificate)}, "", managedgitopsv1alpha1.ConditionReasonInvalidCABundle),
+			Entry("a .spec.caSecretRef with the default key",
+				managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec{CASecretRef: &managedgitopsv1alpha1.ManagedEnvironmentCASecretRef{Name: "my-ca-secret"}},
+				map[string][]byte{"ca.crt": []byte(fakeCACertificate)}, fakeCACertificate, managedgitopsv1alpha1.ManagedEnvironmentConditionReason("")),
+			Entry("a .spec.caSecretRef with a custom key",
+				managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec{CASecretRef: &managedgitopsv1alpha1.ManagedEnvironmentCASecretRef{Name: "my-ca-secret", Key: "bundle.pem"}},
+				map[string][]byte{"bundle.pem": []byte(fakeCACertificate)}, fakeCACertificate, managedgitopsv1alpha1.ManagedEnvironmentConditionReason("")),
+			Entry("a .spec.caSecretRef with a missing key",
+				managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec{CASecretRef: &managedgitopsv1alpha1.ManagedEnvironmentCASecretRef{Name: "my-ca-secret"}},
+				map[string][]byte{"bundle.pem": []byte(fakeCACertificate)}, "", managedgitopsv1alpha1.ConditionReasonInvalidCABundle),
+			Entry("a .spec.caSecretRef to a Secret that doesn't exist",
+				managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec{CASecretRef: &managedgitopsv1alpha1.ManagedEnvironmentCASecretRef{Name: "my-ca-secret"}},
+				nil, "", managedgitopsv1alpha1.ConditionReasonInvalidCABundle),
+		)
+
+		It("Verify that sanityTestCredentials uses the CA bundle and TLS server name of the cluster credentials", func() {
+			restConfig, valid, err := sanityTestCredentials(db.ClusterCredentials{
+				Host:                        "https://api.fake-unit-test-data.origin-ci-int-gce.dev.rhcloud.com:6443",
+				Serviceaccount_bearer_token: "token",
+				Ca_bundle:                   fakeCACertificate,
+				Tls_server_name:             "kubernetes.default.svc",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(valid).To(BeTrue())
+			Expect(restConfig.Insecure).To(BeFalse())
+			Expect(string(restConfig.CAData)).To(Equal(fakeCACertificate))
+			Expect(restConfig.ServerName).To(Equal("kubernetes.default.svc"))
+		})
+
 	})
 
 })
@@ -1305,6 +1461,20 @@ func buildManagedEnvironmentForSRLWithOptionalSA(createNewServiceAccount bool) (
 	return *managedEnv, *secret
 }
 
+// fakeCACertificate is a self-signed CA certificate, which is only used by unit tests.
+const fakeCACertificate = `-----BEGIN CERTIFICATE-----
+MIIBkDCCATWgAwIBAgIUU0VcsjO4rn7DJwAO/sUM8AAaZAQwCgYIKoZIzj0EAwIw
+HDEaMBgGA1UEAwwRZmFrZS11bml0LXRlc3QtY2EwIBcNMjYxMDE3MDIzNzMwWhgP
+MjEyNjA5MjMwMjM3MzBaMBwxGjAYBgNVBAMMEWZha2UtdW5pdC10ZXN0LWNhMFkw
+EwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEH8a/kvmZuX1PqJxApJ+MsuD/bG70Z8Fg
+96Wbeh0ZfJDcFTBqKlHXfMo38zOGXEqcANh3sqCUE9eTcL7hvz9mDqNTMFEwHQYD
+VR0OBBYEFLc9+ExRXSeBkL2nAYYPB4iIMb2nMB8GA1UdIwQYMBaAFLc9+ExRXSeB
+kL2nAYYPB4iIMb2nMA8GA1UdEwEB/wQFMAMBAf8wCgYIKoZIzj0EAwIDSQAwRgIh
+AO5K9krnGQPjMDcb1/niUrgT06rRjqRJzHtI0lNdP52RAiEA1AupC1TVuHzu6mJm
+8awKpKEzpyVpteIN+ZniV5KEHqI=
+-----END CERTIFICATE-----
+`
+
 func generateFakeKubeConfig() string {
 	// This config has been sanitized of any real credentials.
 	return `
@@ -1368,3 +1538,232 @@ users:
 `
 
 }
+
+var _ = Describe("updateManagedEnvironmentConnectionStatus", func() {
+
+	var ctx context.Context
+	var k8sClient client.Client
+	var managedEnv *managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment
+	var recorder *record.FakeRecorder
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme, _, _, workspace, err := tests.GenericTestSetup()
+		Expect(err).ToNot(HaveOccurred())
+
+		managedEnv = &managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-managed-env",
+				Namespace: workspace.Name,
+			},
+		}
+
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(workspace, managedEnv).Build()
+		recorder = record.NewFakeRecorder(10)
+	})
+
+	It("should record a Warning event only when the connection status changes to failed", func() {
+
+		failedCondition := connectionInitializedCondition{
+			managedEnvCR: *managedEnv,
+			status:       metav1.ConditionFalse,
+			reason:       managedgitopsv1alpha1.ConditionReasonUnableToValidateClusterCredentials,
+			message:      "unable to connect",
+		}
+
+		updateManagedEnvironmentConnectionStatus(ctx, *managedEnv, k8sClient, recorder, failedCondition, logr.Discard())
+		Expect(recorder.Events).To(Receive(Equal("Warning ConnectionFailed unable to connect")))
+
+		By("updating the managed environment with the same condition, no event should be recorded")
+		err := k8sClient.Get(ctx, client.ObjectKeyFromObject(managedEnv), managedEnv)
+		Expect(err).ToNot(HaveOccurred())
+		failedCondition.managedEnvCR = *managedEnv
+
+		updateManagedEnvironmentConnectionStatus(ctx, *managedEnv, k8sClient, recorder, failedCondition, logr.Discard())
+		Expect(recorder.Events).ToNot(Receive())
+
+		By("updating the managed environment with a successful connection, no event should be recorded")
+		successCondition := createSuccessEnvInitCondition(*managedEnv)
+
+		updateManagedEnvironmentConnectionStatus(ctx, *managedEnv, k8sClient, recorder, successCondition, logr.Discard())
+		Expect(recorder.Events).ToNot(Receive())
+
+		err = k8sClient.Get(ctx, client.ObjectKeyFromObject(managedEnv), managedEnv)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(managedEnv.Status.Conditions).To(HaveLen(1))
+		Expect(managedEnv.Status.Conditions[0].Status).To(Equal(metav1.ConditionTrue))
+	})
+})
+
+var _ = Describe("generateClusterCredentialsFromSecret with a token Secret", Ordered, func() {
+
+	var ctx context.Context
+	var log logr.Logger
+	var testEnv *envtest.Environment
+	var cfg *rest.Config
+	var k8sClient client.Client
+	var apiURL string
+	var serviceAccountToken string
+
+	const (
+		testNamespace      = "managed-env-token-test"
+		serviceAccountName = "argocd-manager"
+	)
+
+	BeforeAll(func() {
+		ctx = context.Background()
+		log = logf.FromContext(ctx)
+
+		By("starting a test API server, which the managed environment connects to")
+		testEnv = &envtest.Environment{}
+
+		var err error
+		cfg, err = testEnv.Start()
+		Expect(err).ToNot(HaveOccurred())
+
+		apiURL = cfg.Host
+		if !strings.HasPrefix(apiURL, "https://") {
+			apiURL = "https://" + apiURL
+		}
+
+		k8sClient, err = client.New(cfg, client.Options{Scheme: scheme.Scheme})
+		Expect(err).ToNot(HaveOccurred())
+
+		By("creating a ServiceAccount with cluster-admin permissions, and requesting a token for it")
+		err = k8sClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespace}})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = k8sClient.Create(ctx, &corev1.ServiceAccount{
+			ObjectMeta: metav1.ObjectMeta{Name: serviceAccountName, Namespace: testNamespace},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = k8sClient.Create(ctx, &rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: serviceAccountName},
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: serviceAccountName, Namespace: testNamespace}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+
+		clientset, err := kubernetes.NewForConfig(cfg)
+		Expect(err).ToNot(HaveOccurred())
+
+		tokenRequest, err := clientset.CoreV1().ServiceAccounts(testNamespace).CreateToken(ctx, serviceAccountName,
+			&authenticationv1.TokenRequest{}, metav1.CreateOptions{})
+		Expect(err).ToNot(HaveOccurred())
+		serviceAccountToken = tokenRequest.Status.Token
+	})
+
+	AfterAll(func() {
+		By("tearing down the test API server")
+		err := testEnv.Stop()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	buildManagedEnvironmentWithTokenSecret := func(data map[string][]byte) (managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment, corev1.Secret) {
+		secret := corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-managed-env-token-secret",
+				Namespace: testNamespace,
+			},
+			Type: sharedutil.ManagedEnvironmentSecretType,
+			Data: data,
+		}
+
+		managedEnv := managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-managed-env",
+				Namespace: testNamespace,
+				UID:       uuid.NewUUID(),
+			},
+			Spec: managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec{
+				APIURL:                   apiURL,
+				ClusterCredentialsSecret: secret.Name,
+			},
+		}
+
+		return managedEnv, secret
+	}
+
+	It("should generate cluster credentials from a Secret containing a token and the CA certificate of the cluster", func() {
+		managedEnv, secret := buildManagedEnvironmentWithTokenSecret(map[string][]byte{
+			TokenKey:         []byte(serviceAccountToken),
+			CACertificateKey: cfg.CAData,
+		})
+
+		clusterCreds, _, err := generateClusterCredentialsFromSecret(ctx, managedEnv, secret, DefaultK8sClientFactory{}, log, k8sClient)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(clusterCreds.Host).To(Equal(apiURL))
+		Expect(clusterCreds.Serviceaccount_bearer_token).To(Equal(serviceAccountToken))
+		Expect(clusterCreds.Ca_bundle).To(Equal(string(cfg.CAData)))
+		Expect(clusterCreds.AllowInsecureSkipTLSVerify).To(BeFalse())
+		Expect(clusterCreds.Kube_config).To(BeEmpty())
+		Expect(clusterCreds.Client_certificate_data).To(BeEmpty())
+		Expect(clusterCreds.Client_key_data).To(BeEmpty())
+	})
+
+	It("should generate cluster credentials from a Secret that also contains a client certificate and key", func() {
+		managedEnv, secret := buildManagedEnvironmentWithTokenSecret(map[string][]byte{
+			TokenKey:             []byte(serviceAccountToken),
+			CACertificateKey:     cfg.CAData,
+			ClientCertificateKey: cfg.CertData,
+			ClientKeyKey:         cfg.KeyData,
+		})
+
+		clusterCreds, _, err := generateClusterCredentialsFromSecret(ctx, managedEnv, secret, DefaultK8sClientFactory{}, log, k8sClient)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(clusterCreds.Serviceaccount_bearer_token).To(Equal(serviceAccountToken))
+		Expect(clusterCreds.Client_certificate_data).To(Equal(string(cfg.CertData)))
+		Expect(clusterCreds.Client_key_data).To(Equal(string(cfg.KeyData)))
+	})
+
+	It("should not require the CA certificate if TLS verification is disabled", func() {
+		managedEnv, secret := buildManagedEnvironmentWithTokenSecret(map[string][]byte{
+			TokenKey: []byte(serviceAccountToken),
+		})
+		managedEnv.Spec.AllowInsecureSkipTLSVerify = true
+
+		clusterCreds, _, err := generateClusterCredentialsFromSecret(ctx, managedEnv, secret, DefaultK8sClientFactory{}, log, k8sClient)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(clusterCreds.Ca_bundle).To(BeEmpty())
+		Expect(clusterCreds.AllowInsecureSkipTLSVerify).To(BeTrue())
+	})
+
+	DescribeTable("should set a condition reason that identifies the missing or invalid field of the Secret",
+		func(getData func() map[string][]byte, expectedReason managedgitopsv1alpha1.ManagedEnvironmentConditionReason) {
+
+			managedEnv, secret := buildManagedEnvironmentWithTokenSecret(getData())
+
+			_, condition, err := generateClusterCredentialsFromSecret(ctx, managedEnv, secret, DefaultK8sClientFactory{}, log, k8sClient)
+			Expect(err).To(HaveOccurred())
+			Expect(condition.reason).To(Equal(expectedReason))
+			Expect(condition.managedEnvCR.Name).To(Equal(managedEnv.Name))
+		},
+		Entry("missing token", func() map[string][]byte {
+			return map[string][]byte{CACertificateKey: cfg.CAData}
+		}, managedgitopsv1alpha1.ConditionReasonMissingTokenField),
+		Entry("missing CA certificate", func() map[string][]byte {
+			return map[string][]byte{TokenKey: []byte(serviceAccountToken)}
+		}, managedgitopsv1alpha1.ConditionReasonMissingCACertificateField),
+		Entry("invalid CA certificate", func() map[string][]byte {
+			return map[string][]byte{TokenKey: []byte(serviceAccountToken), CACertificateKey: []byte("not-a-certificate")}
+		}, managedgitopsv1alpha1.ConditionReasonInvalidCABundle),
+		Entry("client certificate without a client key", func() map[string][]byte {
+			return map[string][]byte{TokenKey: []byte(serviceAccountToken), CACertificateKey: cfg.CAData, ClientCertificateKey: cfg.CertData}
+		}, managedgitopsv1alpha1.ConditionReasonMissingClientKeyField),
+		Entry("client key without a client certificate", func() map[string][]byte {
+			return map[string][]byte{TokenKey: []byte(serviceAccountToken), CACertificateKey: cfg.CAData, ClientKeyKey: cfg.KeyData}
+		}, managedgitopsv1alpha1.ConditionReasonMissingClientCertificateField),
+		Entry("client key that doesn't match the client certificate", func() map[string][]byte {
+			return map[string][]byte{TokenKey: []byte(serviceAccountToken), CACertificateKey: cfg.CAData,
+				ClientCertificateKey: cfg.CertData, ClientKeyKey: []byte("not-a-key")}
+		}, managedgitopsv1alpha1.ConditionReasonInvalidClientCertificate),
+		Entry("token that is not valid for the cluster", func() map[string][]byte {
+			return map[string][]byte{TokenKey: []byte("not-a-valid-token"), CACertificateKey: cfg.CAData}
+		}, managedgitopsv1alpha1.ConditionReasonUnableToValidateClusterCredentials),
+		Entry("CA certificate that did not sign the certificate of the cluster", func() map[string][]byte {
+			return map[string][]byte{TokenKey: []byte(serviceAccountToken), CACertificateKey: []byte(fakeCACertificate)}
+		}, managedgitopsv1alpha1.ConditionReasonUnableToValidateClusterCredentials),
+	)
+})
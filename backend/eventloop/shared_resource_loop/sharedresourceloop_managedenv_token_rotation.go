@@ -0,0 +1,226 @@
+package shared_resource_loop
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
+	db "github.com/redhat-appstudio/managed-gitops/backend-shared/db"
+	sharedutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util"
+	logutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util/log"
+	"github.com/redhat-appstudio/managed-gitops/backend-shared/util/operations"
+)
+
+// tokenRotationOperationTimeout is the maximum time to wait for the cluster-agent to update the Argo CD cluster secrets
+// of a managed environment with a rotated token, before the previous token is revoked.
+const tokenRotationOperationTimeout = 5 * time.Minute
+
+// internalProcessMessage_RotateServiceAccountToken rotates the bearer token of the ServiceAccount that was created on the
+// target cluster of the ManagedEnvironment CR (when .spec.createNewServiceAccount is true), if the token is older than
+// 'rotationPeriod'. It is only called from within the shared resource loop, so no other changes to the managed
+// environment database entries may occur while the token is rotated.
+//
+// The token is rotated as follows:
+// 1) A new token is issued for the ServiceAccount, using the existing cluster credentials.
+// 2) The new token is verified, by connecting to the cluster with it.
+// 3) The ManagedEnvironment database entry is updated to point to new cluster credentials containing the new token.
+// 4) An Operation is created for each Argo CD instance that uses the managed environment, instructing the cluster-agent
+// to update the Argo CD cluster secret with the new token.
+// 5) Once the Argo CD cluster secrets have been updated, the previous cluster credentials are deleted, and the previous
+// token is revoked.
+//
+// Returns true if the token was rotated, false otherwise.
+func internalProcessMessage_RotateServiceAccountToken(ctx context.Context, managedEnvCR managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment,
+	rotationPeriod time.Duration, k8sClientFactory SRLK8sClientFactory, dbQueries db.DatabaseQueries, l logr.Logger) (bool, error) {
+
+	if !managedEnvCR.Spec.CreateNewServiceAccount {
+		// The token was provided by the user, so it is not ours to rotate.
+		return false, nil
+	}
+
+	managedEnv, err := getManagedEnvironmentForCR(ctx, managedEnvCR, dbQueries)
+	if err != nil || managedEnv == nil {
+		return false, err
+	}
+
+	oldClusterCreds := db.ClusterCredentials{
+		Clustercredentials_cred_id: managedEnv.Clustercredentials_id,
+	}
+	if err := dbQueries.GetClusterCredentialsById(ctx, &oldClusterCreds); err != nil {
+		if db.IsResultNotFoundError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("unable to retrieve cluster credentials '%s': %w", oldClusterCreds.Clustercredentials_cred_id, err)
+	}
+
+	// The creation time of the cluster credentials is the time at which the token was issued: any change to the
+	// credentials (including a rotation) replaces the cluster credentials database entry.
+	if oldClusterCreds.Serviceaccount_bearer_token == "" || time.Since(oldClusterCreds.Created_on) < rotationPeriod {
+		return false, nil
+	}
+
+	log := l.WithValues("managedEnvID", managedEnv.Managedenvironment_id, "clusterCredentials", oldClusterCreds.Clustercredentials_cred_id)
+
+	serviceAccountUID := string(managedEnvCR.UID)
+	serviceAccountNS := oldClusterCreds.Serviceaccount_ns
+
+	// 1) Issue a new token for the ServiceAccount, using the existing credentials
+	oldClient, err := buildK8sClientForClusterCredentials(oldClusterCreds, k8sClientFactory)
+	if err != nil {
+		return false, err
+	}
+
+	newToken, _, err := sharedutil.InstallServiceAccount(ctx, oldClient, serviceAccountUID, serviceAccountNS, log)
+	if err != nil {
+		return false, fmt.Errorf("unable to issue a new token for the service account: %w", err)
+	}
+
+	newClusterCreds := oldClusterCreds
+	newClusterCreds.Clustercredentials_cred_id = ""
+	newClusterCreds.SeqID = 0
+	newClusterCreds.Created_on = time.Time{}
+	newClusterCreds.Serviceaccount_bearer_token = newToken
+
+	// abandonRotation is called if the rotation fails before the managed environment points to the new cluster
+	// credentials, so that the new token (and cluster credentials) do not outlive it
+	abandonRotation := func() {
+		if newClusterCreds.Clustercredentials_cred_id != "" {
+			if _, err := dbQueries.DeleteClusterCredentialsById(ctx, newClusterCreds.Clustercredentials_cred_id); err != nil {
+				log.Error(err, "unable to delete the ClusterCredentials of the abandoned rotation")
+			}
+		}
+		if _, err := sharedutil.RevokeServiceAccountBearerToken(ctx, oldClient, serviceAccountUID, serviceAccountNS, newToken, log); err != nil {
+			log.Error(err, "unable to revoke the new service account token, after the rotation was abandoned")
+		}
+	}
+
+	// 2) Verify that we are able to connect to the cluster with the new token
+	if err := VerifyManagedEnvironmentConnection(ctx, newClusterCreds, managedEnvCR, k8sClientFactory); err != nil {
+		abandonRotation()
+		return false, fmt.Errorf("unable to verify the new service account token: %w", err)
+	}
+
+	// 3) Replace the cluster credentials of the managed environment with new credentials containing the new token
+	if err := dbQueries.CreateClusterCredentials(ctx, &newClusterCreds); err != nil {
+		newClusterCreds.Clustercredentials_cred_id = ""
+		abandonRotation()
+		return false, fmt.Errorf("unable to create cluster credentials for the new service account token: %w", err)
+	}
+	log.Info("Created ClusterCredentials for rotated service account token", newClusterCreds.GetAsLogKeyValues()...)
+
+	managedEnv.Clustercredentials_id = newClusterCreds.Clustercredentials_cred_id
+	if err := dbQueries.UpdateManagedEnvironment(ctx, managedEnv); err != nil {
+		log.Error(err, "Unable to update ManagedEnvironment with rotated cluster credentials ID", managedEnv.GetAsLogKeyValues()...)
+		abandonRotation()
+		return false, fmt.Errorf("unable to update managed environment with rotated credentials: %w", err)
+	}
+	log.Info("Updated ManagedEnvironment with rotated cluster credentials ID", managedEnv.GetAsLogKeyValues()...)
+
+	// 4) Update the Argo CD cluster secrets with the new token
+	if err := updateArgoCDClusterSecretsOfManagedEnv(ctx, *managedEnv, k8sClientFactory, dbQueries, log); err != nil {
+		// The previous cluster credentials and token are kept, as Argo CD may still be using the previous token. (The
+		// previous cluster credentials are no longer referenced, so they are eventually removed by the database reconciler.)
+		return true, fmt.Errorf("unable to update the Argo CD cluster secrets with the rotated token, so the previous token was not revoked: %w", err)
+	}
+
+	// 5) Delete the previous cluster credentials and revoke the previous token, now that they are no longer used
+	rowsDeleted, err := dbQueries.DeleteClusterCredentialsById(ctx, oldClusterCreds.Clustercredentials_cred_id)
+	if err != nil {
+		log.Error(err, "Unable to delete old ClusterCredentials row which is no longer used by ManagedEnv")
+	} else if rowsDeleted != 1 {
+		log.V(logutil.LogLevel_Warn).Info("unexpected number of rows deleted when deleting cluster credentials", "rowsDeleted", rowsDeleted)
+	}
+
+	newClient, err := buildK8sClientForClusterCredentials(newClusterCreds, k8sClientFactory)
+	if err != nil {
+		return true, err
+	}
+	revoked, err := sharedutil.RevokeServiceAccountBearerToken(ctx, newClient, serviceAccountUID, serviceAccountNS,
+		oldClusterCreds.Serviceaccount_bearer_token, log)
+	if err != nil {
+		return true, fmt.Errorf("unable to revoke the previous service account token: %w", err)
+	}
+	log.Info("Rotated service account token of managed environment", "revokedTokenSecrets", revoked)
+
+	return true, nil
+}
+
+// updateArgoCDClusterSecretsOfManagedEnv creates an Operation for each Argo CD instance that has access to the managed
+// environment, instructing the cluster-agent to update the Argo CD cluster secret of the managed environment, then waits
+// for the Operations to complete.
+func updateArgoCDClusterSecretsOfManagedEnv(ctx context.Context, managedEnv db.ManagedEnvironment, k8sClientFactory SRLK8sClientFactory,
+	dbQueries db.DatabaseQueries, log logr.Logger) error {
+
+	var clusterAccesses []db.ClusterAccess
+	if err := dbQueries.ListClusterAccessesByManagedEnvironmentID(ctx, managedEnv.Managedenvironment_id, &clusterAccesses); err != nil {
+		return fmt.Errorf("unable to list cluster accesses by managed id '%s': %w", managedEnv.Managedenvironment_id, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, tokenRotationOperationTimeout)
+	defer cancel()
+
+	// The gitops engine instances which have already been sent an Operation
+	processedGitopsEngineInstances := map[string]bool{}
+
+	for _, clusterAccess := range clusterAccesses {
+
+		if processedGitopsEngineInstances[clusterAccess.Clusteraccess_gitops_engine_instance_id] {
+			continue
+		}
+		processedGitopsEngineInstances[clusterAccess.Clusteraccess_gitops_engine_instance_id] = true
+
+		gitopsEngineInstance := db.GitopsEngineInstance{
+			Gitopsengineinstance_id: clusterAccess.Clusteraccess_gitops_engine_instance_id,
+		}
+		if err := dbQueries.GetGitopsEngineInstanceById(ctx, &gitopsEngineInstance); err != nil {
+			return fmt.Errorf("unable to retrieve gitopsengineinstance '%s': %w", gitopsEngineInstance.Gitopsengineinstance_id, err)
+		}
+
+		gitopsEngineClient, err := k8sClientFactory.GetK8sClientForGitOpsEngineInstance(ctx, &gitopsEngineInstance)
+		if err != nil {
+			return fmt.Errorf("unable to retrieve k8s client for engine instance '%s': %w", gitopsEngineInstance.Gitopsengineinstance_id, err)
+		}
+
+		operation := db.Operation{
+			Instance_id:             gitopsEngineInstance.Gitopsengineinstance_id,
+			Operation_owner_user_id: clusterAccess.Clusteraccess_user_id,
+			Resource_type:           db.OperationResourceType_ManagedEnvironment,
+			Resource_id:             managedEnv.Managedenvironment_id,
+		}
+
+		log.Info("Creating Operation to update Argo CD cluster secret, referencing managed environment",
+			"gitopsEngineInstanceID", gitopsEngineInstance.Gitopsengineinstance_id)
+
+		_, dbOperation, err := operations.CreateOperation(ctx, true, operation, clusterAccess.Clusteraccess_user_id,
+			gitopsEngineInstance.Namespace_name, dbQueries, gitopsEngineClient, log)
+		if err != nil {
+			return fmt.Errorf("unable to create operation for managed environment: %w", err)
+		}
+		if dbOperation.State != db.OperationState_Completed {
+			return fmt.Errorf("operation '%s' to update the Argo CD cluster secret did not complete: %s",
+				dbOperation.Operation_id, dbOperation.Human_readable_state)
+		}
+	}
+
+	return nil
+}
+
+// buildK8sClientForClusterCredentials returns a client that connects to the cluster using the cluster credentials.
+func buildK8sClientForClusterCredentials(clusterCreds db.ClusterCredentials, k8sClientFactory SRLK8sClientFactory) (client.Client, error) {
+
+	restConfig, _, err := sanityTestCredentials(clusterCreds)
+	if err != nil {
+		return nil, err
+	}
+
+	k8sClient, err := k8sClientFactory.BuildK8sClient(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create new K8s client to '%v': %w", restConfig.Host, err)
+	}
+
+	return k8sClient, nil
+}
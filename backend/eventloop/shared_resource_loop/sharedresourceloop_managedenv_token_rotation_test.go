@@ -0,0 +1,297 @@
+package shared_resource_loop
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
+	db "github.com/redhat-appstudio/managed-gitops/backend-shared/db"
+	sharedutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util"
+	"github.com/redhat-appstudio/managed-gitops/backend-shared/util/tests"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	logger "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("SharedResourceEventLoop ServiceAccount token rotation tests", func() {
+
+	Context("Testing RotateServiceAccountToken function", func() {
+
+		const (
+			oldToken       = "old-token"
+			rotationPeriod = 24 * time.Hour
+		)
+
+		var log logr.Logger
+		var ctx context.Context
+		var cancel context.CancelFunc
+		var dbq db.AllDatabaseQueries
+		var k8sClient client.Client
+		var apiNamespace *corev1.Namespace
+		var managedEnvCr *managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment
+		var managedEnvironmentDb db.ManagedEnvironment
+		var clusterCredentialsDb db.ClusterCredentials
+		var clusterAccess *db.ClusterAccess
+		var oldTokenSecret *corev1.Secret
+		var sharedResourceEventLoop *SharedResourceEventLoop
+
+		// simulateTokenControllerAndClusterAgent populates the token of each new ServiceAccount token Secret (as the
+		// Kubernetes token controller would), and sets the state of each Operation on the managed environment to
+		// 'operationState' (as the cluster-agent would), until the context is cancelled.
+		simulateTokenControllerAndClusterAgent := func(operationState db.OperationState) {
+			go func() {
+				defer GinkgoRecover()
+
+				for {
+					select {
+					case <-ctx.Done():
+						return
+					case <-time.After(100 * time.Millisecond):
+					}
+
+					var secrets corev1.SecretList
+					if err := k8sClient.List(ctx, &secrets, client.InNamespace("kube-system")); err == nil {
+						for idx := range secrets.Items {
+							secret := secrets.Items[idx]
+							if secret.Type != corev1.SecretTypeServiceAccountToken || len(secret.Data["token"]) > 0 {
+								continue
+							}
+							secret.Data = map[string][]byte{"token": []byte("token-" + secret.Name)}
+							_ = k8sClient.Update(ctx, &secret)
+						}
+					}
+
+					var operations []db.Operation
+					if err := dbq.ListOperationsByResourceIdAndTypeAndOwnerId(ctx, managedEnvironmentDb.Managedenvironment_id,
+						db.OperationResourceType_ManagedEnvironment, &operations, clusterAccess.Clusteraccess_user_id); err == nil {
+						for idx := range operations {
+							operation := operations[idx]
+							if operation.State != db.OperationState_Waiting {
+								continue
+							}
+							operation.State = operationState
+							_ = dbq.UpdateOperation(ctx, &operation)
+						}
+					}
+				}
+			}()
+		}
+
+		getCurrentClusterCredentials := func() db.ClusterCredentials {
+			managedEnv := db.ManagedEnvironment{Managedenvironment_id: managedEnvironmentDb.Managedenvironment_id}
+			err := dbq.GetManagedEnvironmentById(ctx, &managedEnv)
+			Expect(err).ToNot(HaveOccurred())
+
+			clusterCreds := db.ClusterCredentials{Clustercredentials_cred_id: managedEnv.Clustercredentials_id}
+			err = dbq.GetClusterCredentialsById(ctx, &clusterCreds)
+			Expect(err).ToNot(HaveOccurred())
+			return clusterCreds
+		}
+
+		rotateServiceAccountToken := func(rotationPeriod time.Duration) (bool, error) {
+			return sharedResourceEventLoop.RotateServiceAccountToken(ctx, k8sClient, *apiNamespace, *managedEnvCr, rotationPeriod,
+				MockSRLK8sClientFactory{fakeClient: k8sClient}, log)
+		}
+
+		BeforeEach(func() {
+			scheme,
+				argocdNamespace,
+				kubesystemNamespace,
+				namespace,
+				err := tests.GenericTestSetup()
+			Expect(err).ToNot(HaveOccurred())
+
+			apiNamespace = namespace
+
+			managedEnvCr = &managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-managed-env",
+					Namespace: apiNamespace.Name,
+					UID:       uuid.NewUUID(),
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentManagedEnvironmentSpec{
+					APIURL:                     "https://api.fake-unit-test-data.origin-ci-int-gce.dev.rhcloud.com:6443",
+					ClusterCredentialsSecret:   "test-managed-env-secret",
+					AllowInsecureSkipTLSVerify: true,
+					CreateNewServiceAccount:    true,
+				},
+			}
+
+			oldTokenSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "old-token-secret",
+					Namespace: kubesystemNamespace.Name,
+					Annotations: map[string]string{
+						corev1.ServiceAccountNameKey: sharedutil.GenerateServiceAccountName(string(managedEnvCr.UID)),
+					},
+				},
+				Type: corev1.SecretTypeServiceAccountToken,
+				Data: map[string][]byte{
+					"token": []byte(oldToken),
+				},
+			}
+
+			// Create fake client: it is used both for the namespace of the ManagedEnvironment, and for the target cluster
+			k8sClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(apiNamespace, argocdNamespace, kubesystemNamespace, managedEnvCr, oldTokenSecret).
+				Build()
+
+			err = db.SetupForTestingDBGinkgo()
+			Expect(err).ToNot(HaveOccurred())
+
+			ctx, cancel = context.WithCancel(context.Background())
+			log = logger.FromContext(ctx)
+			dbq, err = db.NewUnsafePostgresDBQueries(true, true)
+			Expect(err).ToNot(HaveOccurred())
+
+			sharedResourceEventLoop = NewSharedResourceLoop()
+
+			By("Create required DB entries.")
+
+			_, _, _, _, clusterAccess, err = db.CreateSampleData(dbq)
+			Expect(err).ToNot(HaveOccurred())
+
+			clusterCredentialsDb = db.ClusterCredentials{
+				Clustercredentials_cred_id:  "test-" + string(uuid.NewUUID()),
+				Host:                        managedEnvCr.Spec.APIURL,
+				Serviceaccount_bearer_token: oldToken,
+				Serviceaccount_ns:           kubesystemNamespace.Name,
+				AllowInsecureSkipTLSVerify:  true,
+				Created_on:                  time.Now().Add(-2 * rotationPeriod),
+			}
+			err = dbq.CreateClusterCredentials(ctx, &clusterCredentialsDb)
+			Expect(err).ToNot(HaveOccurred())
+
+			managedEnvironmentDb = db.ManagedEnvironment{
+				Managedenvironment_id: "test-env-" + string(managedEnvCr.UID),
+				Clustercredentials_id: clusterCredentialsDb.Clustercredentials_cred_id,
+				Name:                  managedEnvCr.Name,
+			}
+			err = dbq.CreateManagedEnvironment(ctx, &managedEnvironmentDb)
+			Expect(err).ToNot(HaveOccurred())
+
+			apiCRToDatabaseMappingDb := db.APICRToDatabaseMapping{
+				APIResourceType:      db.APICRToDatabaseMapping_ResourceType_GitOpsDeploymentManagedEnvironment,
+				APIResourceUID:       string(managedEnvCr.UID),
+				APIResourceName:      managedEnvCr.Name,
+				APIResourceNamespace: managedEnvCr.Namespace,
+				NamespaceUID:         string(apiNamespace.UID),
+				DBRelationType:       db.APICRToDatabaseMapping_DBRelationType_ManagedEnvironment,
+				DBRelationKey:        managedEnvironmentDb.Managedenvironment_id,
+			}
+			err = dbq.CreateAPICRToDatabaseMapping(ctx, &apiCRToDatabaseMappingDb)
+			Expect(err).ToNot(HaveOccurred())
+
+			By("giving the Argo CD instance access to the managed environment")
+			clusterAccess = &db.ClusterAccess{
+				Clusteraccess_user_id:                   clusterAccess.Clusteraccess_user_id,
+				Clusteraccess_managed_environment_id:    managedEnvironmentDb.Managedenvironment_id,
+				Clusteraccess_gitops_engine_instance_id: clusterAccess.Clusteraccess_gitops_engine_instance_id,
+			}
+			err = dbq.CreateClusterAccess(ctx, clusterAccess)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			cancel()
+			dbq.CloseDatabase()
+		})
+
+		It("should rotate a token that is older than the rotation period, and revoke the old token", func() {
+
+			simulateTokenControllerAndClusterAgent(db.OperationState_Completed)
+
+			rotated, err := rotateServiceAccountToken(rotationPeriod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rotated).To(BeTrue())
+
+			By("verifying the managed environment points to new cluster credentials, containing a new token")
+			clusterCreds := getCurrentClusterCredentials()
+			Expect(clusterCreds.Clustercredentials_cred_id).ToNot(Equal(clusterCredentialsDb.Clustercredentials_cred_id))
+			Expect(clusterCreds.Serviceaccount_bearer_token).ToNot(Equal(oldToken))
+			Expect(clusterCreds.Serviceaccount_bearer_token).To(HavePrefix("token-"))
+			Expect(clusterCreds.Host).To(Equal(clusterCredentialsDb.Host))
+			Expect(clusterCreds.AllowInsecureSkipTLSVerify).To(BeTrue())
+			Expect(time.Since(clusterCreds.Created_on)).To(BeNumerically("<", rotationPeriod))
+
+			By("verifying the old cluster credentials were deleted")
+			err = dbq.GetClusterCredentialsById(ctx, &db.ClusterCredentials{Clustercredentials_cred_id: clusterCredentialsDb.Clustercredentials_cred_id})
+			Expect(db.IsResultNotFoundError(err)).To(BeTrue())
+
+			By("verifying an Operation was created to update the Argo CD cluster secret")
+			var operations []db.Operation
+			err = dbq.ListOperationsByResourceIdAndTypeAndOwnerId(ctx, managedEnvironmentDb.Managedenvironment_id,
+				db.OperationResourceType_ManagedEnvironment, &operations, clusterAccess.Clusteraccess_user_id)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(operations).To(HaveLen(1))
+			Expect(operations[0].Instance_id).To(Equal(clusterAccess.Clusteraccess_gitops_engine_instance_id))
+
+			By("verifying the old token was revoked, and the new token was not")
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(oldTokenSecret), oldTokenSecret)
+			Expect(apierr.IsNotFound(err)).To(BeTrue())
+
+			newTokenSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      strings.TrimPrefix(clusterCreds.Serviceaccount_bearer_token, "token-"),
+					Namespace: oldTokenSecret.Namespace,
+				},
+			}
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(newTokenSecret), newTokenSecret)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should not delete the old cluster credentials or revoke the old token, if the Argo CD cluster secret could not be updated", func() {
+
+			simulateTokenControllerAndClusterAgent(db.OperationState_Failed)
+
+			rotated, err := rotateServiceAccountToken(rotationPeriod)
+			Expect(err).To(HaveOccurred())
+			Expect(rotated).To(BeTrue())
+
+			clusterCreds := getCurrentClusterCredentials()
+			Expect(clusterCreds.Serviceaccount_bearer_token).ToNot(Equal(oldToken))
+
+			err = dbq.GetClusterCredentialsById(ctx, &db.ClusterCredentials{Clustercredentials_cred_id: clusterCredentialsDb.Clustercredentials_cred_id})
+			Expect(err).ToNot(HaveOccurred(), "the old cluster credentials should only be deleted once the Argo CD cluster secret is updated")
+
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(oldTokenSecret), oldTokenSecret)
+			Expect(err).ToNot(HaveOccurred(), "the old token should not be revoked, as Argo CD may still be using it")
+		})
+
+		It("should not rotate a token that is newer than the rotation period", func() {
+
+			rotated, err := rotateServiceAccountToken(3 * rotationPeriod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rotated).To(BeFalse())
+
+			clusterCreds := getCurrentClusterCredentials()
+			Expect(clusterCreds.Clustercredentials_cred_id).To(Equal(clusterCredentialsDb.Clustercredentials_cred_id))
+			Expect(clusterCreds.Serviceaccount_bearer_token).To(Equal(oldToken))
+
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(oldTokenSecret), oldTokenSecret)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should not rotate the token of a ManagedEnvironment that does not use a ServiceAccount created by the GitOps Service", func() {
+
+			managedEnvCr.Spec.CreateNewServiceAccount = false
+
+			rotated, err := rotateServiceAccountToken(rotationPeriod)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(rotated).To(BeFalse())
+
+			clusterCreds := getCurrentClusterCredentials()
+			Expect(clusterCreds.Clustercredentials_cred_id).To(Equal(clusterCredentialsDb.Clustercredentials_cred_id))
+			Expect(clusterCreds.Serviceaccount_bearer_token).To(Equal(oldToken))
+		})
+	})
+})
@@ -10,6 +10,7 @@ import (
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/storage/memory"
 
+	"github.com/go-git/go-git/v5/plumbing/transport"
 	"github.com/go-git/go-git/v5/plumbing/transport/http"
 	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
 
@@ -590,28 +591,36 @@ func validateRepositoryCredentials(rawRepoURL string, secret *corev1.Secret) err
 		URLs: []string{normalizedRepoUrl},
 	})
 
+	auth, err := GitAuthFromSecret(secret)
+	if err != nil {
+		return err
+	}
+
+	_, err = rem.List(&git.ListOptions{Auth: auth})
+	return err
+}
+
+// GitAuthFromSecret returns the Git authentication method for the repository credentials in the Secret of a
+// GitOpsDeploymentRepositoryCredential: either an SSH private key ('sshPrivateKey'), or a username/password.
+func GitAuthFromSecret(secret *corev1.Secret) (transport.AuthMethod, error) {
+
 	// Secret exists, so get its data
 	authUsername := string(secret.Data["username"])
 	authPassword := string(secret.Data["password"])
 	authSSHKey := string(secret.Data["sshPrivateKey"])
 
-	listOptions := &git.ListOptions{}
-
 	if authSSHKey != "" {
 		privateKey, err := ssh.NewPublicKeys("git", []byte(authSSHKey), "")
 		if err != nil {
-			return err
-		}
-		listOptions.Auth = privateKey
-	} else {
-		listOptions.Auth = &http.BasicAuth{
-			Username: authUsername,
-			Password: authPassword,
+			return nil, err
 		}
+		return privateKey, nil
 	}
 
-	_, err := rem.List(listOptions)
-	return err
+	return &http.BasicAuth{
+		Username: authUsername,
+		Password: authPassword,
+	}, nil
 }
 
 // EnsurePrefix idempotently ensures that a base string has a given prefix.
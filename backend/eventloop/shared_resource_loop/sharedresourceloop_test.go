@@ -10,6 +10,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -196,7 +197,7 @@ var _ = Describe("SharedResourceEventLoop Test", func() {
 
 			// At first assuming there are no existing resources, hence creating new.
 			sharedResourceOld, err := sharedResourceEventLoop.ReconcileSharedManagedEnv(ctx, k8sClient, *namespace, "", "",
-				true, MockSRLK8sClientFactory{fakeClient: k8sClient}, l)
+				true, MockSRLK8sClientFactory{fakeClient: k8sClient}, &record.FakeRecorder{}, l)
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(sharedResourceOld.ClusterUser).NotTo(BeNil())
@@ -211,7 +212,7 @@ var _ = Describe("SharedResourceEventLoop Test", func() {
 
 			// Resources are created in previous call, then same resources should be returned instead of creating new.
 			sharedResourceNew, err := sharedResourceEventLoop.ReconcileSharedManagedEnv(ctx, k8sClient, *namespace, "", "",
-				true, MockSRLK8sClientFactory{fakeClient: k8sClient}, l)
+				true, MockSRLK8sClientFactory{fakeClient: k8sClient}, &record.FakeRecorder{}, l)
 
 			Expect(err).ToNot(HaveOccurred())
 			Expect(sharedResourceNew.ClusterUser).NotTo(BeNil())
@@ -17,6 +17,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -38,24 +39,25 @@ import (
 
 // Start a workspace event loop router go routine, which is responsible for handling API namespace events and
 // then passing them to the controller loop.
-func newWorkspaceEventLoopRouter(workspaceID string) WorkspaceEventLoopRouterStruct {
+func newWorkspaceEventLoopRouter(workspaceID string, eventRecorder record.EventRecorder) WorkspaceEventLoopRouterStruct {
 
 	res := WorkspaceEventLoopRouterStruct{
 		channel: make(chan workspaceEventLoopMessage),
 	}
 
-	internalStartWorkspaceEventLoopRouter(res.channel, workspaceID, defaultApplicationEventLoopFactory{})
+	internalStartWorkspaceEventLoopRouter(res.channel, workspaceID, defaultApplicationEventLoopFactory{}, eventRecorder)
 
 	return res
 }
 
-func newWorkspaceEventLoopRouterWithFactory(workspaceID string, applEventLoopFactory applicationEventQueueLoopFactory) WorkspaceEventLoopRouterStruct {
+func newWorkspaceEventLoopRouterWithFactory(workspaceID string, applEventLoopFactory applicationEventQueueLoopFactory,
+	eventRecorder record.EventRecorder) WorkspaceEventLoopRouterStruct {
 
 	res := WorkspaceEventLoopRouterStruct{
 		channel: make(chan workspaceEventLoopMessage),
 	}
 
-	internalStartWorkspaceEventLoopRouter(res.channel, workspaceID, applEventLoopFactory)
+	internalStartWorkspaceEventLoopRouter(res.channel, workspaceID, applEventLoopFactory, eventRecorder)
 
 	return res
 }
@@ -89,7 +91,7 @@ type workspaceEventLoopMessage struct {
 // internalStartWorkspaceEventLoopRouter has the primary goal of catching panics from the workspaceEventLoopRouter, and
 // recovering from them.
 func internalStartWorkspaceEventLoopRouter(input chan workspaceEventLoopMessage, workspaceID string,
-	applEventLoopFactory applicationEventQueueLoopFactory) {
+	applEventLoopFactory applicationEventQueueLoopFactory, eventRecorder record.EventRecorder) {
 
 	go func() {
 
@@ -102,7 +104,7 @@ func internalStartWorkspaceEventLoopRouter(input chan workspaceEventLoopMessage,
 
 		for {
 			isPanic, _ := sharedutil.CatchPanic(func() error {
-				workspaceEventLoopRouter(input, workspaceID, applEventLoopFactory)
+				workspaceEventLoopRouter(input, workspaceID, applEventLoopFactory, eventRecorder)
 				return nil
 			})
 
@@ -186,12 +188,15 @@ type workspaceEventLoopInternalState struct {
 
 	// applEventLoopFactory is the factory function to use, to create the application event loop
 	applEventLoopFactory applicationEventQueueLoopFactory
+
+	// eventRecorder is used to record Kubernetes Events on the resources of the namespace
+	eventRecorder record.EventRecorder
 }
 
 // workspaceEventLoopRouter receives all events for the namespace, and passes them to specific goroutine responsible
 // for handling events for individual applications.
 func workspaceEventLoopRouter(input chan workspaceEventLoopMessage, namespaceID string,
-	applEventLoopFactory applicationEventQueueLoopFactory) {
+	applEventLoopFactory applicationEventQueueLoopFactory, eventRecorder record.EventRecorder) {
 
 	ctx := context.Background()
 
@@ -209,7 +214,8 @@ func workspaceEventLoopRouter(input chan workspaceEventLoopMessage, namespaceID
 		orphanedResources:       map[string]map[string]eventlooptypes.EventLoopEvent{},
 		applicationMap:          map[string]workspaceEventLoop_applicationEventLoopEntry{},
 		applEventLoopFactory:    applEventLoopFactory,
-		workspaceResourceLoop:   newWorkspaceResourceLoop(sharedResourceEventLoop, input),
+		workspaceResourceLoop:   newWorkspaceResourceLoop(sharedResourceEventLoop, input, eventRecorder),
+		eventRecorder:           eventRecorder,
 
 		log:         log,
 		input:       input,
@@ -287,6 +293,12 @@ func handleWorkspaceEventLoopMessage(ctx context.Context, event eventlooptypes.E
 	// Managed Environment: Handle, then return
 	if event.Event.ReqResource == eventlooptypes.GitOpsDeploymentManagedEnvironmentTypeName {
 
+		if event.Event.EventType == eventlooptypes.ServiceAccountTokenRotationDue {
+			// Rotate the service account token of the managed environment in the workspace resource loop
+			state.workspaceResourceLoop.rotateServiceAccountToken(ctx, event.Event.Request, event.Event.Client)
+			return
+		}
+
 		// Reconcile to managed environment in the workspace resource loop, to ensure it is up-to-date (exists, or is deleted)
 		state.workspaceResourceLoop.processManagedEnvironment(ctx, event, event.Event.Client)
 
@@ -306,6 +318,15 @@ func handleWorkspaceEventLoopMessage(ctx context.Context, event eventlooptypes.E
 			// The SyncRun no longer exists, or an unrecoverable error occurred, so just continue
 			return
 		}
+
+	} else if event.Event.ReqResource == eventlooptypes.GitOpsDeploymentDiffTypeName {
+
+		associatedGitOpsDeploymentName = getGitOpsDeploymentNameOfDiff(ctx, event, log)
+
+		if associatedGitOpsDeploymentName == "" {
+			// The Diff no longer exists, or an unrecoverable error occurred, so just continue
+			return
+		}
 	}
 
 	if associatedGitOpsDeploymentName == "" {
@@ -321,7 +342,7 @@ func handleWorkspaceEventLoopMessage(ctx context.Context, event eventlooptypes.E
 
 		var err error
 		applicationEntryVal, err = startApplicationEventQueueLoop(ctx, event.Event.Client, associatedGitOpsDeploymentName, event,
-			state.sharedResourceEventLoop, state.applEventLoopFactory, log)
+			state.sharedResourceEventLoop, state.applEventLoopFactory, state.eventRecorder, log)
 		if err != nil {
 			// We already logged the error in startApplicationEventLoop, no need to log here
 			return
@@ -427,7 +448,7 @@ func handleStatusTickerMessage(state workspaceEventLoopInternalState) {
 
 func startApplicationEventQueueLoop(ctx context.Context, k8sClient client.Client, associatedGitOpsDeploymentName string, event eventlooptypes.EventLoopMessage,
 	sharedResourceEventLoop *shared_resource_loop.SharedResourceEventLoop,
-	applEventLoopFactory applicationEventQueueLoopFactory, log logr.Logger) (workspaceEventLoop_applicationEventLoopEntry, error) {
+	applEventLoopFactory applicationEventQueueLoopFactory, eventRecorder record.EventRecorder, log logr.Logger) (workspaceEventLoop_applicationEventLoopEntry, error) {
 
 	// Start the application event queue go-routine
 
@@ -438,6 +459,7 @@ func startApplicationEventQueueLoop(ctx context.Context, k8sClient client.Client
 		SharedResourceEventLoop:   sharedResourceEventLoop,
 		InputChan:                 make(chan application_event_loop.RequestMessage),
 		Client:                    k8sClient,
+		EventRecorder:             eventRecorder,
 	}
 
 	// Start the application event loop's goroutine
@@ -567,6 +589,31 @@ func checkIfOrphanedGitOpsDeploymentSyncRun(ctx context.Context, event eventloop
 
 }
 
+// getGitOpsDeploymentNameOfDiff returns the name of the GitOpsDeployment referenced by a GitOpsDeploymentDiff.
+// - Unlike SyncRuns, a GitOpsDeploymentDiff has no corresponding database entries, so if the Diff no longer exists
+// there is nothing to clean up, and "" is returned.
+//
+// If the Diff doesn't exist, or an error occurred, "" is returned.
+func getGitOpsDeploymentNameOfDiff(ctx context.Context, event eventlooptypes.EventLoopMessage, log logr.Logger) string {
+
+	diffCR := &v1alpha1.GitOpsDeploymentDiff{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      event.Event.Request.Name,
+			Namespace: event.Event.Request.Namespace,
+		},
+	}
+	if err := event.Event.Client.Get(ctx, client.ObjectKeyFromObject(diffCR), diffCR); err != nil {
+		if apierr.IsNotFound(err) {
+			log.V(logutil.LogLevel_Debug).Info("skipping diff resource that could no longer be found:", "resource", diffCR.ObjectMeta)
+		} else {
+			log.Error(err, "unexpected client error on retrieving diff object", "resource", diffCR.ObjectMeta)
+		}
+		return ""
+	}
+
+	return diffCR.Spec.GitopsDeploymentName
+}
+
 func getDBSyncOperationFromAPIMapping(ctx context.Context, dbQueries db.DatabaseQueries, k8sclient client.Client, syncRunCR *v1alpha1.GitOpsDeploymentSyncRun) (db.SyncOperation, error) {
 	namespace := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
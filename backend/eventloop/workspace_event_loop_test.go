@@ -20,6 +20,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	fake "sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -57,7 +58,7 @@ var _ = Describe("Workspace Event Loop Test", Ordered, func() {
 			tAELF = &testApplicationEventLoopFactory{}
 
 			// Start the workspace event loop with our custom test factory, so that we can capture output
-			workspaceEventLoopRouter = newWorkspaceEventLoopRouterWithFactory(string(apiNamespace.UID), tAELF)
+			workspaceEventLoopRouter = newWorkspaceEventLoopRouterWithFactory(string(apiNamespace.UID), tAELF, &record.FakeRecorder{})
 
 			k8sClient = fake.NewClientBuilder().
 				WithScheme(scheme).
@@ -445,7 +446,7 @@ var _ = Describe("Workspace Event Loop Test", Ordered, func() {
 			tAELF := &managedEnvironmentTestApplicationEventLoopFactory{
 				outputChannelMap: map[string]chan application_event_loop.RequestMessage{},
 			}
-			workspaceEventLoopRouter := newWorkspaceEventLoopRouterWithFactory(string(apiNamespace.UID), tAELF)
+			workspaceEventLoopRouter := newWorkspaceEventLoopRouterWithFactory(string(apiNamespace.UID), tAELF, &record.FakeRecorder{})
 
 			k8sClient := fake.NewClientBuilder().
 				WithScheme(scheme).
@@ -479,7 +480,7 @@ var _ = Describe("Workspace Event Loop Test", Ordered, func() {
 			tAELF := &managedEnvironmentTestApplicationEventLoopFactory{
 				outputChannelMap: map[string]chan application_event_loop.RequestMessage{},
 			}
-			workspaceEventLoopRouter := newWorkspaceEventLoopRouterWithFactory(string(apiNamespace.UID), tAELF)
+			workspaceEventLoopRouter := newWorkspaceEventLoopRouterWithFactory(string(apiNamespace.UID), tAELF, &record.FakeRecorder{})
 
 			k8sClient := fake.NewClientBuilder().
 				WithScheme(scheme).
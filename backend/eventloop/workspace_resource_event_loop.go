@@ -15,6 +15,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -44,6 +45,7 @@ type workspaceResourceLoopMessageType string
 const (
 	workspaceResourceLoopMessageType_processRepositoryCredential workspaceResourceLoopMessageType = "processRepositoryCredential"
 	workspaceResourceLoopMessageType_processManagedEnvironment   workspaceResourceLoopMessageType = "processManagedEnvironment"
+	workspaceResourceLoopMessageType_rotateServiceAccountToken   workspaceResourceLoopMessageType = "rotateServiceAccountToken"
 )
 
 func (werl *workspaceResourceEventLoop) processRepositoryCredential(ctx context.Context, req ctrl.Request, apiNamespaceClient client.Client) {
@@ -73,21 +75,34 @@ func (werl *workspaceResourceEventLoop) processManagedEnvironment(ctx context.Co
 	// This function is async: we don't wait for a return value from the loop.
 }
 
+func (werl *workspaceResourceEventLoop) rotateServiceAccountToken(ctx context.Context, req ctrl.Request, apiNamespaceClient client.Client) {
+
+	msg := workspaceResourceLoopMessage{
+		apiNamespaceClient: apiNamespaceClient,
+		messageType:        workspaceResourceLoopMessageType_rotateServiceAccountToken,
+		payload:            req,
+	}
+
+	werl.inputChannel <- msg
+
+	// This function is async: we don't wait for a return value from the loop.
+}
+
 func newWorkspaceResourceLoop(sharedResourceLoop *shared_resource_loop.SharedResourceEventLoop,
-	workspaceEventLoopInputChannel chan workspaceEventLoopMessage) *workspaceResourceEventLoop {
+	workspaceEventLoopInputChannel chan workspaceEventLoopMessage, eventRecorder record.EventRecorder) *workspaceResourceEventLoop {
 
 	workspaceResourceEventLoop := &workspaceResourceEventLoop{
 		inputChannel: make(chan workspaceResourceLoopMessage),
 	}
 
-	go internalWorkspaceResourceEventLoop(workspaceResourceEventLoop.inputChannel, sharedResourceLoop, workspaceEventLoopInputChannel)
+	go internalWorkspaceResourceEventLoop(workspaceResourceEventLoop.inputChannel, sharedResourceLoop, workspaceEventLoopInputChannel, eventRecorder)
 
 	return workspaceResourceEventLoop
 }
 
 func internalWorkspaceResourceEventLoop(inputChan chan workspaceResourceLoopMessage,
 	sharedResourceLoop *shared_resource_loop.SharedResourceEventLoop,
-	workspaceEventLoopInputChannel chan workspaceEventLoopMessage) {
+	workspaceEventLoopInputChannel chan workspaceEventLoopMessage, eventRecorder record.EventRecorder) {
 
 	ctx := context.Background()
 	l := log.FromContext(ctx).
@@ -127,6 +142,16 @@ func internalWorkspaceResourceEventLoop(inputChan chan workspaceResourceLoopMess
 
 			mapKey = "managed-env-" + evlMsg.Event.Request.Namespace + "-" + evlMsg.Event.Request.Name
 
+		} else if msg.messageType == workspaceResourceLoopMessageType_rotateServiceAccountToken {
+
+			managedEnv, ok := (msg.payload).(ctrl.Request)
+			if !ok {
+				l.Error(nil, "SEVERE: Unexpected payload type in workspace resource event loop")
+				continue
+			}
+
+			mapKey = "managed-env-token-rotation-" + managedEnv.Namespace + "-" + managedEnv.Name
+
 		} else {
 			l.Error(nil, "SEVERE: Unexpected message type: "+string(msg.messageType))
 			continue
@@ -141,6 +166,7 @@ func internalWorkspaceResourceEventLoop(inputChan chan workspaceResourceLoopMess
 			log:                            l,
 			sharedResourceLoop:             sharedResourceLoop,
 			workspaceEventLoopInputChannel: workspaceEventLoopInputChannel,
+			eventRecorder:                  eventRecorder,
 		}
 
 		taskRetryLoop.AddTaskIfNotPresent(mapKey, task, sharedutil.ExponentialBackoff{Factor: 2, Min: time.Millisecond * 200, Max: time.Second * 10, Jitter: true})
@@ -153,12 +179,14 @@ type workspaceResourceEventTask struct {
 	log                            logr.Logger
 	sharedResourceLoop             *shared_resource_loop.SharedResourceEventLoop
 	workspaceEventLoopInputChannel chan workspaceEventLoopMessage
+	eventRecorder                  record.EventRecorder
 }
 
 // Returns true if the task should be retried, false otherwise, plus an error
 func (wert *workspaceResourceEventTask) PerformTask(taskContext context.Context) (bool, error) {
 
-	retry, err := internalProcessWorkspaceResourceMessage(taskContext, wert.msg, wert.sharedResourceLoop, wert.workspaceEventLoopInputChannel, wert.dbQueries, wert.log)
+	retry, err := internalProcessWorkspaceResourceMessage(taskContext, wert.msg, wert.sharedResourceLoop, wert.workspaceEventLoopInputChannel,
+		wert.eventRecorder, wert.dbQueries, wert.log)
 
 	return retry, err
 }
@@ -166,7 +194,7 @@ func (wert *workspaceResourceEventTask) PerformTask(taskContext context.Context)
 // Returns true if the task should be retried, false otherwise, plus an error
 func internalProcessWorkspaceResourceMessage(ctx context.Context, msg workspaceResourceLoopMessage,
 	sharedResourceLoop *shared_resource_loop.SharedResourceEventLoop, workspaceEventLoopInputChannel chan workspaceEventLoopMessage,
-	dbQueries db.DatabaseQueries, log logr.Logger) (bool, error) {
+	eventRecorder record.EventRecorder, dbQueries db.DatabaseQueries, log logr.Logger) (bool, error) {
 	const retry, noRetry = true, false
 
 	log.V(logutil.LogLevel_Debug).Info("processWorkspaceResource received message: " + string(msg.messageType))
@@ -241,7 +269,7 @@ func internalProcessWorkspaceResourceMessage(ctx context.Context, msg workspaceR
 
 		// Ask the shared resource loop to ensure the managed environment is reconciled
 		_, err := sharedResourceLoop.ReconcileSharedManagedEnv(ctx, msg.apiNamespaceClient, *namespace, req.Name, req.Namespace,
-			false, shared_resource_loop.DefaultK8sClientFactory{}, log)
+			false, shared_resource_loop.DefaultK8sClientFactory{}, eventRecorder, log)
 		if err != nil {
 			return retry, fmt.Errorf("unable to reconcile shared managed env: %v", err)
 		}
@@ -257,6 +285,16 @@ func internalProcessWorkspaceResourceMessage(ctx context.Context, msg workspaceR
 
 		return noRetry, nil
 
+	} else if msg.messageType == workspaceResourceLoopMessageType_rotateServiceAccountToken {
+
+		req, ok := (msg.payload).(ctrl.Request)
+		if !ok {
+			return noRetry, fmt.Errorf("invalid payload in processWorkspaceResourceMessage")
+		}
+
+		return processServiceAccountTokenRotation(ctx, req, msg.apiNamespaceClient, sharedResourceLoop,
+			shared_resource_loop.DefaultK8sClientFactory{}, eventRecorder, log)
+
 	}
 	return noRetry, fmt.Errorf("SEVERE: unrecognized sharedResourceLoopMessageType: %s " + string(msg.messageType))
 
@@ -1,6 +1,6 @@
 module github.com/redhat-appstudio/managed-gitops/backend
 
-go 1.18
+go 1.19
 
 require (
 	github.com/emicklei/go-restful/v3 v3.9.0
@@ -22,7 +22,6 @@ require (
 	k8s.io/utils v0.0.0-20220728103510-ee6ede2d64ed
 	sigs.k8s.io/controller-runtime v0.13.0
 	sigs.k8s.io/yaml v1.3.0
-
 )
 
 require (
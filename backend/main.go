@@ -17,8 +17,9 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
-	"log"
+	"fmt"
 	"net/http"
 	"os"
 	"strings"
@@ -33,8 +34,11 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 
 	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
 	"github.com/redhat-appstudio/managed-gitops/backend-shared/db"
@@ -45,6 +49,7 @@ import (
 	"github.com/redhat-appstudio/managed-gitops/backend/eventloop/preprocess_event_loop"
 	"github.com/redhat-appstudio/managed-gitops/backend/eventloop/shared_resource_loop"
 	"github.com/redhat-appstudio/managed-gitops/backend/routes"
+	webhooks "github.com/redhat-appstudio/managed-gitops/backend/routes/webhooks"
 	crzap "sigs.k8s.io/controller-runtime/pkg/log/zap"
 	//+kubebuilder:scaffold:imports
 )
@@ -66,12 +71,15 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var profilerAddr string
+	var gitWebhookAddr string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":18080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":18081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
 	flag.StringVar(&profilerAddr, "profiler-address", ":6060", "The address for serving pprof profiles")
+	flag.StringVar(&gitWebhookAddr, "git-webhook-bind-address", "0", "The address the Git webhook endpoint binds to. "+
+		"Set this to '0' to disable the endpoint.")
 
 	opts := crzap.Options{
 		TimeEncoder: zapcore.ISO8601TimeEncoder,
@@ -126,7 +134,10 @@ func main() {
 		os.Exit(1)
 	}
 
-	preprocessEventLoop := preprocess_event_loop.NewPreprocessEventLoop()
+	// Events recorded by the event loops (for example, on GitOpsDeployments) are reported via the manager's EventRecorder
+	eventRecorder := mgr.GetEventRecorderFor("managed-gitops-backend")
+
+	preprocessEventLoop := preprocess_event_loop.NewPreprocessEventLoop(eventRecorder)
 
 	if err = (&managedgitopscontrollers.GitOpsDeploymentReconciler{
 		PreprocessEventLoop: preprocessEventLoop,
@@ -144,6 +155,21 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "GitOpsDeploymentSyncRun")
 		os.Exit(1)
 	}
+	if err = (&managedgitopscontrollers.GitOpsDeploymentDiffReconciler{
+		PreprocessEventLoop: preprocessEventLoop,
+		Client:              mgr.GetClient(),
+		Scheme:              mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GitOpsDeploymentDiff")
+		os.Exit(1)
+	}
+	if err = (&managedgitopscontrollers.GitOpsDeploymentSetReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GitOpsDeploymentSet")
+		os.Exit(1)
+	}
 	if err = (&managedgitopscontrollers.GitOpsDeploymentRepositoryCredentialReconciler{
 		Client:              mgr.GetClient(),
 		Scheme:              mgr.GetScheme(),
@@ -190,6 +216,10 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "GitOpsDeploymentManagedEnvironment")
 			os.Exit(1)
 		}
+		if err = (&managedgitopsv1alpha1.GitOpsDeploymentSet{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "GitOpsDeploymentSet")
+			os.Exit(1)
+		}
 
 	}
 
@@ -198,6 +228,26 @@ func main() {
 	startDBReconciler(mgr)
 	startRepoCredReconciler(mgr)
 	startDBMetricsReconciler(mgr)
+	startManagedEnvironmentProber(mgr, eventRecorder)
+	startServiceAccountTokenRotator(mgr, preprocessEventLoop)
+
+	// The server for the Git webhook endpoint is started by the manager (once its cache has synced), as it uses the
+	// manager's (cached) client.
+	if gitWebhookAddr != "0" {
+		if err := webhooks.IndexGitOpsDeploymentRepoURLs(ctx, mgr.GetFieldIndexer()); err != nil {
+			setupLog.Error(err, "unable to add the field index of GitOpsDeployments by repoURL")
+			os.Exit(1)
+		}
+		if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			return initializeRoutes(ctx, mgr.GetClient(), gitWebhookAddr)
+		})); err != nil {
+			setupLog.Error(err, "unable to add the Git webhook server to the manager")
+			os.Exit(1)
+		}
+	} else {
+		setupLog.Info("The Git webhook endpoint is disabled")
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -272,14 +322,64 @@ func startDBMetricsReconciler(mgr ctrl.Manager) {
 	databaseReconciler.StartDBMetricsReconcilerForMetrics()
 }
 
-// nolint:unused
-func initializeRoutes() {
+func startManagedEnvironmentProber(mgr ctrl.Manager, eventRecorder record.EventRecorder) {
+
+	probeInterval := eventloop.GetManagedEnvProbeInterval(setupLog)
+	if probeInterval == 0 {
+		setupLog.Info("ManagedEnvironment connection probes are disabled")
+		return
+	}
+
+	dbQueries, err := db.NewSharedProductionPostgresDBQueries(false)
+	if err != nil {
+		setupLog.Error(err, "never able to connect to database")
+		os.Exit(1)
+	}
+
+	managedEnvProber := eventloop.ManagedEnvironmentProber{
+		DB:               dbQueries,
+		Client:           mgr.GetClient(),
+		K8sClientFactory: shared_resource_loop.DefaultK8sClientFactory{},
+		EventRecorder:    eventRecorder,
+		Interval:         probeInterval,
+	}
+
+	// Start goroutine for ManagedEnvironment connection prober
+	managedEnvProber.StartManagedEnvironmentProber()
+}
+
+func startServiceAccountTokenRotator(mgr ctrl.Manager, preprocessEventLoop *preprocess_event_loop.PreprocessEventLoop) {
+
+	if eventloop.GetServiceAccountTokenRotationPeriod(setupLog) == 0 {
+		setupLog.Info("ServiceAccount token rotation is disabled")
+		return
+	}
+
+	// The tokens are rotated by the event loops, so that the rotation does not race with other changes to the ManagedEnvironments
+	tokenRotator := eventloop.ServiceAccountTokenRotator{
+		Client:    mgr.GetClient(),
+		EventLoop: preprocessEventLoop,
+	}
+
+	// Start goroutine for ServiceAccount token rotator
+	tokenRotator.StartServiceAccountTokenRotator()
+}
+
+func initializeRoutes(ctx context.Context, k8sClient client.Client, addr string) error {
 
 	// Intializing the server for routing endpoints
-	router := routes.RouteInit()
-	err := router.ListenAndServe()
-	if err != http.ErrServerClosed {
-		log.Println("Error on ListenAndServe:", err)
+	router := routes.RouteInit(k8sClient, addr)
+
+	go func() {
+		<-ctx.Done()
+		if err := router.Shutdown(context.Background()); err != nil {
+			setupLog.Error(err, "unable to shut down the Git webhook server")
+		}
+	}()
+
+	if err := router.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("error on ListenAndServe: %v", err)
 	}
 
+	return nil
 }
@@ -6,23 +6,27 @@ import (
 	"time"
 
 	restful "github.com/emicklei/go-restful/v3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	webhooks "github.com/redhat-appstudio/managed-gitops/backend/routes/webhooks"
 )
 
-func RouteInit() *http.Server {
+// RouteInit returns the server for the backend's HTTP endpoints, listening on the given address: the client is used by
+// the webhook handler to find (and refresh) the GitOpsDeployments affected by a Git push.
+func RouteInit(k8sClient client.Client, addr string) *http.Server {
 	wsContainer := restful.NewContainer()
 	wsContainer.Router(restful.CurlyRouter{})
 
 	webhookR := new(restful.WebService)
 	webhookR.
 		Path("/api/v1/webhookevent").
-		Consumes(restful.MIME_JSON)
-	webhookR.Route(webhookR.POST("").To(webhooks.ParseWebhookInfo))
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON)
+	webhookR.Route(webhookR.POST("").To(webhooks.NewWebhookHandler(k8sClient, webhooks.GetWebhookSecretRef()).ParseWebhookInfo))
 	wsContainer.Add(webhookR)
 
-	log.Print("Main: the server is up, and listening to port 8090 on your host.")
-	server := &http.Server{Addr: ":8090", Handler: wsContainer, ReadHeaderTimeout: time.Second * 30}
+	log.Printf("Main: the server is up, and listening on '%s'.", addr)
+	server := &http.Server{Addr: addr, Handler: wsContainer, ReadHeaderTimeout: time.Second * 30}
 
 	return server
 }
@@ -0,0 +1,93 @@
+package routes
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// deliveryCacheTTL is how long the delivery key of a webhook request is remembered: a request with the same
+	// delivery key (that is, the same signature and payload, from the same provider) is rejected as a replay within this time.
+	deliveryCacheTTL = 24 * time.Hour
+
+	// deliveryCacheMaxEntries is the maximum number of delivery keys that are remembered: once reached, the oldest
+	// delivery keys are forgotten first.
+	deliveryCacheMaxEntries = 10000
+)
+
+// deliveryCache remembers the delivery keys (see WebHookInfo.deliveryKey) of the webhook requests that have been
+// received, in order to reject replayed requests. The cache is in memory, so delivery keys are forgotten when the
+// backend restarts.
+type deliveryCache struct {
+	mutex sync.Mutex
+
+	ttl        time.Duration
+	maxEntries int
+
+	// receivedAt is the time at which each delivery key (that is still remembered) was received
+	receivedAt map[string]time.Time
+
+	// queue contains the delivery keys in the order they were received, so that the oldest may be forgotten first.
+	// An entry is stale if the delivery key was forgotten, or received again after it was forgotten.
+	queue []deliveryCacheEntry
+}
+
+type deliveryCacheEntry struct {
+	key        string
+	receivedAt time.Time
+}
+
+func newDeliveryCache(ttl time.Duration, maxEntries int) *deliveryCache {
+	return &deliveryCache{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		receivedAt: map[string]time.Time{},
+	}
+}
+
+// add remembers the delivery key, and returns true if it was not already remembered.
+func (cache *deliveryCache) add(key string, now time.Time) bool {
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.removeExpiredEntries(now)
+
+	if _, exists := cache.receivedAt[key]; exists {
+		return false
+	}
+
+	for len(cache.receivedAt) >= cache.maxEntries && len(cache.queue) > 0 {
+		cache.removeOldestEntry()
+	}
+
+	cache.receivedAt[key] = now
+	cache.queue = append(cache.queue, deliveryCacheEntry{key: key, receivedAt: now})
+
+	return true
+}
+
+// remove forgets the delivery key, so that the request may be delivered again (for example, if it could not be handled)
+func (cache *deliveryCache) remove(key string) {
+
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	delete(cache.receivedAt, key)
+}
+
+func (cache *deliveryCache) removeExpiredEntries(now time.Time) {
+	for len(cache.queue) > 0 && now.Sub(cache.queue[0].receivedAt) >= cache.ttl {
+		cache.removeOldestEntry()
+	}
+}
+
+func (cache *deliveryCache) removeOldestEntry() {
+
+	entry := cache.queue[0]
+	cache.queue = cache.queue[1:]
+
+	if receivedAt, exists := cache.receivedAt[entry.key]; exists && receivedAt.Equal(entry.receivedAt) {
+		delete(cache.receivedAt, entry.key)
+	}
+}
@@ -0,0 +1,345 @@
+package routes
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+// GitProvider is the Git hosting service that sent a webhook request
+type GitProvider string
+
+const (
+	GitProvider_GitHub          GitProvider = "github"
+	GitProvider_GitLab          GitProvider = "gitlab"
+	GitProvider_Gitea           GitProvider = "gitea"
+	GitProvider_BitbucketServer GitProvider = "bitbucketserver"
+)
+
+// The headers of each provider that identify the event, the delivery, and the signature (or token) of the request
+const (
+	headerGitHubEvent     = "X-GitHub-Event"
+	headerGitHubDelivery  = "X-GitHub-Delivery"
+	headerGitHubSignature = "X-Hub-Signature-256"
+
+	headerGitLabEvent    = "X-Gitlab-Event"
+	headerGitLabDelivery = "X-Gitlab-Event-UUID"
+	headerGitLabToken    = "X-Gitlab-Token"
+
+	headerGiteaEvent     = "X-Gitea-Event"
+	headerGiteaDelivery  = "X-Gitea-Delivery"
+	headerGiteaSignature = "X-Gitea-Signature"
+
+	headerBitbucketServerEvent     = "X-Event-Key"
+	headerBitbucketServerDelivery  = "X-Request-Id"
+	headerBitbucketServerSignature = "X-Hub-Signature"
+)
+
+// The events of each provider that are handled: all other events are ignored
+const (
+	githubEventPush = "push"
+	githubEventPing = "ping"
+
+	gitlabEventPush    = "Push Hook"
+	gitlabEventTagPush = "Tag Push Hook"
+
+	giteaEventPush = "push"
+
+	bitbucketServerEventRefsChanged = "repo:refs_changed"
+	bitbucketServerEventPing        = "diagnostics:ping"
+)
+
+// signaturePrefixSHA256 is the prefix of the hex-encoded HMAC-SHA256 signatures sent by GitHub and Bitbucket Server
+const signaturePrefixSHA256 = "sha256="
+
+// webhookEventType is how a webhook event is handled
+type webhookEventType string
+
+const (
+	webhookEventType_Push    webhookEventType = "push"
+	webhookEventType_Ping    webhookEventType = "ping"
+	webhookEventType_Ignored webhookEventType = "ignored"
+)
+
+// newWebHookInfo identifies the provider of the webhook request from its headers, and returns the event, delivery ID
+// and signature (or token) of the request. The payload is not read.
+func newWebHookInfo(header http.Header) (*WebHookInfo, error) {
+
+	var res *WebHookInfo
+	var deliveryHeader string
+
+	// Gitea also sends the GitHub headers (for compatibility with GitHub webhook consumers), so is checked first.
+	switch {
+	case header.Get(headerGiteaEvent) != "":
+		res = &WebHookInfo{
+			Provider:  GitProvider_Gitea,
+			Event:     header.Get(headerGiteaEvent),
+			Id:        header.Get(headerGiteaDelivery),
+			Signature: header.Get(headerGiteaSignature),
+		}
+		deliveryHeader = headerGiteaDelivery
+	case header.Get(headerGitHubEvent) != "":
+		res = &WebHookInfo{
+			Provider:  GitProvider_GitHub,
+			Event:     header.Get(headerGitHubEvent),
+			Id:        header.Get(headerGitHubDelivery),
+			Signature: header.Get(headerGitHubSignature),
+		}
+		deliveryHeader = headerGitHubDelivery
+	case header.Get(headerGitLabEvent) != "":
+		res = &WebHookInfo{
+			Provider:  GitProvider_GitLab,
+			Event:     header.Get(headerGitLabEvent),
+			Id:        header.Get(headerGitLabDelivery),
+			Signature: header.Get(headerGitLabToken),
+		}
+		deliveryHeader = headerGitLabDelivery
+	case header.Get(headerBitbucketServerEvent) != "":
+		res = &WebHookInfo{
+			Provider:  GitProvider_BitbucketServer,
+			Event:     header.Get(headerBitbucketServerEvent),
+			Id:        header.Get(headerBitbucketServerDelivery),
+			Signature: header.Get(headerBitbucketServerSignature),
+		}
+		deliveryHeader = headerBitbucketServerDelivery
+	default:
+		return nil, fmt.Errorf("unable to identify the Git provider of the request: missing '%s', '%s', '%s' or '%s' header",
+			headerGitHubEvent, headerGitLabEvent, headerGiteaEvent, headerBitbucketServerEvent)
+	}
+
+	if res.Id == "" {
+		return nil, fmt.Errorf("missing '%s' header of %s webhook request", deliveryHeader, res.Provider)
+	}
+
+	return res, nil
+}
+
+// eventType returns how the event of the webhook should be handled
+func (webhook WebHookInfo) eventType() webhookEventType {
+
+	switch webhook.Provider {
+	case GitProvider_GitHub:
+		switch webhook.Event {
+		case githubEventPush:
+			return webhookEventType_Push
+		case githubEventPing:
+			return webhookEventType_Ping
+		}
+	case GitProvider_GitLab:
+		if webhook.Event == gitlabEventPush || webhook.Event == gitlabEventTagPush {
+			return webhookEventType_Push
+		}
+	case GitProvider_Gitea:
+		if webhook.Event == giteaEventPush {
+			return webhookEventType_Push
+		}
+	case GitProvider_BitbucketServer:
+		switch webhook.Event {
+		case bitbucketServerEventRefsChanged:
+			return webhookEventType_Push
+		case bitbucketServerEventPing:
+			return webhookEventType_Ping
+		}
+	}
+
+	return webhookEventType_Ignored
+}
+
+// deliveryKey returns the key by which a (verified) webhook request is remembered, in order to reject replays of it: a
+// hash of the signature and the payload. The delivery ID of the request is not used, since it is not covered by the
+// signature: a replayed request with a modified delivery ID would otherwise be accepted. The signature is lower-cased,
+// as an upper-case hex signature is equally valid.
+func (webhook WebHookInfo) deliveryKey() string {
+	hash := sha256.New()
+	hash.Write([]byte(strings.ToLower(webhook.Signature)))
+	hash.Write([]byte{0})
+	hash.Write(webhook.Payload)
+	return string(webhook.Provider) + "/" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// verifySignature verifies that the webhook request was sent by a sender that knows the secret: for GitLab the secret
+// is sent as is, while the other providers sign the payload with an HMAC-SHA256.
+func (webhook WebHookInfo) verifySignature(secret []byte) error {
+
+	if webhook.Signature == "" {
+		return fmt.Errorf("%s webhook request is not signed", webhook.Provider)
+	}
+
+	if webhook.Provider == GitProvider_GitLab {
+		if subtle.ConstantTimeCompare([]byte(webhook.Signature), secret) != 1 {
+			return fmt.Errorf("%s webhook request has an invalid token", webhook.Provider)
+		}
+		return nil
+	}
+
+	signature := webhook.Signature
+	if webhook.Provider != GitProvider_Gitea {
+		if !strings.HasPrefix(signature, signaturePrefixSHA256) {
+			return fmt.Errorf("%s webhook request has an unsupported signature algorithm", webhook.Provider)
+		}
+		signature = strings.TrimPrefix(signature, signaturePrefixSHA256)
+	}
+
+	actual, err := hex.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("%s webhook request has a malformed signature", webhook.Provider)
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(webhook.Payload)
+
+	if !hmac.Equal(actual, mac.Sum(nil)) {
+		return fmt.Errorf("%s webhook request has an invalid signature", webhook.Provider)
+	}
+
+	return nil
+}
+
+// parsePushEvents parses the payload of a push event into one PushEvent for each branch or tag that was updated.
+func (webhook WebHookInfo) parsePushEvents() ([]PushEvent, error) {
+
+	switch webhook.Provider {
+	case GitProvider_GitHub:
+		event, err := github.ParseWebHook(webhook.Event, webhook.Payload)
+		if err != nil {
+			return nil, err
+		}
+		githubPushEvent, ok := event.(*github.PushEvent)
+		if !ok {
+			return nil, fmt.Errorf("unexpected payload for event type '%s'", webhook.Event)
+		}
+		pushEvent, err := convertGitHubPushEvent(githubPushEvent)
+		if err != nil {
+			return nil, err
+		}
+		return []PushEvent{*pushEvent}, nil
+
+	case GitProvider_GitLab:
+		return parseGitLabPushEvent(webhook.Payload)
+
+	case GitProvider_Gitea:
+		return parseGiteaPushEvent(webhook.Payload)
+
+	case GitProvider_BitbucketServer:
+		return parseBitbucketServerPushEvent(webhook.Payload)
+	}
+
+	return nil, fmt.Errorf("unsupported Git provider '%s'", webhook.Provider)
+}
+
+// gitlabPushEvent contains the fields of a GitLab 'Push Hook' or 'Tag Push Hook' event payload that are used
+type gitlabPushEvent struct {
+	Ref     string `json:"ref"`
+	Project struct {
+		GitHTTPURL    string `json:"git_http_url"`
+		GitSSHURL     string `json:"git_ssh_url"`
+		WebURL        string `json:"web_url"`
+		DefaultBranch string `json:"default_branch"`
+	} `json:"project"`
+}
+
+func parseGitLabPushEvent(payload []byte) ([]PushEvent, error) {
+
+	var event gitlabPushEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("unable to parse GitLab push event: %v", err)
+	}
+
+	pushEvent, err := newPushEvent(event.Ref, event.Project.DefaultBranch,
+		[]string{event.Project.GitHTTPURL, event.Project.GitSSHURL, event.Project.WebURL})
+	if err != nil {
+		return nil, err
+	}
+
+	return []PushEvent{*pushEvent}, nil
+}
+
+// giteaPushEvent contains the fields of a Gitea 'push' event payload that are used
+type giteaPushEvent struct {
+	Ref        string `json:"ref"`
+	Repository struct {
+		CloneURL      string `json:"clone_url"`
+		SSHURL        string `json:"ssh_url"`
+		HTMLURL       string `json:"html_url"`
+		DefaultBranch string `json:"default_branch"`
+	} `json:"repository"`
+}
+
+func parseGiteaPushEvent(payload []byte) ([]PushEvent, error) {
+
+	var event giteaPushEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("unable to parse Gitea push event: %v", err)
+	}
+
+	pushEvent, err := newPushEvent(event.Ref, event.Repository.DefaultBranch,
+		[]string{event.Repository.CloneURL, event.Repository.SSHURL, event.Repository.HTMLURL})
+	if err != nil {
+		return nil, err
+	}
+
+	return []PushEvent{*pushEvent}, nil
+}
+
+// bitbucketServerRefsChangedEvent contains the fields of a Bitbucket Server 'repo:refs_changed' event payload that are used
+type bitbucketServerRefsChangedEvent struct {
+	Repository struct {
+		Links struct {
+			Clone []struct {
+				Href string `json:"href"`
+				Name string `json:"name"`
+			} `json:"clone"`
+		} `json:"links"`
+	} `json:"repository"`
+	Changes []struct {
+		Ref struct {
+			ID string `json:"id"`
+		} `json:"ref"`
+		Type string `json:"type"`
+	} `json:"changes"`
+}
+
+func parseBitbucketServerPushEvent(payload []byte) ([]PushEvent, error) {
+
+	var event bitbucketServerRefsChangedEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("unable to parse Bitbucket Server push event: %v", err)
+	}
+
+	repoURLs := []string{}
+	for _, link := range event.Repository.Links.Clone {
+		repoURL := link.Href
+		// The HTTP clone URL contains the name of the user that the payload was generated for, which is removed
+		if parsedURL, err := url.Parse(repoURL); err == nil && (parsedURL.Scheme == "http" || parsedURL.Scheme == "https") {
+			parsedURL.User = nil
+			repoURL = parsedURL.String()
+		}
+		repoURLs = append(repoURLs, repoURL)
+	}
+
+	res := []PushEvent{}
+	for _, change := range event.Changes {
+
+		// A deleted branch or tag no longer has any content to deploy
+		if change.Type == "DELETE" {
+			continue
+		}
+
+		// The default branch of the repository is not part of the payload
+		pushEvent, err := newPushEvent(change.Ref.ID, "", repoURLs)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *pushEvent)
+	}
+
+	return res, nil
+}
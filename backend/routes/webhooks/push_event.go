@@ -0,0 +1,213 @@
+package routes
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/github"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
+	"github.com/redhat-appstudio/managed-gitops/backend/eventloop/shared_resource_loop"
+)
+
+const (
+	refPrefixBranch = "refs/heads/"
+	refPrefixTag    = "refs/tags/"
+)
+
+// PushEvent is a push to a Git repository, independent of the Git provider that sent the webhook
+type PushEvent struct {
+	// RepoURLs contains the URLs by which the repository may be referenced (for example, the HTTPS and SSH URLs)
+	RepoURLs []string
+
+	// Revision is the branch or tag that was pushed to (without the 'refs/heads/' or 'refs/tags/' prefix)
+	Revision string
+
+	// IsTag is true if a tag was pushed, and false if a branch was pushed
+	IsTag bool
+
+	// DefaultBranch is the default branch of the repository, if known
+	DefaultBranch string
+}
+
+// newPushEvent returns the PushEvent of a push to the ref (for example, 'refs/heads/main') of a repository with the
+// given URLs. The default branch may be empty, if it is unknown.
+func newPushEvent(ref string, defaultBranch string, repoURLs []string) (*PushEvent, error) {
+
+	res := &PushEvent{DefaultBranch: defaultBranch}
+
+	if strings.HasPrefix(ref, refPrefixBranch) {
+		res.Revision = strings.TrimPrefix(ref, refPrefixBranch)
+	} else if strings.HasPrefix(ref, refPrefixTag) {
+		res.Revision = strings.TrimPrefix(ref, refPrefixTag)
+		res.IsTag = true
+	} else {
+		return nil, fmt.Errorf("push event has an unsupported ref: '%s'", ref)
+	}
+
+	for _, url := range repoURLs {
+		if url != "" {
+			res.RepoURLs = append(res.RepoURLs, url)
+		}
+	}
+	if len(res.RepoURLs) == 0 {
+		return nil, fmt.Errorf("push event does not contain a repository URL")
+	}
+
+	return res, nil
+}
+
+// convertGitHubPushEvent converts a GitHub push event into a PushEvent
+func convertGitHubPushEvent(event *github.PushEvent) (*PushEvent, error) {
+
+	if event.Repo == nil {
+		return nil, fmt.Errorf("push event does not contain a repository")
+	}
+
+	return newPushEvent(event.GetRef(), event.Repo.GetDefaultBranch(),
+		[]string{event.Repo.GetCloneURL(), event.Repo.GetHTMLURL(), event.Repo.GetSSHURL(), event.Repo.GetGitURL()})
+}
+
+// GitOpsDeploymentRepoURLIndex is the field index of GitOpsDeployments by the normalized repoURL of each of their
+// sources: it allows the GitOpsDeployments of a repository to be listed, without listing every GitOpsDeployment.
+const GitOpsDeploymentRepoURLIndex = "spec.source.repoURL"
+
+// IndexGitOpsDeploymentRepoURLs adds the GitOpsDeploymentRepoURLIndex field index, which is used to find the
+// GitOpsDeployments of a push event.
+func IndexGitOpsDeploymentRepoURLs(ctx context.Context, indexer client.FieldIndexer) error {
+	return indexer.IndexField(ctx, &managedgitopsv1alpha1.GitOpsDeployment{}, GitOpsDeploymentRepoURLIndex,
+		func(obj client.Object) []string {
+			gitopsDepl, ok := obj.(*managedgitopsv1alpha1.GitOpsDeployment)
+			if !ok {
+				return nil
+			}
+			res := []string{}
+			for _, source := range gitopsDepl.Spec.GetSources() {
+				if source.RepoURL != "" {
+					res = append(res, shared_resource_loop.NormalizeGitURL(source.RepoURL))
+				}
+			}
+			return res
+		})
+}
+
+// findGitOpsDeploymentsForPushEvents returns the GitOpsDeployments (in any namespace) with a source that deploys
+// from the repository and revision of any of the push events.
+func findGitOpsDeploymentsForPushEvents(ctx context.Context, pushEvents []PushEvent, k8sClient client.Client) ([]managedgitopsv1alpha1.GitOpsDeployment, error) {
+
+	res := []managedgitopsv1alpha1.GitOpsDeployment{}
+
+	// The GitOpsDeployments that have already been found (by namespace/name), as they may be listed for more than one URL
+	found := map[client.ObjectKey]bool{}
+
+	for _, repoURL := range normalizedRepoURLsOfPushEvents(pushEvents) {
+
+		var gitopsDeplList managedgitopsv1alpha1.GitOpsDeploymentList
+		if err := k8sClient.List(ctx, &gitopsDeplList, client.MatchingFields{GitOpsDeploymentRepoURLIndex: repoURL}); err != nil {
+			return nil, fmt.Errorf("unable to list GitOpsDeployments: %v", err)
+		}
+
+		for _, gitopsDepl := range gitopsDeplList.Items {
+
+			if gitopsDepl.DeletionTimestamp != nil || found[client.ObjectKeyFromObject(&gitopsDepl)] {
+				continue
+			}
+
+			// Not every client supports field indexes (for example, the fake client ignores them), so the
+			// repository is checked here as well.
+			if gitopsDeploymentMatchesPushEvents(gitopsDepl, pushEvents) {
+				found[client.ObjectKeyFromObject(&gitopsDepl)] = true
+				res = append(res, gitopsDepl)
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// normalizedRepoURLsOfPushEvents returns the (unique) normalized URLs of the repositories of the push events
+func normalizedRepoURLsOfPushEvents(pushEvents []PushEvent) []string {
+	res := []string{}
+	seen := map[string]bool{}
+	for _, pushEvent := range pushEvents {
+		for _, url := range pushEvent.RepoURLs {
+			normalizedURL := shared_resource_loop.NormalizeGitURL(url)
+			if normalizedURL != "" && !seen[normalizedURL] {
+				seen[normalizedURL] = true
+				res = append(res, normalizedURL)
+			}
+		}
+	}
+	return res
+}
+
+func gitopsDeploymentMatchesPushEvents(gitopsDepl managedgitopsv1alpha1.GitOpsDeployment, pushEvents []PushEvent) bool {
+	for _, source := range gitopsDepl.Spec.GetSources() {
+		for _, pushEvent := range pushEvents {
+			if pushEventMatchesSource(pushEvent, source) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// pushEventMatchesSource returns true if the push event is to the repository and revision of the source.
+func pushEventMatchesSource(pushEvent PushEvent, source managedgitopsv1alpha1.ApplicationSource) bool {
+
+	if source.RepoURL == "" {
+		return false
+	}
+
+	normalizedRepoURL := shared_resource_loop.NormalizeGitURL(source.RepoURL)
+
+	repoMatches := false
+	for _, url := range pushEvent.RepoURLs {
+		if shared_resource_loop.NormalizeGitURL(url) == normalizedRepoURL {
+			repoMatches = true
+			break
+		}
+	}
+	if !repoMatches {
+		return false
+	}
+
+	targetRevision := strings.TrimSpace(source.TargetRevision)
+
+	// An empty target revision, or HEAD, refers to the default branch of the repository
+	if targetRevision == "" || targetRevision == "HEAD" {
+		return !pushEvent.IsTag && pushEvent.DefaultBranch != "" && pushEvent.Revision == pushEvent.DefaultBranch
+	}
+
+	if pushEvent.IsTag {
+		return targetRevision == pushEvent.Revision || targetRevision == refPrefixTag+pushEvent.Revision
+	}
+	return targetRevision == pushEvent.Revision || targetRevision == refPrefixBranch+pushEvent.Revision
+}
+
+// requestGitOpsDeploymentRefresh sets the refresh annotation on the GitOpsDeployment: the GitOpsDeployment controller
+// then passes the refresh request to the event loop (and removes the annotation, once it is handled).
+func requestGitOpsDeploymentRefresh(ctx context.Context, gitopsDepl *managedgitopsv1alpha1.GitOpsDeployment, k8sClient client.Client) error {
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+
+		if err := k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDepl), gitopsDepl); err != nil {
+			return err
+		}
+
+		// A refresh has already been requested, but not yet handled
+		if _, exists := gitopsDepl.Annotations[managedgitopsv1alpha1.AnnotationKeyRefresh]; exists {
+			return nil
+		}
+
+		if gitopsDepl.Annotations == nil {
+			gitopsDepl.Annotations = map[string]string{}
+		}
+		gitopsDepl.Annotations[managedgitopsv1alpha1.AnnotationKeyRefresh] = string(managedgitopsv1alpha1.RefreshType_Normal)
+
+		return k8sClient.Update(ctx, gitopsDepl)
+	})
+}
@@ -1,86 +1,260 @@
 package routes
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
 	"fmt"
 	"io"
-	"log"
+	"net/http"
+	"os"
 	"strings"
+	"time"
 
 	"github.com/emicklei/go-restful/v3"
-	"github.com/google/go-github/github"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	logutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util/log"
 )
 
+const (
+	// DefaultWebhookSecretName is the default name of the Secret that contains the webhook secret of each Git provider
+	DefaultWebhookSecretName = "gitops-service-webhook-secret"
+
+	// DefaultWebhookSecretNamespace is the default namespace of the webhook Secret: the namespace of the backend
+	DefaultWebhookSecretNamespace = "gitops"
+)
+
+// maxWebhookPayloadSize is the maximum size of a webhook payload that is accepted (GitHub caps payloads at 25MB)
+const maxWebhookPayloadSize = 25 * 1024 * 1024
+
 type WebHookInfo struct {
-	Id        string // Id for the github webhook request
-	Event     string // indicates which event took place (push, starred, pull request etc)
-	Signature string // signature of the github webhook request
-	Payload   []byte // consists of all the contents within the webhook
+	Provider  GitProvider // the Git provider that sent the webhook request
+	Id        string      // delivery ID of the webhook request
+	Event     string      // indicates which event took place (push, starred, pull request etc)
+	Signature string      // signature of the webhook request (or, for GitLab, the secret token)
+	Payload   []byte      // consists of all the contents within the webhook
 }
 
-func ParseWebhookInfo(request *restful.Request, response *restful.Response) {
-	webhook := new(WebHookInfo)
-	if !strings.EqualFold(request.Request.Method, "POST") {
-		log.Fatalln(nil, errors.New("POST method not found, unknown method occurred!"))
+// WebhookResponse is the body of the response to a webhook request
+type WebhookResponse struct {
+	// Message describes how the event was handled
+	Message string `json:"message"`
+
+	// RefreshedGitOpsDeployments is the list of GitOpsDeployments (as 'namespace/name') that a refresh was requested for
+	RefreshedGitOpsDeployments []string `json:"refreshedGitOpsDeployments,omitempty"`
+
+	// FailedGitOpsDeployments is the list of GitOpsDeployments (as 'namespace/name') that a refresh could not be requested for
+	FailedGitOpsDeployments []string `json:"failedGitOpsDeployments,omitempty"`
+}
+
+// WebhookHandler handles Git webhook events: on a push to a Git repository, a refresh is requested of each
+// GitOpsDeployment that deploys from the branch (or tag) that was pushed to.
+//
+// Requests are only handled if they are signed with (or, for GitLab, contain) the secret of the Git provider, which is
+// read from the webhook Secret: the key of the secret of each provider is 'webhook.<provider>.secret', for example
+// 'webhook.github.secret'. Requests from a provider without a secret are rejected.
+type WebhookHandler struct {
+	Client client.Client
+
+	// SecretRef is the name and namespace of the Secret that contains the webhook secret of each Git provider
+	SecretRef types.NamespacedName
+
+	// deliveries contains the delivery keys of recent requests, so that replayed requests can be rejected
+	deliveries *deliveryCache
+}
+
+// NewWebhookHandler returns a WebhookHandler that uses the given client to read the webhook Secret, and to find and
+// refresh GitOpsDeployments
+func NewWebhookHandler(k8sClient client.Client, secretRef types.NamespacedName) *WebhookHandler {
+	return &WebhookHandler{
+		Client:     k8sClient,
+		SecretRef:  secretRef,
+		deliveries: newDeliveryCache(deliveryCacheTTL, deliveryCacheMaxEntries),
 	}
-	if webhook.Event = request.Request.Header.Get("x-github-event"); len(webhook.Event) == 0 {
-		log.Fatalln(nil, errors.New("No event!"))
+}
+
+// GetWebhookSecretRef returns the name and namespace of the webhook Secret, which may be set via the
+// 'WEBHOOK_SECRET_NAME' and 'WEBHOOK_SECRET_NAMESPACE' environment variables.
+func GetWebhookSecretRef() types.NamespacedName {
+
+	res := types.NamespacedName{Name: DefaultWebhookSecretName, Namespace: DefaultWebhookSecretNamespace}
+
+	if name := strings.TrimSpace(os.Getenv("WEBHOOK_SECRET_NAME")); name != "" {
+		res.Name = name
 	}
-	if webhook.Id = request.Request.Header.Get("x-github-delivery"); len(webhook.Id) == 0 {
-		log.Fatalln(nil, errors.New("No event Id!"))
+	if namespace := strings.TrimSpace(os.Getenv("WEBHOOK_SECRET_NAMESPACE")); namespace != "" {
+		res.Namespace = namespace
 	}
-	// assigning payload data
-	payload, err := io.ReadAll(request.Request.Body)
+
+	return res
+}
+
+// webhookSecretKey returns the key of the webhook Secret that contains the secret of the Git provider
+func webhookSecretKey(provider GitProvider) string {
+	return "webhook." + string(provider) + ".secret"
+}
+
+func (h *WebhookHandler) ParseWebhookInfo(request *restful.Request, response *restful.Response) {
+
+	ctx := request.Request.Context()
+	log := log.FromContext(ctx).WithName(logutil.LogLogger_managed_gitops).WithValues("component", "webhook")
+
+	if request.Request.Method != http.MethodPost {
+		writeWebhookError(response, http.StatusMethodNotAllowed, "unsupported method: "+request.Request.Method, log)
+		return
+	}
+
+	webhook, err := newWebHookInfo(request.Request.Header)
 	if err != nil {
-		log.Printf("error reading request body: err=%s\n", err)
+		writeWebhookError(response, http.StatusBadRequest, err.Error(), log)
 		return
 	}
-	webhook.Payload = payload
+
+	log = log.WithValues("provider", webhook.Provider, "event", webhook.Event, "deliveryID", webhook.Id)
+
+	// assigning payload data
 	defer func() {
-		err = request.Request.Body.Close()
-		if err != nil {
-			log.Printf("error closing request body: err=%v\n", err)
+		if err := request.Request.Body.Close(); err != nil {
+			log.Error(err, "error closing request body")
 		}
 	}()
-
-	event, err := github.ParseWebHook(github.WebHookType(request.Request), webhook.Payload)
+	payload, err := io.ReadAll(io.LimitReader(request.Request.Body, maxWebhookPayloadSize+1))
 	if err != nil {
-		log.Printf("could not parse webhook: err=%s\n", err)
+		writeWebhookError(response, http.StatusBadRequest, fmt.Sprintf("unable to read request body: %v", err), log)
 		return
 	}
+	if len(payload) > maxWebhookPayloadSize {
+		writeWebhookError(response, http.StatusRequestEntityTooLarge, "request body is too large", log)
+		return
+	}
+	webhook.Payload = payload
 
-	// printing the payload data in structured format
-	webhookData, err := json.MarshalIndent(event, "", "  ")
+	// The request is verified before anything else is done with it, including recording its delivery key.
+	secret, err := h.getWebhookSecret(ctx, webhook.Provider)
 	if err != nil {
-		log.Printf("error, %v", err)
+		log.Error(err, "unable to retrieve the webhook secret")
+		writeWebhookError(response, http.StatusInternalServerError, "unable to retrieve the webhook secret", log)
+		return
+	}
+	if secret == nil {
+		writeWebhookError(response, http.StatusForbidden,
+			fmt.Sprintf("webhooks from %s are not accepted: no webhook secret is configured", webhook.Provider), log)
+		return
+	}
+	if err := webhook.verifySignature(secret); err != nil {
+		writeWebhookError(response, http.StatusUnauthorized, err.Error(), log)
 		return
 	}
 
-	errWrite := response.WriteEntity(event)
-	if errWrite != nil {
-		log.Fatal(errWrite)
+	deliveryKey := webhook.deliveryKey()
+	if !h.deliveries.add(deliveryKey, time.Now()) {
+		writeWebhookError(response, http.StatusConflict, fmt.Sprintf("webhook request (delivery '%s') was already received", webhook.Id), log)
+		return
 	}
 
 	// classifying the type of event
-	switch e := event.(type) {
-	case *github.PushEvent:
+	switch webhook.eventType() {
+	case webhookEventType_Push:
 		// this is a commit push
-		fmt.Println("A Commit event occurred")
-		fmt.Println(string(webhookData))
-	case *github.PullRequestEvent:
-		// this is a pull request
-		fmt.Println("A pull request has been created")
-		fmt.Println(string(webhookData))
-	case *github.WatchEvent:
-		// if someone starred our repository
-		if e.Action != nil && *e.Action == "starred" {
-			fmt.Printf("%s starred repository %s\n",
-				*e.Sender.Login, *e.Repo.FullName)
+		pushEvents, err := webhook.parsePushEvents()
+		if err != nil {
+			writeWebhookError(response, http.StatusBadRequest, fmt.Sprintf("could not parse webhook: %v", err), log)
+			return
 		}
-		fmt.Println(string(webhookData))
+		if !h.handlePushEvents(ctx, pushEvents, response, log) {
+			// Allow the provider to redeliver the request, since it was not handled
+			h.deliveries.remove(deliveryKey)
+		}
+
+	case webhookEventType_Ping:
+		// sent when the webhook is first configured
+		writeWebhookResponse(response, http.StatusOK, WebhookResponse{Message: "pong"}, log)
+
 	default:
-		log.Printf("unknown event type %s\n", github.WebHookType(request.Request))
-		return
+		// Other events (pull requests, stars, etc) do not affect GitOpsDeployments
+		writeWebhookResponse(response, http.StatusAccepted, WebhookResponse{
+			Message: fmt.Sprintf("event type '%s' was ignored", webhook.Event),
+		}, log)
+	}
+}
+
+// getWebhookSecret returns the webhook secret of the Git provider, or nil if no secret is configured for it.
+func (h *WebhookHandler) getWebhookSecret(ctx context.Context, provider GitProvider) ([]byte, error) {
+
+	secret := &corev1.Secret{}
+	if err := h.Client.Get(ctx, h.SecretRef, secret); err != nil {
+		if apierr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	value := secret.Data[webhookSecretKey(provider)]
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	return value, nil
+}
+
+// handlePushEvents requests a refresh of each GitOpsDeployment that deploys from a branch (or tag) that was pushed to.
+// A failure to request the refresh of one GitOpsDeployment does not prevent the refresh of the others.
+// Returns false if the push events could not be handled (for all of the GitOpsDeployments).
+func (h *WebhookHandler) handlePushEvents(ctx context.Context, pushEvents []PushEvent, response *restful.Response, log logr.Logger) bool {
+
+	gitopsDepls, err := findGitOpsDeploymentsForPushEvents(ctx, pushEvents, h.Client)
+	if err != nil {
+		log.Error(err, "unable to find the GitOpsDeployments of the push event")
+		writeWebhookError(response, http.StatusInternalServerError, "unable to find the GitOpsDeployments of the push event", log)
+		return false
+	}
+
+	res := WebhookResponse{RefreshedGitOpsDeployments: []string{}}
+
+	for i := range gitopsDepls {
+		gitopsDepl := gitopsDepls[i]
+		if err := requestGitOpsDeploymentRefresh(ctx, &gitopsDepl, h.Client); err != nil {
+			if apierr.IsNotFound(err) {
+				// The GitOpsDeployment was deleted after it was listed, so there is nothing to refresh
+				continue
+			}
+			log.Error(err, "unable to request a refresh of the GitOpsDeployment", "name", gitopsDepl.Name, "namespace", gitopsDepl.Namespace)
+			res.FailedGitOpsDeployments = append(res.FailedGitOpsDeployments, gitopsDepl.Namespace+"/"+gitopsDepl.Name)
+			continue
+		}
+		res.RefreshedGitOpsDeployments = append(res.RefreshedGitOpsDeployments, gitopsDepl.Namespace+"/"+gitopsDepl.Name)
 	}
+
+	if len(res.FailedGitOpsDeployments) > 0 {
+		res.Message = fmt.Sprintf("refresh requested for %d GitOpsDeployment(s), but unable to request a refresh of %d GitOpsDeployment(s)",
+			len(res.RefreshedGitOpsDeployments), len(res.FailedGitOpsDeployments))
+		log.Info("Unable to handle push event", "pushEvents", pushEvents, "refreshedGitOpsDeployments", res.RefreshedGitOpsDeployments,
+			"failedGitOpsDeployments", res.FailedGitOpsDeployments)
+
+		writeWebhookResponse(response, http.StatusInternalServerError, res, log)
+
+		return false
+	}
+
+	res.Message = fmt.Sprintf("refresh requested for %d GitOpsDeployment(s)", len(res.RefreshedGitOpsDeployments))
+	log.Info("Handled push event", "pushEvents", pushEvents, "refreshedGitOpsDeployments", res.RefreshedGitOpsDeployments)
+
+	writeWebhookResponse(response, http.StatusOK, res, log)
+
+	return true
+}
+
+func writeWebhookResponse(response *restful.Response, status int, body WebhookResponse, log logr.Logger) {
+	if err := response.WriteHeaderAndEntity(status, body); err != nil {
+		log.Error(err, "unable to write webhook response")
+	}
+}
+
+func writeWebhookError(response *restful.Response, status int, message string, log logr.Logger) {
+	log.Info("Rejected webhook request", "status", status, "reason", message)
+	writeWebhookResponse(response, status, WebhookResponse{Message: message}, log)
 }
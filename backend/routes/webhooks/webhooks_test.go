@@ -0,0 +1,505 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	restful "github.com/emicklei/go-restful/v3"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
+	"github.com/redhat-appstudio/managed-gitops/backend-shared/util/tests"
+)
+
+var _ = Describe("Webhook handler tests", func() {
+
+	Context("ParseWebhookInfo", func() {
+
+		var ctx context.Context
+		var k8sClient client.Client
+		var namespace *corev1.Namespace
+		var container *restful.Container
+		var webhookSecret *corev1.Secret
+
+		const githubSecret = "github-webhook-secret"
+		const gitlabSecret = "gitlab-webhook-secret"
+		const giteaSecret = "gitea-webhook-secret"
+		const bitbucketServerSecret = "bitbucketserver-webhook-secret"
+
+		newGitOpsDeployment := func(name string, repoURL string, targetRevision string) *managedgitopsv1alpha1.GitOpsDeployment {
+			gitopsDepl := &managedgitopsv1alpha1.GitOpsDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace.Name,
+				},
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentSpec{
+					Source: managedgitopsv1alpha1.ApplicationSource{
+						RepoURL:        repoURL,
+						Path:           "environments/dev",
+						TargetRevision: targetRevision,
+					},
+					Type: managedgitopsv1alpha1.GitOpsDeploymentSpecType_Automated,
+				},
+			}
+			err := k8sClient.Create(ctx, gitopsDepl)
+			Expect(err).ToNot(HaveOccurred())
+			return gitopsDepl
+		}
+
+		isRefreshRequested := func(gitopsDepl *managedgitopsv1alpha1.GitOpsDeployment) bool {
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDepl), gitopsDepl)
+			Expect(err).ToNot(HaveOccurred())
+			refreshType, exists := gitopsDepl.Annotations[managedgitopsv1alpha1.AnnotationKeyRefresh]
+			return exists && refreshType == string(managedgitopsv1alpha1.RefreshType_Normal)
+		}
+
+		sendWebhook := func(headers map[string]string, payload []byte) (int, WebhookResponse) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/webhookevent", bytes.NewReader(payload))
+			req.Header.Set("Content-Type", restful.MIME_JSON)
+			for key, value := range headers {
+				req.Header.Set(key, value)
+			}
+
+			recorder := httptest.NewRecorder()
+			container.ServeHTTP(recorder, req)
+
+			res := WebhookResponse{}
+			Expect(json.Unmarshal(recorder.Body.Bytes(), &res)).To(Succeed())
+			return recorder.Code, res
+		}
+
+		sign := func(secret string, payload []byte) string {
+			mac := hmac.New(sha256.New, []byte(secret))
+			mac.Write(payload)
+			return hex.EncodeToString(mac.Sum(nil))
+		}
+
+		// githubHeaders returns the headers of a GitHub webhook request, with a new delivery ID, signed with the secret
+		githubHeaders := func(event string, payload []byte) map[string]string {
+			return map[string]string{
+				"X-GitHub-Event":      event,
+				"X-GitHub-Delivery":   string(uuid.NewUUID()),
+				"X-Hub-Signature-256": "sha256=" + sign(githubSecret, payload),
+			}
+		}
+
+		readPayload := func(name string) []byte {
+			payload, err := os.ReadFile(filepath.Join("testdata", name))
+			Expect(err).ToNot(HaveOccurred())
+			return payload
+		}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			scheme, argocdNamespace, kubesystemNamespace, workspace, err := tests.GenericTestSetup()
+			Expect(err).ToNot(HaveOccurred())
+			namespace = workspace
+
+			k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespace, argocdNamespace, kubesystemNamespace).Build()
+
+			webhookSecret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      DefaultWebhookSecretName,
+					Namespace: argocdNamespace.Name,
+				},
+				Data: map[string][]byte{
+					"webhook.github.secret":          []byte(githubSecret),
+					"webhook.gitlab.secret":          []byte(gitlabSecret),
+					"webhook.gitea.secret":           []byte(giteaSecret),
+					"webhook.bitbucketserver.secret": []byte(bitbucketServerSecret),
+				},
+			}
+			err = k8sClient.Create(ctx, webhookSecret)
+			Expect(err).ToNot(HaveOccurred())
+
+			ws := new(restful.WebService)
+			ws.Path("/api/v1/webhookevent").Consumes(restful.MIME_JSON).Produces(restful.MIME_JSON)
+			ws.Route(ws.POST("").To(NewWebhookHandler(k8sClient, types.NamespacedName{
+				Namespace: webhookSecret.Namespace,
+				Name:      webhookSecret.Name,
+			}).ParseWebhookInfo))
+
+			container = restful.NewContainer()
+			container.Add(ws)
+		})
+
+		It("should request a refresh of the GitOpsDeployments that deploy from the branch that was pushed to", func() {
+
+			By("creating GitOpsDeployments that do, and do not, match the repository and branch of the push")
+			matchingDepl := newGitOpsDeployment("matching", "https://github.com/redhat-appstudio/managed-gitops", "main")
+			matchingSSHDepl := newGitOpsDeployment("matching-ssh", "git@github.com:redhat-appstudio/managed-gitops.git", "refs/heads/main")
+			matchingHEADDepl := newGitOpsDeployment("matching-head", "https://github.com/Redhat-AppStudio/managed-gitops.git", "HEAD")
+			otherBranchDepl := newGitOpsDeployment("other-branch", "https://github.com/redhat-appstudio/managed-gitops", "dev")
+			otherRepoDepl := newGitOpsDeployment("other-repo", "https://github.com/redhat-appstudio/gitops-repository-template", "main")
+
+			By("sending the recorded push event")
+			payload := readPayload("github-push-event.json")
+			code, res := sendWebhook(githubHeaders("push", payload), payload)
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(res.RefreshedGitOpsDeployments).To(ConsistOf(
+				namespace.Name+"/matching", namespace.Name+"/matching-ssh", namespace.Name+"/matching-head"))
+
+			Expect(isRefreshRequested(matchingDepl)).To(BeTrue())
+			Expect(isRefreshRequested(matchingSSHDepl)).To(BeTrue())
+			Expect(isRefreshRequested(matchingHEADDepl)).To(BeTrue())
+			Expect(isRefreshRequested(otherBranchDepl)).To(BeFalse())
+			Expect(isRefreshRequested(otherRepoDepl)).To(BeFalse())
+		})
+
+		It("should not change an existing refresh request of a matching GitOpsDeployment", func() {
+
+			gitopsDepl := newGitOpsDeployment("matching", "https://github.com/redhat-appstudio/managed-gitops", "main")
+			gitopsDepl.Annotations = map[string]string{managedgitopsv1alpha1.AnnotationKeyRefresh: string(managedgitopsv1alpha1.RefreshType_Hard)}
+			Expect(k8sClient.Update(ctx, gitopsDepl)).To(Succeed())
+
+			payload := readPayload("github-push-event.json")
+			code, res := sendWebhook(githubHeaders("push", payload), payload)
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(res.RefreshedGitOpsDeployments).To(ConsistOf(namespace.Name + "/matching"))
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(gitopsDepl), gitopsDepl)).To(Succeed())
+			Expect(gitopsDepl.Annotations[managedgitopsv1alpha1.AnnotationKeyRefresh]).To(Equal(string(managedgitopsv1alpha1.RefreshType_Hard)))
+		})
+
+		It("should request a refresh of the other matching GitOpsDeployments, if one is deleted or cannot be refreshed", func() {
+
+			deletedDepl := newGitOpsDeployment("deleted", "https://github.com/redhat-appstudio/managed-gitops", "main")
+			failingDepl := newGitOpsDeployment("failing", "https://github.com/redhat-appstudio/managed-gitops", "main")
+			matchingDepl := newGitOpsDeployment("matching", "https://github.com/redhat-appstudio/managed-gitops", "main")
+
+			By("using a client that reports the 'deleted' GitOpsDeployment as deleted after it is listed, and fails to update the 'failing' GitOpsDeployment")
+			ws := new(restful.WebService)
+			ws.Path("/api/v1/webhookevent").Consumes(restful.MIME_JSON).Produces(restful.MIME_JSON)
+			ws.Route(ws.POST("").To(NewWebhookHandler(&refreshFailingClient{
+				Client:     k8sClient,
+				deleted:    deletedDepl.Name,
+				failUpdate: failingDepl.Name,
+			}, client.ObjectKeyFromObject(webhookSecret)).ParseWebhookInfo))
+			container = restful.NewContainer()
+			container.Add(ws)
+
+			payload := readPayload("github-push-event.json")
+			headers := githubHeaders("push", payload)
+			code, res := sendWebhook(headers, payload)
+			Expect(code).To(Equal(http.StatusInternalServerError))
+			Expect(res.RefreshedGitOpsDeployments).To(ConsistOf(namespace.Name + "/matching"))
+			Expect(res.FailedGitOpsDeployments).To(ConsistOf(namespace.Name + "/failing"))
+
+			Expect(isRefreshRequested(matchingDepl)).To(BeTrue())
+			Expect(isRefreshRequested(failingDepl)).To(BeFalse())
+
+			By("redelivering the request, which is accepted since it was not handled")
+			code, _ = sendWebhook(headers, payload)
+			Expect(code).To(Equal(http.StatusInternalServerError))
+
+			By("deleting the failing GitOpsDeployment, so that the push event can be handled")
+			Expect(k8sClient.Delete(ctx, failingDepl)).To(Succeed())
+			code, res = sendWebhook(headers, payload)
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(res.RefreshedGitOpsDeployments).To(ConsistOf(namespace.Name + "/matching"))
+			Expect(res.FailedGitOpsDeployments).To(BeEmpty())
+		})
+
+		It("should request a refresh of the GitOpsDeployments matching a GitLab push event", func() {
+
+			gitopsDepl := newGitOpsDeployment("matching", "https://gitlab.example.com/appstudio/gitops-repository.git", "")
+			otherBranchDepl := newGitOpsDeployment("other-branch", "https://gitlab.example.com/appstudio/gitops-repository.git", "dev")
+
+			payload := readPayload("gitlab-push-event.json")
+			code, res := sendWebhook(map[string]string{
+				"X-Gitlab-Event":      "Push Hook",
+				"X-Gitlab-Event-UUID": string(uuid.NewUUID()),
+				"X-Gitlab-Token":      gitlabSecret,
+			}, payload)
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(res.RefreshedGitOpsDeployments).To(ConsistOf(namespace.Name + "/matching"))
+
+			Expect(isRefreshRequested(gitopsDepl)).To(BeTrue())
+			Expect(isRefreshRequested(otherBranchDepl)).To(BeFalse())
+		})
+
+		It("should request a refresh of the GitOpsDeployments matching a Gitea push event", func() {
+
+			gitopsDepl := newGitOpsDeployment("matching", "git@gitea.example.com:appstudio/gitops-repository.git", "main")
+
+			payload := readPayload("gitea-push-event.json")
+			code, res := sendWebhook(map[string]string{
+				// Gitea also sends the GitHub event headers
+				"X-GitHub-Event":    "push",
+				"X-Gitea-Event":     "push",
+				"X-Gitea-Delivery":  string(uuid.NewUUID()),
+				"X-Gitea-Signature": sign(giteaSecret, payload),
+			}, payload)
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(res.RefreshedGitOpsDeployments).To(ConsistOf(namespace.Name + "/matching"))
+
+			Expect(isRefreshRequested(gitopsDepl)).To(BeTrue())
+		})
+
+		It("should request a refresh of the GitOpsDeployments matching a Bitbucket Server push event", func() {
+
+			gitopsDepl := newGitOpsDeployment("matching", "https://bitbucket.example.com/scm/appstudio/gitops-repository.git", "main")
+			sshDepl := newGitOpsDeployment("matching-ssh", "ssh://git@bitbucket.example.com:7999/appstudio/gitops-repository.git", "main")
+			deletedBranchDepl := newGitOpsDeployment("deleted-branch", "https://bitbucket.example.com/scm/appstudio/gitops-repository.git", "old-feature")
+
+			payload := readPayload("bitbucketserver-push-event.json")
+			code, res := sendWebhook(map[string]string{
+				"X-Event-Key":     "repo:refs_changed",
+				"X-Request-Id":    string(uuid.NewUUID()),
+				"X-Hub-Signature": "sha256=" + sign(bitbucketServerSecret, payload),
+			}, payload)
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(res.RefreshedGitOpsDeployments).To(ConsistOf(namespace.Name+"/matching", namespace.Name+"/matching-ssh"))
+
+			Expect(isRefreshRequested(gitopsDepl)).To(BeTrue())
+			Expect(isRefreshRequested(sshDepl)).To(BeTrue())
+			Expect(isRefreshRequested(deletedBranchDepl)).To(BeFalse())
+		})
+
+		It("should respond to a ping event", func() {
+			payload := readPayload("github-ping-event.json")
+			code, res := sendWebhook(githubHeaders("ping", payload), payload)
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(res.Message).To(Equal("pong"))
+		})
+
+		It("should ignore events other than push events", func() {
+			gitopsDepl := newGitOpsDeployment("matching", "https://github.com/redhat-appstudio/managed-gitops", "main")
+
+			payload := []byte(`{"action": "created"}`)
+			code, _ := sendWebhook(githubHeaders("star", payload), payload)
+			Expect(code).To(Equal(http.StatusAccepted))
+			Expect(isRefreshRequested(gitopsDepl)).To(BeFalse())
+		})
+
+		It("should reject requests with a missing or invalid signature", func() {
+			gitopsDepl := newGitOpsDeployment("matching", "https://github.com/redhat-appstudio/managed-gitops", "main")
+
+			payload := readPayload("github-push-event.json")
+
+			By("sending a request without a signature")
+			headers := githubHeaders("push", payload)
+			delete(headers, "X-Hub-Signature-256")
+			code, _ := sendWebhook(headers, payload)
+			Expect(code).To(Equal(http.StatusUnauthorized))
+
+			By("sending a request signed with a different secret")
+			headers = githubHeaders("push", payload)
+			headers["X-Hub-Signature-256"] = "sha256=" + sign("not-the-secret", payload)
+			code, _ = sendWebhook(headers, payload)
+			Expect(code).To(Equal(http.StatusUnauthorized))
+
+			By("sending a GitLab request with a different token")
+			code, _ = sendWebhook(map[string]string{
+				"X-Gitlab-Event":      "Push Hook",
+				"X-Gitlab-Event-UUID": string(uuid.NewUUID()),
+				"X-Gitlab-Token":      "not-the-secret",
+			}, readPayload("gitlab-push-event.json"))
+			Expect(code).To(Equal(http.StatusUnauthorized))
+
+			Expect(isRefreshRequested(gitopsDepl)).To(BeFalse())
+		})
+
+		It("should reject requests from a provider without a webhook secret", func() {
+
+			delete(webhookSecret.Data, "webhook.github.secret")
+			Expect(k8sClient.Update(ctx, webhookSecret)).To(Succeed())
+
+			payload := readPayload("github-push-event.json")
+			code, _ := sendWebhook(githubHeaders("push", payload), payload)
+			Expect(code).To(Equal(http.StatusForbidden))
+
+			By("deleting the webhook Secret")
+			Expect(k8sClient.Delete(ctx, webhookSecret)).To(Succeed())
+
+			payload = readPayload("gitea-push-event.json")
+			code, _ = sendWebhook(map[string]string{
+				"X-Gitea-Event":     "push",
+				"X-Gitea-Delivery":  string(uuid.NewUUID()),
+				"X-Gitea-Signature": sign(giteaSecret, payload),
+			}, payload)
+			Expect(code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should reject a replayed request", func() {
+			gitopsDepl := newGitOpsDeployment("matching", "https://github.com/redhat-appstudio/managed-gitops", "main")
+
+			payload := readPayload("github-push-event.json")
+			headers := githubHeaders("push", payload)
+
+			code, _ := sendWebhook(headers, payload)
+			Expect(code).To(Equal(http.StatusOK))
+			Expect(isRefreshRequested(gitopsDepl)).To(BeTrue())
+
+			By("removing the refresh annotation, as the GitOpsDeployment controller would once the refresh is handled")
+			delete(gitopsDepl.Annotations, managedgitopsv1alpha1.AnnotationKeyRefresh)
+			Expect(k8sClient.Update(ctx, gitopsDepl)).To(Succeed())
+
+			By("sending the same request again")
+			code, _ = sendWebhook(headers, payload)
+			Expect(code).To(Equal(http.StatusConflict))
+			Expect(isRefreshRequested(gitopsDepl)).To(BeFalse())
+
+			By("sending the same request again, with a different delivery ID, which is not covered by the signature")
+			headers["X-GitHub-Delivery"] = string(uuid.NewUUID())
+			code, _ = sendWebhook(headers, payload)
+			Expect(code).To(Equal(http.StatusConflict))
+
+			By("sending the same request again, with an upper-case signature")
+			headers["X-Hub-Signature-256"] = "sha256=" + strings.ToUpper(sign(githubSecret, payload))
+			code, _ = sendWebhook(headers, payload)
+			Expect(code).To(Equal(http.StatusConflict))
+			Expect(isRefreshRequested(gitopsDepl)).To(BeFalse())
+		})
+
+		It("should return a 400 error if a header is missing", func() {
+			payload := readPayload("github-push-event.json")
+
+			code, res := sendWebhook(map[string]string{"X-GitHub-Delivery": string(uuid.NewUUID())}, payload)
+			Expect(code).To(Equal(http.StatusBadRequest))
+			Expect(res.Message).To(ContainSubstring("X-GitHub-Event"))
+
+			headers := githubHeaders("push", payload)
+			delete(headers, "X-GitHub-Delivery")
+			code, res = sendWebhook(headers, payload)
+			Expect(code).To(Equal(http.StatusBadRequest))
+			Expect(res.Message).To(ContainSubstring("X-GitHub-Delivery"))
+		})
+
+		It("should return a 400 error if the payload is invalid", func() {
+			payload := []byte("not json")
+			code, _ := sendWebhook(githubHeaders("push", payload), payload)
+			Expect(code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("pushEventMatchesSource", func() {
+
+		It("should match the revision of a branch or tag push", func() {
+			repoURL := "https://github.com/redhat-appstudio/managed-gitops"
+
+			branchPush := PushEvent{RepoURLs: []string{repoURL + ".git"}, Revision: "main", DefaultBranch: "main"}
+			tagPush := PushEvent{RepoURLs: []string{repoURL + ".git"}, Revision: "v1.0.0", IsTag: true, DefaultBranch: "main"}
+
+			source := func(targetRevision string) managedgitopsv1alpha1.ApplicationSource {
+				return managedgitopsv1alpha1.ApplicationSource{RepoURL: repoURL, TargetRevision: targetRevision}
+			}
+
+			Expect(pushEventMatchesSource(branchPush, source("main"))).To(BeTrue())
+			Expect(pushEventMatchesSource(branchPush, source(""))).To(BeTrue())
+			Expect(pushEventMatchesSource(branchPush, source("HEAD"))).To(BeTrue())
+			Expect(pushEventMatchesSource(branchPush, source("v1.0.0"))).To(BeFalse())
+
+			Expect(pushEventMatchesSource(tagPush, source("v1.0.0"))).To(BeTrue())
+			Expect(pushEventMatchesSource(tagPush, source("refs/tags/v1.0.0"))).To(BeTrue())
+			Expect(pushEventMatchesSource(tagPush, source("HEAD"))).To(BeFalse())
+			Expect(pushEventMatchesSource(tagPush, source("main"))).To(BeFalse())
+		})
+	})
+
+	Context("IndexGitOpsDeploymentRepoURLs", func() {
+
+		It("should index a GitOpsDeployment by the normalized repoURL of each of its sources", func() {
+			indexer := &capturingFieldIndexer{}
+			Expect(IndexGitOpsDeploymentRepoURLs(context.Background(), indexer)).To(Succeed())
+			Expect(indexer.field).To(Equal(GitOpsDeploymentRepoURLIndex))
+
+			gitopsDepl := &managedgitopsv1alpha1.GitOpsDeployment{
+				Spec: managedgitopsv1alpha1.GitOpsDeploymentSpec{
+					Sources: []managedgitopsv1alpha1.ApplicationSource{
+						{RepoURL: "https://github.com/Redhat-AppStudio/managed-gitops.git"},
+						{RepoURL: "git@github.com:redhat-appstudio/gitops-repository-template.git"},
+					},
+				},
+			}
+			Expect(indexer.extractValue(gitopsDepl)).To(Equal([]string{
+				"https://github.com/redhat-appstudio/managed-gitops",
+				"git@github.com/redhat-appstudio/gitops-repository-template",
+			}))
+		})
+	})
+
+	Context("deliveryCache", func() {
+
+		It("should remember delivery IDs until they expire, or until the oldest are evicted", func() {
+			cache := newDeliveryCache(time.Hour, 2)
+			now := time.Now()
+
+			Expect(cache.add("github/1", now)).To(BeTrue())
+			Expect(cache.add("github/1", now.Add(time.Minute))).To(BeFalse())
+			Expect(cache.add("gitlab/1", now.Add(time.Minute))).To(BeTrue())
+
+			By("adding a delivery ID when the cache is full, which evicts the oldest")
+			Expect(cache.add("github/2", now.Add(2*time.Minute))).To(BeTrue())
+			Expect(cache.add("github/1", now.Add(3*time.Minute))).To(BeTrue())
+
+			By("adding a delivery ID after the others have expired")
+			Expect(cache.add("github/2", now.Add(2*time.Hour))).To(BeTrue())
+		})
+
+		It("should forget a removed delivery ID", func() {
+			cache := newDeliveryCache(time.Hour, 10)
+			now := time.Now()
+
+			Expect(cache.add("github/1", now)).To(BeTrue())
+			cache.remove("github/1")
+			Expect(cache.add("github/1", now.Add(time.Second))).To(BeTrue())
+			Expect(cache.add("github/1", now.Add(2*time.Second))).To(BeFalse())
+		})
+	})
+})
+
+// refreshFailingClient simulates failures in requesting a refresh of GitOpsDeployments: the GitOpsDeployment named
+// 'deleted' is not found (as if it were deleted after it was listed), and the update of the GitOpsDeployment named
+// 'failUpdate' fails.
+type refreshFailingClient struct {
+	client.Client
+	deleted    string
+	failUpdate string
+}
+
+func (c *refreshFailingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if _, isGitOpsDepl := obj.(*managedgitopsv1alpha1.GitOpsDeployment); isGitOpsDepl && key.Name == c.deleted {
+		return apierr.NewNotFound(managedgitopsv1alpha1.GroupVersion.WithResource("gitopsdeployments").GroupResource(), key.Name)
+	}
+	return c.Client.Get(ctx, key, obj, opts...)
+}
+
+func (c *refreshFailingClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if _, isGitOpsDepl := obj.(*managedgitopsv1alpha1.GitOpsDeployment); isGitOpsDepl && obj.GetName() == c.failUpdate {
+		return fmt.Errorf("simulated failure to update '%s'", obj.GetName())
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+// capturingFieldIndexer records the field index that is added to it
+type capturingFieldIndexer struct {
+	field        string
+	extractValue client.IndexerFunc
+}
+
+func (i *capturingFieldIndexer) IndexField(ctx context.Context, obj client.Object, field string, extractValue client.IndexerFunc) error {
+	i.field = field
+	i.extractValue = extractValue
+	return nil
+}
@@ -22,14 +22,17 @@ import (
 	"fmt"
 
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
 	"gopkg.in/yaml.v2"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	appv1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/go-logr/logr"
+	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
 	"github.com/redhat-appstudio/managed-gitops/backend-shared/db"
 	sharedutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util"
 	argosharedutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util/argocd"
@@ -124,7 +127,8 @@ func (r *ApplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		Applicationstate_application_id: applicationDB.Application_id,
 	}
 
-	if _, _, errGet := r.Cache.GetApplicationStateById(ctx, applicationState.Applicationstate_application_id); errGet != nil {
+	existingApplicationState, _, errGet := r.Cache.GetApplicationStateById(ctx, applicationState.Applicationstate_application_id)
+	if errGet != nil {
 		if db.IsResultNotFoundError(errGet) {
 
 			// 3a) ApplicationState doesn't exist: so create it
@@ -167,6 +171,11 @@ func (r *ApplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				return ctrl.Result{}, err
 			}
 
+			if err := setApplicationHistory(applicationState, nil, app); err != nil {
+				log.Error(err, "failed to set Application history in ApplicationState DB")
+				return ctrl.Result{}, err
+			}
+
 			if errCreate := r.Cache.CreateApplicationState(ctx, *applicationState); errCreate != nil {
 				log.Error(errCreate, "unexpected error on writing new application state")
 				return ctrl.Result{}, errCreate
@@ -218,6 +227,12 @@ func (r *ApplicationReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
+	// The deployment history is accumulated across reconciles, so the previous value is required to compute the new value.
+	if err := setApplicationHistory(applicationState, existingApplicationState.History, app); err != nil {
+		log.Error(err, "failed to set Application history in ApplicationState DB")
+		return ctrl.Result{}, err
+	}
+
 	if err := r.Cache.UpdateApplicationState(ctx, *applicationState); err != nil {
 
 		if strings.Contains(err.Error(), db.ErrorUnexpectedNumberOfRowsAffected) {
@@ -287,6 +302,13 @@ func storeInComparedToFieldInApplicationState(argoCDAppParam appv1.Application,
 			Name:      comparedToParam.Destination.Name,
 		},
 	}
+	for _, source := range comparedToParam.Sources {
+		comparedTo.Sources = append(comparedTo.Sources, fauxargocd.ApplicationSource{
+			RepoURL:        source.RepoURL,
+			Path:           source.Path,
+			TargetRevision: source.TargetRevision,
+		})
+	}
 
 	// If the comparedToParam is non-empty, then process it
 	if !reflect.DeepEqual(comparedToParam, appv1.ComparedTo{}) {
@@ -339,3 +361,129 @@ func setApplicationConditions(appState *db.ApplicationState, conditions []appv1.
 	appState.Conditions = conditionBytes
 	return nil
 }
+
+// setApplicationHistory updates the deployment history of the ApplicationState, by merging the history and operation state
+// of the Argo CD Application into the previous history (as stored in the database).
+func setApplicationHistory(appState *db.ApplicationState, previousHistoryBytes []byte, app appv1.Application) error {
+
+	var previousHistory []managedgitopsv1alpha1.DeploymentHistoryEntry
+
+	if len(previousHistoryBytes) != 0 {
+		historyBytes, err := sharedutil.DecompressObject(previousHistoryBytes)
+		if err != nil {
+			return fmt.Errorf("failed to decompress history data: %v", err)
+		}
+
+		if err := yaml.Unmarshal(historyBytes, &previousHistory); err != nil {
+			return fmt.Errorf("unable to unmarshal history data: %v", err)
+		}
+	}
+
+	history := updateDeploymentHistory(previousHistory, app)
+	if len(history) == 0 {
+		appState.History = nil
+		return nil
+	}
+
+	var err error
+	appState.History, err = sharedutil.CompressObject(history)
+	if err != nil {
+		return fmt.Errorf("unable to compress history data: %v", err)
+	}
+
+	return nil
+}
+
+// updateDeploymentHistory returns the deployment history of an Argo CD Application, ordered from oldest to newest, and
+// bounded to the most recent DeploymentHistoryLimit entries.
+//
+// Argo CD's .status.history only contains successful syncs, and does not record who initiated the sync. So, in addition to
+// Argo CD's history, we record every completed sync operation (from .status.operationState) as it is observed. Entries
+// are identified by the time the sync started.
+func updateDeploymentHistory(previousHistory []managedgitopsv1alpha1.DeploymentHistoryEntry, app appv1.Application) []managedgitopsv1alpha1.DeploymentHistoryEntry {
+
+	history := append([]managedgitopsv1alpha1.DeploymentHistoryEntry{}, previousHistory...)
+
+	findEntry := func(id *int64, startedAt *metav1.Time) int {
+		for i, entry := range history {
+			if id != nil && entry.ID != nil && *entry.ID == *id {
+				return i
+			}
+			if startedAt != nil && entry.DeployStartedAt != nil && entry.DeployStartedAt.Equal(startedAt) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	// 1) Add any entries from the Argo CD Application's history that we have not yet seen.
+	for _, argoHistory := range app.Status.History {
+
+		id := argoHistory.ID
+
+		if idx := findEntry(&id, argoHistory.DeployStartedAt); idx != -1 {
+			history[idx].ID = &id
+			continue
+		}
+
+		history = append(history, managedgitopsv1alpha1.DeploymentHistoryEntry{
+			ID:              &id,
+			Revision:        argoHistory.Revision,
+			Source:          convertToDeploymentSource(argoHistory.Source),
+			DeployStartedAt: argoHistory.DeployStartedAt,
+			DeployedAt:      argoHistory.DeployedAt,
+			SyncResult:      managedgitopsv1alpha1.OperationSucceeded,
+		})
+	}
+
+	// 2) Add (or complete) the entry for the most recent operation, once it has completed.
+	// - A dry-run sync made no changes to the cluster, and so it is not a deployment.
+	if opState := app.Status.OperationState; opState != nil && opState.Phase.Completed() && opState.FinishedAt != nil &&
+		!opState.Operation.DryRun() {
+
+		entry := managedgitopsv1alpha1.DeploymentHistoryEntry{
+			DeployStartedAt: opState.StartedAt.DeepCopy(),
+			DeployedAt:      *opState.FinishedAt.DeepCopy(),
+			InitiatedBy: managedgitopsv1alpha1.OperationInitiator{
+				Username:  opState.Operation.InitiatedBy.Username,
+				Automated: opState.Operation.InitiatedBy.Automated,
+			},
+			SyncResult: managedgitopsv1alpha1.OperationPhase(opState.Phase),
+			Message:    db.TruncateVarchar(opState.Message, db.ApplicationStateMessageLength),
+		}
+
+		if opState.SyncResult != nil {
+			entry.Revision = opState.SyncResult.Revision
+			entry.Source = convertToDeploymentSource(opState.SyncResult.Source)
+		} else if opState.Operation.Sync != nil {
+			entry.Revision = opState.Operation.Sync.Revision
+		}
+
+		if idx := findEntry(nil, entry.DeployStartedAt); idx != -1 {
+			// The entry from Argo CD's history is more accurate regarding ID, revision and deploy time.
+			history[idx].InitiatedBy = entry.InitiatedBy
+			history[idx].SyncResult = entry.SyncResult
+			history[idx].Message = entry.Message
+		} else {
+			history = append(history, entry)
+		}
+	}
+
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].DeployedAt.Before(&history[j].DeployedAt)
+	})
+
+	if len(history) > managedgitopsv1alpha1.DeploymentHistoryLimit {
+		history = history[len(history)-managedgitopsv1alpha1.DeploymentHistoryLimit:]
+	}
+
+	return history
+}
+
+func convertToDeploymentSource(source appv1.ApplicationSource) managedgitopsv1alpha1.GitOpsDeploymentSource {
+	return managedgitopsv1alpha1.GitOpsDeploymentSource{
+		Path:    source.Path,
+		RepoURL: source.RepoURL,
+		Branch:  source.TargetRevision,
+	}
+}
@@ -80,7 +80,7 @@ var _ = Describe("Application Controller", func() {
 					},
 				},
 				Spec: appv1.ApplicationSpec{
-					Source: appv1.ApplicationSource{
+					Source: &appv1.ApplicationSource{
 						Path:           "guestbook",
 						TargetRevision: "HEAD",
 						RepoURL:        "https://github.com/argoproj/argocd-example-apps.git",
@@ -379,7 +379,7 @@ var _ = Describe("Application Controller", func() {
 					},
 				},
 				Spec: appv1.ApplicationSpec{
-					Source: appv1.ApplicationSource{
+					Source: &appv1.ApplicationSource{
 						Path:           "guestbook",
 						TargetRevision: "HEAD",
 						RepoURL:        "https://github.com/argoproj/argocd-example-apps.git",
@@ -489,7 +489,7 @@ var _ = Describe("Application Controller", func() {
 					},
 				},
 				Spec: appv1.ApplicationSpec{
-					Source: appv1.ApplicationSource{
+					Source: &appv1.ApplicationSource{
 						Path:           "guestbook",
 						TargetRevision: "HEAD",
 						RepoURL:        "https://github.com/argoproj/argocd-example-apps.git",
@@ -750,7 +750,7 @@ var _ = Describe("Application Controller", func() {
 					},
 				},
 				Spec: appv1.ApplicationSpec{
-					Source: appv1.ApplicationSource{
+					Source: &appv1.ApplicationSource{
 						Path:           "guestbook",
 						TargetRevision: "HEAD",
 						RepoURL:        "https://github.com/argoproj/argocd-example-apps.git",
@@ -934,6 +934,53 @@ var _ = Describe("Application Controller", func() {
 		})
 	})
 
+	Context("Test storeInComparedToFieldInApplicationState function", func() {
+		It("should store the sources of an Application with multiple sources", func() {
+			argoCDApp := appv1.Application{
+				Status: appv1.ApplicationStatus{
+					Sync: appv1.SyncStatus{
+						ComparedTo: appv1.ComparedTo{
+							Sources: appv1.ApplicationSources{
+								{
+									RepoURL:        "https://github.com/my-org/my-chart",
+									Path:           "chart",
+									TargetRevision: "main",
+								},
+								{
+									RepoURL: "https://github.com/my-org/my-values",
+									Ref:     "values",
+								},
+							},
+							Destination: appv1.ApplicationDestination{
+								Namespace: "test-namespace",
+								Name:      "in-cluster",
+							},
+						},
+					},
+				},
+			}
+
+			reconciledState, err := storeInComparedToFieldInApplicationState(argoCDApp, db.ApplicationState{})
+			Expect(err).ToNot(HaveOccurred())
+
+			comparedTo := fauxargocd.FauxComparedTo{}
+			err = json.Unmarshal([]byte(reconciledState), &comparedTo)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(comparedTo.Sources).To(Equal(fauxargocd.ApplicationSources{
+				{
+					RepoURL:        "https://github.com/my-org/my-chart",
+					Path:           "chart",
+					TargetRevision: "main",
+				},
+				{
+					RepoURL: "https://github.com/my-org/my-values",
+				},
+			}))
+			Expect(comparedTo.Destination.Namespace).To(Equal("test-namespace"))
+		})
+	})
+
 	Context("Test compressObject function", func() {
 		It("Should compress resource data into byte array", func() {
 			resourceStatus := appv1.ResourceStatus{
@@ -1008,6 +1055,137 @@ var _ = Describe("Application Controller", func() {
 			Expect(byteArr).NotTo(BeEmpty())
 		})
 	})
+
+	Context("Test updateDeploymentHistory function", func() {
+
+		var app appv1.Application
+		var startedAt, finishedAt metav1.Time
+
+		BeforeEach(func() {
+			startedAt = metav1.NewTime(time.Now().Add(-time.Minute))
+			finishedAt = metav1.NewTime(time.Now())
+
+			app = appv1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-app",
+					Namespace: "gitops-service-argocd",
+				},
+			}
+		})
+
+		It("should return an empty history if the Application has not been synced", func() {
+			Expect(updateDeploymentHistory(nil, app)).To(BeEmpty())
+		})
+
+		It("should record a failed sync from the operation state, which is not in Argo CD's history", func() {
+			app.Status.OperationState = &appv1.OperationState{
+				Operation: appv1.Operation{
+					Sync:        &appv1.SyncOperation{Revision: "abc123"},
+					InitiatedBy: appv1.OperationInitiator{Username: "jane"},
+				},
+				Phase:      common.OperationFailed,
+				Message:    "one or more objects failed to apply",
+				StartedAt:  startedAt,
+				FinishedAt: &finishedAt,
+			}
+
+			history := updateDeploymentHistory(nil, app)
+			Expect(history).To(HaveLen(1))
+			Expect(history[0].ID).To(BeNil())
+			Expect(history[0].Revision).To(Equal("abc123"))
+			Expect(history[0].InitiatedBy.Username).To(Equal("jane"))
+			Expect(history[0].SyncResult).To(Equal(managedgitopsv1alpha1.OperationFailed))
+			Expect(history[0].Message).To(Equal("one or more objects failed to apply"))
+			Expect(history[0].DeployStartedAt.Equal(&startedAt)).To(BeTrue())
+			Expect(history[0].DeployedAt.Equal(&finishedAt)).To(BeTrue())
+
+			By("calling it again with the same operation state, no new entry should be added")
+			history = updateDeploymentHistory(history, app)
+			Expect(history).To(HaveLen(1))
+		})
+
+		It("should not record a dry-run sync, as it made no changes to the cluster", func() {
+			app.Status.OperationState = &appv1.OperationState{
+				Operation: appv1.Operation{
+					Sync:        &appv1.SyncOperation{Revision: "abc123", DryRun: true},
+					InitiatedBy: appv1.OperationInitiator{Username: "jane"},
+				},
+				Phase:      common.OperationSucceeded,
+				StartedAt:  startedAt,
+				FinishedAt: &finishedAt,
+				SyncResult: &appv1.SyncOperationResult{Revision: "abc123"},
+			}
+
+			Expect(updateDeploymentHistory(nil, app)).To(BeEmpty())
+		})
+
+		It("should not record an operation that is still running", func() {
+			app.Status.OperationState = &appv1.OperationState{
+				Phase:     common.OperationRunning,
+				StartedAt: startedAt,
+			}
+
+			Expect(updateDeploymentHistory(nil, app)).To(BeEmpty())
+		})
+
+		It("should merge a successful sync from the operation state with the corresponding Argo CD history entry", func() {
+			source := appv1.ApplicationSource{
+				RepoURL:        "https://github.com/test/test",
+				Path:           "environments/prod",
+				TargetRevision: "main",
+			}
+
+			app.Status.OperationState = &appv1.OperationState{
+				Operation: appv1.Operation{
+					InitiatedBy: appv1.OperationInitiator{Automated: true},
+				},
+				Phase:      common.OperationSucceeded,
+				StartedAt:  startedAt,
+				FinishedAt: &finishedAt,
+				SyncResult: &appv1.SyncOperationResult{
+					Revision: "abc123",
+					Source:   source,
+				},
+			}
+			app.Status.History = appv1.RevisionHistories{
+				{
+					ID:              5,
+					Revision:        "abc123",
+					Source:          source,
+					DeployStartedAt: &startedAt,
+					DeployedAt:      finishedAt,
+				},
+			}
+
+			history := updateDeploymentHistory(nil, app)
+			Expect(history).To(HaveLen(1))
+			Expect(*history[0].ID).To(Equal(int64(5)))
+			Expect(history[0].Revision).To(Equal("abc123"))
+			Expect(history[0].Source).To(Equal(managedgitopsv1alpha1.GitOpsDeploymentSource{
+				RepoURL: source.RepoURL,
+				Path:    source.Path,
+				Branch:  source.TargetRevision,
+			}))
+			Expect(history[0].InitiatedBy.Automated).To(BeTrue())
+			Expect(history[0].SyncResult).To(Equal(managedgitopsv1alpha1.OperationSucceeded))
+		})
+
+		It("should only keep the most recent entries, ordered from oldest to newest", func() {
+			for i := 1; i <= managedgitopsv1alpha1.DeploymentHistoryLimit+5; i++ {
+				deployStartedAt := metav1.NewTime(startedAt.Add(time.Duration(i) * time.Second))
+				app.Status.History = append(app.Status.History, appv1.RevisionHistory{
+					ID:              int64(i),
+					DeployStartedAt: &deployStartedAt,
+					DeployedAt:      metav1.NewTime(deployStartedAt.Add(time.Second)),
+				})
+			}
+
+			history := updateDeploymentHistory(nil, app)
+			Expect(history).To(HaveLen(managedgitopsv1alpha1.DeploymentHistoryLimit))
+			Expect(*history[0].ID).To(Equal(int64(6)))
+			Expect(*history[len(history)-1].ID).To(Equal(int64(managedgitopsv1alpha1.DeploymentHistoryLimit + 5)))
+		})
+	})
 })
 
 var _ = Describe("Namespace Reconciler Tests.", func() {
@@ -1318,7 +1496,7 @@ func createDummyApplicationData() (fauxargocd.FauxApplication, string, appv1.App
 			Namespace: "gitops-service-argocd",
 		},
 		Spec: appv1.ApplicationSpec{
-			Source: appv1.ApplicationSource{
+			Source: &appv1.ApplicationSource{
 				RepoURL:        "https://github.com/redhat-appstudio/managed-gitops",
 				Path:           "resources/test-data/sample-gitops-repository/environments/overlays/dev",
 				TargetRevision: "",
@@ -15,10 +15,12 @@ import (
 	dbutil "github.com/redhat-appstudio/managed-gitops/backend-shared/db/util"
 	sharedutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util"
 	argosharedutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util/argocd"
+	"github.com/redhat-appstudio/managed-gitops/backend-shared/util/fauxargocd"
 	logutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util/log"
 	"github.com/redhat-appstudio/managed-gitops/cluster-agent/controllers"
 	"github.com/redhat-appstudio/managed-gitops/cluster-agent/metrics"
 	"github.com/redhat-appstudio/managed-gitops/cluster-agent/utils"
+	goyaml "gopkg.in/yaml.v2"
 	corev1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -463,6 +465,29 @@ func (task *processOperationEventTask) internalPerformTask(taskContext context.C
 
 		return &dbOperation, shouldRetry, err
 
+	} else if dbOperation.Resource_type == db.OperationResourceType_ApplicationRefresh ||
+		dbOperation.Resource_type == db.OperationResourceType_ApplicationHardRefresh {
+
+		// Process a (hard) refresh of an Application
+		shouldRetry, err := processOperation_ApplicationRefresh(taskContext, dbOperation, *operationCR, operationConfigParams)
+
+		if err != nil {
+			log.Error(err, "error occurred on processing the application refresh operation")
+		}
+
+		return &dbOperation, shouldRetry, err
+
+	} else if dbOperation.Resource_type == db.OperationResourceType_ApplicationDiff {
+
+		// Process a request for the diff of an Application: the diff is returned via the result field of the Operation
+		shouldRetry, err := processOperation_ApplicationDiff(taskContext, &dbOperation, *operationCR, operationConfigParams)
+
+		if err != nil {
+			log.Error(err, "error occurred on processing the application diff operation")
+		}
+
+		return &dbOperation, shouldRetry, err
+
 	} else if dbOperation.Resource_type == db.OperationResourceType_GitOpsEngineInstance {
 
 		// Process a SyncOperation event
@@ -554,8 +579,11 @@ func processOperation_SyncOperation(ctx context.Context, dbOperation db.Operatio
 	// 3) Process the event, based on whether the SyncOperation is requesting an app sync, or a terminate.
 	if dbSyncOperation.DesiredState == db.SyncOperation_DesiredState_Running {
 		// refresh the Application before syncing to make sure that the latest revision is deployed.
-		if err := opConfig.syncFuncs.refreshApp(ctx, opConfig.eventClient, dbApplication.Name, opConfig.argoCDNamespace.Name); err != nil {
-			return shouldRetryTrue, err
+		// - a rollback deploys a previous revision, so no refresh is required.
+		if dbSyncOperation.RollbackHistoryID == nil {
+			if err := opConfig.syncFuncs.refreshApp(ctx, opConfig.eventClient, dbApplication.Name, opConfig.argoCDNamespace.Name); err != nil {
+				return shouldRetryTrue, err
+			}
 		}
 
 		return runAppSync(ctx, dbOperation, *dbSyncOperation, &dbApplication, opConfig)
@@ -570,7 +598,13 @@ func processOperation_SyncOperation(ctx context.Context, dbOperation db.Operatio
 	}
 }
 
+// refreshApplication requests a normal refresh of the Argo CD Application, and waits for the refresh to complete.
 func refreshApplication(ctx context.Context, k8sClient client.Client, appName, appNS string) error {
+	return refreshApplicationWithType(ctx, k8sClient, appName, appNS, appv1.RefreshTypeNormal)
+}
+
+// refreshApplicationWithType requests a refresh of the given type of the Argo CD Application, and waits for the refresh to complete.
+func refreshApplicationWithType(ctx context.Context, k8sClient client.Client, appName, appNS string, refreshType appv1.RefreshType) error {
 	appCR := &appv1.Application{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      appName,
@@ -588,9 +622,9 @@ func refreshApplication(ctx context.Context, k8sClient client.Client, appName, a
 		if appCR.Annotations == nil {
 			appCR.Annotations = map[string]string{}
 		}
-		refreshType, ok := appCR.Annotations[appv1.AnnotationKeyRefresh]
-		if !ok || refreshType != string(appv1.RefreshTypeNormal) {
-			appCR.Annotations[appv1.AnnotationKeyRefresh] = string(appv1.RefreshTypeNormal)
+		existingRefreshType, ok := appCR.Annotations[appv1.AnnotationKeyRefresh]
+		if !ok || existingRefreshType != string(refreshType) {
+			appCR.Annotations[appv1.AnnotationKeyRefresh] = string(refreshType)
 			return k8sClient.Update(ctx, appCR)
 		}
 		return nil
@@ -625,6 +659,110 @@ func waitForApplicationToRefresh(ctx context.Context, k8sClient client.Client, a
 	}
 }
 
+// Process an ApplicationRefresh/ApplicationHardRefresh Operation, by requesting that Argo CD refreshes the Application
+// that is pointed to by the Operation, and waiting for the refresh to complete.
+// returns shouldRetry, error
+func processOperation_ApplicationRefresh(ctx context.Context, dbOperation db.Operation, crOperation operation.Operation,
+	opConfig operationConfig) (bool, error) {
+
+	log := opConfig.log
+
+	// Sanity checks
+	if dbOperation.Resource_id == "" {
+		return shouldRetryFalse, fmt.Errorf("resource id was nil while processing operation: " + crOperation.Name)
+	}
+
+	// 1) Retrieve the Application DB entry pointed to by the Operation DB entry
+	dbApplication := db.Application{
+		Application_id: dbOperation.Resource_id,
+	}
+	if err := opConfig.dbQueries.GetApplicationById(ctx, &dbApplication); err != nil {
+
+		if db.IsResultNotFoundError(err) {
+			// On db row not found, there is nothing to refresh.
+			log.V(logutil.LogLevel_Debug).Info("Application '" + dbApplication.Application_id + "' DB entry was no longer available.")
+			return shouldRetryFalse, err
+		} else {
+			// On generic error, return true so the operation is retried.
+			log.Error(err, "Error occurred on retrieving Application: "+dbApplication.Application_id)
+			return shouldRetryTrue, err
+		}
+	}
+
+	refreshType := appv1.RefreshTypeNormal
+	if dbOperation.Resource_type == db.OperationResourceType_ApplicationHardRefresh {
+		refreshType = appv1.RefreshTypeHard
+	}
+
+	// 2) Refresh the Argo CD Application, and wait for Argo CD to complete the refresh
+	if err := refreshApplicationWithType(ctx, opConfig.eventClient, dbApplication.Name, opConfig.argoCDNamespace.Name, refreshType); err != nil {
+
+		if apierr.IsNotFound(err) {
+			log.Error(err, "Argo CD Application to refresh does not exist: "+dbApplication.Name)
+			return shouldRetryFalse, err
+		}
+
+		log.Error(err, "unable to refresh Argo CD Application: "+dbApplication.Name)
+		return shouldRetryTrue, err
+	}
+
+	log.Info("Successfully refreshed application '"+dbApplication.Name+"'", "refreshType", string(refreshType))
+
+	return shouldRetryFalse, nil
+}
+
+// Process an ApplicationDiff Operation, by retrieving the diff of each OutOfSync resource of the Application that is
+// pointed to by the Operation, from Argo CD. The diff is stored (compressed) in the 'Operation_result' field of the
+// Operation, which is written to the database once the Operation has completed.
+// returns shouldRetry, error
+func processOperation_ApplicationDiff(ctx context.Context, dbOperation *db.Operation, crOperation operation.Operation,
+	opConfig operationConfig) (bool, error) {
+
+	log := opConfig.log
+
+	// Sanity checks
+	if dbOperation.Resource_id == "" {
+		return shouldRetryFalse, fmt.Errorf("resource id was nil while processing operation: " + crOperation.Name)
+	}
+
+	// 1) Retrieve the Application DB entry pointed to by the Operation DB entry
+	dbApplication := db.Application{
+		Application_id: dbOperation.Resource_id,
+	}
+	if err := opConfig.dbQueries.GetApplicationById(ctx, &dbApplication); err != nil {
+
+		if db.IsResultNotFoundError(err) {
+			// On db row not found, there is nothing to diff.
+			log.V(logutil.LogLevel_Debug).Info("Application '" + dbApplication.Application_id + "' DB entry was no longer available.")
+			return shouldRetryFalse, err
+		} else {
+			// On generic error, return true so the operation is retried.
+			log.Error(err, "Error occurred on retrieving Application: "+dbApplication.Application_id)
+			return shouldRetryTrue, err
+		}
+	}
+
+	// 2) Retrieve the diff of the Argo CD Application
+	resourceDiffs, err := opConfig.syncFuncs.appDiff(ctx, dbApplication.Name, opConfig.argoCDNamespace, opConfig.credentialService,
+		opConfig.eventClient)
+	if err != nil {
+		log.Error(err, "unable to retrieve the diff of Argo CD Application: "+dbApplication.Name)
+		return shouldRetryTrue, err
+	}
+
+	// 3) Store the diff in the result field of the Operation
+	operationResult, err := sharedutil.CompressObject(resourceDiffs)
+	if err != nil {
+		log.Error(err, "unable to compress the diff of Argo CD Application: "+dbApplication.Name)
+		return shouldRetryFalse, err
+	}
+	dbOperation.Operation_result = operationResult
+
+	log.Info("Successfully retrieved the diff of application '"+dbApplication.Name+"'", "outOfSyncResources", len(resourceDiffs))
+
+	return shouldRetryFalse, nil
+}
+
 // returns shouldRetry, error
 func terminateExistingOperation(ctx context.Context, dbApplication *db.Application, opConfig operationConfig) (bool, error) {
 
@@ -671,19 +809,24 @@ func isOperationRunning(ctx context.Context, k8sClient client.Client, appName, a
 	return true, nil
 }
 
-// syncFuncs is a wrapper over sync and terminate functions and is used in unit testing different sync scenarios
+// syncFuncs is a wrapper over sync, rollback, terminate and diff functions and is used in unit testing different sync scenarios
 type syncFuncs struct {
-	appSync            func(context.Context, string, string, string, client.Client, *utils.CredentialService, bool) error
+	appSync            func(context.Context, string, string, utils.AppSyncOptions, string, client.Client, *utils.CredentialService, bool) error
+	appRollback        func(context.Context, string, int64, string, client.Client, *utils.CredentialService, bool) error
 	terminateOperation func(context.Context, string, corev1.Namespace, *utils.CredentialService, client.Client, time.Duration, logr.Logger) error
 
 	refreshApp func(context.Context, client.Client, string, string) error
+
+	appDiff func(context.Context, string, corev1.Namespace, *utils.CredentialService, client.Client) ([]operation.ResourceDiff, error)
 }
 
 func defaultSyncFuncs() *syncFuncs {
 	return &syncFuncs{
 		appSync:            utils.AppSync,
+		appRollback:        utils.AppRollback,
 		terminateOperation: utils.TerminateOperation,
 		refreshApp:         refreshApplication,
+		appDiff:            utils.AppDiff,
 	}
 }
 
@@ -701,15 +844,25 @@ func runAppSync(ctx context.Context, dbOperation db.Operation, dbSyncOperation d
 
 	defer cancelFunc()
 
-	// Start the AppSync operation in a separate thread.
+	isRollback := dbSyncOperation.RollbackHistoryID != nil
+
+	// Start the AppSync (or rollback) operation in a separate thread.
 	go func() {
-		err = opConfig.syncFuncs.appSync(cancellableCtx, dbApplication.Name, dbSyncOperation.Revision, opConfig.argoCDNamespace.Name, opConfig.eventClient,
-			opConfig.credentialService, false)
+		if isRollback {
+			err = rollbackApplication(cancellableCtx, dbApplication.Name, *dbSyncOperation.RollbackHistoryID, opConfig)
+		} else {
+			var syncOptions utils.AppSyncOptions
+			if syncOptions, err = convertToAppSyncOptions(dbSyncOperation); err == nil {
+				err = opConfig.syncFuncs.appSync(cancellableCtx, dbApplication.Name, dbSyncOperation.Revision, syncOptions, opConfig.argoCDNamespace.Name,
+					opConfig.eventClient, opConfig.credentialService, false)
+			}
+		}
 
 		var failed bool
 		if err != nil {
-			log.Error(err, "app sync failed on application '"+dbApplication.Name+"'")
-			failed = true
+			log.Error(err, "app sync failed on application '"+dbApplication.Name+"'", "rollback", isRollback)
+			// A failed rollback is not retried: instead, the failure is reported to the user.
+			failed = !isRollback
 		}
 
 		// signal the AppSync has exited
@@ -761,15 +914,113 @@ outer:
 	return shouldRetry, err
 }
 
+// rollbackApplication rolls back the Argo CD Application to the revision history entry with the given ID.
+//
+// Argo CD does not allow rolling back an Application with automated sync enabled, so automated sync is disabled before
+// the rollback. If the rollback succeeds, automated sync remains disabled, as otherwise Argo CD would immediately sync
+// the Application back to the latest revision: the backend likewise disables it in the Application DB row, until the
+// spec of the GitOpsDeployment is changed. If the rollback fails, automated sync is restored.
+func rollbackApplication(ctx context.Context, appName string, historyID int64, opConfig operationConfig) error {
+
+	appKey := client.ObjectKey{Name: appName, Namespace: opConfig.argoCDNamespace.Name}
+
+	var automated *appv1.SyncPolicyAutomated
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		app := &appv1.Application{}
+		if err := opConfig.eventClient.Get(ctx, appKey, app); err != nil {
+			return err
+		}
+		if app.Spec.SyncPolicy == nil || app.Spec.SyncPolicy.Automated == nil {
+			return nil
+		}
+		automated = app.Spec.SyncPolicy.Automated.DeepCopy()
+		app.Spec.SyncPolicy.Automated = nil
+		return opConfig.eventClient.Update(ctx, app)
+	}); err != nil {
+		return fmt.Errorf("unable to disable automated sync of Application '%s' before rollback: %v", appName, err)
+	}
+
+	if automated != nil {
+		opConfig.log.Info("Disabled automated sync of Application before the rollback", "application", appName)
+	}
+
+	rollbackErr := opConfig.syncFuncs.appRollback(ctx, appName, historyID, opConfig.argoCDNamespace.Name, opConfig.eventClient,
+		opConfig.credentialService, false)
+
+	if rollbackErr != nil && automated != nil {
+		// Use a new context, as the rollback context may have been cancelled.
+		if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			app := &appv1.Application{}
+			if err := opConfig.eventClient.Get(context.Background(), appKey, app); err != nil {
+				return err
+			}
+			if app.Spec.SyncPolicy == nil {
+				app.Spec.SyncPolicy = &appv1.SyncPolicy{}
+			}
+			if app.Spec.SyncPolicy.Automated != nil {
+				// Automated sync was re-enabled in the meantime (for example, by an update to the GitOpsDeployment)
+				return nil
+			}
+			app.Spec.SyncPolicy.Automated = automated
+			return opConfig.eventClient.Update(context.Background(), app)
+		}); err != nil {
+			opConfig.log.Error(err, "unable to restore automated sync of Application after failed rollback", "application", appName)
+		}
+	}
+
+	return rollbackErr
+}
+
+// convertToAppSyncOptions converts the sync options of a SyncOperation (as set from the GitOpsDeploymentSyncRun) into
+// the options of an Argo CD sync.
+func convertToAppSyncOptions(dbSyncOperation db.SyncOperation) (utils.AppSyncOptions, error) {
+
+	syncOptions := utils.AppSyncOptions{
+		Prune:    dbSyncOperation.Prune,
+		DryRun:   dbSyncOperation.DryRun,
+		Force:    dbSyncOperation.Force,
+		Strategy: dbSyncOperation.SyncStrategy,
+	}
+
+	if len(dbSyncOperation.Resources) == 0 {
+		return syncOptions, nil
+	}
+
+	resourcesBytes, err := sharedutil.DecompressObject(dbSyncOperation.Resources)
+	if err != nil {
+		return syncOptions, fmt.Errorf("failed to decompress sync operation resources: %v", err)
+	}
+
+	var resources []operation.SyncOperationResource
+	if err := goyaml.Unmarshal(resourcesBytes, &resources); err != nil {
+		return syncOptions, fmt.Errorf("unable to unmarshal sync operation resources: %v", err)
+	}
+
+	for _, resource := range resources {
+		syncOptions.Resources = append(syncOptions.Resources, appv1.SyncOperationResource{
+			Group:     resource.Group,
+			Kind:      resource.Kind,
+			Name:      resource.Name,
+			Namespace: resource.Namespace,
+		})
+	}
+
+	return syncOptions, nil
+}
+
 // processOperation_ManagedEnvironment handles an Operation that targets an Application.
 // Returns true if the task should be retried (eg due to failure), false otherwise.
 func processOperation_ManagedEnvironment(ctx context.Context, dbOperation db.Operation, crOperation operation.Operation,
 	opConfig operationConfig) (bool, error) {
 
-	// The only operation we currently support for managed environment is deletion (creation is handled by Application operations).
-	// Thus, we expect the ManagedEnvironment database entry here to not be found.
+	// Creation of managed environments is handled by Application operations. An operation on a managed environment
+	// indicates either that:
+	// - the managed environment was deleted, in which case the ManagedEnvironment database entry will not be found, or
+	// - the cluster credentials of the managed environment were replaced (for example, because the ServiceAccount token
+	//   was rotated), in which case the Argo CD cluster secret should be updated with the new credentials.
 
-	// 1) Make sure the managed environment db entry DOESN'T exist (see above)
+	// 1) If the managed environment db entry still exists, ensure the Argo CD cluster secret is up to date
 	{
 		managedEnv := &db.ManagedEnvironment{
 			Managedenvironment_id: dbOperation.Resource_id, // managed env id referencing managed env row
@@ -779,8 +1030,11 @@ func processOperation_ManagedEnvironment(ctx context.Context, dbOperation db.Ope
 				return shouldRetryTrue, fmt.Errorf("an unexpected error occcurred on retrieving managed env: %v", err)
 			}
 		} else {
-			// The database entry still exists, so return an error
-			return shouldRetryFalse, fmt.Errorf("managed environment still exists in the database")
+			if err := ensureManagedEnvironmentExists(ctx, db.Application{Managed_environment_id: managedEnv.Managedenvironment_id}, opConfig); err != nil {
+				return shouldRetryTrue, fmt.Errorf("unable to update the Argo CD cluster secret of managed environment '%s': %v",
+					managedEnv.Managedenvironment_id, err)
+			}
+			return shouldRetryFalse, nil
 		}
 	}
 
@@ -844,6 +1098,12 @@ func processOperation_Application(ctx context.Context, dbOperation db.Operation,
 		}
 	}
 
+	deletionPolicy, err := specFieldDeletionPolicy(dbApplication.Spec_field)
+	if err != nil {
+		log.Error(err, "SEVERE: unable to unmarshal application spec field, while retrieving the deletion policy")
+		return shouldRetryFalse, nil
+	}
+
 	if shouldRetry, err := createOrUpdateAppProjectWithValidation(ctx, dbOperation, opConfig, log); err != nil {
 		log.Error(err, "failed to call createOrUpdateAppProjectWithValidation function")
 		return shouldRetry, err
@@ -870,7 +1130,7 @@ func processOperation_Application(ctx context.Context, dbOperation db.Operation,
 			}
 
 			// Copy the contents of the Spec_field database column, into the Spec field of the Argo CD Application CR
-			if err := yaml.Unmarshal([]byte(dbApplication.Spec_field), app); err != nil {
+			if err := controllers.UnmarshalApplicationSpecField(dbApplication.Spec_field, app); err != nil {
 				log.Error(err, "SEVERE: unable to unmarshal application spec field on creating Application CR.")
 				// We return nil here, because there's likely nothing else that can be done to fix this.
 				// Thus there is no need to keep retrying.
@@ -880,6 +1140,8 @@ func processOperation_Application(ctx context.Context, dbOperation db.Operation,
 			// Add databaseID label
 			app.ObjectMeta.Labels = map[string]string{controllers.ArgoCDApplicationDatabaseIDLabel: dbApplication.Application_id}
 
+			setDeletionPolicyAnnotation(app, deletionPolicy)
+
 			// Before we create the application, make sure that the managed environment exists that the application points to
 			if app.Spec.Destination.Name != argosharedutil.ArgoCDDefaultDestinationInCluster {
 				if err := ensureManagedEnvironmentExists(ctx, *dbApplication, opConfig); err != nil {
@@ -918,10 +1180,14 @@ func processOperation_Application(ctx context.Context, dbOperation db.Operation,
 		return shouldRetryFalse, err
 	}
 
+	if specDiff == "" && app.Annotations[argosharedutil.ArgoCDApplicationDeletionPolicyAnnotation] != deletionPolicy {
+		specDiff = "deletion policy differs"
+	}
+
 	if specDiff != "" {
 		specFieldApp := &appv1.Application{}
 
-		if err := yaml.Unmarshal([]byte(dbApplication.Spec_field), specFieldApp); err != nil {
+		if err := controllers.UnmarshalApplicationSpecField(dbApplication.Spec_field, specFieldApp); err != nil {
 			log.Error(err, "SEVERE: unable to unmarshal DB application spec field, on updating existing Application cR: "+app.Name)
 			// We return nil here, with no retry, because there's likely nothing else that can be done to fix this.
 			// Thus there is no need to keep retrying.
@@ -930,8 +1196,11 @@ func processOperation_Application(ctx context.Context, dbOperation db.Operation,
 
 		app.Spec.Destination = specFieldApp.Spec.Destination
 		app.Spec.Source = specFieldApp.Spec.Source
+		app.Spec.Sources = specFieldApp.Spec.Sources
 		app.Spec.Project = specFieldApp.Spec.Project
 		app.Spec.SyncPolicy = specFieldApp.Spec.SyncPolicy
+		app.Spec.IgnoreDifferences = specFieldApp.Spec.IgnoreDifferences
+		setDeletionPolicyAnnotation(app, deletionPolicy)
 
 		if err := opConfig.eventClient.Update(ctx, app); err != nil {
 			log.Error(err, "unable to update application after difference detected.")
@@ -957,6 +1226,32 @@ func processOperation_Application(ctx context.Context, dbOperation db.Operation,
 	return shouldRetryFalse, nil
 }
 
+// specFieldDeletionPolicy returns the deletion policy of the Application spec field (from the database), or "" if
+// the default deletion policy should be used.
+func specFieldDeletionPolicy(specField string) (string, error) {
+	specFieldApp := fauxargocd.FauxApplication{}
+
+	if err := yaml.Unmarshal([]byte(specField), &specFieldApp); err != nil {
+		return "", err
+	}
+
+	return specFieldApp.Annotations[argosharedutil.ArgoCDApplicationDeletionPolicyAnnotation], nil
+}
+
+// setDeletionPolicyAnnotation sets the deletion policy annotation of the Argo CD Application, which is used when the
+// Application is deleted. If the deletion policy is empty, the annotation is removed.
+func setDeletionPolicyAnnotation(app *appv1.Application, deletionPolicy string) {
+	if deletionPolicy == "" {
+		delete(app.Annotations, argosharedutil.ArgoCDApplicationDeletionPolicyAnnotation)
+		return
+	}
+
+	if app.Annotations == nil {
+		app.Annotations = map[string]string{}
+	}
+	app.Annotations[argosharedutil.ArgoCDApplicationDeletionPolicyAnnotation] = deletionPolicy
+}
+
 // Delete all Argo CD Applications that reference a specific Application row
 func deleteArgoCDApplicationOfDeletedApplicationRow(ctx context.Context, dbApplicationID string, dbOperation db.Operation, opConfig operationConfig, log logr.Logger) (bool, error) {
 	// Find the Application that has the corresponding databaseID label
@@ -1287,7 +1582,11 @@ func generateExpectedClusterSecret(ctx context.Context, application db.Applicati
 	clusterSecretConfigJSON := argosharedutil.ClusterSecretConfigJSON{
 		BearerToken: bearerToken,
 		TLSClientConfig: argosharedutil.ClusterSecretTLSClientConfigJSON{
-			Insecure: insecureVerifyTLS,
+			Insecure:   insecureVerifyTLS,
+			ServerName: clusterCredentials.Tls_server_name,
+			CAData:     []byte(clusterCredentials.Ca_bundle),
+			CertData:   []byte(clusterCredentials.Client_certificate_data),
+			KeyData:    []byte(clusterCredentials.Client_key_data),
 		},
 	}
 
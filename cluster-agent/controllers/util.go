@@ -7,8 +7,10 @@ import (
 
 	appv1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	"github.com/go-logr/logr"
+	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
 	"github.com/redhat-appstudio/managed-gitops/backend-shared/db"
 	sharedutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util"
+	argosharedutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util/argocd"
 	logutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util/log"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -22,7 +24,8 @@ const (
 )
 
 const (
-	argoCDResourcesFinalizer = "resources-finalizer.argocd.argoproj.io/background"
+	argoCDResourcesFinalizer           = "resources-finalizer.argocd.argoproj.io/background"
+	argoCDResourcesForegroundFinalizer = "resources-finalizer.argocd.argoproj.io"
 )
 
 const (
@@ -33,6 +36,8 @@ const (
 )
 
 // DeleteArgoCDApplication attempts to gracefully delete an Argo CD application:
+// - Ensure the Argo CD resources finalizer matches the deletion policy of the Application (if the deletion policy is
+// 'Orphan', the finalizer is removed, so that Argo CD does not delete the resources of the Application)
 // - Issue a Delete to K8s API
 // - If the Application is not deleted after X minutes, remove the finalizer
 // - If the Application is not deleted after X+2 minutes, return an error
@@ -67,18 +72,24 @@ func DeleteArgoCDApplication(ctx context.Context, appFromList appv1.Application,
 
 	if app.DeletionTimestamp == nil {
 
-		// Ensure finalizer is set
+		resourcesFinalizer, policy := getDeletionFinalizerAndPropagationPolicy(app.Annotations[argosharedutil.ArgoCDApplicationDeletionPolicyAnnotation])
+
+		log = log.WithValues("deletionPolicy", app.Annotations[argosharedutil.ArgoCDApplicationDeletionPolicyAnnotation])
+
+		// Ensure the finalizer is set (or, for 'Orphan', not set)
 		{
-			containsFinalizer := false
+			finalizers := []string{}
 			for _, finalizer := range app.Finalizers {
-				if finalizer == argoCDResourcesFinalizer {
-					containsFinalizer = true
-					break
+				if finalizer != argoCDResourcesFinalizer && finalizer != argoCDResourcesForegroundFinalizer {
+					finalizers = append(finalizers, finalizer)
 				}
 			}
+			if resourcesFinalizer != "" {
+				finalizers = append(finalizers, resourcesFinalizer)
+			}
 
-			if !containsFinalizer {
-				app.Finalizers = append(app.Finalizers, argoCDResourcesFinalizer)
+			if len(finalizers) != len(app.Finalizers) || (len(finalizers) > 0 && !reflect.DeepEqual(finalizers, app.Finalizers)) {
+				app.Finalizers = finalizers
 				if err := eventClient.Update(ctx, app); err != nil {
 					log.Error(err, "unable to update application with finalizer")
 					return err
@@ -88,8 +99,7 @@ func DeleteArgoCDApplication(ctx context.Context, appFromList appv1.Application,
 			}
 		}
 
-		// Tell K8s to start deleting the Application, which triggers Argo CD to delete children
-		policy := metav1.DeletePropagationForeground
+		// Tell K8s to start deleting the Application, which triggers Argo CD to delete children (unless orphaned)
 		if err := eventClient.Delete(ctx, app, &client.DeleteOptions{PropagationPolicy: &policy}); err != nil {
 			log.Error(err, "unable to delete application with finalizer")
 			return err
@@ -195,6 +205,38 @@ func DeleteArgoCDApplication(ctx context.Context, appFromList appv1.Application,
 	return nil
 }
 
+// getDeletionFinalizerAndPropagationPolicy returns the Argo CD resources finalizer (or "", if none) and the
+// K8s propagation policy that should be used to delete an Argo CD Application, based on its deletion policy.
+func getDeletionFinalizerAndPropagationPolicy(deletionPolicy string) (string, metav1.DeletionPropagation) {
+
+	switch managedgitopsv1alpha1.DeletionPolicy(deletionPolicy) {
+	case managedgitopsv1alpha1.DeletionPolicy_Orphan:
+		// Without a resources finalizer, Argo CD will not delete the resources of the Application
+		return "", metav1.DeletePropagationOrphan
+	case managedgitopsv1alpha1.DeletionPolicy_CascadeForeground:
+		return argoCDResourcesForegroundFinalizer, metav1.DeletePropagationForeground
+	default:
+		// Cascade-Background is the default
+		return argoCDResourcesFinalizer, metav1.DeletePropagationForeground
+	}
+}
+
+// UnmarshalApplicationSpecField unmarshals the spec field of a DB Application row into an Argo CD Application.
+//
+// The spec field always contains a 'source' field, which is empty for an application with multiple sources: Argo CD
+// expects only the 'sources' field to be set in this case, so the 'source' field is removed.
+func UnmarshalApplicationSpecField(specField string, app *appv1.Application) error {
+	if err := yaml.Unmarshal([]byte(specField), app); err != nil {
+		return err
+	}
+
+	if app.Spec.HasMultipleSources() {
+		app.Spec.Source = nil
+	}
+
+	return nil
+}
+
 // CompareApplication compares an Argo CD Application and the spec field of a DB Application row, returning "" if the same,
 // otherwise returning the specific difference.
 func CompareApplication(argoCDApp appv1.Application, dbApplication db.Application, log logr.Logger) (string, error) {
@@ -208,13 +250,37 @@ func CompareApplication(argoCDApp appv1.Application, dbApplication db.Applicatio
 				input.Spec.SyncPolicy.SyncOptions = appv1.SyncOptions{}
 			}
 		}
+		if input.Spec.Source != nil {
+			sanitizeApplicationSource(input.Spec.Source)
+		}
+		for i := range input.Spec.Sources {
+			sanitizeApplicationSource(&input.Spec.Sources[i])
+		}
+		if len(input.Spec.Sources) == 0 {
+			input.Spec.Sources = nil
+		}
+		for i := range input.Spec.IgnoreDifferences {
+			ignoreDifferences := &input.Spec.IgnoreDifferences[i]
+			if len(ignoreDifferences.JSONPointers) == 0 {
+				ignoreDifferences.JSONPointers = []string{}
+			}
+			if len(ignoreDifferences.JQPathExpressions) == 0 {
+				ignoreDifferences.JQPathExpressions = []string{}
+			}
+			if len(ignoreDifferences.ManagedFieldsManagers) == 0 {
+				ignoreDifferences.ManagedFieldsManagers = []string{}
+			}
+		}
+		if len(input.Spec.IgnoreDifferences) == 0 {
+			input.Spec.IgnoreDifferences = nil
+		}
 		return input
 	}
 	argoCDApp = sanitizeApp(*argoCDApp.DeepCopy())
 
 	specFieldAppFromDB := appv1.Application{}
 
-	if err := yaml.Unmarshal([]byte(dbApplication.Spec_field), &specFieldAppFromDB); err != nil {
+	if err := UnmarshalApplicationSpecField(dbApplication.Spec_field, &specFieldAppFromDB); err != nil {
 		log.Error(err, "SEVERE: unable to unmarshal DB application spec field, on updating existing Application CR: "+argoCDApp.Name)
 		// We return nil here, with no retry, because there's likely nothing else that can be done to fix this.
 		// Thus there is no need to keep retrying.
@@ -224,12 +290,30 @@ func CompareApplication(argoCDApp appv1.Application, dbApplication db.Applicatio
 	specFieldAppFromDB = sanitizeApp(specFieldAppFromDB)
 
 	var specDiff string
-	if !reflect.DeepEqual(specFieldAppFromDB.Spec.Source, argoCDApp.Spec.Source) {
+	if (specFieldAppFromDB.Spec.Source == nil) != (argoCDApp.Spec.Source == nil) {
 		specDiff = "spec.source fields differ"
+	} else if specFieldAppFromDB.Spec.Source != nil &&
+		!reflect.DeepEqual(specFieldAppFromDB.Spec.Source.Helm, argoCDApp.Spec.Source.Helm) {
+		specDiff = "spec.source.helm fields differ"
+	} else if specFieldAppFromDB.Spec.Source != nil &&
+		!reflect.DeepEqual(specFieldAppFromDB.Spec.Source.Kustomize, argoCDApp.Spec.Source.Kustomize) {
+		specDiff = "spec.source.kustomize fields differ"
+	} else if !reflect.DeepEqual(specFieldAppFromDB.Spec.Source, argoCDApp.Spec.Source) {
+		specDiff = "spec.source fields differ"
+	} else if !reflect.DeepEqual(specFieldAppFromDB.Spec.Sources, argoCDApp.Spec.Sources) {
+		specDiff = "spec.sources fields differ"
 	} else if !reflect.DeepEqual(specFieldAppFromDB.Spec.Destination, argoCDApp.Spec.Destination) {
 		specDiff = "spec.destination fields differ"
 	} else if specFieldAppFromDB.Spec.Project != argoCDApp.Spec.Project {
 		specDiff = "spec project fields differ"
+	} else if !reflect.DeepEqual(specFieldAppFromDB.Spec.IgnoreDifferences, argoCDApp.Spec.IgnoreDifferences) {
+		specDiff = "spec.ignoreDifferences fields differ"
+	} else if specFieldAppFromDB.Spec.SyncPolicy != nil && argoCDApp.Spec.SyncPolicy != nil &&
+		!reflect.DeepEqual(specFieldAppFromDB.Spec.SyncPolicy.Automated, argoCDApp.Spec.SyncPolicy.Automated) {
+		specDiff = "spec.syncPolicy.automated fields differ"
+	} else if specFieldAppFromDB.Spec.SyncPolicy != nil && argoCDApp.Spec.SyncPolicy != nil &&
+		!reflect.DeepEqual(specFieldAppFromDB.Spec.SyncPolicy.Retry, argoCDApp.Spec.SyncPolicy.Retry) {
+		specDiff = "spec.syncPolicy.retry fields differ"
 	} else if !reflect.DeepEqual(specFieldAppFromDB.Spec.SyncPolicy, argoCDApp.Spec.SyncPolicy) {
 		specDiff = "sync policy fields differ"
 	}
@@ -237,3 +321,33 @@ func CompareApplication(argoCDApp appv1.Application, dbApplication db.Applicatio
 	return specDiff, nil
 
 }
+
+// sanitizeApplicationSource ensures that the empty slices and maps of an Argo CD ApplicationSource are defined the
+// same way, so that they may be compared with reflect.DeepEqual.
+func sanitizeApplicationSource(source *appv1.ApplicationSource) {
+	if source.Helm != nil {
+		if len(source.Helm.ValueFiles) == 0 {
+			source.Helm.ValueFiles = []string{}
+		}
+		if len(source.Helm.Parameters) == 0 {
+			source.Helm.Parameters = []appv1.HelmParameter{}
+		}
+		if len(source.Helm.FileParameters) == 0 {
+			source.Helm.FileParameters = []appv1.HelmFileParameter{}
+		}
+	}
+	if source.Kustomize != nil {
+		if len(source.Kustomize.Images) == 0 {
+			source.Kustomize.Images = appv1.KustomizeImages{}
+		}
+		if len(source.Kustomize.CommonLabels) == 0 {
+			source.Kustomize.CommonLabels = map[string]string{}
+		}
+		if len(source.Kustomize.CommonAnnotations) == 0 {
+			source.Kustomize.CommonAnnotations = map[string]string{}
+		}
+		if len(source.Kustomize.Patches) == 0 {
+			source.Kustomize.Patches = appv1.KustomizePatches{}
+		}
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
 	"github.com/redhat-appstudio/managed-gitops/backend-shared/db"
+	argosharedutil "github.com/redhat-appstudio/managed-gitops/backend-shared/util/argocd"
 	"github.com/redhat-appstudio/managed-gitops/backend-shared/util/fauxargocd"
 	"github.com/redhat-appstudio/managed-gitops/backend-shared/util/tests"
 	corev1 "k8s.io/api/core/v1"
@@ -188,6 +190,57 @@ var _ = Describe("Tests for the small number of utility functions in cluster-age
 
 		})
 
+		It("should remove the resources finalizer, and delete the Argo CD Application, if the deletion policy is Orphan", func() {
+
+			By("creating an Argo CD Application with a finalizer, a databaseID label, and the Orphan deletion policy")
+			application := appv1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-name",
+					Namespace: "my-namespace",
+					Labels: map[string]string{
+						ArgoCDApplicationDatabaseIDLabel: "test-my-database-id-label",
+					},
+					Annotations: map[string]string{
+						argosharedutil.ArgoCDApplicationDeletionPolicyAnnotation: string(managedgitopsv1alpha1.DeletionPolicy_Orphan),
+					},
+					Finalizers: []string{
+						argoCDResourcesFinalizer,
+					},
+				},
+			}
+			err := k8sClient.Create(ctx, &application)
+			Expect(err).ToNot(HaveOccurred())
+
+			By("calling the DeleteArgoCDApplication function: as there is no finalizer, Argo CD is not required to delete the Application")
+			err = DeleteArgoCDApplication(ctx, application, k8sClient, logger)
+			Expect(err).ToNot(HaveOccurred())
+
+			err = k8sClient.Get(ctx, client.ObjectKeyFromObject(&application), &application)
+			Expect(err).To(HaveOccurred(), "Application should not exist: it should have been deleted")
+
+		})
+
+	})
+
+	Context("Testing for getDeletionFinalizerAndPropagationPolicy function.", func() {
+
+		It("should return the Argo CD resources finalizer and propagation policy of each deletion policy", func() {
+			finalizer, policy := getDeletionFinalizerAndPropagationPolicy("")
+			Expect(finalizer).To(Equal(argoCDResourcesFinalizer))
+			Expect(policy).To(Equal(metav1.DeletePropagationForeground))
+
+			finalizer, policy = getDeletionFinalizerAndPropagationPolicy(string(managedgitopsv1alpha1.DeletionPolicy_CascadeBackground))
+			Expect(finalizer).To(Equal(argoCDResourcesFinalizer))
+			Expect(policy).To(Equal(metav1.DeletePropagationForeground))
+
+			finalizer, policy = getDeletionFinalizerAndPropagationPolicy(string(managedgitopsv1alpha1.DeletionPolicy_CascadeForeground))
+			Expect(finalizer).To(Equal(argoCDResourcesForegroundFinalizer))
+			Expect(policy).To(Equal(metav1.DeletePropagationForeground))
+
+			finalizer, policy = getDeletionFinalizerAndPropagationPolicy(string(managedgitopsv1alpha1.DeletionPolicy_Orphan))
+			Expect(finalizer).To(BeEmpty())
+			Expect(policy).To(Equal(metav1.DeletePropagationOrphan))
+		})
 	})
 
 	Context("Testing for CompareApplications function.", func() {
@@ -199,7 +252,7 @@ var _ = Describe("Tests for the small number of utility functions in cluster-age
 					Namespace: "gitops-service-argocd",
 				},
 				Spec: appv1.ApplicationSpec{
-					Source: appv1.ApplicationSource{
+					Source: &appv1.ApplicationSource{
 						RepoURL:        "https://github.com/redhat-appstudio/managed-gitops",
 						Path:           "resources/test-data/sample-gitops-repository/environments/overlays/dev",
 						TargetRevision: "",
@@ -332,6 +385,242 @@ var _ = Describe("Tests for the small number of utility functions in cluster-age
 			applicationFromArgoCD.Spec.SyncPolicy.Automated.AllowEmpty = applicationFromDB.Spec.SyncPolicy.Automated.AllowEmpty
 		})
 
+		It("Should detect differences in the automated and retry fields of the sync policy", func() {
+
+			applicationFromDB, _, applicationFromArgoCD, err := createDummyApplicationData()
+			Expect(err).ToNot(HaveOccurred())
+
+			factor := int64(2)
+			applicationFromDB.Spec.SyncPolicy.Retry = &fauxargocd.RetryStrategy{
+				Limit:   5,
+				Backoff: &fauxargocd.Backoff{Duration: "5s", Factor: &factor, MaxDuration: "3m"},
+			}
+			yamlData, err := yaml.Marshal(applicationFromDB)
+			Expect(err).ToNot(HaveOccurred())
+			dbApp := db.Application{Spec_field: string(yamlData)}
+
+			var ctx context.Context
+			log := log.FromContext(ctx)
+
+			By("retry is set in the DB, but not in Argo CD, so the applications differ")
+			result, err := CompareApplication(applicationFromArgoCD, dbApp, log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal("spec.syncPolicy.retry fields differ"))
+
+			By("retry is the same in both, so the applications are the same")
+			applicationFromArgoCD.Spec.SyncPolicy.Retry = &appv1.RetryStrategy{
+				Limit:   5,
+				Backoff: &appv1.Backoff{Duration: "5s", Factor: &factor, MaxDuration: "3m"},
+			}
+			result, err = CompareApplication(applicationFromArgoCD, dbApp, log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeEmpty())
+
+			By("selfHeal differs, so the applications differ")
+			applicationFromArgoCD.Spec.SyncPolicy.Automated.SelfHeal = true
+			result, err = CompareApplication(applicationFromArgoCD, dbApp, log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal("spec.syncPolicy.automated fields differ"))
+		})
+
+		It("Should detect differences in the ignoreDifferences field", func() {
+
+			applicationFromDB, _, applicationFromArgoCD, err := createDummyApplicationData()
+			Expect(err).ToNot(HaveOccurred())
+
+			applicationFromDB.Spec.IgnoreDifferences = []fauxargocd.ResourceIgnoreDifferences{
+				{Group: "apps", Kind: "Deployment", JSONPointers: []string{"/spec/replicas"}},
+			}
+			yamlData, err := yaml.Marshal(applicationFromDB)
+			Expect(err).ToNot(HaveOccurred())
+			dbApp := db.Application{Spec_field: string(yamlData)}
+
+			var ctx context.Context
+			log := log.FromContext(ctx)
+
+			By("ignoreDifferences is set in the DB, but not in Argo CD, so the applications differ")
+			result, err := CompareApplication(applicationFromArgoCD, dbApp, log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal("spec.ignoreDifferences fields differ"))
+
+			By("ignoreDifferences is the same in both, so the applications are the same")
+			applicationFromArgoCD.Spec.IgnoreDifferences = []appv1.ResourceIgnoreDifferences{
+				{Group: "apps", Kind: "Deployment", JSONPointers: []string{"/spec/replicas"}},
+			}
+			result, err = CompareApplication(applicationFromArgoCD, dbApp, log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeEmpty())
+
+			By("a JSON pointer differs, so the applications differ")
+			applicationFromArgoCD.Spec.IgnoreDifferences[0].JSONPointers = []string{"/spec/template"}
+			result, err = CompareApplication(applicationFromArgoCD, dbApp, log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal("spec.ignoreDifferences fields differ"))
+		})
+
+		It("Should detect differences in the Helm fields of the source", func() {
+
+			applicationFromDB, _, applicationFromArgoCD, err := createDummyApplicationData()
+			Expect(err).ToNot(HaveOccurred())
+
+			applicationFromDB.Spec.Source.Helm = &fauxargocd.ApplicationSourceHelm{
+				ValueFiles: []string{"values-prod.yaml"},
+				Values:     "replicaCount: 2\n",
+				Parameters: []fauxargocd.HelmParameter{{Name: "image.tag", Value: "v1"}},
+			}
+			yamlData, err := yaml.Marshal(applicationFromDB)
+			Expect(err).ToNot(HaveOccurred())
+			dbApp := db.Application{Spec_field: string(yamlData)}
+
+			var ctx context.Context
+			log := log.FromContext(ctx)
+
+			By("Helm is set in the DB, but not in Argo CD, so the applications differ")
+			result, err := CompareApplication(applicationFromArgoCD, dbApp, log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal("spec.source.helm fields differ"))
+
+			By("Helm is the same in both, so the applications are the same")
+			applicationFromArgoCD.Spec.Source.Helm = &appv1.ApplicationSourceHelm{
+				ValueFiles: []string{"values-prod.yaml"},
+				Values:     "replicaCount: 2\n",
+				Parameters: []appv1.HelmParameter{{Name: "image.tag", Value: "v1"}},
+			}
+			result, err = CompareApplication(applicationFromArgoCD, dbApp, log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeEmpty())
+
+			By("a Helm parameter value differs, so the applications differ")
+			applicationFromArgoCD.Spec.Source.Helm.Parameters[0].Value = "v2"
+			result, err = CompareApplication(applicationFromArgoCD, dbApp, log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal("spec.source.helm fields differ"))
+		})
+
+		It("Should detect differences in the Kustomize fields of the source", func() {
+
+			applicationFromDB, _, applicationFromArgoCD, err := createDummyApplicationData()
+			Expect(err).ToNot(HaveOccurred())
+
+			applicationFromDB.Spec.Source.Kustomize = &fauxargocd.ApplicationSourceKustomize{
+				NamePrefix:        "prod-",
+				Images:            []string{"quay.io/org/app:v2"},
+				CommonLabels:      map[string]string{"team": "gitops"},
+				CommonAnnotations: map[string]string{"example.com/link": "https://example.com/?a=1&b=2;c=50%"},
+				Patches: []fauxargocd.KustomizePatch{{
+					Patch:  "- op: replace\n  path: /spec/replicas\n  value: 3",
+					Target: &fauxargocd.KustomizeSelector{Kind: "Deployment", Name: "my-app"},
+				}},
+			}
+			yamlData, err := yaml.Marshal(applicationFromDB)
+			Expect(err).ToNot(HaveOccurred())
+			dbApp := db.Application{Spec_field: string(yamlData)}
+
+			var ctx context.Context
+			log := log.FromContext(ctx)
+
+			By("Kustomize is set in the DB, but not in Argo CD, so the applications differ")
+			result, err := CompareApplication(applicationFromArgoCD, dbApp, log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal("spec.source.kustomize fields differ"))
+
+			By("Kustomize is the same in both, so the applications are the same")
+			applicationFromArgoCD.Spec.Source.Kustomize = &appv1.ApplicationSourceKustomize{
+				NamePrefix:        "prod-",
+				Images:            appv1.KustomizeImages{"quay.io/org/app:v2"},
+				CommonLabels:      map[string]string{"team": "gitops"},
+				CommonAnnotations: map[string]string{"example.com/link": "https://example.com/?a=1&b=2;c=50%"},
+				Patches: appv1.KustomizePatches{{
+					Patch: "- op: replace\n  path: /spec/replicas\n  value: 3",
+					Target: &appv1.KustomizeSelector{
+						KustomizeResId: appv1.KustomizeResId{KustomizeGvk: appv1.KustomizeGvk{Kind: "Deployment"}, Name: "my-app"},
+					},
+				}},
+			}
+			result, err = CompareApplication(applicationFromArgoCD, dbApp, log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeEmpty())
+
+			By("a patch differs, so the applications differ")
+			applicationFromArgoCD.Spec.Source.Kustomize.Patches[0].Target.Name = "other-app"
+			result, err = CompareApplication(applicationFromArgoCD, dbApp, log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal("spec.source.kustomize fields differ"))
+			applicationFromArgoCD.Spec.Source.Kustomize.Patches[0].Target.Name = "my-app"
+
+			By("a common label differs, so the applications differ")
+			applicationFromArgoCD.Spec.Source.Kustomize.CommonLabels["team"] = "other"
+			result, err = CompareApplication(applicationFromArgoCD, dbApp, log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal("spec.source.kustomize fields differ"))
+		})
+
+		It("Should compare applications with multiple sources", func() {
+
+			applicationFromDB, _, applicationFromArgoCD, err := createDummyApplicationData()
+			Expect(err).ToNot(HaveOccurred())
+
+			// The DB spec field of an application with multiple sources contains an empty 'source' field
+			applicationFromDB.Spec.Source = fauxargocd.ApplicationSource{}
+			applicationFromDB.Spec.Sources = fauxargocd.ApplicationSources{
+				{
+					RepoURL: "https://github.com/my-org/my-chart",
+					Path:    "chart",
+					Helm: &fauxargocd.ApplicationSourceHelm{
+						ValueFiles: []string{"$values/environments/prod/values.yaml"},
+					},
+				},
+				{
+					RepoURL: "https://github.com/my-org/my-values",
+					Ref:     "values",
+				},
+			}
+			yamlData, err := yaml.Marshal(applicationFromDB)
+			Expect(err).ToNot(HaveOccurred())
+			dbApp := db.Application{Spec_field: string(yamlData)}
+
+			var ctx context.Context
+			log := log.FromContext(ctx)
+
+			By("verifying the 'source' field is removed when unmarshalling a spec field with multiple sources")
+			specFieldApp := appv1.Application{}
+			err = UnmarshalApplicationSpecField(dbApp.Spec_field, &specFieldApp)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(specFieldApp.Spec.Source).To(BeNil())
+			Expect(specFieldApp.Spec.Sources).To(HaveLen(2))
+			Expect(specFieldApp.Spec.Sources[1].Ref).To(Equal("values"))
+
+			By("the Argo CD Application has a single source, so the applications differ")
+			result, err := CompareApplication(applicationFromArgoCD, dbApp, log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal("spec.source fields differ"))
+
+			By("the Argo CD Application has the same sources, so the applications are the same")
+			applicationFromArgoCD.Spec.Source = nil
+			applicationFromArgoCD.Spec.Sources = appv1.ApplicationSources{
+				{
+					RepoURL: "https://github.com/my-org/my-chart",
+					Path:    "chart",
+					Helm: &appv1.ApplicationSourceHelm{
+						ValueFiles: []string{"$values/environments/prod/values.yaml"},
+					},
+				},
+				{
+					RepoURL: "https://github.com/my-org/my-values",
+					Ref:     "values",
+				},
+			}
+			result, err = CompareApplication(applicationFromArgoCD, dbApp, log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(BeEmpty())
+
+			By("the ref of a source differs, so the applications differ")
+			applicationFromArgoCD.Spec.Sources[1].Ref = "other-values"
+			result, err = CompareApplication(applicationFromArgoCD, dbApp, log)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result).To(Equal("spec.sources fields differ"))
+		})
+
 		It("Should compare applications if fields are nil.", func() {
 
 			// Convert a FauxApplication into a db.Application, by marshalling the FA back into YAML
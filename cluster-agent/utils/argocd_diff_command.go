@@ -0,0 +1,217 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	applicationpkg "github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	argoio "github.com/argoproj/argo-cd/v2/util/io"
+	"github.com/pmezard/go-difflib/difflib"
+	managedgitopsv1alpha1 "github.com/redhat-appstudio/managed-gitops/backend-shared/apis/managed-gitops/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// This file is loosely based on the 'argocd app diff' CLI command:
+// https://github.com/argoproj/argo-cd/blob/0a46d37fc6af9fe0aa963bdd845e3d799aa0320d/cmd/argocd/commands/app.go#L1067
+
+const (
+	// redactedSecretValue is the value that the values of a Secret are replaced with. As with Argo CD, values which
+	// differ between the live and target state are replaced with masks of differing lengths, so that the diff still
+	// indicates which values have changed.
+	redactedSecretValue = "++++++++"
+
+	// lastAppliedConfigAnnotation may contain the unredacted values of a Secret, so is removed from Secrets
+	lastAppliedConfigAnnotation = corev1.LastAppliedConfigAnnotation
+)
+
+// AppDiff calls the Argo CD GRPC API to retrieve the diff between the normalized live state, and the target state, of
+// each OutOfSync resource of an Argo CD Application, in the given namespace.
+func AppDiff(ctx context.Context, appName string, argocdNamespace corev1.Namespace, credentialService *CredentialService,
+	k8sClient client.Client) ([]managedgitopsv1alpha1.ResourceDiff, error) {
+
+	_, acdClient, err := credentialService.GetArgoCDLoginCredentials(ctx, argocdNamespace.Name,
+		string(argocdNamespace.UID), false, k8sClient)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return appDiff(ctx, appName, acdClient)
+}
+
+func appDiff(ctx context.Context, appName string, acdClient apiclient.Client) ([]managedgitopsv1alpha1.ResourceDiff, error) {
+
+	conn, appIf, err := acdClient.NewApplicationClient()
+	if err != nil {
+		return nil, fmt.Errorf("unable to create application client for diff: %v", err)
+	}
+	defer argoio.Close(conn)
+
+	resources, err := appIf.ManagedResources(ctx, &applicationpkg.ResourcesQuery{ApplicationName: &appName})
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve managed resources of application '%s': %v", appName, err)
+	}
+
+	res := []managedgitopsv1alpha1.ResourceDiff{}
+
+	for _, item := range resources.Items {
+
+		if item == nil || item.Hook {
+			continue
+		}
+
+		liveState, err := parseResourceState(item.NormalizedLiveState)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse live state of '%s': %v", item.FullName(), err)
+		}
+
+		targetState, err := parseResourceState(item.PredictedLiveState)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse target state of '%s': %v", item.FullName(), err)
+		}
+
+		// A resource is OutOfSync if it has been modified, is missing from the cluster, or requires pruning.
+		if !item.Modified && (liveState == nil) == (targetState == nil) {
+			continue
+		}
+
+		if item.Kind == "Secret" && item.Group == "" {
+			redactSecrets(liveState, targetState)
+		}
+
+		diff, err := unifiedDiff(liveState, targetState)
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate diff of '%s': %v", item.FullName(), err)
+		}
+
+		if diff == "" {
+			// Ignore resources whose only differences are normalized away (or redacted)
+			continue
+		}
+
+		res = append(res, managedgitopsv1alpha1.ResourceDiff{
+			Group:     item.Group,
+			Kind:      item.Kind,
+			Namespace: item.Namespace,
+			Name:      item.Name,
+			Diff:      diff,
+		})
+	}
+
+	return res, nil
+}
+
+// parseResourceState converts the JSON serialized resource state returned by Argo CD into an unstructured object.
+// Returns nil if the resource does not exist in that state (for example, it is not yet deployed).
+func parseResourceState(state string) (*unstructured.Unstructured, error) {
+	if state == "" || state == "null" {
+		return nil, nil
+	}
+
+	obj := &unstructured.Unstructured{}
+	if err := json.Unmarshal([]byte(state), &obj.Object); err != nil {
+		return nil, err
+	}
+
+	return obj, nil
+}
+
+// unifiedDiff returns a unified diff of the YAML representation of the live and target states.
+func unifiedDiff(liveState *unstructured.Unstructured, targetState *unstructured.Unstructured) (string, error) {
+
+	toYAML := func(obj *unstructured.Unstructured) (string, error) {
+		if obj == nil {
+			return "", nil
+		}
+		yamlBytes, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return "", err
+		}
+		return string(yamlBytes), nil
+	}
+
+	liveYAML, err := toYAML(liveState)
+	if err != nil {
+		return "", err
+	}
+
+	targetYAML, err := toYAML(targetState)
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(liveYAML),
+		B:        difflib.SplitLines(targetYAML),
+		FromFile: "live",
+		ToFile:   "target",
+		Context:  3,
+	})
+}
+
+// redactSecrets replaces the values of the 'data' and 'stringData' fields of the live and target state of a Secret.
+// Values that are the same in both states are replaced with the same mask, while values that differ are replaced
+// with masks of different lengths.
+func redactSecrets(liveState *unstructured.Unstructured, targetState *unstructured.Unstructured) {
+
+	secrets := []*unstructured.Unstructured{}
+	for _, secret := range []*unstructured.Unstructured{liveState, targetState} {
+		if secret != nil {
+			secrets = append(secrets, secret)
+		}
+	}
+
+	for _, field := range []string{"data", "stringData"} {
+
+		// For each key, the distinct values of that key across the secrets, in the order they were first seen
+		valuesByKey := map[string][]string{}
+
+		for _, secret := range secrets {
+			values, _, _ := unstructured.NestedMap(secret.Object, field)
+			for key, value := range values {
+				valueString := fmt.Sprintf("%v", value)
+				if indexOfString(valuesByKey[key], valueString) == -1 {
+					valuesByKey[key] = append(valuesByKey[key], valueString)
+				}
+			}
+		}
+
+		for _, secret := range secrets {
+			values, found, _ := unstructured.NestedMap(secret.Object, field)
+			if !found {
+				continue
+			}
+
+			for key, value := range values {
+				maskIndex := indexOfString(valuesByKey[key], fmt.Sprintf("%v", value))
+				values[key] = redactedSecretValue + strings.Repeat("+", maskIndex)
+			}
+
+			_ = unstructured.SetNestedMap(secret.Object, values, field)
+		}
+	}
+
+	for _, secret := range secrets {
+		annotations := secret.GetAnnotations()
+		if _, exists := annotations[lastAppliedConfigAnnotation]; exists {
+			delete(annotations, lastAppliedConfigAnnotation)
+			secret.SetAnnotations(annotations)
+		}
+	}
+}
+
+// indexOfString returns the index of value in values, or -1 if it is not present
+func indexOfString(values []string, value string) int {
+	for idx, v := range values {
+		if v == value {
+			return idx
+		}
+	}
+	return -1
+}
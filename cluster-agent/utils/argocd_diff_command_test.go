@@ -0,0 +1,147 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	applicationpkg "github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	appv1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/redhat-appstudio/managed-gitops/cluster-agent/utils/mocks"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ = Describe("Diff of an Argo CD Application", func() {
+
+	toJSON := func(obj map[string]interface{}) string {
+		jsonBytes, err := json.Marshal(obj)
+		Expect(err).ToNot(HaveOccurred())
+		return string(jsonBytes)
+	}
+
+	configMap := func(value string) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata":   map[string]interface{}{"name": "my-config-map", "namespace": "my-namespace"},
+			"data":       map[string]interface{}{"key": value},
+		}
+	}
+
+	secret := func(data map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata": map[string]interface{}{
+				"name": "my-secret", "namespace": "my-namespace",
+				"annotations": map[string]interface{}{
+					lastAppliedConfigAnnotation: `{"data":{"password":"c2VjcmV0"}}`,
+				},
+			},
+			"data": data,
+		}
+	}
+
+	appDiffWithResources := func(items []*appv1.ResourceDiff) ([]string, []string) {
+		mockAppServiceClient := &mocks.ApplicationServiceClient{}
+		mockAppClient := &mocks.Client{}
+
+		appName := "my-app"
+		mockAppClient.On("NewApplicationClient").Return(mockCloser{}, mockAppServiceClient, nil)
+		mockAppServiceClient.On("ManagedResources", mock.Anything, &applicationpkg.ResourcesQuery{ApplicationName: &appName}).
+			Return(&applicationpkg.ManagedResourcesResponse{Items: items}, nil)
+
+		res, err := appDiff(context.Background(), appName, mockAppClient)
+		Expect(err).ToNot(HaveOccurred())
+
+		names := []string{}
+		diffs := []string{}
+		for _, resourceDiff := range res {
+			names = append(names, resourceDiff.Kind+"/"+resourceDiff.Name)
+			diffs = append(diffs, resourceDiff.Diff)
+		}
+		return names, diffs
+	}
+
+	It("should return a unified diff for modified, missing and extraneous resources, but not for synced resources", func() {
+
+		names, diffs := appDiffWithResources([]*appv1.ResourceDiff{
+			{
+				Kind: "ConfigMap", Namespace: "my-namespace", Name: "my-config-map",
+				NormalizedLiveState: toJSON(configMap("old-value")),
+				PredictedLiveState:  toJSON(configMap("new-value")),
+				Modified:            true,
+			},
+			{
+				Kind: "ConfigMap", Namespace: "my-namespace", Name: "synced-config-map",
+				NormalizedLiveState: toJSON(configMap("value")),
+				PredictedLiveState:  toJSON(configMap("value")),
+			},
+			{
+				Kind: "ConfigMap", Namespace: "my-namespace", Name: "missing-config-map",
+				NormalizedLiveState: "null",
+				PredictedLiveState:  toJSON(configMap("value")),
+			},
+			{
+				Kind: "ConfigMap", Namespace: "my-namespace", Name: "extraneous-config-map",
+				NormalizedLiveState: toJSON(configMap("value")),
+				PredictedLiveState:  "null",
+			},
+			{
+				Kind: "Job", Namespace: "my-namespace", Name: "hook", Hook: true, Modified: true,
+			},
+		})
+
+		Expect(names).To(Equal([]string{"ConfigMap/my-config-map", "ConfigMap/missing-config-map", "ConfigMap/extraneous-config-map"}))
+
+		Expect(diffs[0]).To(ContainSubstring("--- live"))
+		Expect(diffs[0]).To(ContainSubstring("+++ target"))
+		Expect(diffs[0]).To(ContainSubstring("-  key: old-value"))
+		Expect(diffs[0]).To(ContainSubstring("+  key: new-value"))
+
+		Expect(diffs[1]).To(ContainSubstring("+  key: value"))
+		Expect(diffs[1]).ToNot(ContainSubstring("-  key: value"))
+
+		Expect(diffs[2]).To(ContainSubstring("-  key: value"))
+		Expect(diffs[2]).ToNot(ContainSubstring("+  key: value"))
+	})
+
+	It("should redact the values of Secrets, while still indicating which values changed", func() {
+
+		_, diffs := appDiffWithResources([]*appv1.ResourceDiff{
+			{
+				Kind: "Secret", Namespace: "my-namespace", Name: "my-secret",
+				NormalizedLiveState: toJSON(secret(map[string]interface{}{"password": "c2VjcmV0", "username": "YWRtaW4="})),
+				PredictedLiveState:  toJSON(secret(map[string]interface{}{"password": "bmV3LXNlY3JldA==", "username": "YWRtaW4="})),
+				Modified:            true,
+			},
+		})
+
+		Expect(diffs).To(HaveLen(1))
+
+		for _, value := range []string{"c2VjcmV0", "bmV3LXNlY3JldA==", "YWRtaW4=", lastAppliedConfigAnnotation} {
+			Expect(diffs[0]).ToNot(ContainSubstring(value))
+		}
+
+		Expect(diffs[0]).To(ContainSubstring("-  password: " + redactedSecretValue + "\n"))
+		Expect(diffs[0]).To(ContainSubstring("+  password: " + redactedSecretValue + "+\n"))
+		Expect(diffs[0]).To(ContainSubstring("   username: " + redactedSecretValue))
+		Expect(strings.Count(diffs[0], "username")).To(Equal(1))
+	})
+
+	It("should not return a diff for a Secret whose only difference is redacted away", func() {
+
+		_, diffs := appDiffWithResources([]*appv1.ResourceDiff{
+			{
+				Kind: "Secret", Namespace: "my-namespace", Name: "my-secret",
+				NormalizedLiveState: toJSON(secret(map[string]interface{}{"password": "c2VjcmV0"})),
+				PredictedLiveState:  toJSON(secret(map[string]interface{}{"password": "c2VjcmV0"})),
+				Modified:            true,
+			},
+		})
+
+		Expect(diffs).To(BeEmpty())
+	})
+})
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	argocdclient "github.com/argoproj/argo-cd/v2/pkg/apiclient"
+	applicationpkg "github.com/argoproj/argo-cd/v2/pkg/apiclient/application"
+	argoio "github.com/argoproj/argo-cd/v2/util/io"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// This contents of this file are loosely based on the 'argocd app rollback' CLI command:
+// https://github.com/argoproj/argo-cd/blob/0a46d37fc6af9fe0aa963bdd845e3d799aa0320d/cmd/argocd/commands/app.go#L2053
+
+// AppRollback will roll back the given Argo CD Application, in the given namespace, to the revision history entry with the given ID.
+// - Argo CD does not allow rolling back an Application with automated sync enabled: it is the responsibility of the
+// caller to disable automated sync before calling this function.
+func AppRollback(ctx context.Context, appName string, historyID int64, namespaceName string, k8sClient client.Client,
+	credentialsService *CredentialService, skipTLSTest bool) error {
+
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: namespaceName,
+		},
+	}
+
+	err := k8sClient.Get(ctx, client.ObjectKeyFromObject(namespace), namespace)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve namespace in AppRollback: %s, %v", namespaceName, err)
+	}
+
+	_, acdClient, err := credentialsService.GetArgoCDLoginCredentials(ctx, namespaceName, string(namespace.UID), false, k8sClient)
+	if err != nil {
+		return err
+	}
+
+	return appRollback(ctx, acdClient, appName, historyID, 0)
+}
+
+func appRollback(ctx context.Context, acdClient argocdclient.Client, appName string, historyID int64, timeout uint) error {
+
+	conn, appIf, err := acdClient.NewApplicationClient()
+	if err != nil {
+		return fmt.Errorf("unable to retrieve acd client: %v", err)
+	}
+	defer argoio.Close(conn)
+
+	prune := false
+	_, err = appIf.Rollback(ctx, &applicationpkg.ApplicationRollbackRequest{
+		Name:  &appName,
+		Id:    &historyID,
+		Prune: &prune,
+	})
+	if err != nil {
+		return err
+	}
+
+	app, err := waitOnApplicationStatus(ctx, acdClient, appName, timeout, false, false, true, false, nil)
+	if err != nil {
+		return err
+	}
+
+	operationState := app.Status.OperationState
+	if operationState == nil {
+		return fmt.Errorf("rollback of application '%s' completed without an operation state", appName)
+	}
+
+	if !operationState.Phase.Successful() {
+		return fmt.Errorf("rollback operation has completed with phase: %s and message: %s", operationState.Phase, operationState.Message)
+	}
+
+	return nil
+}
@@ -25,8 +25,22 @@ import (
 // This contents of this file are loosely based on the 'argocd app sync' CLI command:
 // https://github.com/argoproj/argo-cd/blob/0a46d37fc6af9fe0aa963bdd845e3d799aa0320d/cmd/argocd/commands/app.go#L1333
 
+// AppSyncOptions contains the options of a sync operation, which correspond to the flags of the 'argocd app sync' CLI command.
+type AppSyncOptions struct {
+	// Prune deletes resources that are no longer defined in the GitOps repository
+	Prune bool
+	// DryRun simulates the sync, without modifying the target cluster
+	DryRun bool
+	// Force deletes and recreates resources that cannot be updated
+	Force bool
+	// Strategy is the sync strategy: 'hook' or 'apply'. If empty, 'hook' is used.
+	Strategy string
+	// Resources, if non-empty, are the only resources of the Application that are synced
+	Resources []argoappv1.SyncOperationResource
+}
+
 // AppSync will trigger a synchronize application on the given Argo CD appliatication, in the given namespace.
-func AppSync(ctx context.Context, appName string, revision string, namespaceName string, k8sClient client.Client,
+func AppSync(ctx context.Context, appName string, revision string, syncOptions AppSyncOptions, namespaceName string, k8sClient client.Client,
 	credentialsService *CredentialService, skipTLSTest bool) error {
 
 	namespace := &corev1.Namespace{
@@ -45,7 +59,8 @@ func AppSync(ctx context.Context, appName string, revision string, namespaceName
 		return err
 	}
 
-	err = appSync(ctx, acdClient, appName, false, false, revision, false, "", false, false, 0, 0, 0, 0, 0)
+	err = appSync(ctx, acdClient, appName, syncOptions.DryRun, false, revision, syncOptions.Prune, syncOptions.Strategy, syncOptions.Force,
+		syncOptions.Resources, false, 0, 0, 0, 0, 0)
 	if err != nil {
 		return err
 	}
@@ -55,7 +70,7 @@ func AppSync(ctx context.Context, appName string, revision string, namespaceName
 }
 
 func appSync(ctx context.Context, acdClient argocdclient.Client, appName string, dryRun bool, replace bool, revision string, prune bool,
-	strategy string, force bool, async bool, timeout uint, retryLimit int64, retryBackoffDuration time.Duration,
+	strategy string, force bool, selectedResources []argoappv1.SyncOperationResource, async bool, timeout uint, retryLimit int64, retryBackoffDuration time.Duration,
 	retryBackoffMaxDuration time.Duration, retryBackoffFactor int64) error {
 
 	conn, appIf, err := acdClient.NewApplicationClient()
@@ -79,11 +94,16 @@ func appSync(ctx context.Context, acdClient argocdclient.Client, appName string,
 		return &syncOptions
 	}
 
+	var resources []*argoappv1.SyncOperationResource
+	for idx := range selectedResources {
+		resources = append(resources, &selectedResources[idx])
+	}
+
 	syncReq := applicationpkg.ApplicationSyncRequest{
 		Name:        &appName,
 		DryRun:      &dryRun,
 		Revision:    &revision,
-		Resources:   nil,
+		Resources:   resources,
 		Prune:       &prune,
 		Manifests:   nil,
 		Infos:       []*argoappv1.Info{},
@@ -116,7 +136,7 @@ func appSync(ctx context.Context, acdClient argocdclient.Client, appName string,
 	}
 
 	if !async {
-		app, err := waitOnApplicationStatus(ctx, acdClient, appName, timeout, false, false, true, false, []argoappv1.SyncOperationResource{})
+		app, err := waitOnApplicationStatus(ctx, acdClient, appName, timeout, false, false, true, false, selectedResources)
 		if err != nil {
 			return err
 		}
@@ -125,7 +145,7 @@ func appSync(ctx context.Context, acdClient argocdclient.Client, appName string,
 			operationState := app.Status.OperationState
 			if !operationState.Phase.Successful() {
 				return fmt.Errorf("operation has completed with phase: %s and message: %s", operationState.Phase, operationState.Message)
-			} else if /*len(selectedResources) == 0 &&*/ app.Status.Sync.Status != argoappv1.SyncStatusCodeSynced {
+			} else if len(selectedResources) == 0 && app.Status.Sync.Status != argoappv1.SyncStatusCodeSynced {
 				// Only get resources to be pruned if sync was application-wide and final status is not synced
 				pruningRequired := operationState.SyncResult.Resources.PruningRequired()
 				if pruningRequired > 0 {
@@ -124,8 +124,66 @@ var _ = Describe("ArgoCD AppSync Command", func() {
 			}
 
 			cs := NewCredentialService(&clientGenerator, true)
-			err = AppSync(context.Background(), appName, "master", "openshift-gitops", k8sClient, cs, true)
+			err = AppSync(context.Background(), appName, "master", AppSyncOptions{}, "openshift-gitops", k8sClient, cs, true)
 			Expect(err).ToNot(HaveOccurred())
 		})
+
+		It("should pass the sync options to the Sync request, and only wait on the selected resources", func() {
+			nowTime := metav1.Now()
+			appName := "my-app"
+
+			app := &appv1.Application{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      appName,
+					Namespace: "openshift-gitops",
+				},
+				Status: appv1.ApplicationStatus{
+					ReconciledAt: &nowTime,
+					Health:       appv1.HealthStatus{Status: health.HealthStatusHealthy},
+					Sync: appv1.SyncStatus{
+						// The Application as a whole is OutOfSync, but the selected resource is Synced
+						Status: appv1.SyncStatusCodeOutOfSync,
+					},
+					Resources: []appv1.ResourceStatus{
+						{Group: "apps", Kind: "Deployment", Namespace: "my-namespace", Name: "my-deployment", Status: appv1.SyncStatusCodeSynced},
+						{Kind: "ConfigMap", Namespace: "my-namespace", Name: "my-config-map", Status: appv1.SyncStatusCodeOutOfSync},
+					},
+					OperationState: &appv1.OperationState{
+						Phase:      common.OperationSucceeded,
+						FinishedAt: &nowTime,
+						SyncResult: &appv1.SyncOperationResult{},
+					},
+				},
+			}
+
+			selectedResources := []appv1.SyncOperationResource{
+				{Group: "apps", Kind: "Deployment", Namespace: "my-namespace", Name: "my-deployment"},
+			}
+
+			mockAppClient := &mocks.Client{}
+			mockAppServiceClient := &mocks.ApplicationServiceClient{}
+			mockAppClient.On("NewApplicationClient").Return(mockCloser{}, mockAppServiceClient, nil)
+
+			mockAppServiceClient.On("Sync", mock.Anything, mock.MatchedBy(func(asr *applicationpkg.ApplicationSyncRequest) bool {
+				return *asr.Name == appName && *asr.Revision == "main" && *asr.Prune && !*asr.DryRun &&
+					asr.Strategy.Apply != nil && asr.Strategy.Apply.Force &&
+					len(asr.Resources) == 1 && *asr.Resources[0] == selectedResources[0]
+			})).Return(nil, nil)
+
+			mockAppServiceClient.On("Get", mock.Anything, &applicationpkg.ApplicationQuery{Name: &appName}).Return(app, nil)
+			awe := make(chan *appv1.ApplicationWatchEvent)
+			go func() {
+				awe <- &appv1.ApplicationWatchEvent{
+					Application: *app,
+				}
+				close(awe)
+			}()
+			mockAppClient.On("WatchApplicationWithRetry", mock.Anything, app.Name, mock.Anything).Return(awe)
+
+			err := appSync(context.Background(), mockAppClient, appName, false, false, "main", true, "apply", true, selectedResources, false, 0, 0, 0, 0, 0)
+			Expect(err).ToNot(HaveOccurred())
+
+			mockAppServiceClient.AssertCalled(GinkgoT(), "Sync", mock.Anything, mock.Anything)
+		})
 	})
 })
@@ -1,4 +1,4 @@
-// Code generated by mockery v2.14.1. DO NOT EDIT.
+// Code generated by mockery. DO NOT EDIT.
 
 package mocks
 
@@ -33,7 +33,15 @@ func (_m *ApplicationServiceClient) Create(ctx context.Context, in *application.
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
 	var r0 *v1alpha1.Application
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationCreateRequest, ...grpc.CallOption) (*v1alpha1.Application, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationCreateRequest, ...grpc.CallOption) *v1alpha1.Application); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -42,7 +50,6 @@ func (_m *ApplicationServiceClient) Create(ctx context.Context, in *application.
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationCreateRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -63,7 +70,15 @@ func (_m *ApplicationServiceClient) Delete(ctx context.Context, in *application.
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
 	var r0 *application.ApplicationResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationDeleteRequest, ...grpc.CallOption) (*application.ApplicationResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationDeleteRequest, ...grpc.CallOption) *application.ApplicationResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -72,7 +87,6 @@ func (_m *ApplicationServiceClient) Delete(ctx context.Context, in *application.
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationDeleteRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -93,7 +107,15 @@ func (_m *ApplicationServiceClient) DeleteResource(ctx context.Context, in *appl
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteResource")
+	}
+
 	var r0 *application.ApplicationResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationResourceDeleteRequest, ...grpc.CallOption) (*application.ApplicationResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationResourceDeleteRequest, ...grpc.CallOption) *application.ApplicationResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -102,7 +124,6 @@ func (_m *ApplicationServiceClient) DeleteResource(ctx context.Context, in *appl
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationResourceDeleteRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -123,7 +144,15 @@ func (_m *ApplicationServiceClient) Get(ctx context.Context, in *application.App
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
 	var r0 *v1alpha1.Application
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationQuery, ...grpc.CallOption) (*v1alpha1.Application, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationQuery, ...grpc.CallOption) *v1alpha1.Application); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -132,7 +161,6 @@ func (_m *ApplicationServiceClient) Get(ctx context.Context, in *application.App
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationQuery, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -153,7 +181,15 @@ func (_m *ApplicationServiceClient) GetApplicationSyncWindows(ctx context.Contex
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetApplicationSyncWindows")
+	}
+
 	var r0 *application.ApplicationSyncWindowsResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationSyncWindowsQuery, ...grpc.CallOption) (*application.ApplicationSyncWindowsResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationSyncWindowsQuery, ...grpc.CallOption) *application.ApplicationSyncWindowsResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -162,7 +198,6 @@ func (_m *ApplicationServiceClient) GetApplicationSyncWindows(ctx context.Contex
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationSyncWindowsQuery, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -183,7 +218,15 @@ func (_m *ApplicationServiceClient) GetManifests(ctx context.Context, in *applic
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetManifests")
+	}
+
 	var r0 *apiclient.ManifestResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationManifestQuery, ...grpc.CallOption) (*apiclient.ManifestResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationManifestQuery, ...grpc.CallOption) *apiclient.ManifestResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -192,7 +235,6 @@ func (_m *ApplicationServiceClient) GetManifests(ctx context.Context, in *applic
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationManifestQuery, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -213,7 +255,15 @@ func (_m *ApplicationServiceClient) GetManifestsWithFiles(ctx context.Context, o
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetManifestsWithFiles")
+	}
+
 	var r0 application.ApplicationService_GetManifestsWithFilesClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) (application.ApplicationService_GetManifestsWithFilesClient, error)); ok {
+		return rf(ctx, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, ...grpc.CallOption) application.ApplicationService_GetManifestsWithFilesClient); ok {
 		r0 = rf(ctx, opts...)
 	} else {
@@ -222,7 +272,6 @@ func (_m *ApplicationServiceClient) GetManifestsWithFiles(ctx context.Context, o
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, opts...)
 	} else {
@@ -243,7 +292,15 @@ func (_m *ApplicationServiceClient) GetResource(ctx context.Context, in *applica
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetResource")
+	}
+
 	var r0 *application.ApplicationResourceResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationResourceRequest, ...grpc.CallOption) (*application.ApplicationResourceResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationResourceRequest, ...grpc.CallOption) *application.ApplicationResourceResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -252,7 +309,6 @@ func (_m *ApplicationServiceClient) GetResource(ctx context.Context, in *applica
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationResourceRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -273,7 +329,15 @@ func (_m *ApplicationServiceClient) List(ctx context.Context, in *application.Ap
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for List")
+	}
+
 	var r0 *v1alpha1.ApplicationList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationQuery, ...grpc.CallOption) (*v1alpha1.ApplicationList, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationQuery, ...grpc.CallOption) *v1alpha1.ApplicationList); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -282,7 +346,6 @@ func (_m *ApplicationServiceClient) List(ctx context.Context, in *application.Ap
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationQuery, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -292,6 +355,43 @@ func (_m *ApplicationServiceClient) List(ctx context.Context, in *application.Ap
 	return r0, r1
 }
 
+// ListLinks provides a mock function with given fields: ctx, in, opts
+func (_m *ApplicationServiceClient) ListLinks(ctx context.Context, in *application.ListAppLinksRequest, opts ...grpc.CallOption) (*application.LinksResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListLinks")
+	}
+
+	var r0 *application.LinksResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ListAppLinksRequest, ...grpc.CallOption) (*application.LinksResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ListAppLinksRequest, ...grpc.CallOption) *application.LinksResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*application.LinksResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *application.ListAppLinksRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListResourceActions provides a mock function with given fields: ctx, in, opts
 func (_m *ApplicationServiceClient) ListResourceActions(ctx context.Context, in *application.ApplicationResourceRequest, opts ...grpc.CallOption) (*application.ResourceActionsListResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -303,7 +403,15 @@ func (_m *ApplicationServiceClient) ListResourceActions(ctx context.Context, in
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for ListResourceActions")
+	}
+
 	var r0 *application.ResourceActionsListResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationResourceRequest, ...grpc.CallOption) (*application.ResourceActionsListResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationResourceRequest, ...grpc.CallOption) *application.ResourceActionsListResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -312,7 +420,6 @@ func (_m *ApplicationServiceClient) ListResourceActions(ctx context.Context, in
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationResourceRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -333,7 +440,15 @@ func (_m *ApplicationServiceClient) ListResourceEvents(ctx context.Context, in *
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for ListResourceEvents")
+	}
+
 	var r0 *v1.EventList
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationResourceEventsQuery, ...grpc.CallOption) (*v1.EventList, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationResourceEventsQuery, ...grpc.CallOption) *v1.EventList); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -342,7 +457,6 @@ func (_m *ApplicationServiceClient) ListResourceEvents(ctx context.Context, in *
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationResourceEventsQuery, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -352,6 +466,43 @@ func (_m *ApplicationServiceClient) ListResourceEvents(ctx context.Context, in *
 	return r0, r1
 }
 
+// ListResourceLinks provides a mock function with given fields: ctx, in, opts
+func (_m *ApplicationServiceClient) ListResourceLinks(ctx context.Context, in *application.ApplicationResourceRequest, opts ...grpc.CallOption) (*application.LinksResponse, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListResourceLinks")
+	}
+
+	var r0 *application.LinksResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationResourceRequest, ...grpc.CallOption) (*application.LinksResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationResourceRequest, ...grpc.CallOption) *application.LinksResponse); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*application.LinksResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationResourceRequest, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ManagedResources provides a mock function with given fields: ctx, in, opts
 func (_m *ApplicationServiceClient) ManagedResources(ctx context.Context, in *application.ResourcesQuery, opts ...grpc.CallOption) (*application.ManagedResourcesResponse, error) {
 	_va := make([]interface{}, len(opts))
@@ -363,7 +514,15 @@ func (_m *ApplicationServiceClient) ManagedResources(ctx context.Context, in *ap
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for ManagedResources")
+	}
+
 	var r0 *application.ManagedResourcesResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ResourcesQuery, ...grpc.CallOption) (*application.ManagedResourcesResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ResourcesQuery, ...grpc.CallOption) *application.ManagedResourcesResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -372,7 +531,6 @@ func (_m *ApplicationServiceClient) ManagedResources(ctx context.Context, in *ap
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ResourcesQuery, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -393,7 +551,15 @@ func (_m *ApplicationServiceClient) Patch(ctx context.Context, in *application.A
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Patch")
+	}
+
 	var r0 *v1alpha1.Application
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationPatchRequest, ...grpc.CallOption) (*v1alpha1.Application, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationPatchRequest, ...grpc.CallOption) *v1alpha1.Application); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -402,7 +568,6 @@ func (_m *ApplicationServiceClient) Patch(ctx context.Context, in *application.A
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationPatchRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -423,7 +588,15 @@ func (_m *ApplicationServiceClient) PatchResource(ctx context.Context, in *appli
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for PatchResource")
+	}
+
 	var r0 *application.ApplicationResourceResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationResourcePatchRequest, ...grpc.CallOption) (*application.ApplicationResourceResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationResourcePatchRequest, ...grpc.CallOption) *application.ApplicationResourceResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -432,7 +605,6 @@ func (_m *ApplicationServiceClient) PatchResource(ctx context.Context, in *appli
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationResourcePatchRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -453,7 +625,15 @@ func (_m *ApplicationServiceClient) PodLogs(ctx context.Context, in *application
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for PodLogs")
+	}
+
 	var r0 application.ApplicationService_PodLogsClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationPodLogsQuery, ...grpc.CallOption) (application.ApplicationService_PodLogsClient, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationPodLogsQuery, ...grpc.CallOption) application.ApplicationService_PodLogsClient); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -462,7 +642,6 @@ func (_m *ApplicationServiceClient) PodLogs(ctx context.Context, in *application
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationPodLogsQuery, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -483,7 +662,15 @@ func (_m *ApplicationServiceClient) ResourceTree(ctx context.Context, in *applic
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for ResourceTree")
+	}
+
 	var r0 *v1alpha1.ApplicationTree
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ResourcesQuery, ...grpc.CallOption) (*v1alpha1.ApplicationTree, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ResourcesQuery, ...grpc.CallOption) *v1alpha1.ApplicationTree); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -492,7 +679,6 @@ func (_m *ApplicationServiceClient) ResourceTree(ctx context.Context, in *applic
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ResourcesQuery, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -502,6 +688,43 @@ func (_m *ApplicationServiceClient) ResourceTree(ctx context.Context, in *applic
 	return r0, r1
 }
 
+// RevisionChartDetails provides a mock function with given fields: ctx, in, opts
+func (_m *ApplicationServiceClient) RevisionChartDetails(ctx context.Context, in *application.RevisionMetadataQuery, opts ...grpc.CallOption) (*v1alpha1.ChartDetails, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, in)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RevisionChartDetails")
+	}
+
+	var r0 *v1alpha1.ChartDetails
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.RevisionMetadataQuery, ...grpc.CallOption) (*v1alpha1.ChartDetails, error)); ok {
+		return rf(ctx, in, opts...)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *application.RevisionMetadataQuery, ...grpc.CallOption) *v1alpha1.ChartDetails); ok {
+		r0 = rf(ctx, in, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*v1alpha1.ChartDetails)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *application.RevisionMetadataQuery, ...grpc.CallOption) error); ok {
+		r1 = rf(ctx, in, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RevisionMetadata provides a mock function with given fields: ctx, in, opts
 func (_m *ApplicationServiceClient) RevisionMetadata(ctx context.Context, in *application.RevisionMetadataQuery, opts ...grpc.CallOption) (*v1alpha1.RevisionMetadata, error) {
 	_va := make([]interface{}, len(opts))
@@ -513,7 +736,15 @@ func (_m *ApplicationServiceClient) RevisionMetadata(ctx context.Context, in *ap
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for RevisionMetadata")
+	}
+
 	var r0 *v1alpha1.RevisionMetadata
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.RevisionMetadataQuery, ...grpc.CallOption) (*v1alpha1.RevisionMetadata, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.RevisionMetadataQuery, ...grpc.CallOption) *v1alpha1.RevisionMetadata); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -522,7 +753,6 @@ func (_m *ApplicationServiceClient) RevisionMetadata(ctx context.Context, in *ap
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.RevisionMetadataQuery, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -543,7 +773,15 @@ func (_m *ApplicationServiceClient) Rollback(ctx context.Context, in *applicatio
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Rollback")
+	}
+
 	var r0 *v1alpha1.Application
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationRollbackRequest, ...grpc.CallOption) (*v1alpha1.Application, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationRollbackRequest, ...grpc.CallOption) *v1alpha1.Application); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -552,7 +790,6 @@ func (_m *ApplicationServiceClient) Rollback(ctx context.Context, in *applicatio
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationRollbackRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -573,7 +810,15 @@ func (_m *ApplicationServiceClient) RunResourceAction(ctx context.Context, in *a
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for RunResourceAction")
+	}
+
 	var r0 *application.ApplicationResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ResourceActionRunRequest, ...grpc.CallOption) (*application.ApplicationResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ResourceActionRunRequest, ...grpc.CallOption) *application.ApplicationResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -582,7 +827,6 @@ func (_m *ApplicationServiceClient) RunResourceAction(ctx context.Context, in *a
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ResourceActionRunRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -603,7 +847,15 @@ func (_m *ApplicationServiceClient) Sync(ctx context.Context, in *application.Ap
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Sync")
+	}
+
 	var r0 *v1alpha1.Application
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationSyncRequest, ...grpc.CallOption) (*v1alpha1.Application, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationSyncRequest, ...grpc.CallOption) *v1alpha1.Application); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -612,7 +864,6 @@ func (_m *ApplicationServiceClient) Sync(ctx context.Context, in *application.Ap
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationSyncRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -633,7 +884,15 @@ func (_m *ApplicationServiceClient) TerminateOperation(ctx context.Context, in *
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for TerminateOperation")
+	}
+
 	var r0 *application.OperationTerminateResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.OperationTerminateRequest, ...grpc.CallOption) (*application.OperationTerminateResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.OperationTerminateRequest, ...grpc.CallOption) *application.OperationTerminateResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -642,7 +901,6 @@ func (_m *ApplicationServiceClient) TerminateOperation(ctx context.Context, in *
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.OperationTerminateRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -663,7 +921,15 @@ func (_m *ApplicationServiceClient) Update(ctx context.Context, in *application.
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
 	var r0 *v1alpha1.Application
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationUpdateRequest, ...grpc.CallOption) (*v1alpha1.Application, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationUpdateRequest, ...grpc.CallOption) *v1alpha1.Application); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -672,7 +938,6 @@ func (_m *ApplicationServiceClient) Update(ctx context.Context, in *application.
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationUpdateRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -693,7 +958,15 @@ func (_m *ApplicationServiceClient) UpdateSpec(ctx context.Context, in *applicat
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSpec")
+	}
+
 	var r0 *v1alpha1.ApplicationSpec
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationUpdateSpecRequest, ...grpc.CallOption) (*v1alpha1.ApplicationSpec, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationUpdateSpecRequest, ...grpc.CallOption) *v1alpha1.ApplicationSpec); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -702,7 +975,6 @@ func (_m *ApplicationServiceClient) UpdateSpec(ctx context.Context, in *applicat
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationUpdateSpecRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -723,7 +995,15 @@ func (_m *ApplicationServiceClient) Watch(ctx context.Context, in *application.A
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Watch")
+	}
+
 	var r0 application.ApplicationService_WatchClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationQuery, ...grpc.CallOption) (application.ApplicationService_WatchClient, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ApplicationQuery, ...grpc.CallOption) application.ApplicationService_WatchClient); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -732,7 +1012,6 @@ func (_m *ApplicationServiceClient) Watch(ctx context.Context, in *application.A
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ApplicationQuery, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -753,7 +1032,15 @@ func (_m *ApplicationServiceClient) WatchResourceTree(ctx context.Context, in *a
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for WatchResourceTree")
+	}
+
 	var r0 application.ApplicationService_WatchResourceTreeClient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *application.ResourcesQuery, ...grpc.CallOption) (application.ApplicationService_WatchResourceTreeClient, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *application.ResourcesQuery, ...grpc.CallOption) application.ApplicationService_WatchResourceTreeClient); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -762,7 +1049,6 @@ func (_m *ApplicationServiceClient) WatchResourceTree(ctx context.Context, in *a
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *application.ResourcesQuery, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -772,13 +1058,12 @@ func (_m *ApplicationServiceClient) WatchResourceTree(ctx context.Context, in *a
 	return r0, r1
 }
 
-type mockConstructorTestingTNewApplicationServiceClient interface {
+// NewApplicationServiceClient creates a new instance of ApplicationServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewApplicationServiceClient(t interface {
 	mock.TestingT
 	Cleanup(func())
-}
-
-// NewApplicationServiceClient creates a new instance of ApplicationServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-func NewApplicationServiceClient(t mockConstructorTestingTNewApplicationServiceClient) *ApplicationServiceClient {
+}) *ApplicationServiceClient {
 	mock := &ApplicationServiceClient{}
 	mock.Mock.Test(t)
 
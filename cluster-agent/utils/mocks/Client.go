@@ -1,4 +1,4 @@
-// Code generated by mockery v2.14.1. DO NOT EDIT.
+// Code generated by mockery. DO NOT EDIT.
 
 package mocks
 
@@ -28,7 +28,7 @@ import (
 
 	oauth2 "golang.org/x/oauth2"
 
-	oidc "github.com/coreos/go-oidc"
+	oidc "github.com/coreos/go-oidc/v3/oidc"
 
 	project "github.com/argoproj/argo-cd/v2/pkg/apiclient/project"
 
@@ -50,10 +50,14 @@ type Client struct {
 	mock.Mock
 }
 
-// ClientOptions provides a mock function with given fields:
+// ClientOptions provides a mock function with no fields
 func (_m *Client) ClientOptions() apiclient.ClientOptions {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for ClientOptions")
+	}
+
 	var r0 apiclient.ClientOptions
 	if rf, ok := ret.Get(0).(func() apiclient.ClientOptions); ok {
 		r0 = rf()
@@ -64,11 +68,19 @@ func (_m *Client) ClientOptions() apiclient.ClientOptions {
 	return r0
 }
 
-// HTTPClient provides a mock function with given fields:
+// HTTPClient provides a mock function with no fields
 func (_m *Client) HTTPClient() (*http.Client, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for HTTPClient")
+	}
+
 	var r0 *http.Client
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*http.Client, error)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() *http.Client); ok {
 		r0 = rf()
 	} else {
@@ -77,7 +89,6 @@ func (_m *Client) HTTPClient() (*http.Client, error) {
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func() error); ok {
 		r1 = rf()
 	} else {
@@ -87,11 +98,20 @@ func (_m *Client) HTTPClient() (*http.Client, error) {
 	return r0, r1
 }
 
-// NewAccountClient provides a mock function with given fields:
+// NewAccountClient provides a mock function with no fields
 func (_m *Client) NewAccountClient() (io.Closer, account.AccountServiceClient, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewAccountClient")
+	}
+
 	var r0 io.Closer
+	var r1 account.AccountServiceClient
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (io.Closer, account.AccountServiceClient, error)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -100,7 +120,6 @@ func (_m *Client) NewAccountClient() (io.Closer, account.AccountServiceClient, e
 		}
 	}
 
-	var r1 account.AccountServiceClient
 	if rf, ok := ret.Get(1).(func() account.AccountServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -109,7 +128,6 @@ func (_m *Client) NewAccountClient() (io.Closer, account.AccountServiceClient, e
 		}
 	}
 
-	var r2 error
 	if rf, ok := ret.Get(2).(func() error); ok {
 		r2 = rf()
 	} else {
@@ -119,11 +137,19 @@ func (_m *Client) NewAccountClient() (io.Closer, account.AccountServiceClient, e
 	return r0, r1, r2
 }
 
-// NewAccountClientOrDie provides a mock function with given fields:
+// NewAccountClientOrDie provides a mock function with no fields
 func (_m *Client) NewAccountClientOrDie() (io.Closer, account.AccountServiceClient) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewAccountClientOrDie")
+	}
+
 	var r0 io.Closer
+	var r1 account.AccountServiceClient
+	if rf, ok := ret.Get(0).(func() (io.Closer, account.AccountServiceClient)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -132,7 +158,6 @@ func (_m *Client) NewAccountClientOrDie() (io.Closer, account.AccountServiceClie
 		}
 	}
 
-	var r1 account.AccountServiceClient
 	if rf, ok := ret.Get(1).(func() account.AccountServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -144,11 +169,20 @@ func (_m *Client) NewAccountClientOrDie() (io.Closer, account.AccountServiceClie
 	return r0, r1
 }
 
-// NewApplicationClient provides a mock function with given fields:
+// NewApplicationClient provides a mock function with no fields
 func (_m *Client) NewApplicationClient() (io.Closer, application.ApplicationServiceClient, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewApplicationClient")
+	}
+
 	var r0 io.Closer
+	var r1 application.ApplicationServiceClient
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (io.Closer, application.ApplicationServiceClient, error)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -157,7 +191,6 @@ func (_m *Client) NewApplicationClient() (io.Closer, application.ApplicationServ
 		}
 	}
 
-	var r1 application.ApplicationServiceClient
 	if rf, ok := ret.Get(1).(func() application.ApplicationServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -166,7 +199,6 @@ func (_m *Client) NewApplicationClient() (io.Closer, application.ApplicationServ
 		}
 	}
 
-	var r2 error
 	if rf, ok := ret.Get(2).(func() error); ok {
 		r2 = rf()
 	} else {
@@ -176,11 +208,19 @@ func (_m *Client) NewApplicationClient() (io.Closer, application.ApplicationServ
 	return r0, r1, r2
 }
 
-// NewApplicationClientOrDie provides a mock function with given fields:
+// NewApplicationClientOrDie provides a mock function with no fields
 func (_m *Client) NewApplicationClientOrDie() (io.Closer, application.ApplicationServiceClient) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewApplicationClientOrDie")
+	}
+
 	var r0 io.Closer
+	var r1 application.ApplicationServiceClient
+	if rf, ok := ret.Get(0).(func() (io.Closer, application.ApplicationServiceClient)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -189,7 +229,6 @@ func (_m *Client) NewApplicationClientOrDie() (io.Closer, application.Applicatio
 		}
 	}
 
-	var r1 application.ApplicationServiceClient
 	if rf, ok := ret.Get(1).(func() application.ApplicationServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -201,11 +240,20 @@ func (_m *Client) NewApplicationClientOrDie() (io.Closer, application.Applicatio
 	return r0, r1
 }
 
-// NewApplicationSetClient provides a mock function with given fields:
+// NewApplicationSetClient provides a mock function with no fields
 func (_m *Client) NewApplicationSetClient() (io.Closer, applicationset.ApplicationSetServiceClient, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewApplicationSetClient")
+	}
+
 	var r0 io.Closer
+	var r1 applicationset.ApplicationSetServiceClient
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (io.Closer, applicationset.ApplicationSetServiceClient, error)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -214,7 +262,6 @@ func (_m *Client) NewApplicationSetClient() (io.Closer, applicationset.Applicati
 		}
 	}
 
-	var r1 applicationset.ApplicationSetServiceClient
 	if rf, ok := ret.Get(1).(func() applicationset.ApplicationSetServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -223,7 +270,6 @@ func (_m *Client) NewApplicationSetClient() (io.Closer, applicationset.Applicati
 		}
 	}
 
-	var r2 error
 	if rf, ok := ret.Get(2).(func() error); ok {
 		r2 = rf()
 	} else {
@@ -233,11 +279,19 @@ func (_m *Client) NewApplicationSetClient() (io.Closer, applicationset.Applicati
 	return r0, r1, r2
 }
 
-// NewApplicationSetClientOrDie provides a mock function with given fields:
+// NewApplicationSetClientOrDie provides a mock function with no fields
 func (_m *Client) NewApplicationSetClientOrDie() (io.Closer, applicationset.ApplicationSetServiceClient) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewApplicationSetClientOrDie")
+	}
+
 	var r0 io.Closer
+	var r1 applicationset.ApplicationSetServiceClient
+	if rf, ok := ret.Get(0).(func() (io.Closer, applicationset.ApplicationSetServiceClient)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -246,7 +300,6 @@ func (_m *Client) NewApplicationSetClientOrDie() (io.Closer, applicationset.Appl
 		}
 	}
 
-	var r1 applicationset.ApplicationSetServiceClient
 	if rf, ok := ret.Get(1).(func() applicationset.ApplicationSetServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -258,11 +311,20 @@ func (_m *Client) NewApplicationSetClientOrDie() (io.Closer, applicationset.Appl
 	return r0, r1
 }
 
-// NewCertClient provides a mock function with given fields:
+// NewCertClient provides a mock function with no fields
 func (_m *Client) NewCertClient() (io.Closer, certificate.CertificateServiceClient, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewCertClient")
+	}
+
 	var r0 io.Closer
+	var r1 certificate.CertificateServiceClient
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (io.Closer, certificate.CertificateServiceClient, error)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -271,7 +333,6 @@ func (_m *Client) NewCertClient() (io.Closer, certificate.CertificateServiceClie
 		}
 	}
 
-	var r1 certificate.CertificateServiceClient
 	if rf, ok := ret.Get(1).(func() certificate.CertificateServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -280,7 +341,6 @@ func (_m *Client) NewCertClient() (io.Closer, certificate.CertificateServiceClie
 		}
 	}
 
-	var r2 error
 	if rf, ok := ret.Get(2).(func() error); ok {
 		r2 = rf()
 	} else {
@@ -290,11 +350,19 @@ func (_m *Client) NewCertClient() (io.Closer, certificate.CertificateServiceClie
 	return r0, r1, r2
 }
 
-// NewCertClientOrDie provides a mock function with given fields:
+// NewCertClientOrDie provides a mock function with no fields
 func (_m *Client) NewCertClientOrDie() (io.Closer, certificate.CertificateServiceClient) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewCertClientOrDie")
+	}
+
 	var r0 io.Closer
+	var r1 certificate.CertificateServiceClient
+	if rf, ok := ret.Get(0).(func() (io.Closer, certificate.CertificateServiceClient)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -303,7 +371,6 @@ func (_m *Client) NewCertClientOrDie() (io.Closer, certificate.CertificateServic
 		}
 	}
 
-	var r1 certificate.CertificateServiceClient
 	if rf, ok := ret.Get(1).(func() certificate.CertificateServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -315,11 +382,20 @@ func (_m *Client) NewCertClientOrDie() (io.Closer, certificate.CertificateServic
 	return r0, r1
 }
 
-// NewClusterClient provides a mock function with given fields:
+// NewClusterClient provides a mock function with no fields
 func (_m *Client) NewClusterClient() (io.Closer, cluster.ClusterServiceClient, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewClusterClient")
+	}
+
 	var r0 io.Closer
+	var r1 cluster.ClusterServiceClient
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (io.Closer, cluster.ClusterServiceClient, error)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -328,7 +404,6 @@ func (_m *Client) NewClusterClient() (io.Closer, cluster.ClusterServiceClient, e
 		}
 	}
 
-	var r1 cluster.ClusterServiceClient
 	if rf, ok := ret.Get(1).(func() cluster.ClusterServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -337,7 +412,6 @@ func (_m *Client) NewClusterClient() (io.Closer, cluster.ClusterServiceClient, e
 		}
 	}
 
-	var r2 error
 	if rf, ok := ret.Get(2).(func() error); ok {
 		r2 = rf()
 	} else {
@@ -347,11 +421,19 @@ func (_m *Client) NewClusterClient() (io.Closer, cluster.ClusterServiceClient, e
 	return r0, r1, r2
 }
 
-// NewClusterClientOrDie provides a mock function with given fields:
+// NewClusterClientOrDie provides a mock function with no fields
 func (_m *Client) NewClusterClientOrDie() (io.Closer, cluster.ClusterServiceClient) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewClusterClientOrDie")
+	}
+
 	var r0 io.Closer
+	var r1 cluster.ClusterServiceClient
+	if rf, ok := ret.Get(0).(func() (io.Closer, cluster.ClusterServiceClient)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -360,7 +442,6 @@ func (_m *Client) NewClusterClientOrDie() (io.Closer, cluster.ClusterServiceClie
 		}
 	}
 
-	var r1 cluster.ClusterServiceClient
 	if rf, ok := ret.Get(1).(func() cluster.ClusterServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -372,11 +453,20 @@ func (_m *Client) NewClusterClientOrDie() (io.Closer, cluster.ClusterServiceClie
 	return r0, r1
 }
 
-// NewGPGKeyClient provides a mock function with given fields:
+// NewGPGKeyClient provides a mock function with no fields
 func (_m *Client) NewGPGKeyClient() (io.Closer, gpgkey.GPGKeyServiceClient, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewGPGKeyClient")
+	}
+
 	var r0 io.Closer
+	var r1 gpgkey.GPGKeyServiceClient
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (io.Closer, gpgkey.GPGKeyServiceClient, error)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -385,7 +475,6 @@ func (_m *Client) NewGPGKeyClient() (io.Closer, gpgkey.GPGKeyServiceClient, erro
 		}
 	}
 
-	var r1 gpgkey.GPGKeyServiceClient
 	if rf, ok := ret.Get(1).(func() gpgkey.GPGKeyServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -394,7 +483,6 @@ func (_m *Client) NewGPGKeyClient() (io.Closer, gpgkey.GPGKeyServiceClient, erro
 		}
 	}
 
-	var r2 error
 	if rf, ok := ret.Get(2).(func() error); ok {
 		r2 = rf()
 	} else {
@@ -404,11 +492,19 @@ func (_m *Client) NewGPGKeyClient() (io.Closer, gpgkey.GPGKeyServiceClient, erro
 	return r0, r1, r2
 }
 
-// NewGPGKeyClientOrDie provides a mock function with given fields:
+// NewGPGKeyClientOrDie provides a mock function with no fields
 func (_m *Client) NewGPGKeyClientOrDie() (io.Closer, gpgkey.GPGKeyServiceClient) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewGPGKeyClientOrDie")
+	}
+
 	var r0 io.Closer
+	var r1 gpgkey.GPGKeyServiceClient
+	if rf, ok := ret.Get(0).(func() (io.Closer, gpgkey.GPGKeyServiceClient)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -417,7 +513,6 @@ func (_m *Client) NewGPGKeyClientOrDie() (io.Closer, gpgkey.GPGKeyServiceClient)
 		}
 	}
 
-	var r1 gpgkey.GPGKeyServiceClient
 	if rf, ok := ret.Get(1).(func() gpgkey.GPGKeyServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -429,11 +524,20 @@ func (_m *Client) NewGPGKeyClientOrDie() (io.Closer, gpgkey.GPGKeyServiceClient)
 	return r0, r1
 }
 
-// NewNotificationClient provides a mock function with given fields:
+// NewNotificationClient provides a mock function with no fields
 func (_m *Client) NewNotificationClient() (io.Closer, notification.NotificationServiceClient, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewNotificationClient")
+	}
+
 	var r0 io.Closer
+	var r1 notification.NotificationServiceClient
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (io.Closer, notification.NotificationServiceClient, error)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -442,7 +546,6 @@ func (_m *Client) NewNotificationClient() (io.Closer, notification.NotificationS
 		}
 	}
 
-	var r1 notification.NotificationServiceClient
 	if rf, ok := ret.Get(1).(func() notification.NotificationServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -451,7 +554,6 @@ func (_m *Client) NewNotificationClient() (io.Closer, notification.NotificationS
 		}
 	}
 
-	var r2 error
 	if rf, ok := ret.Get(2).(func() error); ok {
 		r2 = rf()
 	} else {
@@ -461,11 +563,19 @@ func (_m *Client) NewNotificationClient() (io.Closer, notification.NotificationS
 	return r0, r1, r2
 }
 
-// NewNotificationClientOrDie provides a mock function with given fields:
+// NewNotificationClientOrDie provides a mock function with no fields
 func (_m *Client) NewNotificationClientOrDie() (io.Closer, notification.NotificationServiceClient) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewNotificationClientOrDie")
+	}
+
 	var r0 io.Closer
+	var r1 notification.NotificationServiceClient
+	if rf, ok := ret.Get(0).(func() (io.Closer, notification.NotificationServiceClient)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -474,7 +584,6 @@ func (_m *Client) NewNotificationClientOrDie() (io.Closer, notification.Notifica
 		}
 	}
 
-	var r1 notification.NotificationServiceClient
 	if rf, ok := ret.Get(1).(func() notification.NotificationServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -486,11 +595,20 @@ func (_m *Client) NewNotificationClientOrDie() (io.Closer, notification.Notifica
 	return r0, r1
 }
 
-// NewProjectClient provides a mock function with given fields:
+// NewProjectClient provides a mock function with no fields
 func (_m *Client) NewProjectClient() (io.Closer, project.ProjectServiceClient, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewProjectClient")
+	}
+
 	var r0 io.Closer
+	var r1 project.ProjectServiceClient
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (io.Closer, project.ProjectServiceClient, error)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -499,7 +617,6 @@ func (_m *Client) NewProjectClient() (io.Closer, project.ProjectServiceClient, e
 		}
 	}
 
-	var r1 project.ProjectServiceClient
 	if rf, ok := ret.Get(1).(func() project.ProjectServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -508,7 +625,6 @@ func (_m *Client) NewProjectClient() (io.Closer, project.ProjectServiceClient, e
 		}
 	}
 
-	var r2 error
 	if rf, ok := ret.Get(2).(func() error); ok {
 		r2 = rf()
 	} else {
@@ -518,11 +634,19 @@ func (_m *Client) NewProjectClient() (io.Closer, project.ProjectServiceClient, e
 	return r0, r1, r2
 }
 
-// NewProjectClientOrDie provides a mock function with given fields:
+// NewProjectClientOrDie provides a mock function with no fields
 func (_m *Client) NewProjectClientOrDie() (io.Closer, project.ProjectServiceClient) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewProjectClientOrDie")
+	}
+
 	var r0 io.Closer
+	var r1 project.ProjectServiceClient
+	if rf, ok := ret.Get(0).(func() (io.Closer, project.ProjectServiceClient)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -531,7 +655,6 @@ func (_m *Client) NewProjectClientOrDie() (io.Closer, project.ProjectServiceClie
 		}
 	}
 
-	var r1 project.ProjectServiceClient
 	if rf, ok := ret.Get(1).(func() project.ProjectServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -543,11 +666,20 @@ func (_m *Client) NewProjectClientOrDie() (io.Closer, project.ProjectServiceClie
 	return r0, r1
 }
 
-// NewRepoClient provides a mock function with given fields:
+// NewRepoClient provides a mock function with no fields
 func (_m *Client) NewRepoClient() (io.Closer, repository.RepositoryServiceClient, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewRepoClient")
+	}
+
 	var r0 io.Closer
+	var r1 repository.RepositoryServiceClient
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (io.Closer, repository.RepositoryServiceClient, error)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -556,7 +688,6 @@ func (_m *Client) NewRepoClient() (io.Closer, repository.RepositoryServiceClient
 		}
 	}
 
-	var r1 repository.RepositoryServiceClient
 	if rf, ok := ret.Get(1).(func() repository.RepositoryServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -565,7 +696,6 @@ func (_m *Client) NewRepoClient() (io.Closer, repository.RepositoryServiceClient
 		}
 	}
 
-	var r2 error
 	if rf, ok := ret.Get(2).(func() error); ok {
 		r2 = rf()
 	} else {
@@ -575,11 +705,19 @@ func (_m *Client) NewRepoClient() (io.Closer, repository.RepositoryServiceClient
 	return r0, r1, r2
 }
 
-// NewRepoClientOrDie provides a mock function with given fields:
+// NewRepoClientOrDie provides a mock function with no fields
 func (_m *Client) NewRepoClientOrDie() (io.Closer, repository.RepositoryServiceClient) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewRepoClientOrDie")
+	}
+
 	var r0 io.Closer
+	var r1 repository.RepositoryServiceClient
+	if rf, ok := ret.Get(0).(func() (io.Closer, repository.RepositoryServiceClient)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -588,7 +726,6 @@ func (_m *Client) NewRepoClientOrDie() (io.Closer, repository.RepositoryServiceC
 		}
 	}
 
-	var r1 repository.RepositoryServiceClient
 	if rf, ok := ret.Get(1).(func() repository.RepositoryServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -600,11 +737,20 @@ func (_m *Client) NewRepoClientOrDie() (io.Closer, repository.RepositoryServiceC
 	return r0, r1
 }
 
-// NewRepoCredsClient provides a mock function with given fields:
+// NewRepoCredsClient provides a mock function with no fields
 func (_m *Client) NewRepoCredsClient() (io.Closer, repocreds.RepoCredsServiceClient, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewRepoCredsClient")
+	}
+
 	var r0 io.Closer
+	var r1 repocreds.RepoCredsServiceClient
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (io.Closer, repocreds.RepoCredsServiceClient, error)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -613,7 +759,6 @@ func (_m *Client) NewRepoCredsClient() (io.Closer, repocreds.RepoCredsServiceCli
 		}
 	}
 
-	var r1 repocreds.RepoCredsServiceClient
 	if rf, ok := ret.Get(1).(func() repocreds.RepoCredsServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -622,7 +767,6 @@ func (_m *Client) NewRepoCredsClient() (io.Closer, repocreds.RepoCredsServiceCli
 		}
 	}
 
-	var r2 error
 	if rf, ok := ret.Get(2).(func() error); ok {
 		r2 = rf()
 	} else {
@@ -632,11 +776,19 @@ func (_m *Client) NewRepoCredsClient() (io.Closer, repocreds.RepoCredsServiceCli
 	return r0, r1, r2
 }
 
-// NewRepoCredsClientOrDie provides a mock function with given fields:
+// NewRepoCredsClientOrDie provides a mock function with no fields
 func (_m *Client) NewRepoCredsClientOrDie() (io.Closer, repocreds.RepoCredsServiceClient) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewRepoCredsClientOrDie")
+	}
+
 	var r0 io.Closer
+	var r1 repocreds.RepoCredsServiceClient
+	if rf, ok := ret.Get(0).(func() (io.Closer, repocreds.RepoCredsServiceClient)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -645,7 +797,6 @@ func (_m *Client) NewRepoCredsClientOrDie() (io.Closer, repocreds.RepoCredsServi
 		}
 	}
 
-	var r1 repocreds.RepoCredsServiceClient
 	if rf, ok := ret.Get(1).(func() repocreds.RepoCredsServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -657,11 +808,20 @@ func (_m *Client) NewRepoCredsClientOrDie() (io.Closer, repocreds.RepoCredsServi
 	return r0, r1
 }
 
-// NewSessionClient provides a mock function with given fields:
+// NewSessionClient provides a mock function with no fields
 func (_m *Client) NewSessionClient() (io.Closer, session.SessionServiceClient, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewSessionClient")
+	}
+
 	var r0 io.Closer
+	var r1 session.SessionServiceClient
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (io.Closer, session.SessionServiceClient, error)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -670,7 +830,6 @@ func (_m *Client) NewSessionClient() (io.Closer, session.SessionServiceClient, e
 		}
 	}
 
-	var r1 session.SessionServiceClient
 	if rf, ok := ret.Get(1).(func() session.SessionServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -679,7 +838,6 @@ func (_m *Client) NewSessionClient() (io.Closer, session.SessionServiceClient, e
 		}
 	}
 
-	var r2 error
 	if rf, ok := ret.Get(2).(func() error); ok {
 		r2 = rf()
 	} else {
@@ -689,11 +847,19 @@ func (_m *Client) NewSessionClient() (io.Closer, session.SessionServiceClient, e
 	return r0, r1, r2
 }
 
-// NewSessionClientOrDie provides a mock function with given fields:
+// NewSessionClientOrDie provides a mock function with no fields
 func (_m *Client) NewSessionClientOrDie() (io.Closer, session.SessionServiceClient) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewSessionClientOrDie")
+	}
+
 	var r0 io.Closer
+	var r1 session.SessionServiceClient
+	if rf, ok := ret.Get(0).(func() (io.Closer, session.SessionServiceClient)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -702,7 +868,6 @@ func (_m *Client) NewSessionClientOrDie() (io.Closer, session.SessionServiceClie
 		}
 	}
 
-	var r1 session.SessionServiceClient
 	if rf, ok := ret.Get(1).(func() session.SessionServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -714,11 +879,20 @@ func (_m *Client) NewSessionClientOrDie() (io.Closer, session.SessionServiceClie
 	return r0, r1
 }
 
-// NewSettingsClient provides a mock function with given fields:
+// NewSettingsClient provides a mock function with no fields
 func (_m *Client) NewSettingsClient() (io.Closer, settings.SettingsServiceClient, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewSettingsClient")
+	}
+
 	var r0 io.Closer
+	var r1 settings.SettingsServiceClient
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (io.Closer, settings.SettingsServiceClient, error)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -727,7 +901,6 @@ func (_m *Client) NewSettingsClient() (io.Closer, settings.SettingsServiceClient
 		}
 	}
 
-	var r1 settings.SettingsServiceClient
 	if rf, ok := ret.Get(1).(func() settings.SettingsServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -736,7 +909,6 @@ func (_m *Client) NewSettingsClient() (io.Closer, settings.SettingsServiceClient
 		}
 	}
 
-	var r2 error
 	if rf, ok := ret.Get(2).(func() error); ok {
 		r2 = rf()
 	} else {
@@ -746,11 +918,19 @@ func (_m *Client) NewSettingsClient() (io.Closer, settings.SettingsServiceClient
 	return r0, r1, r2
 }
 
-// NewSettingsClientOrDie provides a mock function with given fields:
+// NewSettingsClientOrDie provides a mock function with no fields
 func (_m *Client) NewSettingsClientOrDie() (io.Closer, settings.SettingsServiceClient) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewSettingsClientOrDie")
+	}
+
 	var r0 io.Closer
+	var r1 settings.SettingsServiceClient
+	if rf, ok := ret.Get(0).(func() (io.Closer, settings.SettingsServiceClient)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -759,7 +939,6 @@ func (_m *Client) NewSettingsClientOrDie() (io.Closer, settings.SettingsServiceC
 		}
 	}
 
-	var r1 settings.SettingsServiceClient
 	if rf, ok := ret.Get(1).(func() settings.SettingsServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -771,11 +950,20 @@ func (_m *Client) NewSettingsClientOrDie() (io.Closer, settings.SettingsServiceC
 	return r0, r1
 }
 
-// NewVersionClient provides a mock function with given fields:
+// NewVersionClient provides a mock function with no fields
 func (_m *Client) NewVersionClient() (io.Closer, version.VersionServiceClient, error) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewVersionClient")
+	}
+
 	var r0 io.Closer
+	var r1 version.VersionServiceClient
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (io.Closer, version.VersionServiceClient, error)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -784,7 +972,6 @@ func (_m *Client) NewVersionClient() (io.Closer, version.VersionServiceClient, e
 		}
 	}
 
-	var r1 version.VersionServiceClient
 	if rf, ok := ret.Get(1).(func() version.VersionServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -793,7 +980,6 @@ func (_m *Client) NewVersionClient() (io.Closer, version.VersionServiceClient, e
 		}
 	}
 
-	var r2 error
 	if rf, ok := ret.Get(2).(func() error); ok {
 		r2 = rf()
 	} else {
@@ -803,11 +989,19 @@ func (_m *Client) NewVersionClient() (io.Closer, version.VersionServiceClient, e
 	return r0, r1, r2
 }
 
-// NewVersionClientOrDie provides a mock function with given fields:
+// NewVersionClientOrDie provides a mock function with no fields
 func (_m *Client) NewVersionClientOrDie() (io.Closer, version.VersionServiceClient) {
 	ret := _m.Called()
 
+	if len(ret) == 0 {
+		panic("no return value specified for NewVersionClientOrDie")
+	}
+
 	var r0 io.Closer
+	var r1 version.VersionServiceClient
+	if rf, ok := ret.Get(0).(func() (io.Closer, version.VersionServiceClient)); ok {
+		return rf()
+	}
 	if rf, ok := ret.Get(0).(func() io.Closer); ok {
 		r0 = rf()
 	} else {
@@ -816,7 +1010,6 @@ func (_m *Client) NewVersionClientOrDie() (io.Closer, version.VersionServiceClie
 		}
 	}
 
-	var r1 version.VersionServiceClient
 	if rf, ok := ret.Get(1).(func() version.VersionServiceClient); ok {
 		r1 = rf()
 	} else {
@@ -832,7 +1025,16 @@ func (_m *Client) NewVersionClientOrDie() (io.Closer, version.VersionServiceClie
 func (_m *Client) OIDCConfig(_a0 context.Context, _a1 *settings.Settings) (*oauth2.Config, *oidc.Provider, error) {
 	ret := _m.Called(_a0, _a1)
 
+	if len(ret) == 0 {
+		panic("no return value specified for OIDCConfig")
+	}
+
 	var r0 *oauth2.Config
+	var r1 *oidc.Provider
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *settings.Settings) (*oauth2.Config, *oidc.Provider, error)); ok {
+		return rf(_a0, _a1)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *settings.Settings) *oauth2.Config); ok {
 		r0 = rf(_a0, _a1)
 	} else {
@@ -841,7 +1043,6 @@ func (_m *Client) OIDCConfig(_a0 context.Context, _a1 *settings.Settings) (*oaut
 		}
 	}
 
-	var r1 *oidc.Provider
 	if rf, ok := ret.Get(1).(func(context.Context, *settings.Settings) *oidc.Provider); ok {
 		r1 = rf(_a0, _a1)
 	} else {
@@ -850,7 +1051,6 @@ func (_m *Client) OIDCConfig(_a0 context.Context, _a1 *settings.Settings) (*oaut
 		}
 	}
 
-	var r2 error
 	if rf, ok := ret.Get(2).(func(context.Context, *settings.Settings) error); ok {
 		r2 = rf(_a0, _a1)
 	} else {
@@ -864,6 +1064,10 @@ func (_m *Client) OIDCConfig(_a0 context.Context, _a1 *settings.Settings) (*oaut
 func (_m *Client) WatchApplicationWithRetry(ctx context.Context, appName string, revision string) chan *v1alpha1.ApplicationWatchEvent {
 	ret := _m.Called(ctx, appName, revision)
 
+	if len(ret) == 0 {
+		panic("no return value specified for WatchApplicationWithRetry")
+	}
+
 	var r0 chan *v1alpha1.ApplicationWatchEvent
 	if rf, ok := ret.Get(0).(func(context.Context, string, string) chan *v1alpha1.ApplicationWatchEvent); ok {
 		r0 = rf(ctx, appName, revision)
@@ -876,13 +1080,12 @@ func (_m *Client) WatchApplicationWithRetry(ctx context.Context, appName string,
 	return r0
 }
 
-type mockConstructorTestingTNewClient interface {
+// NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewClient(t interface {
 	mock.TestingT
 	Cleanup(func())
-}
-
-// NewClient creates a new instance of Client. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-func NewClient(t mockConstructorTestingTNewClient) *Client {
+}) *Client {
 	mock := &Client{}
 	mock.Mock.Test(t)
 
@@ -1,4 +1,4 @@
-// Code generated by mockery v2.14.1. DO NOT EDIT.
+// Code generated by mockery. DO NOT EDIT.
 
 package mocks
 
@@ -28,7 +28,15 @@ func (_m *SessionServiceClient) Create(ctx context.Context, in *session.SessionC
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
 	var r0 *session.SessionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *session.SessionCreateRequest, ...grpc.CallOption) (*session.SessionResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *session.SessionCreateRequest, ...grpc.CallOption) *session.SessionResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -37,7 +45,6 @@ func (_m *SessionServiceClient) Create(ctx context.Context, in *session.SessionC
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *session.SessionCreateRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -58,7 +65,15 @@ func (_m *SessionServiceClient) Delete(ctx context.Context, in *session.SessionD
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
 	var r0 *session.SessionResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *session.SessionDeleteRequest, ...grpc.CallOption) (*session.SessionResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *session.SessionDeleteRequest, ...grpc.CallOption) *session.SessionResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -67,7 +82,6 @@ func (_m *SessionServiceClient) Delete(ctx context.Context, in *session.SessionD
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *session.SessionDeleteRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -88,7 +102,15 @@ func (_m *SessionServiceClient) GetUserInfo(ctx context.Context, in *session.Get
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserInfo")
+	}
+
 	var r0 *session.GetUserInfoResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *session.GetUserInfoRequest, ...grpc.CallOption) (*session.GetUserInfoResponse, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *session.GetUserInfoRequest, ...grpc.CallOption) *session.GetUserInfoResponse); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -97,7 +119,6 @@ func (_m *SessionServiceClient) GetUserInfo(ctx context.Context, in *session.Get
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *session.GetUserInfoRequest, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -107,13 +128,12 @@ func (_m *SessionServiceClient) GetUserInfo(ctx context.Context, in *session.Get
 	return r0, r1
 }
 
-type mockConstructorTestingTNewSessionServiceClient interface {
+// NewSessionServiceClient creates a new instance of SessionServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSessionServiceClient(t interface {
 	mock.TestingT
 	Cleanup(func())
-}
-
-// NewSessionServiceClient creates a new instance of SessionServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-func NewSessionServiceClient(t mockConstructorTestingTNewSessionServiceClient) *SessionServiceClient {
+}) *SessionServiceClient {
 	mock := &SessionServiceClient{}
 	mock.Mock.Test(t)
 
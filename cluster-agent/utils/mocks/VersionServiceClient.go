@@ -1,4 +1,4 @@
-// Code generated by mockery v2.14.1. DO NOT EDIT.
+// Code generated by mockery. DO NOT EDIT.
 
 package mocks
 
@@ -29,7 +29,15 @@ func (_m *VersionServiceClient) Version(ctx context.Context, in *emptypb.Empty,
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
+	if len(ret) == 0 {
+		panic("no return value specified for Version")
+	}
+
 	var r0 *version.VersionMessage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) (*version.VersionMessage, error)); ok {
+		return rf(ctx, in, opts...)
+	}
 	if rf, ok := ret.Get(0).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) *version.VersionMessage); ok {
 		r0 = rf(ctx, in, opts...)
 	} else {
@@ -38,7 +46,6 @@ func (_m *VersionServiceClient) Version(ctx context.Context, in *emptypb.Empty,
 		}
 	}
 
-	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *emptypb.Empty, ...grpc.CallOption) error); ok {
 		r1 = rf(ctx, in, opts...)
 	} else {
@@ -48,13 +55,12 @@ func (_m *VersionServiceClient) Version(ctx context.Context, in *emptypb.Empty,
 	return r0, r1
 }
 
-type mockConstructorTestingTNewVersionServiceClient interface {
+// NewVersionServiceClient creates a new instance of VersionServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewVersionServiceClient(t interface {
 	mock.TestingT
 	Cleanup(func())
-}
-
-// NewVersionServiceClient creates a new instance of VersionServiceClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-func NewVersionServiceClient(t mockConstructorTestingTNewVersionServiceClient) *VersionServiceClient {
+}) *VersionServiceClient {
 	mock := &VersionServiceClient{}
 	mock.Mock.Test(t)
 
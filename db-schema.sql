@@ -49,7 +49,20 @@ CREATE TABLE ClusterCredentials (
 
 	-- Whether or not Argo CD is able to deploy cluster-scoped resources using these cluster credentials
 	-- - This corresponds to the Argo CD cluster secret field of the same name.
-	cluster_resources BOOLEAN DEFAULT FALSE
+	cluster_resources BOOLEAN DEFAULT FALSE,
+
+	-- A PEM-encoded bundle of CA certificates, used to verify the TLS certificate of the cluster
+	-- - This corresponds to the 'caData' field of the Argo CD cluster secret TLS client config.
+	ca_bundle VARCHAR (65000),
+
+	-- The name used to verify the TLS certificate of the cluster, if it differs from the host name of the API URL
+	-- - This corresponds to the 'serverName' field of the Argo CD cluster secret TLS client config.
+	tls_server_name VARCHAR (512),
+
+	-- State 2) PEM-encoded client certificate (and key), used in addition to the bearer token to authenticate to the cluster
+	-- - These correspond to the 'certData'/'keyData' fields of the Argo CD cluster secret TLS client config.
+	client_certificate_data VARCHAR (65000),
+	client_key_data VARCHAR (65000)
 
 );
 
@@ -218,6 +231,8 @@ CREATE TABLE Operation (
 	-- * GitopsEngineInstance (specified to CRUD an Argo instance, for example to create a new namespace and put Argo CD in it, then signal when it's done)
 	-- * Application (user creates a new Application via service/web UI)
 	-- * SyncOperation (user wants a GitOps engine sync operation performed)
+	-- * ApplicationRefresh / ApplicationHardRefresh (user wants a GitOps engine Application to be (hard) refreshed)
+	-- * ApplicationDiff (user wants the diff of the OutOfSync resources of a GitOps engine Application)
 	resource_type VARCHAR(32) NOT NULL,
 
 	-- When the operation was created. Used for garbage collection, as operations should be short lived.
@@ -238,7 +253,10 @@ CREATE TABLE Operation (
 	human_readable_state VARCHAR ( 1024 ),
 
 	-- Amount of time to wait in seconds after last_state_update for a completed/failed operation to be garbage collected.
-	gc_expiration_time INT
+	gc_expiration_time INT,
+
+	-- The (compressed) result of the operation, for operations that return data to the backend (for example, ApplicationDiff)
+	operation_result bytea
 
 );
 
@@ -318,7 +336,11 @@ CREATE TABLE ApplicationState (
 	operation_state bytea,
 
 	-- conditions field comes directly from Argo CD Application CR's .status.conditions field
-	conditions bytea
+	conditions bytea,
+
+	-- history contains the (compressed) deployment history of the Application: it is built from the Argo CD Application CR's
+	-- .status.history and .status.operationState fields, and is bounded in size.
+	history bytea
 );
 
 -- Represents the relationship from GitOpsDeployment CR in the API namespace, to an Application table row.
@@ -483,6 +505,22 @@ CREATE TABLE SyncOperation (
 	-- values: Running, Terminated
 	desired_state VARCHAR(16) NOT NULL,	
 
+	-- If non-null, the SyncOperation is a rollback to the Argo CD Application revision history entry with this ID
+	-- (based on the 'rollbackToHistoryID'/'rollbackToRevision' fields of the GitOpsDeploymentSyncRun CR)
+	rollback_history_id BIGINT,
+
+	-- The 'prune', 'dryRun' and 'force' fields of the GitOpsDeploymentSyncRun CR
+	prune BOOLEAN DEFAULT FALSE,
+	dry_run BOOLEAN DEFAULT FALSE,
+	force BOOLEAN DEFAULT FALSE,
+
+	-- The 'strategy' field of the GitOpsDeploymentSyncRun CR
+	-- values: hook, apply (or empty, which is equivalent to hook)
+	sync_strategy VARCHAR(16),
+
+	-- The (compressed) 'resources' field of the GitOpsDeploymentSyncRun CR: if null, all resources are synced.
+	resources bytea,
+
 	seq_id serial,
 
 	-- When SyncOperation was created, which allow us to tell how old the resources are
@@ -43,12 +43,12 @@ var _ = Describe("GitOpsDeployment Condition Tests", func() {
 			err = k8s.Create(&gitOpsDeploymentResource, k8sClient)
 			Expect(err).To(Succeed())
 
-			expectedConditions := []managedgitopsv1alpha1.GitOpsDeploymentCondition{
+			expectedConditions := []metav1.Condition{
 				{
-					Type:    managedgitopsv1alpha1.GitOpsDeploymentConditionErrorOccurred,
+					Type:    string(managedgitopsv1alpha1.GitOpsDeploymentConditionErrorOccurred),
 					Message: "an unknown error occurred",
-					Status:  managedgitopsv1alpha1.GitOpsConditionStatusTrue,
-					Reason:  managedgitopsv1alpha1.GitopsDeploymentReasonErrorOccurred,
+					Status:  metav1.ConditionTrue,
+					Reason:  string(managedgitopsv1alpha1.GitopsDeploymentReasonErrorOccurred),
 				},
 			}
 
@@ -140,12 +140,12 @@ var _ = Describe("GitOpsDeployment Status.Conditions tests", func() {
 			err = k8s.Create(&gitOpsDeploymentResource, k8sClient)
 			Expect(err).To(Succeed())
 
-			expectedConditions := []managedgitopsv1alpha1.GitOpsDeploymentCondition{
+			expectedConditions := []metav1.Condition{
 				{
-					Type:    managedgitopsv1alpha1.GitOpsDeploymentConditionErrorOccurred,
+					Type:    string(managedgitopsv1alpha1.GitOpsDeploymentConditionErrorOccurred),
 					Message: managedgitopsv1alpha1.GitOpsDeploymentUserError_PathIsRequired,
-					Status:  managedgitopsv1alpha1.GitOpsConditionStatusTrue,
-					Reason:  managedgitopsv1alpha1.GitopsDeploymentReasonErrorOccurred,
+					Status:  metav1.ConditionTrue,
+					Reason:  string(managedgitopsv1alpha1.GitopsDeploymentReasonErrorOccurred),
 				},
 			}
 
@@ -181,12 +181,12 @@ var _ = Describe("GitOpsDeployment Status.Conditions tests", func() {
 			err = k8s.Create(&gitOpsDeploymentResource, k8sClient)
 			Expect(err).To(Succeed())
 
-			expectedConditions := []managedgitopsv1alpha1.GitOpsDeploymentCondition{
+			expectedConditions := []metav1.Condition{
 				{
-					Type:    managedgitopsv1alpha1.GitOpsDeploymentConditionErrorOccurred,
+					Type:    string(managedgitopsv1alpha1.GitOpsDeploymentConditionErrorOccurred),
 					Message: managedgitopsv1alpha1.GitOpsDeploymentUserError_InvalidPathSlash,
-					Status:  managedgitopsv1alpha1.GitOpsConditionStatusTrue,
-					Reason:  managedgitopsv1alpha1.GitopsDeploymentReasonErrorOccurred,
+					Status:  metav1.ConditionTrue,
+					Reason:  string(managedgitopsv1alpha1.GitopsDeploymentReasonErrorOccurred),
 				},
 			}
 
@@ -222,12 +222,12 @@ var _ = Describe("GitOpsDeployment Status.Conditions tests", func() {
 			Expect(err).To(Succeed())
 
 			errMessage := `rpc error: code = Unknown desc = authentication required`
-			expectedConditions := []managedgitopsv1alpha1.GitOpsDeploymentCondition{
+			expectedConditions := []metav1.Condition{
 				{
-					Type:    managedgitopsv1alpha1.ApplicationConditionComparisonError,
+					Type:    string(managedgitopsv1alpha1.ApplicationConditionComparisonError),
 					Message: errMessage,
-					Status:  managedgitopsv1alpha1.GitOpsConditionStatusTrue,
-					Reason:  managedgitopsv1alpha1.ApplicationConditionComparisonError,
+					Status:  metav1.ConditionTrue,
+					Reason:  string(managedgitopsv1alpha1.ApplicationConditionComparisonError),
 				},
 			}
 
@@ -348,12 +348,12 @@ var _ = Describe("GitOpsDeployment Managed Environment E2E tests", func() {
 
 			By("ensuring GitOpsDeployment has the expected error condition")
 
-			expectedConditions := []managedgitopsv1alpha1.GitOpsDeploymentCondition{
+			expectedConditions := []metav1.Condition{
 				{
-					Type:    managedgitopsv1alpha1.GitOpsDeploymentConditionErrorOccurred,
+					Type:    string(managedgitopsv1alpha1.GitOpsDeploymentConditionErrorOccurred),
 					Message: "Unable to reconcile the ManagedEnvironment. Verify that the ManagedEnvironment and Secret are correctly defined, and have valid credentials",
-					Status:  managedgitopsv1alpha1.GitOpsConditionStatusTrue,
-					Reason:  managedgitopsv1alpha1.GitopsDeploymentReasonErrorOccurred,
+					Status:  metav1.ConditionTrue,
+					Reason:  string(managedgitopsv1alpha1.GitopsDeploymentReasonErrorOccurred),
 				},
 			}
 
@@ -438,12 +438,12 @@ var _ = Describe("GitOpsDeployment Managed Environment E2E tests", func() {
 
 			By("ensuring GitOpsDeployment has the expected error condition")
 
-			expectedConditions := []managedgitopsv1alpha1.GitOpsDeploymentCondition{
+			expectedConditions := []metav1.Condition{
 				{
-					Type:    managedgitopsv1alpha1.GitOpsDeploymentConditionErrorOccurred,
+					Type:    string(managedgitopsv1alpha1.GitOpsDeploymentConditionErrorOccurred),
 					Message: "Unable to reconcile the ManagedEnvironment. Verify that the ManagedEnvironment and Secret are correctly defined, and have valid credentials",
-					Status:  managedgitopsv1alpha1.GitOpsConditionStatusTrue,
-					Reason:  managedgitopsv1alpha1.GitopsDeploymentReasonErrorOccurred,
+					Status:  metav1.ConditionTrue,
+					Reason:  string(managedgitopsv1alpha1.GitopsDeploymentReasonErrorOccurred),
 				},
 			}
 
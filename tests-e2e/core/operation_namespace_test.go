@@ -278,7 +278,7 @@ func createCustomizedDummyApplicationData(repoPath string) (appv1.Application, s
 			Namespace: "test-argocd-namespace",
 		},
 		Spec: appv1.ApplicationSpec{
-			Source: appv1.ApplicationSource{
+			Source: &appv1.ApplicationSource{
 				Path:           "guestbook",
 				TargetRevision: "HEAD",
 				RepoURL:        "https://github.com/argoproj/argocd-example-apps.git",
@@ -296,14 +296,15 @@ func HaveSpecSource(source managedgitopsv1alpha1.ApplicationSource) matcher.Gome
 }
 
 // HaveConditions will return a matcher that will check whether a GitOpsDeployment has the expected conditons.
-// - When comparing conditions, it will ignore the LastProbeTime/LastTransitionTime fields.
-func HaveConditions(conditions []managedgitopsv1alpha1.GitOpsDeploymentCondition) matcher.GomegaMatcher {
+// - When comparing conditions, it will ignore the LastTransitionTime/ObservedGeneration fields.
+// - The Ready/Synced/Healthy/Progressing conditions are only compared if they are included in the expected conditions.
+func HaveConditions(conditions []metav1.Condition) matcher.GomegaMatcher {
 
-	// sanitizeCondition removes ephemeral fields from the GitOpsDeploymentCondition which should not be compared using
+	// sanitizeCondition removes ephemeral fields from the Condition which should not be compared using
 	// reflect.DeepEqual
-	sanitizeCondition := func(cond managedgitopsv1alpha1.GitOpsDeploymentCondition) managedgitopsv1alpha1.GitOpsDeploymentCondition {
+	sanitizeCondition := func(cond metav1.Condition) metav1.Condition {
 
-		res := managedgitopsv1alpha1.GitOpsDeploymentCondition{
+		res := metav1.Condition{
 			Type:    cond.Type,
 			Message: cond.Message,
 			Status:  cond.Status,
@@ -314,6 +315,21 @@ func HaveConditions(conditions []managedgitopsv1alpha1.GitOpsDeploymentCondition
 
 	}
 
+	expectedTypes := map[string]bool{}
+	for _, cond := range conditions {
+		expectedTypes[cond.Type] = true
+	}
+
+	// isIgnoredCondition returns true for readiness conditions which were not part of the expected conditions
+	isIgnoredCondition := func(cond metav1.Condition) bool {
+		switch managedgitopsv1alpha1.GitOpsDeploymentConditionType(cond.Type) {
+		case managedgitopsv1alpha1.GitOpsDeploymentConditionReady, managedgitopsv1alpha1.GitOpsDeploymentConditionSynced,
+			managedgitopsv1alpha1.GitOpsDeploymentConditionHealthy, managedgitopsv1alpha1.GitOpsDeploymentConditionProgressing:
+			return !expectedTypes[cond.Type]
+		}
+		return false
+	}
+
 	return WithTransform(func(gitopsDeployment managedgitopsv1alpha1.GitOpsDeployment) bool {
 
 		config, err := fixture.GetE2ETestUserWorkspaceKubeConfig()
@@ -332,7 +348,12 @@ func HaveConditions(conditions []managedgitopsv1alpha1.GitOpsDeploymentCondition
 		}
 
 		conditionExists := false
-		existingConditionList := gitopsDeployment.Status.Conditions
+		existingConditionList := []metav1.Condition{}
+		for _, existingCondition := range gitopsDeployment.Status.Conditions {
+			if !isIgnoredCondition(existingCondition) {
+				existingConditionList = append(existingConditionList, existingCondition)
+			}
+		}
 
 		if len(conditions) != len(existingConditionList) {
 			fmt.Println("HaveConditions:", conditionExists, "/ Expected:", conditions, "/ Actual:", gitopsDeployment.Status.Conditions)
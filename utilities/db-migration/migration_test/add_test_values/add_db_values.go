@@ -6,6 +6,8 @@ import (
 
 // To avoid code duplication all the structs are declared as variable which can be acessed in our test files
 var (
+	addTest_RollbackHistoryID = int64(2)
+
 	AddTest_PreClusterUser = db.ClusterUser{
 		Clusteruser_id: "test-user-1",
 		User_name:      "test-user-1",
@@ -41,6 +43,10 @@ var (
 		Kube_config_context:         "kube-config-context",
 		Serviceaccount_bearer_token: "serviceaccount_bearer_token",
 		Serviceaccount_ns:           "Serviceaccount_ns",
+		Ca_bundle:                   "ca-bundle",
+		Tls_server_name:             "tls-server-name",
+		Client_certificate_data:     "client-certificate-data",
+		Client_key_data:             "client-key-data",
 	}
 
 	AddTest_PreManagedEnvironment = db.ManagedEnvironment{
@@ -70,6 +76,7 @@ var (
 		ReconciledState:                 "test-reconcile",
 		OperationState:                  []byte("operation_state"),
 		Conditions:                      []byte("conditions"),
+		History:                         []byte("history"),
 	}
 
 	AddTest_PreDTAM = db.DeploymentToApplicationMapping{
@@ -87,6 +94,7 @@ var (
 		Resource_type:           db.OperationResourceType_Application,
 		Operation_owner_user_id: AddTest_PreClusterUser.Clusteruser_id,
 		State:                   db.OperationState_Waiting,
+		Operation_result:        []byte("operation_result"),
 	}
 
 	AddTest_PreKubernetesToDBResourceMapping = db.KubernetesToDBResourceMapping{
@@ -123,6 +131,11 @@ var (
 		Revision:            "master",
 		DeploymentNameField: AddTest_PreDTAM.DeploymentName,
 		DesiredState:        "Synced",
+		RollbackHistoryID:   &addTest_RollbackHistoryID,
+		Prune:               true,
+		Force:               true,
+		SyncStrategy:        "apply",
+		Resources:           []byte("test-resources"),
 	}
 
 	AddTest_PreATDMForSyncOperation = db.APICRToDatabaseMapping{
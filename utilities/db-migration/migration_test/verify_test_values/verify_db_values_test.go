@@ -111,6 +111,7 @@ var _ = Describe("Test to verify that the data added to database is still presen
 			Expect(err).ToNot(HaveOccurred())
 			Expect(addtestvalues.AddTest_PreApplicationState).To(Equal(applicationState))
 			Expect(addtestvalues.AddTest_PreApplicationState.Conditions).To(Equal(applicationState.Conditions))
+			Expect(addtestvalues.AddTest_PreApplicationState.History).To(Equal(applicationState.History))
 
 			By("Get a deployment to application mapping to the application")
 			dtam := db.DeploymentToApplicationMapping{
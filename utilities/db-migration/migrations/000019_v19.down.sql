@@ -0,0 +1 @@
+ALTER TABLE ApplicationState DROP COLUMN history;
\ No newline at end of file
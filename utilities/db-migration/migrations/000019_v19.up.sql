@@ -0,0 +1 @@
+ALTER TABLE ApplicationState ADD COLUMN history bytea;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE SyncOperation DROP COLUMN rollback_history_id;
\ No newline at end of file
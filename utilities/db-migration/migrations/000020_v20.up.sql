@@ -0,0 +1 @@
+ALTER TABLE SyncOperation ADD COLUMN rollback_history_id BIGINT;
\ No newline at end of file
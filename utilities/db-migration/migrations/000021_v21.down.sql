@@ -0,0 +1,5 @@
+ALTER TABLE SyncOperation DROP COLUMN prune;
+ALTER TABLE SyncOperation DROP COLUMN dry_run;
+ALTER TABLE SyncOperation DROP COLUMN force;
+ALTER TABLE SyncOperation DROP COLUMN sync_strategy;
+ALTER TABLE SyncOperation DROP COLUMN resources;
\ No newline at end of file
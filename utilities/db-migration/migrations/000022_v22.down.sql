@@ -0,0 +1 @@
+ALTER TABLE Operation DROP COLUMN operation_result;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE Operation ADD COLUMN operation_result bytea;
\ No newline at end of file
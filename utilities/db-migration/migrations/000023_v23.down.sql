@@ -0,0 +1,2 @@
+ALTER TABLE ClusterCredentials DROP COLUMN tls_server_name;
+ALTER TABLE ClusterCredentials DROP COLUMN ca_bundle;
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE ClusterCredentials ADD COLUMN ca_bundle VARCHAR (65000);
+ALTER TABLE ClusterCredentials ADD COLUMN tls_server_name VARCHAR (512);
\ No newline at end of file
@@ -0,0 +1,2 @@
+ALTER TABLE ClusterCredentials DROP COLUMN client_key_data;
+ALTER TABLE ClusterCredentials DROP COLUMN client_certificate_data;
\ No newline at end of file
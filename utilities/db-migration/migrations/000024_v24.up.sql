@@ -0,0 +1,2 @@
+ALTER TABLE ClusterCredentials ADD COLUMN client_certificate_data VARCHAR (65000);
+ALTER TABLE ClusterCredentials ADD COLUMN client_key_data VARCHAR (65000);
\ No newline at end of file